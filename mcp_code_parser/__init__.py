@@ -2,19 +2,70 @@
 
 from mcp_code_parser.api import (
     AgentTools,
+    SourceRoot,
+    extract_dir,
+    extract_dir_each,
+    extract_dir_jsonl,
+    extract_file,
+    extract_reader,
+    extract_roots,
+    extract_symbols,
     is_language_available,
     parse_code,
     parse_file,
+    run_query,
     supported_languages,
+    validate,
 )
+from mcp_code_parser.blobs import BlobStore, FileBlobStore, MemoryBlobStore
+from mcp_code_parser.chunking import Chunk, chunk_file, chunk_symbols
+from mcp_code_parser.context import Packable, estimate_tokens, pack_context
+from mcp_code_parser.export import format_csv, format_tsv
+from mcp_code_parser.extractors.base import ExtractOptions, Outline, OutputOptions, Symbol
+from mcp_code_parser.imports import ImportResolution, resolve_import
+from mcp_code_parser.merge import merge_outlines
 from mcp_code_parser.parsers.base import ParseResult
+from mcp_code_parser.redact import Redactor
+from mcp_code_parser.registry import LanguageBackend, register_language, unregister_language
 from mcp_code_parser.__version__ import __version__
 
 __all__ = [
     "AgentTools",
+    "BlobStore",
+    "Chunk",
+    "ExtractOptions",
+    "FileBlobStore",
+    "ImportResolution",
+    "LanguageBackend",
+    "MemoryBlobStore",
+    "Outline",
+    "OutputOptions",
+    "Packable",
     "ParseResult",
+    "Redactor",
+    "SourceRoot",
+    "Symbol",
+    "chunk_file",
+    "chunk_symbols",
+    "estimate_tokens",
+    "extract_dir",
+    "extract_dir_each",
+    "extract_dir_jsonl",
+    "extract_file",
+    "extract_reader",
+    "extract_roots",
+    "extract_symbols",
+    "format_csv",
+    "format_tsv",
+    "merge_outlines",
+    "pack_context",
     "parse_code",
     "parse_file",
+    "register_language",
+    "resolve_import",
+    "run_query",
     "supported_languages",
+    "unregister_language",
+    "validate",
     "is_language_available",
 ]
\ No newline at end of file
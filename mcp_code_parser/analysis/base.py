@@ -0,0 +1,67 @@
+"""Shared helpers for multi-file code analysis."""
+
+from dataclasses import dataclass
+from typing import TYPE_CHECKING, Iterator, List, Optional
+
+import tree_sitter
+
+from mcp_code_parser.extractors.base import ExtractOptions, SourceFile, Symbol, assign_stable_ids
+from mcp_code_parser.parsers.base import LanguageNotSupportedError
+
+if TYPE_CHECKING:
+    from mcp_code_parser.api import AgentTools
+
+
+@dataclass
+class ParsedFile:
+    """A source file together with its syntax tree and extracted symbols."""
+
+    file: SourceFile
+    source: bytes
+    tree: tree_sitter.Tree
+    symbols: List[Symbol]
+
+
+async def parse_files(
+    files: List[SourceFile],
+    language: str,
+    options: Optional[ExtractOptions] = None,
+    tools: Optional["AgentTools"] = None
+) -> List[ParsedFile]:
+    """Parse files and extract their symbols for analysis.
+
+    Args:
+        files: Files to analyse, all in the same language
+        language: Programming language of the files
+        options: Optional extraction options
+        tools: AgentTools instance to use (defaults to the global one)
+
+    Raises:
+        LanguageNotSupportedError: If no symbol extractor exists for language
+    """
+    if tools is None:
+        from mcp_code_parser.api import _global_tools
+        tools = _global_tools
+
+    extractor = tools.get_extractor(language)
+    if extractor is None:
+        raise LanguageNotSupportedError(f"Symbol extraction not supported for {language}")
+
+    parsed = []
+    for file in files:
+        tree = await tools.parse_tree(file.content, language)
+        source = bytes(file.content, "utf8")
+        symbols = extractor.extract(tree, source, options or ExtractOptions(), file.path)
+        assign_stable_ids(symbols, extractor.overloads)
+        parsed.append(ParsedFile(file=file, source=source, tree=tree, symbols=symbols))
+    return parsed
+
+
+def walk(node: tree_sitter.Node) -> Iterator[tree_sitter.Node]:
+    """Yield a node and all its descendants in source order."""
+    # Iterative so deeply nested (e.g. generated) code can't hit the recursion limit
+    stack = [node]
+    while stack:
+        current = stack.pop()
+        yield current
+        stack.extend(reversed(current.children))
@@ -0,0 +1,211 @@
+"""Finding calls to given functions and their arguments."""
+
+import re
+from dataclasses import dataclass, field
+from typing import TYPE_CHECKING, Dict, Iterable, List, Optional
+
+import tree_sitter
+
+from mcp_code_parser.analysis.base import walk
+from mcp_code_parser.extractors.base import CALLABLE_KINDS, Symbol, node_text, walk_symbols
+
+if TYPE_CHECKING:
+    from mcp_code_parser.api import AgentTools
+
+# Call node types, per grammar
+_CALL_NODES = ("call_expression", "call")
+
+# Nodes inside argument lists that aren't arguments
+_SKIPPED_ARGUMENT_NODES = ("comment",)
+
+# Literal nodes whose value is fixed in the source
+_LITERAL_NODES = frozenset((
+    # Go
+    "interpreted_string_literal", "raw_string_literal", "int_literal", "float_literal",
+    "imaginary_literal", "rune_literal", "nil",
+    # Python
+    "integer", "float", "none", "concatenated_string",
+    # JavaScript, TypeScript
+    "number", "null", "undefined",
+    # C++
+    "string_literal", "number_literal", "char_literal", "nullptr",
+    # Shared
+    "string", "true", "false",
+))
+
+# Strings that embed expressions aren't constant
+_INTERPOLATION_NODES = ("interpolation", "template_substitution")
+
+# Name at the end of a callee, e.g. "Get" in `s.cache.Get`
+_CALLEE_NAME = re.compile(r"[A-Za-z_$][\w$]*$")
+
+
+@dataclass
+class CallSite:
+    """A call to one of the searched-for functions."""
+
+    # Callee as written, e.g. "fmt.Errorf"
+    callee: str
+    # Source text of each argument, in order
+    arguments: List[str] = field(default_factory=list)
+    # Whether each argument is a literal, e.g. to spot exec.Command with
+    # non-constant arguments
+    literal_arguments: List[bool] = field(default_factory=list)
+    # 1-based line and byte column of the call
+    line: int = 0
+    column: int = 0
+    start_byte: int = 0
+    end_byte: int = 0
+    text: str = ""
+
+
+async def find_calls(
+    content: str,
+    language: str,
+    targets: Iterable[str],
+    tools: Optional["AgentTools"] = None
+) -> List[CallSite]:
+    """Find calls whose callee matches one of targets exactly.
+
+    The callee is compared as written (whitespace collapsed), so
+    `fmt.Errorf` doesn't match a call through an import alias. Method calls
+    match by receiver expression and name, e.g. `s.cache.Get`.
+
+    Args:
+        content: Source code
+        language: Programming language
+        targets: Callee names, e.g. ["fmt.Errorf", "errors.New"]
+        tools: AgentTools instance to use (defaults to the global one)
+
+    Returns:
+        Matching calls in source order, including calls nested in arguments
+
+    Raises:
+        ToolError: If the language has no grammar
+    """
+    if tools is None:
+        from mcp_code_parser.api import _global_tools
+        tools = _global_tools
+
+    wanted = set(targets)
+    tree = await tools.parse_tree(content, language)
+    source = bytes(content, "utf8")
+
+    calls = []
+    for node in walk(tree.root_node):
+        if node.type not in _CALL_NODES:
+            continue
+        function = node.child_by_field_name("function")
+        if function is None:
+            continue
+        callee = " ".join(node_text(function, source).split())
+        if callee not in wanted:
+            continue
+        arguments = _arguments(node.child_by_field_name("arguments"))
+        calls.append(CallSite(
+            callee=callee,
+            arguments=[node_text(a, source) for a in arguments],
+            literal_arguments=[_is_literal(a) for a in arguments],
+            line=node.start_point[0] + 1,
+            column=node.start_point[1] + 1,
+            start_byte=node.start_byte,
+            end_byte=node.end_byte,
+            text=node_text(node, source),
+        ))
+    return calls
+
+
+def call_graph(symbols: List[Symbol], root: tree_sitter.Node, source: bytes) -> Dict[str, List[str]]:
+    """Map each callable in a file to the callables in the same file it calls.
+
+    Calls are attributed to the innermost enclosing callable symbol, so calls
+    in closures count as calls by the function containing them. Callees are
+    resolved by name: a bare name (`isOdd(n)`) means the functions with that
+    name, falling back to methods; a qualified one (`s.visit(n)`) means the
+    methods with the last name. Symbols need stable IDs.
+
+    Args:
+        symbols: Symbols extracted from the file
+        root: Root node of the file's syntax tree
+        source: Source bytes the tree was parsed from
+
+    Returns:
+        Caller stable ID to the stable IDs of its callees, in source order of
+        first call, for every callable (including ones that call nothing)
+    """
+    callables = [s for s in walk_symbols(symbols) if s.kind in CALLABLE_KINDS]
+    graph: Dict[str, List[str]] = {s.stable_id: [] for s in callables}
+    for node in walk(root):
+        if node.type not in _CALL_NODES:
+            continue
+        function = node.child_by_field_name("function")
+        caller = _enclosing(callables, node.start_byte)
+        if function is None or caller is None:
+            continue
+        callee = " ".join(node_text(function, source).split())
+        name = _CALLEE_NAME.search(callee)
+        if name is None:
+            continue
+        methods = [s for s in callables if s.name == name.group() and s.kind not in ("function", "init")]
+        if name.group() == callee:
+            targets = [s for s in callables if s.name == callee and s.kind == "function"] or methods
+        else:
+            targets = methods
+        for target in targets:
+            if target.stable_id not in graph[caller.stable_id]:
+                graph[caller.stable_id].append(target.stable_id)
+    return graph
+
+
+def mark_recursion(symbols: List[Symbol], root: tree_sitter.Node, source: bytes) -> None:
+    """Set recursive and recursion_cycle on callables from the file's call graph, in place.
+
+    A callable is recursive when it can reach itself through calls in the
+    file: directly (its cycle is just itself) or mutually, in which case the
+    cycle lists every callable that calls back into it, in source order.
+    """
+    graph = call_graph(symbols, root, source)
+    reachable = {caller: _reachable(graph, caller) for caller in graph}
+    for sym in walk_symbols(symbols):
+        if sym.stable_id not in reachable or sym.stable_id not in reachable[sym.stable_id]:
+            continue
+        sym.recursive = True
+        sym.recursion_cycle = [
+            other for other in graph
+            if other in reachable[sym.stable_id] and sym.stable_id in reachable[other]
+        ]
+
+
+def _enclosing(callables: List[Symbol], offset: int) -> Optional[Symbol]:
+    """The innermost callable whose span contains offset."""
+    containing = [s for s in callables if s.start_byte <= offset < s.end_byte]
+    return min(containing, key=lambda s: s.end_byte - s.start_byte, default=None)
+
+
+def _reachable(graph: Dict[str, List[str]], start: str) -> List[str]:
+    """Callables reachable from start through one or more calls."""
+    seen: List[str] = []
+    stack = list(graph.get(start, []))
+    while stack:
+        current = stack.pop()
+        if current in seen:
+            continue
+        seen.append(current)
+        stack.extend(graph.get(current, []))
+    return seen
+
+
+def _arguments(argument_list: Optional[tree_sitter.Node]) -> List[tree_sitter.Node]:
+    """Argument expressions of a call's argument list."""
+    if argument_list is None:
+        return []
+    return [a for a in argument_list.named_children if a.type not in _SKIPPED_ARGUMENT_NODES]
+
+
+def _is_literal(node: tree_sitter.Node) -> bool:
+    """Whether an argument is a literal constant (strings without interpolation)."""
+    if node.type == "template_string":
+        return not any(c.type in _INTERPOLATION_NODES for c in node.named_children)
+    if node.type not in _LITERAL_NODES:
+        return False
+    return not any(n.type in _INTERPOLATION_NODES for n in walk(node))
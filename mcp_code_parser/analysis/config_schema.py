@@ -0,0 +1,431 @@
+"""Lenient schemas inferred from JSON and YAML config files."""
+
+import json
+import textwrap
+from dataclasses import dataclass, field
+from difflib import get_close_matches
+from typing import TYPE_CHECKING, Any, Dict, List, Optional, Tuple
+
+import tree_sitter
+
+from mcp_code_parser.errors import (
+    Diagnostic,
+    ParseFailedError,
+    UnsupportedLanguageError,
+    node_diagnostic,
+    syntax_diagnostics,
+)
+from mcp_code_parser.extractors.base import SourceFile, node_text
+from mcp_code_parser.utils import detect_language_from_file
+
+if TYPE_CHECKING:
+    from mcp_code_parser.api import AgentTools
+
+CONFIG_LANGUAGES = ("json", "yaml")
+
+# Rule IDs of validation diagnostics
+RULE_TYPE = "config-type"
+RULE_MISSING_KEY = "config-missing-key"
+RULE_UNKNOWN_KEY = "config-unknown-key"
+RULE_ENUM = "config-enum"
+
+# Most distinct string values a key may take and still be given an enum
+DEFAULT_ENUM_LIMIT = 5
+
+_YAML_BOOLEANS = {"true": True, "false": False, "yes": True, "no": False, "on": True, "off": False}
+_YAML_FLOATS = {".inf": float("inf"), "+.inf": float("inf"), "-.inf": float("-inf"), ".nan": float("nan")}
+# Wrappers holding one value along with any anchor, tag or comment
+_YAML_NODES = ("stream", "document", "block_node", "flow_node", "block_sequence_item")
+
+
+@dataclass
+class ConfigSchema:
+    """Schema of one value position, inferred from the values observed there.
+
+    Schemas are lenient: they describe what the sample configs had in
+    common rather than everything a config may contain.
+    """
+
+    # JSON Schema type names observed: object, array, string, integer,
+    # number, boolean or null ("number" subsumes "integer")
+    types: List[str] = field(default_factory=list)
+    # Number of values observed, across files and array elements
+    observed: int = 0
+    # Keys of objects, each with the schema of its values; a key is
+    # optional unless it's also in required
+    properties: Dict[str, "ConfigSchema"] = field(default_factory=dict)
+    # Keys present in every observed object
+    required: List[str] = field(default_factory=list)
+    # Schema of array elements
+    items: Optional["ConfigSchema"] = None
+    # Distinct string values observed, when every non-null value was one
+    # of a few strings, some seen more than once
+    enum: Optional[List[str]] = None
+
+    @classmethod
+    def from_dict(cls, data: Dict[str, Any]) -> "ConfigSchema":
+        """Rebuild a schema from its asdict() form."""
+        data = dict(data)
+        data["properties"] = {k: cls.from_dict(v) for k, v in (data.get("properties") or {}).items()}
+        if data.get("items") is not None:
+            data["items"] = cls.from_dict(data["items"])
+        return cls(**data)
+
+    def to_json_schema(self) -> Dict[str, Any]:
+        """Convert to a JSON Schema (draft 2020-12) document.
+
+        Keys not in properties are allowed, so the JSON Schema accepts
+        configs with keys that validate_config reports as unknown.
+        """
+        schema: Dict[str, Any] = {}
+        if self.types:
+            schema["type"] = self.types[0] if len(self.types) == 1 else list(self.types)
+        if self.properties:
+            schema["properties"] = {k: v.to_json_schema() for k, v in self.properties.items()}
+        if self.required:
+            schema["required"] = list(self.required)
+        if self.items is not None:
+            schema["items"] = self.items.to_json_schema()
+        if self.enum is not None:
+            schema["enum"] = list(self.enum) + ([None] if "null" in self.types else [])
+        return schema
+
+
+@dataclass
+class _Value:
+    """A parsed config value and the node it came from."""
+
+    node: tree_sitter.Node
+    type: str
+    scalar: Any = None
+    # Object keys -> key node and value, in source order
+    members: Optional[Dict[str, Tuple[tree_sitter.Node, "_Value"]]] = None
+    items: Optional[List["_Value"]] = None
+
+
+class _Builder:
+    """Accumulates the values observed at one position into a schema."""
+
+    def __init__(self):
+        self.observed = 0
+        self.types: List[str] = []
+        self.objects = 0
+        self.properties: Dict[str, "_Builder"] = {}
+        self.items: Optional["_Builder"] = None
+        self.strings: Dict[str, int] = {}
+
+    def add(self, value: _Value) -> None:
+        self.observed += 1
+        if value.type not in self.types:
+            self.types.append(value.type)
+        if value.members is not None:
+            self.objects += 1
+            for key, (_, member) in value.members.items():
+                self.properties.setdefault(key, _Builder()).add(member)
+        elif value.items is not None:
+            for item in value.items:
+                if self.items is None:
+                    self.items = _Builder()
+                self.items.add(item)
+        elif value.type == "string":
+            self.strings[value.scalar] = self.strings.get(value.scalar, 0) + 1
+
+    def build(self, enum_limit: int) -> ConfigSchema:
+        types = self.types
+        if "number" in types:
+            types = [t for t in types if t != "integer"]
+        schema = ConfigSchema(
+            types=sorted(types),
+            observed=self.observed,
+            properties={k: p.build(enum_limit) for k, p in self.properties.items()},
+            required=[k for k, p in self.properties.items() if p.observed >= self.objects],
+            items=self.items.build(enum_limit) if self.items is not None else None,
+        )
+        if (
+            set(types) - {"null"} == {"string"}
+            and len(self.strings) <= enum_limit
+            # A value recurring hints at a closed set rather than free text
+            and sum(self.strings.values()) > len(self.strings)
+        ):
+            schema.enum = list(self.strings)
+        return schema
+
+
+async def infer_config_schema(
+    files: List[SourceFile],
+    language: Optional[str] = None,
+    enum_limit: int = DEFAULT_ENUM_LIMIT,
+    tools: Optional["AgentTools"] = None
+) -> ConfigSchema:
+    """Infer the schema a set of similar config files share.
+
+    Each file's document is merged into one schema: the types seen at each
+    position, the keys of each object with those present in every file (or
+    every element of an array) marked required, and, for keys holding a few
+    recurring strings, the strings seen. YAML anchors and aliases are
+    resolved; tags are ignored.
+
+    Args:
+        files: Config files; their language is detected from their paths
+            unless given
+        language: "json" or "yaml", for every file
+        enum_limit: Most distinct strings a key may take and still be given
+            an enum; 0 infers no enums
+        tools: AgentTools instance to use (defaults to the global one)
+
+    Raises:
+        UnsupportedLanguageError: If a file isn't JSON or YAML
+        ParseFailedError: If a file has syntax errors
+    """
+    if tools is None:
+        from mcp_code_parser.api import _global_tools
+        tools = _global_tools
+
+    builder = _Builder()
+    for file in files:
+        file_language = language or detect_language_from_file(file.path)
+        if file_language not in CONFIG_LANGUAGES:
+            raise UnsupportedLanguageError(f"{file.path} is not a JSON or YAML file")
+        value, diagnostics = await _load(file.content, file_language, tools)
+        if diagnostics:
+            raise ParseFailedError(f"Syntax errors in {file.path}", diagnostics)
+        builder.add(value)
+    return builder.build(enum_limit)
+
+
+async def validate_config(
+    content: str,
+    schema: ConfigSchema,
+    language: str = "yaml",
+    tools: Optional["AgentTools"] = None
+) -> List[Diagnostic]:
+    """Check a config file against an inferred schema.
+
+    Reports values of a type never observed at their position (config-type),
+    missing required keys (config-missing-key), keys no sample had
+    (config-unknown-key, suggesting a close match) and strings outside an
+    inferred enum (config-enum). A file with syntax errors gets only its
+    syntax diagnostics.
+
+    Args:
+        content: Config file content
+        schema: Schema from infer_config_schema
+        language: "json" or "yaml"
+        tools: AgentTools instance to use (defaults to the global one)
+
+    Returns:
+        Diagnostics in document order
+
+    Raises:
+        UnsupportedLanguageError: If language isn't JSON or YAML
+    """
+    if tools is None:
+        from mcp_code_parser.api import _global_tools
+        tools = _global_tools
+    if language not in CONFIG_LANGUAGES:
+        raise UnsupportedLanguageError(f"Config validation not supported for {language}")
+
+    value, diagnostics = await _load(content, language, tools)
+    if diagnostics:
+        return diagnostics
+    _check(value, schema, "", value.node, content.encode("utf8").split(b"\n"), diagnostics)
+    return sorted(diagnostics, key=lambda d: (d.line, d.column))
+
+
+async def _load(content: str, language: str, tools: "AgentTools") -> Tuple[_Value, List[Diagnostic]]:
+    """Parse a config file into its document value and any syntax diagnostics."""
+    tree = await tools.parse_tree(content, language)
+    source = bytes(content, "utf8")
+    diagnostics = syntax_diagnostics(tree.root_node, source=source)
+    root = tree.root_node
+    if language == "yaml":
+        # Only the first document of a multi-document stream is read
+        root = next((c for c in root.named_children if c.type == "document"), root)
+    return _convert(root, source, {}), diagnostics
+
+
+def _convert(node: tree_sitter.Node, source: bytes, anchors: Dict[str, _Value]) -> _Value:
+    """Convert a JSON or YAML node to a value."""
+    if node.type in _YAML_NODES:
+        anchor = None
+        inner = None
+        for child in node.named_children:
+            if child.type == "anchor":
+                anchor = node_text(child, source).lstrip("&")
+            elif child.type not in ("tag", "comment", "yaml_directive", "tag_directive", "reserved_directive"):
+                inner = child
+                break
+        value = _convert(inner, source, anchors) if inner is not None else _Value(node, "null")
+        if anchor:
+            anchors[anchor] = value
+        return value
+
+    if node.type in ("object", "block_mapping", "flow_mapping"):
+        members: Dict[str, Tuple[tree_sitter.Node, _Value]] = {}
+        for pair in node.named_children:
+            if pair.type in ("pair", "block_mapping_pair", "flow_pair"):
+                key = pair.child_by_field_name("key")
+                value = pair.child_by_field_name("value")
+            elif pair.type == "flow_node":
+                # `{debug}` is a key with no value
+                key, value = pair, None
+            else:
+                continue
+            if key is None:
+                continue
+            members[_key(_convert(key, source, anchors))] = (
+                key,
+                _convert(value, source, anchors) if value is not None else _Value(pair, "null"),
+            )
+        return _Value(node, "object", members=members)
+
+    if node.type in ("array", "block_sequence", "flow_sequence"):
+        items = []
+        for child in node.named_children:
+            if child.type == "comment":
+                continue
+            if child.type == "flow_pair":
+                # `[a: 1]` is a sequence of one-entry mappings
+                items.append(_Value(child, "object", members={}))
+                key = child.child_by_field_name("key")
+                value = child.child_by_field_name("value")
+                if key is not None:
+                    items[-1].members[_key(_convert(key, source, anchors))] = (
+                        key,
+                        _convert(value, source, anchors) if value is not None else _Value(child, "null"),
+                    )
+            elif child.type == "block_sequence_item" and not child.named_children:
+                items.append(_Value(child, "null"))
+            else:
+                items.append(_convert(child, source, anchors))
+        return _Value(node, "array", items=items)
+
+    if node.type == "alias":
+        target = anchors.get(node_text(node, source).lstrip("*"))
+        return _Value(node, target.type, target.scalar, target.members, target.items) if target else _Value(node, "null")
+
+    return _scalar(node, source)
+
+
+def _scalar(node: tree_sitter.Node, source: bytes) -> _Value:
+    """Convert a JSON or YAML scalar node to a value."""
+    text = node_text(node, source)
+    if node.type in ("true", "false"):
+        return _Value(node, "boolean", node.type == "true")
+    if node.type == "null":
+        return _Value(node, "null")
+    if node.type in ("number", "string", "double_quote_scalar"):
+        try:
+            decoded = json.loads(text)
+        except ValueError:
+            decoded = text[1:-1] if node.type != "number" else text
+        if isinstance(decoded, bool) or not isinstance(decoded, (int, float)):
+            return _Value(node, "string", str(decoded))
+        return _Value(node, "integer" if isinstance(decoded, int) else "number", decoded)
+    if node.type == "single_quote_scalar":
+        return _Value(node, "string", text[1:-1].replace("''", "'"))
+    if node.type == "block_scalar":
+        return _Value(node, "string", _block_scalar(text))
+    if node.type == "plain_scalar" and node.named_children:
+        return _plain_scalar(node.named_children[0], source)
+    return _Value(node, "string", text)
+
+
+def _plain_scalar(node: tree_sitter.Node, source: bytes) -> _Value:
+    """Resolve an unquoted YAML scalar by the YAML core schema."""
+    text = node_text(node, source)
+    if node.type == "null_scalar":
+        return _Value(node, "null")
+    if node.type == "boolean_scalar" and text.lower() in _YAML_BOOLEANS:
+        return _Value(node, "boolean", _YAML_BOOLEANS[text.lower()])
+    if node.type == "integer_scalar":
+        digits = text.replace("_", "")
+        for base in (0, 10):
+            # Base 0 reads 0x and 0o prefixes but rejects leading zeros
+            try:
+                return _Value(node, "integer", int(digits, base))
+            except ValueError:
+                continue
+    if node.type == "float_scalar":
+        if text.lower() in _YAML_FLOATS:
+            return _Value(node, "number", _YAML_FLOATS[text.lower()])
+        try:
+            return _Value(node, "number", float(text.replace("_", "")))
+        except ValueError:
+            pass
+    return _Value(node, "string", text)
+
+
+def _block_scalar(text: str) -> str:
+    """Content of a `|` (literal) or `>` (folded) block scalar."""
+    header, _, body = text.partition("\n")
+    lines = textwrap.dedent(body).rstrip("\n").split("\n")
+    # `|-` and `>-` strip the final line break
+    end = "" if "-" in header else "\n"
+    if header.startswith(">"):
+        return " ".join(line for line in lines if line) + end
+    return "\n".join(lines) + end
+
+
+def _key(value: _Value) -> str:
+    """Object key a value names; non-string YAML keys are keyed by their text."""
+    if value.type == "string":
+        return value.scalar
+    if value.type == "null":
+        return "null"
+    if value.type == "boolean":
+        return "true" if value.scalar else "false"
+    return str(value.scalar) if value.scalar is not None else value.type
+
+
+def _check(
+    value: _Value,
+    schema: ConfigSchema,
+    path: str,
+    at: tree_sitter.Node,
+    lines: List[bytes],
+    diagnostics: List[Diagnostic]
+) -> None:
+    """Check a value against its schema, reporting problems at a node (its key, if it has one)."""
+    where = f"`{path}`" if path else "The document"
+    if schema.types and not (
+        value.type in schema.types or (value.type == "integer" and "number" in schema.types)
+    ):
+        observed = " or ".join(schema.types)
+        diagnostics.append(node_diagnostic(
+            at, f"{where} is {_article(value.type)}; the samples have {observed}", lines, rule=RULE_TYPE
+        ))
+        return
+
+    if value.members is not None:
+        for key, (key_node, member) in value.members.items():
+            member_path = f"{path}.{key}" if path else key
+            if key in schema.properties:
+                _check(member, schema.properties[key], member_path, key_node, lines, diagnostics)
+                continue
+            message = f"Unknown key `{member_path}`, in none of the samples"
+            close = get_close_matches(key, list(schema.properties), n=1)
+            if close:
+                message += f"; did you mean `{close[0]}`?"
+            diagnostics.append(node_diagnostic(key_node, message, lines, rule=RULE_UNKNOWN_KEY))
+        for key in schema.required:
+            if key not in value.members:
+                member_path = f"{path}.{key}" if path else key
+                diagnostics.append(node_diagnostic(
+                    at, f"Missing key `{member_path}`, present in every sample", lines, rule=RULE_MISSING_KEY
+                ))
+    elif value.items is not None and schema.items is not None:
+        for i, item in enumerate(value.items):
+            _check(item, schema.items, f"{path}[{i}]", item.node, lines, diagnostics)
+    elif value.type == "string" and schema.enum is not None and value.scalar not in schema.enum:
+        allowed = ", ".join(f"`{v}`" for v in schema.enum)
+        diagnostics.append(node_diagnostic(
+            value.node, f"{where} is `{value.scalar}`; the samples have {allowed}", lines, rule=RULE_ENUM
+        ))
+
+
+def _article(type_name: str) -> str:
+    """A type name with its indefinite article, e.g. "an integer"."""
+    if type_name == "null":
+        return "null"
+    return ("an " if type_name[0] in "aeiou" else "a ") + type_name
@@ -0,0 +1,199 @@
+"""Import graphs of a source tree and the cycles in them."""
+
+import logging
+import os
+from pathlib import Path
+from typing import TYPE_CHECKING, Dict, Iterable, List, Optional, Set
+
+from mcp_code_parser.errors import NotFoundError, UnsupportedLanguageError
+from mcp_code_parser.ignore import IgnoreRules
+from mcp_code_parser.imports import resolve_import
+from mcp_code_parser.utils import detect_language_from_file
+
+if TYPE_CHECKING:
+    from mcp_code_parser.api import AgentTools
+
+logger = logging.getLogger(__name__)
+
+# Languages whose files import each other, by the language asked for
+_GRAPH_LANGUAGES = {
+    "go": ("go",),
+    "python": ("python",),
+    "javascript": ("javascript", "typescript", "vue", "svelte"),
+    "typescript": ("javascript", "typescript", "vue", "svelte"),
+}
+
+
+async def import_graph(
+    root: str,
+    language: str,
+    tools: Optional["AgentTools"] = None
+) -> Dict[str, Set[str]]:
+    """Build the graph of local imports under a directory.
+
+    Nodes are Go package directories, or for other languages files, as
+    root-relative POSIX paths ("." for a Go package at the root). JavaScript
+    and TypeScript share one graph, with Vue and Svelte components. Only
+    imports resolving inside root are edges (see resolve_import); imports of
+    a node's own package and repeated imports of one target give no extra
+    edges. Paths ignored by .gitignore files and hidden paths are skipped.
+
+    Args:
+        root: Directory to walk
+        language: "go", "python", "javascript" or "typescript"
+        tools: AgentTools instance to use (defaults to the global one)
+
+    Returns:
+        Imported nodes of every node with local imports or files, in path order
+
+    Raises:
+        NotFoundError: If root isn't a directory
+        UnsupportedLanguageError: If the language has no import graph
+    """
+    if tools is None:
+        from mcp_code_parser.api import _global_tools
+        tools = _global_tools
+    from mcp_code_parser.api import _walk_files
+
+    languages = _GRAPH_LANGUAGES.get(language)
+    if languages is None:
+        raise UnsupportedLanguageError(f"Import graphs not supported for language: {language}")
+    if not Path(root).is_dir():
+        raise NotFoundError(f"Root directory not found: {root}")
+    root_path = Path(root).resolve()
+
+    graph: Dict[str, Set[str]] = {}
+    for path in _walk_files(root_path, IgnoreRules.load(str(root_path))):
+        file_language = detect_language_from_file(str(path))
+        if file_language not in languages:
+            continue
+        node = _node(root_path, path.parent if language == "go" else path)
+        edges = graph.setdefault(node, set())
+        outline = await tools.extract_file(str(path), file_language)
+        if not outline.success:
+            logger.debug(f"Leaving {path} out of the import graph: {outline.error}")
+            continue
+        for item in outline.flatten():
+            if item.symbol.kind != "import":
+                continue
+            resolution = resolve_import(str(path), item.symbol, file_language)
+            if not resolution.resolved or resolution.path is None:
+                continue
+            target = Path(resolution.path)
+            if root_path != target and root_path not in target.parents:
+                continue
+            imported = _node(root_path, target)
+            if imported != node:
+                edges.add(imported)
+    return dict(sorted(graph.items()))
+
+
+def import_cycles(graph: Dict[str, Iterable[str]]) -> List[List[str]]:
+    """Find the import cycles of a graph.
+
+    Each group of nodes that import each other, directly or indirectly, is
+    reported once, as its shortest cycle through the group's first node in
+    sorted order: ["a", "b"] means a imports b and b imports a. Self-imports
+    aren't cycles.
+
+    Returns:
+        Cycles, ordered by their first node
+    """
+    edges = {node: sorted(set(targets) - {node}) for node, targets in graph.items()}
+    for targets in list(edges.values()):
+        for target in targets:
+            edges.setdefault(target, [])
+
+    cycles = []
+    for component in _components(edges):
+        if len(component) < 2:
+            continue
+        start = min(component)
+        cycles.append(_shortest_cycle(edges, start, component))
+    return sorted(cycles)
+
+
+async def find_import_cycles(
+    root: str,
+    language: str,
+    tools: Optional["AgentTools"] = None
+) -> List[List[str]]:
+    """Find import cycles between the packages or modules under a directory.
+
+    See import_graph for the nodes and import_cycles for how cycles are
+    reported.
+
+    Raises:
+        NotFoundError: If root isn't a directory
+        UnsupportedLanguageError: If the language has no import graph
+    """
+    return import_cycles(await import_graph(root, language, tools))
+
+
+def _node(root: Path, path: Path) -> str:
+    """Root-relative POSIX name of a graph node."""
+    return Path(os.path.relpath(path, root)).as_posix()
+
+
+def _components(edges: Dict[str, List[str]]) -> List[Set[str]]:
+    """Strongly connected components (Tarjan's algorithm, iterative)."""
+    index: Dict[str, int] = {}
+    low: Dict[str, int] = {}
+    on_stack: Set[str] = set()
+    stack: List[str] = []
+    components = []
+
+    for start in sorted(edges):
+        if start in index:
+            continue
+        work = [(start, 0)]
+        while work:
+            node, position = work.pop()
+            if position == 0:
+                index[node] = low[node] = len(index)
+                stack.append(node)
+                on_stack.add(node)
+            targets = edges[node]
+            if position < len(targets):
+                work.append((node, position + 1))
+                target = targets[position]
+                if target not in index:
+                    work.append((target, 0))
+                elif target in on_stack:
+                    low[node] = min(low[node], index[target])
+                continue
+            if low[node] == index[node]:
+                component = set()
+                while True:
+                    member = stack.pop()
+                    on_stack.discard(member)
+                    component.add(member)
+                    if member == node:
+                        break
+                components.append(component)
+            if work:
+                parent = work[-1][0]
+                low[parent] = min(low[parent], low[node])
+    return components
+
+
+def _shortest_cycle(edges: Dict[str, List[str]], start: str, component: Set[str]) -> List[str]:
+    """Shortest path from start back to itself within a component, without the repeated start."""
+    previous: Dict[str, str] = {}
+    frontier = [start]
+    while frontier:
+        following = []
+        for node in frontier:
+            for target in edges[node]:
+                if target not in component:
+                    continue
+                if target == start:
+                    path = [node]
+                    while path[-1] != start:
+                        path.append(previous[path[-1]])
+                    return path[::-1]
+                if target not in previous:
+                    previous[target] = node
+                    following.append(target)
+        frontier = following
+    return [start]
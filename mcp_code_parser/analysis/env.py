@@ -0,0 +1,281 @@
+"""Finding the environment variables a service reads."""
+
+import ast
+import re
+from dataclasses import dataclass
+from pathlib import Path
+from typing import TYPE_CHECKING, Callable, Dict, List, Optional, Tuple
+
+import tree_sitter
+
+from mcp_code_parser.analysis.base import ParsedFile, parse_files, walk
+from mcp_code_parser.analysis.calls import _arguments
+from mcp_code_parser.analysis.routes import _go_string, _js_string
+from mcp_code_parser.extractors.base import SourceFile, _collapse, node_text
+from mcp_code_parser.globs import PathFilter
+from mcp_code_parser.ignore import IgnoreRules
+from mcp_code_parser.logging import get_logger
+from mcp_code_parser.utils import detect_language_from_file, safe_read_file
+
+if TYPE_CHECKING:
+    from mcp_code_parser.api import AgentTools
+
+logger = get_logger("analysis.env")
+
+_GO_READERS = ("os.Getenv", "os.LookupEnv", "syscall.Getenv")
+# Go 1.22 `cmp.Or(os.Getenv("X"), "default")`
+_GO_FALLBACK = "cmp.Or"
+
+_PYTHON_GETTERS = ("os.getenv", "os.environ.get")
+_PYTHON_MAPPINGS = ("os.environ",)
+# Names usable unqualified after `from os import environ, getenv`
+_PYTHON_IMPORTED = {"getenv": "os.getenv", "environ.get": "os.environ.get", "environ": "os.environ"}
+_PYTHON_FROM_OS = re.compile(rb"^[ \t]*from[ \t]+os[ \t]+import\b", re.MULTILINE)
+
+_JS_MAPPINGS = ("process.env", "import.meta.env")
+_JS_FALLBACKS = ("||", "??")
+
+
+@dataclass
+class EnvUsage:
+    """A read of an environment variable."""
+
+    # Variable name; the source text of the key when it isn't a literal
+    name: str
+    # How it's read, e.g. "os.Getenv", "os.environ" or "process.env"
+    accessor: str
+    # Fallback used when the variable is unset, as written, e.g. '":8080"'
+    default: Optional[str] = None
+    # Whether the name is a string literal (False for `os.Getenv(key)`)
+    literal: bool = True
+    # Root-relative POSIX path and 1-based line and byte column of the read
+    file: str = ""
+    line: int = 0
+    column: int = 0
+
+    @property
+    def has_default(self) -> bool:
+        """Check if a fallback value is given where the variable is read."""
+        return self.default is not None
+
+
+async def extract_env_usage(
+    root: str,
+    include: Optional[List[str]] = None,
+    exclude: Optional[List[str]] = None,
+    tools: Optional["AgentTools"] = None
+) -> List[EnvUsage]:
+    """List the environment variables read by the code under root.
+
+    Recognised reads are Go `os.Getenv` and `os.LookupEnv`, Python
+    `os.environ[...]`, `os.environ.get` and `os.getenv`, and JavaScript and
+    TypeScript `process.env.X`, `process.env["X"]` and destructuring of
+    `process.env` (and `import.meta.env`). Defaults are the fallback
+    argument of a getter, the right side of `or`, `||` or `??` after a
+    read, a destructuring default, or the rest of a Go `cmp.Or`. Paths
+    ignored by .gitignore files and hidden paths are skipped, as are files
+    that can't be read or parsed.
+
+    Args:
+        root: Directory to search
+        include: Only search files matching one of these glob patterns
+        exclude: Skip files matching one of these glob patterns
+        tools: AgentTools instance to use (defaults to the global one)
+
+    Returns:
+        Reads in file then source order
+    """
+    if tools is None:
+        from mcp_code_parser.api import _global_tools
+        tools = _global_tools
+    from mcp_code_parser.api import _walk_files
+
+    paths = PathFilter(include, exclude)
+    usages: List[EnvUsage] = []
+    for path in _walk_files(Path(root), IgnoreRules.load(root)):
+        language = detect_language_from_file(str(path))
+        rel = path.relative_to(root).as_posix()
+        finder = _FINDERS.get(language or "")
+        if finder is None or not paths.matches(rel):
+            continue
+        try:
+            content = safe_read_file(str(path), detector=tools.binary_detector)
+            pf = (await parse_files([SourceFile(rel, content)], language, tools=tools))[0]
+        except Exception as e:
+            logger.debug(f"Not searching {rel} for environment variables: {e}")
+            continue
+        found = finder(pf)
+        for node, usage in sorted(found, key=lambda f: f[0].start_byte):
+            usage.file = rel
+            usage.line = node.start_point[0] + 1
+            usage.column = node.start_point[1] + 1
+            usages.append(usage)
+    return usages
+
+
+def _go_usages(pf: ParsedFile) -> List[Tuple[tree_sitter.Node, EnvUsage]]:
+    """Reads in a Go file."""
+    found = []
+    for node in walk(pf.tree.root_node):
+        if node.type != "call_expression" or _callee(node, pf.source) not in _GO_READERS:
+            continue
+        args = _arguments(node.child_by_field_name("arguments"))
+        if len(args) != 1:
+            continue
+        name, literal = _name(args[0], pf.source, _go_string)
+        default = None
+        outer = node.parent.parent if node.parent is not None else None
+        if outer is not None and outer.type == "call_expression" and _callee(outer, pf.source) == _GO_FALLBACK:
+            rest = _arguments(outer.child_by_field_name("arguments"))
+            if rest and rest[-1] != node:
+                default = node_text(rest[-1], pf.source)
+        found.append((node, EnvUsage(name, _callee(node, pf.source), default, literal)))
+    return found
+
+
+def _python_usages(pf: ParsedFile) -> List[Tuple[tree_sitter.Node, EnvUsage]]:
+    """Reads in a Python file."""
+    names = {n: n for n in _PYTHON_GETTERS + _PYTHON_MAPPINGS}
+    if _PYTHON_FROM_OS.search(pf.source):
+        names.update(_PYTHON_IMPORTED)
+    found = []
+    for node in walk(pf.tree.root_node):
+        if node.type == "call":
+            accessor = names.get(_callee(node, pf.source) or "")
+            if accessor not in _PYTHON_GETTERS:
+                continue
+            arguments = node.child_by_field_name("arguments")
+            args = [a for a in _arguments(arguments) if a.type != "keyword_argument"]
+            keywords = {
+                node_text(k.child_by_field_name("name"), pf.source): k.child_by_field_name("value")
+                for k in _arguments(arguments) if k.type == "keyword_argument"
+            }
+            if not args:
+                continue
+            fallback = args[1] if len(args) > 1 else keywords.get("default")
+            default = node_text(fallback, pf.source) if fallback is not None else _fallback(node, pf.source, ("or",))
+            name, literal = _name(args[0], pf.source, _python_string)
+            found.append((node, EnvUsage(name, accessor, default, literal)))
+        elif node.type == "subscript":
+            value = node.child_by_field_name("value")
+            key = node.child_by_field_name("subscript")
+            accessor = names.get(node_text(value, pf.source)) if value is not None else None
+            if accessor not in _PYTHON_MAPPINGS or key is None or _assigned(node):
+                continue
+            name, literal = _name(key, pf.source, _python_string)
+            found.append((node, EnvUsage(name, accessor, _fallback(node, pf.source, ("or",)), literal)))
+    return found
+
+
+def _js_usages(pf: ParsedFile) -> List[Tuple[tree_sitter.Node, EnvUsage]]:
+    """Reads in a JavaScript or TypeScript file."""
+    found = []
+    for node in walk(pf.tree.root_node):
+        if node.type in ("member_expression", "subscript_expression"):
+            obj = node.child_by_field_name("object")
+            accessor = _collapse(node_text(obj, pf.source)) if obj is not None else None
+            if accessor not in _JS_MAPPINGS or _assigned(node):
+                continue
+            if node.type == "member_expression":
+                prop = node.child_by_field_name("property")
+                if prop is None:
+                    continue
+                name, literal = node_text(prop, pf.source), True
+            else:
+                index = node.child_by_field_name("index")
+                if index is None:
+                    continue
+                name, literal = _name(index, pf.source, _js_string)
+            found.append((node, EnvUsage(name, accessor, _fallback(node, pf.source, _JS_FALLBACKS), literal)))
+        elif node.type == "variable_declarator":
+            value = node.child_by_field_name("value")
+            pattern = node.child_by_field_name("name")
+            accessor = _collapse(node_text(value, pf.source)) if value is not None else None
+            if accessor in _JS_MAPPINGS and pattern is not None and pattern.type == "object_pattern":
+                found.extend(_destructured(pattern, pf.source, accessor))
+    return found
+
+
+def _destructured(pattern: tree_sitter.Node, source: bytes, accessor: str) -> List[Tuple[tree_sitter.Node, EnvUsage]]:
+    """Variables read by `const { A, B = "b", C: c } = process.env`."""
+    found = []
+    for entry in pattern.named_children:
+        default = None
+        if entry.type == "shorthand_property_identifier_pattern":
+            key = entry
+        elif entry.type == "object_assignment_pattern":
+            key = entry.child_by_field_name("left")
+            right = entry.child_by_field_name("right")
+            default = node_text(right, source) if right is not None else None
+        elif entry.type == "pair_pattern":
+            key = entry.child_by_field_name("key")
+            value = entry.child_by_field_name("value")
+            if value is not None and value.type == "assignment_pattern":
+                right = value.child_by_field_name("right")
+                default = node_text(right, source) if right is not None else None
+        else:
+            # ...rest
+            continue
+        if key is None:
+            continue
+        name, literal = _name(key, source, _js_string) if key.type == "string" else (node_text(key, source), True)
+        found.append((entry, EnvUsage(name, accessor, default, literal)))
+    return found
+
+
+def _callee(call: tree_sitter.Node, source: bytes) -> Optional[str]:
+    """Callee of a call as written, whitespace collapsed."""
+    function = call.child_by_field_name("function")
+    return _collapse(node_text(function, source)) if function is not None else None
+
+
+def _name(
+    node: tree_sitter.Node,
+    source: bytes,
+    string: Callable[[tree_sitter.Node, bytes], Optional[str]]
+) -> Tuple[str, bool]:
+    """A key's variable name and whether it is a literal."""
+    value = string(node, source)
+    if value is not None:
+        return value, True
+    return _collapse(node_text(node, source)), False
+
+
+def _fallback(node: tree_sitter.Node, source: bytes, operators: Tuple[str, ...]) -> Optional[str]:
+    """Right side of `read || fallback` and the like, with the read on the left."""
+    parent = node.parent
+    if parent is None or parent.type not in ("binary_expression", "boolean_operator"):
+        return None
+    operator = parent.child_by_field_name("operator")
+    left = parent.child_by_field_name("left")
+    right = parent.child_by_field_name("right")
+    if operator is None or node_text(operator, source) not in operators or left != node or right is None:
+        return None
+    return node_text(right, source)
+
+
+def _assigned(node: tree_sitter.Node) -> bool:
+    """Whether node is the target of an assignment (a write, not a read)."""
+    parent = node.parent
+    if parent is None or parent.type not in ("assignment", "assignment_expression", "augmented_assignment"):
+        return False
+    return parent.child_by_field_name("left") == node
+
+
+def _python_string(node: tree_sitter.Node, source: bytes) -> Optional[str]:
+    """Value of a Python string literal (not an f-string), or None."""
+    if node.type != "string" or any(c.type == "interpolation" for c in node.named_children):
+        return None
+    try:
+        value = ast.literal_eval(node_text(node, source))
+    except (ValueError, SyntaxError):
+        return None
+    return value if isinstance(value, str) else None
+
+
+_FINDERS: Dict[str, Callable[[ParsedFile], List[Tuple[tree_sitter.Node, EnvUsage]]]] = {
+    "go": _go_usages,
+    "python": _python_usages,
+    "javascript": _js_usages,
+    "typescript": _js_usages,
+}
@@ -0,0 +1,177 @@
+"""Manifests of a package's public API, for checking in and diffing across releases."""
+
+import json
+from dataclasses import asdict, dataclass, field
+from typing import TYPE_CHECKING, Any, Dict, List, Optional
+
+from mcp_code_parser.analysis.base import parse_files
+from mcp_code_parser.extractors.base import Outline, SourceFile, Symbol
+from mcp_code_parser.extractors.names import qualify
+from mcp_code_parser.merge import merge_outlines
+
+if TYPE_CHECKING:
+    from mcp_code_parser.api import AgentTools
+
+# Bumped when the manifest layout changes incompatibly
+MANIFEST_FORMAT = 1
+
+
+@dataclass
+class ManifestEntry:
+    """One exported symbol of an API manifest."""
+
+    # Stable ID, e.g. "method:UserService.GetUser"
+    id: str
+    kind: str
+    # Dotted name path, e.g. "UserService.GetUser"
+    name: str
+    # Declaration with whitespace collapsed (None for kinds without one)
+    signature: Optional[str] = None
+
+
+@dataclass
+class ManifestChange:
+    """An entry present in both manifests whose declaration differs."""
+
+    old: ManifestEntry
+    new: ManifestEntry
+
+
+@dataclass
+class ManifestDiff:
+    """Differences between two API manifests, each list sorted by ID."""
+
+    added: List[ManifestEntry] = field(default_factory=list)
+    removed: List[ManifestEntry] = field(default_factory=list)
+    changed: List[ManifestChange] = field(default_factory=list)
+
+    @property
+    def breaking(self) -> bool:
+        """Check whether code using the old API may not build against the new one."""
+        return bool(self.removed or self.changed)
+
+    def to_dict(self) -> Dict[str, Any]:
+        """Convert to a plain dictionary, including breaking."""
+        return {**asdict(self), "breaking": self.breaking}
+
+
+async def api_manifest(
+    files: List[SourceFile],
+    language: str,
+    tools: Optional["AgentTools"] = None
+) -> bytes:
+    """Build a canonical manifest of a package's exported symbols.
+
+    The manifest is JSON listing every exported symbol reachable from the
+    package (exported members of exported types included) by stable ID,
+    kind, name and whitespace-normalized signature, sorted by ID and without
+    positions, so it only changes when the API does. Methods declared in a
+    different file from their type are listed under the type.
+
+    Args:
+        files: Files making up the package, all in the same language
+        language: Programming language of the files
+        tools: AgentTools instance to use (defaults to the global one)
+
+    Returns:
+        UTF-8 JSON ending in a newline, suitable for version control
+
+    Raises:
+        LanguageNotSupportedError: If no symbol extractor exists for language
+    """
+    parsed = await parse_files(files, language, tools=tools)
+    if tools is None:
+        from mcp_code_parser.api import _global_tools
+        tools = _global_tools
+    extractor = tools.get_extractor(language)
+
+    package = None
+    outlines = []
+    for pf in parsed:
+        package = package or extractor.package_name(pf.tree.root_node, pf.source)
+        outlines.append(Outline(language=language, symbols=pf.symbols, metadata={"file": pf.file.path}))
+
+    entries: List[ManifestEntry] = []
+    if outlines:
+        _collect_exported(merge_outlines(*outlines).symbols, "", entries)
+    # Several symbols can't share an ID unless the package doesn't compile
+    unique = {e.id: e for e in entries}
+    return _dump({
+        "format": MANIFEST_FORMAT,
+        "language": language,
+        "package": package,
+        "symbols": [asdict(unique[key]) for key in sorted(unique)],
+    })
+
+
+def diff_manifests(old: bytes, new: bytes) -> ManifestDiff:
+    """Compare two manifests from api_manifest, e.g. of consecutive releases.
+
+    An entry is changed when its ID is in both but its signature differs;
+    renamed symbols show up as removed and added.
+
+    Raises:
+        ValueError: If either manifest isn't valid api_manifest output
+    """
+    return _diff(_load_entries(old), _load_entries(new))
+
+
+def compare_outlines(old: Outline, new: Outline) -> ManifestDiff:
+    """Compare the exported API of two outlines, e.g. of a file before and after a change.
+
+    Entries are collected as api_manifest collects them, from symbols with
+    stable IDs (as extract_symbols and extract_file assign them).
+    """
+    return _diff(_outline_entries(old), _outline_entries(new))
+
+
+def _diff(old_entries: Dict[str, ManifestEntry], new_entries: Dict[str, ManifestEntry]) -> ManifestDiff:
+    """Compare entries by ID (see diff_manifests)."""
+    diff = ManifestDiff()
+    for key in sorted(set(old_entries) | set(new_entries)):
+        before, after = old_entries.get(key), new_entries.get(key)
+        if before is None:
+            diff.added.append(after)
+        elif after is None:
+            diff.removed.append(before)
+        elif before != after:
+            diff.changed.append(ManifestChange(old=before, new=after))
+    return diff
+
+
+def _collect_exported(symbols: List[Symbol], scope: Optional[str], entries: List[ManifestEntry]) -> None:
+    """Add entries for exported symbols whose ancestors are all exported."""
+    for sym in symbols:
+        if not sym.exported:
+            continue
+        name = qualify(sym, scope)
+        entries.append(ManifestEntry(
+            id=sym.stable_id,
+            kind=sym.kind,
+            name=name,
+            signature=" ".join(sym.signature.split()) if sym.signature else None,
+        ))
+        _collect_exported(sym.children, name, entries)
+
+
+def _outline_entries(outline: Outline) -> Dict[str, ManifestEntry]:
+    """Entries of an outline's exported symbols by ID."""
+    entries: List[ManifestEntry] = []
+    _collect_exported(outline.symbols, None, entries)
+    return {e.id: e for e in entries}
+
+
+def _load_entries(manifest: bytes) -> Dict[str, ManifestEntry]:
+    """Parse a manifest into entries by ID."""
+    try:
+        data = json.loads(manifest)
+        if data.get("format") != MANIFEST_FORMAT:
+            raise ValueError(f"Unsupported manifest format {data.get('format')!r}")
+        return {e["id"]: ManifestEntry(**e) for e in data["symbols"]}
+    except (AttributeError, KeyError, TypeError, json.JSONDecodeError) as e:
+        raise ValueError(f"Invalid API manifest: {e}") from e
+
+
+def _dump(data: Dict[str, Any]) -> bytes:
+    """Serialize a manifest deterministically."""
+    return (json.dumps(data, indent=2, sort_keys=True, ensure_ascii=False) + "\n").encode("utf8")
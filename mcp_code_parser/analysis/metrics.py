@@ -0,0 +1,160 @@
+"""Line counts and symbol statistics for source files."""
+
+from dataclasses import asdict, dataclass, field
+from pathlib import Path
+from typing import TYPE_CHECKING, Any, Dict, Optional
+
+from mcp_code_parser.comments import comment_ranges, comment_syntax
+from mcp_code_parser.errors import ToolError
+from mcp_code_parser.extractors.base import ExtractOptions
+from mcp_code_parser.logging import get_logger
+from mcp_code_parser.utils import detect_language_from_file, safe_read_file
+
+if TYPE_CHECKING:
+    from mcp_code_parser.api import AgentTools
+
+logger = get_logger("analysis.metrics")
+
+
+@dataclass
+class FileMetrics:
+    """Line and symbol counts for a file (or a sum over files)."""
+
+    total_lines: int = 0
+    code_lines: int = 0
+    comment_lines: int = 0
+    blank_lines: int = 0
+    # Symbols at any depth, e.g. {"function": 7, "method": 12}
+    symbols_by_kind: Dict[str, int] = field(default_factory=dict)
+
+    @property
+    def comment_ratio(self) -> float:
+        """Share of non-blank lines that are comments only (0 for empty files)."""
+        non_blank = self.code_lines + self.comment_lines
+        return self.comment_lines / non_blank if non_blank else 0.0
+
+    def to_dict(self) -> Dict[str, Any]:
+        """Convert to a plain dictionary, including comment_ratio."""
+        return {**asdict(self), "comment_ratio": self.comment_ratio}
+
+    def add(self, other: "FileMetrics") -> None:
+        """Add another file's counts to these, in place."""
+        self.total_lines += other.total_lines
+        self.code_lines += other.code_lines
+        self.comment_lines += other.comment_lines
+        self.blank_lines += other.blank_lines
+        for kind, count in other.symbols_by_kind.items():
+            self.symbols_by_kind[kind] = self.symbols_by_kind.get(kind, 0) + count
+
+
+@dataclass
+class DirMetrics:
+    """Metrics for every supported file under a directory."""
+
+    # Root-relative POSIX path to metrics, in path order
+    files: Dict[str, FileMetrics] = field(default_factory=dict)
+    total: FileMetrics = field(default_factory=FileMetrics)
+    # Files that couldn't be read or parsed, with the reason
+    errors: Dict[str, str] = field(default_factory=dict)
+
+
+async def file_metrics(
+    content: str,
+    language: str,
+    tools: Optional["AgentTools"] = None
+) -> FileMetrics:
+    """Count lines by type and symbols by kind.
+
+    A line is a comment line if everything on it but whitespace belongs to
+    comments, as recognised by the language's grammar or registered
+    CommentSyntax; a line with both code and a trailing comment is a code
+    line. Python docstrings are string literals, so they count as code.
+    Languages registered with only a CommentSyntax have no symbol counts.
+
+    Args:
+        content: Source code
+        language: Programming language
+        tools: AgentTools instance to use (defaults to the global one)
+
+    Raises:
+        ToolError: If the language has no symbol extractor, grammar or
+            CommentSyntax
+    """
+    if tools is None:
+        from mcp_code_parser.api import _global_tools
+        tools = _global_tools
+
+    metrics = FileMetrics()
+    if tools.get_extractor(language) is not None or comment_syntax(language) is None:
+        outline = await tools.extract_symbols(content, language)
+        outline.raise_for_error()
+        for flat in outline.flatten():
+            metrics.symbols_by_kind[flat.symbol.kind] = metrics.symbols_by_kind.get(flat.symbol.kind, 0) + 1
+
+    source = bytearray(content, "utf8")
+    original = bytes(source)
+    try:
+        comments = await comment_ranges(content, language, tools)
+    except ToolError as e:
+        # Formats embedding other languages have no grammar of their own
+        logger.debug(f"Not classifying {language} comments: {e}")
+    else:
+        for start, end in comments:
+            # Blank out comments, keeping newlines so lines stay aligned
+            source[start:end] = bytes(b if b == 0x0A else 0x20 for b in source[start:end])
+
+    lines = original.split(b"\n")
+    masked = bytes(source).split(b"\n")
+    if lines and lines[-1] == b"":
+        # A trailing newline ends the last line rather than starting another
+        lines.pop()
+        masked.pop()
+    metrics.total_lines = len(lines)
+    for line, code in zip(lines, masked):
+        if not line.strip():
+            metrics.blank_lines += 1
+        elif not code.strip():
+            metrics.comment_lines += 1
+        else:
+            metrics.code_lines += 1
+    return metrics
+
+
+async def dir_metrics(
+    root: str,
+    options: Optional[ExtractOptions] = None,
+    tools: Optional["AgentTools"] = None
+) -> DirMetrics:
+    """Compute file_metrics for every supported file under root and their total.
+
+    Walks the tree like extract_dir: hidden paths and linked directories are
+    skipped. Files that can't be measured, including those over
+    options.max_file_size, are listed in errors and left out of the total.
+
+    Args:
+        root: Directory to walk
+        options: Optional extraction options (only max_file_size is used)
+        tools: AgentTools instance to use (defaults to the global one)
+    """
+    if tools is None:
+        from mcp_code_parser.api import _global_tools
+        tools = _global_tools
+    from mcp_code_parser.api import _walk_files
+
+    options = options or ExtractOptions()
+    result = DirMetrics()
+    for path in _walk_files(Path(root)):
+        language = detect_language_from_file(str(path))
+        if tools.get_extractor(language or "") is None and comment_syntax(language or "") is None:
+            continue
+        rel = path.relative_to(root).as_posix()
+        try:
+            content = safe_read_file(str(path), max_size=options.max_file_size, detector=tools.binary_detector)
+            metrics = await file_metrics(content, language, tools)
+        except Exception as e:
+            result.errors[rel] = str(e)
+            continue
+        result.files[rel] = metrics
+        result.total.add(metrics)
+    return result
+
@@ -0,0 +1,390 @@
+"""Workspace-wide declaration and reference search by symbol name."""
+
+import re
+from dataclasses import dataclass, field
+from pathlib import Path
+from typing import TYPE_CHECKING, AsyncIterator, Dict, List, Optional, Set
+
+import tree_sitter
+
+from mcp_code_parser.globs import PathFilter
+from mcp_code_parser.ignore import IgnoreRules
+from mcp_code_parser.index import Index, IndexMatch, MemoryStorage
+from mcp_code_parser.logging import get_logger
+from mcp_code_parser.redact import Redactor, merge_counts
+from mcp_code_parser.utils import detect_language_from_file, safe_read_file
+
+if TYPE_CHECKING:
+    from mcp_code_parser.api import AgentTools
+
+logger = get_logger("analysis.references")
+
+# Nodes inside which code can appear again, e.g. `${name}` or f"{name}"
+_CODE_IN_LITERAL_NODES = ("template_substitution", "interpolation", "string_interpolation")
+
+
+@dataclass
+class Reference:
+    """An occurrence of a name outside its declaration."""
+
+    # Root-relative POSIX path
+    file: str
+    line: int
+    # 1-based, counting UTF-8 bytes like Diagnostic.column
+    column: int
+    # The source line, without surrounding whitespace
+    text: str
+    # How a Go method is used: "call", "method_value" for `s.GetUser`
+    # passed around uncalled, or "method_expression" for
+    # `(*UserService).GetUser`; None for other uses and other languages
+    kind: Optional[str] = None
+    # With context_before and context_after: the lines just before and after
+    # the use's line, as in the file (fewer near the file's ends, or where a
+    # neighbouring use already shows them)
+    before: List[str] = field(default_factory=list)
+    after: List[str] = field(default_factory=list)
+    # Secrets masked in text, before and after by AgentTools.redactor, by rule
+    redactions: Dict[str, int] = field(default_factory=dict)
+
+
+@dataclass
+class SymbolLocations:
+    """Where a name is declared and used."""
+
+    declarations: List[IndexMatch] = field(default_factory=list)
+    references: List[Reference] = field(default_factory=list)
+
+
+async def where_is_symbol(
+    root: str,
+    name: str,
+    index: Optional[Index] = None,
+    include_comments_and_strings: bool = False,
+    include: Optional[List[str]] = None,
+    exclude: Optional[List[str]] = None,
+    context_before: int = 0,
+    context_after: int = 0,
+    tools: Optional["AgentTools"] = None
+) -> SymbolLocations:
+    """Find the declarations of a name under root and the places it is used.
+
+    Declarations come from the symbol index, so outlines of unchanged files
+    are reused between calls when the same index is passed in. Uses are found
+    by whole-word text search and then, by default, checked against the
+    syntax tree to drop matches in comments and string literals. This is a
+    name-based approximation: other symbols sharing the name count as uses.
+    Paths ignored by .gitignore files and hidden paths are skipped. When the
+    name is declared as a Go method, uses in Go files get a kind telling
+    calls from method values and method expressions. Secrets in the lines
+    given are masked when tools has a redactor.
+
+    Args:
+        root: Directory to search
+        name: Symbol name, e.g. "GetUser"
+        index: Index over root to take declarations from (a fresh in-memory
+            index is built if None); it is updated before use
+        include_comments_and_strings: Keep matches in comments and strings
+        include: Only search files matching one of these glob patterns
+            (e.g. "src/**/*.{ts,tsx}"; see mcp_code_parser.globs)
+        exclude: Skip files matching one of these glob patterns
+        context_before: Lines of context to give before each use, like
+            `grep -B`
+        context_after: Lines of context to give after each use, like
+            `grep -A`
+        tools: AgentTools instance to use (defaults to the global one)
+
+    Returns:
+        Declarations and references, each in file then source order
+
+    Raises:
+        ValueError: If a context line count is negative
+    """
+    search = await _Search.start(root, name, index, include, exclude, tools)
+    references = [
+        ref async for ref in search.references(include_comments_and_strings, context_before, context_after)
+    ]
+    return SymbolLocations(declarations=search.declarations, references=references)
+
+
+async def iter_references(
+    root: str,
+    name: str,
+    index: Optional[Index] = None,
+    include_comments_and_strings: bool = False,
+    include: Optional[List[str]] = None,
+    exclude: Optional[List[str]] = None,
+    context_before: int = 0,
+    context_after: int = 0,
+    tools: Optional["AgentTools"] = None
+) -> AsyncIterator[Reference]:
+    """Yield the uses of a name under root as each file is searched.
+
+    Same search and arguments as where_is_symbol, but references stream out
+    a file at a time instead of being collected, so a caller can show the
+    first matches of a large tree early or stop partway.
+
+    Yields:
+        References in file then source order
+
+    Raises:
+        ValueError: If a context line count is negative
+    """
+    search = await _Search.start(root, name, index, include, exclude, tools)
+    async for ref in search.references(include_comments_and_strings, context_before, context_after):
+        yield ref
+
+
+class _Search:
+    """One where_is_symbol or iter_references search."""
+
+    def __init__(
+        self,
+        root: str,
+        name: str,
+        declarations: List[IndexMatch],
+        ignore: IgnoreRules,
+        paths: PathFilter,
+        tools: "AgentTools"
+    ):
+        self.root = root
+        self.name = name
+        self.declarations = declarations
+        self.ignore = ignore
+        self.paths = paths
+        self.tools = tools
+
+    @classmethod
+    async def start(
+        cls,
+        root: str,
+        name: str,
+        index: Optional[Index],
+        include: Optional[List[str]],
+        exclude: Optional[List[str]],
+        tools: Optional["AgentTools"]
+    ) -> "_Search":
+        """Update the index and look up the name's declarations."""
+        if tools is None:
+            from mcp_code_parser.api import _global_tools
+            tools = _global_tools
+        if index is None:
+            index = Index(root, MemoryStorage(), tools=tools, respect_gitignore=True)
+        await index.update()
+
+        ignore = IgnoreRules.load(root)
+        paths = PathFilter(include, exclude)
+        declarations = [
+            m for m in index.query(name=name)
+            if not _ignored_path(ignore, m.file) and paths.matches(m.file)
+        ]
+        return cls(root, name, declarations, ignore, paths, tools)
+
+    async def references(
+        self,
+        include_comments_and_strings: bool,
+        context_before: int,
+        context_after: int
+    ) -> AsyncIterator[Reference]:
+        """Search every file for uses of the name."""
+        from mcp_code_parser.api import _walk_files
+
+        if context_before < 0 or context_after < 0:
+            raise ValueError("Context line counts can't be negative")
+        # Each declaration mentions its own name once; don't report that as a use
+        declared: Dict[str, List[IndexMatch]] = {}
+        for match in self.declarations:
+            declared.setdefault(match.file, []).append(match)
+        # Types a method expression may be written on, e.g. UserService
+        receivers = {
+            m.symbol.receiver for m in self.declarations if m.symbol.kind == "method" and m.symbol.receiver
+        }
+
+        tools = self.tools
+        word = re.compile(rb"(?<![\w$])" + re.escape(self.name.encode("utf8")) + rb"(?![\w$])")
+        for path in _walk_files(Path(self.root), self.ignore):
+            language = detect_language_from_file(str(path))
+            if tools.get_extractor(language or "") is None:
+                continue
+            rel = path.relative_to(self.root).as_posix()
+            if not self.paths.matches(rel):
+                continue
+            try:
+                content = safe_read_file(str(path), detector=tools.binary_detector)
+            except Exception as e:
+                logger.debug(f"Not searching {rel}: {e}")
+                continue
+
+            source = content.encode("utf8")
+            offsets = [m.start() for m in word.finditer(source)]
+            if not offsets:
+                continue
+            offsets = _without_declaration_names(offsets, declared.get(rel, []), source, word)
+            length = len(self.name.encode("utf8"))
+            classify = bool(receivers) and language == "go"
+            tree = None
+            if not include_comments_and_strings or classify:
+                tree = await _parse(content, language, tools)
+            if not include_comments_and_strings and tree is not None:
+                offsets = _outside_comments_and_strings(offsets, length, tree)
+
+            references = []
+            for offset in offsets:
+                line_start = source.rfind(b"\n", 0, offset) + 1
+                line_end = source.find(b"\n", offset)
+                line = source[line_start:line_end if line_end != -1 else len(source)]
+                references.append(Reference(
+                    file=rel,
+                    line=source.count(b"\n", 0, offset) + 1,
+                    column=offset - line_start + 1,
+                    text=line.decode("utf8", errors="replace").strip(),
+                    kind=_go_method_use(tree, offset, length, source, receivers) if classify else None,
+                ))
+            if context_before or context_after:
+                _add_context(references, source, context_before, context_after)
+            if tools.redactor is not None:
+                for ref in references:
+                    _redact(ref, tools.redactor)
+            for ref in references:
+                yield ref
+
+
+def _add_context(references: List[Reference], source: bytes, before: int, after: int) -> None:
+    """Fill in the context lines of a file's references, in source order.
+
+    As with grep, a line is given once: context stops short of the next use's
+    line, and a use's leading context starts after what the previous use
+    already showed.
+    """
+    lines = source.split(b"\n")
+    if lines and lines[-1] == b"":
+        # A trailing newline ends the last line rather than starting another
+        lines.pop()
+
+    def text(number: int) -> str:
+        return lines[number - 1].decode("utf8", errors="replace").rstrip("\r")
+
+    shown = 0
+    for i, ref in enumerate(references):
+        first = max(ref.line - before, shown + 1, 1)
+        ref.before = [text(n) for n in range(first, ref.line)]
+        last = min(ref.line + after, len(lines))
+        following = next((r.line for r in references[i + 1:] if r.line > ref.line), None)
+        if following is not None:
+            last = min(last, following - 1)
+        ref.after = [text(n) for n in range(ref.line + 1, last + 1)]
+        shown = max(shown, ref.line, last)
+
+
+def _redact(ref: Reference, redactor: Redactor) -> None:
+    """Mask secrets in a reference's line and context lines."""
+    ref.text, counts = redactor.redact(ref.text)
+    merge_counts(ref.redactions, counts)
+    for lines in (ref.before, ref.after):
+        for i, line in enumerate(lines):
+            lines[i], counts = redactor.redact(line)
+            merge_counts(ref.redactions, counts)
+
+
+def _ignored_path(ignore: IgnoreRules, rel: str) -> bool:
+    """Whether a file or any directory above it is ignored."""
+    parts = rel.split("/")
+    for i in range(1, len(parts)):
+        if ignore.is_ignored("/".join(parts[:i]), is_dir=True):
+            return True
+    return ignore.is_ignored(rel)
+
+
+def _without_declaration_names(
+    offsets: List[int],
+    declarations: List[IndexMatch],
+    source: bytes,
+    word: re.Pattern
+) -> List[int]:
+    """Drop the first occurrence of the name inside each declaration, which is its name."""
+    names = set()
+    for match in declarations:
+        found = word.search(source, match.symbol.start_byte, match.symbol.end_byte)
+        if found is not None:
+            names.add(found.start())
+    return [o for o in offsets if o not in names]
+
+
+async def _code_offsets(
+    offsets: List[int],
+    length: int,
+    content: str,
+    language: str,
+    tools: "AgentTools"
+) -> List[int]:
+    """Keep the offsets that aren't inside a comment or string literal."""
+    tree = await _parse(content, language, tools)
+    if tree is None:
+        return offsets
+    return _outside_comments_and_strings(offsets, length, tree)
+
+
+def _outside_comments_and_strings(offsets: List[int], length: int, tree: tree_sitter.Tree) -> List[int]:
+    """Offsets in a parsed file that aren't inside a comment or string literal."""
+    return [o for o in offsets if not _in_comment_or_string(tree.root_node.descendant_for_byte_range(o, o + length))]
+
+
+async def _parse(content: str, language: str, tools: "AgentTools") -> Optional[tree_sitter.Tree]:
+    """Syntax tree of a file, or None if its language has no grammar."""
+    try:
+        return await tools.parse_tree(content, language)
+    except Exception as e:
+        # Embedded-language formats have no grammar of their own; keep every match
+        logger.debug(f"Not filtering {language} matches: {e}")
+        return None
+
+
+def _in_comment_or_string(node: Optional[tree_sitter.Node]) -> bool:
+    """Whether a node is (inside) a comment or a string literal's text."""
+    while node is not None:
+        if node.type in _CODE_IN_LITERAL_NODES:
+            return False
+        if "comment" in node.type or "string" in node.type or node.type == "char_literal":
+            return True
+        node = node.parent
+    return False
+
+
+def _go_method_use(
+    tree: Optional[tree_sitter.Tree],
+    offset: int,
+    length: int,
+    source: bytes,
+    receivers: Set[str]
+) -> Optional[str]:
+    """Kind of a use of a Go method name (see Reference.kind)."""
+    if tree is None:
+        return None
+    node = tree.root_node.descendant_for_byte_range(offset, offset + length)
+    selector = node.parent if node is not None else None
+    if selector is None or selector.type != "selector_expression" or selector.child_by_field_name("field") != node:
+        return None
+    # `(s.GetUser)()` is still a call
+    used = selector
+    while used.parent is not None and used.parent.type == "parenthesized_expression":
+        used = used.parent
+    call = used.parent
+    if call is not None and call.type == "call_expression" and call.child_by_field_name("function") == used:
+        return "call"
+    if _type_operand(selector.child_by_field_name("operand"), source) in receivers:
+        return "method_expression"
+    return "method_value"
+
+
+def _type_operand(node: Optional[tree_sitter.Node], source: bytes) -> Optional[str]:
+    """Type name a selector's operand could be, e.g. UserService for `(*UserService)` or `pkg.UserService`."""
+    while node is not None and node.type == "parenthesized_expression" and node.named_children:
+        node = node.named_children[0]
+        if node.type == "unary_expression":
+            node = node.child_by_field_name("operand")
+    if node is None:
+        return None
+    if node.type == "selector_expression":
+        node = node.child_by_field_name("field")
+    if node is not None and node.type in ("identifier", "type_identifier", "field_identifier"):
+        return source[node.start_byte:node.end_byte].decode("utf8")
+    return None
@@ -0,0 +1,166 @@
+"""Suggesting the symbols worth reading alongside a given one."""
+
+import re
+from dataclasses import dataclass, field
+from typing import TYPE_CHECKING, Dict, List, Optional, Set
+
+from mcp_code_parser.analysis.calls import call_graph
+from mcp_code_parser.errors import NotFoundError
+from mcp_code_parser.extractors.base import CALLABLE_KINDS, FlatSymbol, Outline, Symbol
+
+if TYPE_CHECKING:
+    from mcp_code_parser.api import AgentTools
+
+# How much each relation adds to a related symbol's score
+RELATION_WEIGHTS = {
+    "parent": 5.0,
+    "calls": 4.0,
+    "called_by": 4.0,
+    "signature_type": 3.0,
+    "sibling": 1.0,
+}
+# Added to a sibling's score per word its name shares with the symbol's,
+# e.g. "User" in GetUser and CreateUser
+SHARED_WORD_WEIGHT = 0.5
+
+# Kinds that signatures can refer to by name
+_TYPE_KINDS = ("class", "struct", "interface", "type", "union", "enum")
+
+_IDENTIFIER = re.compile(r"[A-Za-z_]\w*")
+# Words of camelCase, PascalCase and snake_case names
+_WORD = re.compile(r"[A-Z]+(?![a-z])|[A-Z]?[a-z]+|\d+")
+
+
+@dataclass
+class RelatedSymbol:
+    """A symbol related to another, with why and how strongly."""
+
+    symbol: Symbol
+    qualified_name: str
+    # Sum of the weights of its relations (see RELATION_WEIGHTS)
+    score: float
+    # Relations in RELATION_WEIGHTS order, e.g. ["parent", "signature_type"]
+    relations: List[str] = field(default_factory=list)
+
+
+def related_symbols(
+    outline: Outline,
+    stable_id: str,
+    graph: Optional[Dict[str, List[str]]] = None,
+    limit: Optional[int] = None
+) -> List[RelatedSymbol]:
+    """Rank the symbols of an outline by how related they are to one of them.
+
+    Relations are the symbol's parent (its containing type, or a Go method's
+    receiver type), types named in its parameters, results or signature,
+    siblings under the same parent, and with a call graph the callables it
+    calls or is called by.
+
+    Args:
+        outline: Outline with stable IDs, e.g. from extract_file
+        stable_id: Stable ID of the symbol, e.g. "method:UserService.GetUser"
+        graph: Call graph of the file (see call_graph); without it call
+            relations aren't considered
+        limit: Maximum number of results
+
+    Returns:
+        Related symbols, highest score first, then in source order
+
+    Raises:
+        NotFoundError: If no symbol has the stable ID
+    """
+    flat = outline.flatten()
+    target = next((f for f in flat if f.symbol.stable_id == stable_id), None)
+    if target is None:
+        raise NotFoundError(f"Symbol not found: {stable_id}")
+    by_id = {f.symbol.stable_id: f for f in flat}
+    relations: Dict[str, Set[str]] = {}
+
+    def relate(item: Optional[FlatSymbol], relation: str) -> None:
+        if item is not None and item.symbol.stable_id != stable_id:
+            relations.setdefault(item.symbol.stable_id, set()).add(relation)
+
+    types = {f.symbol.name: f for f in flat if f.symbol.kind in _TYPE_KINDS}
+    parent = by_id.get(target.parent_stable_id) if target.parent_stable_id else None
+    if parent is None and target.symbol.receiver:
+        parent = types.get(target.symbol.receiver)
+    relate(parent, "parent")
+
+    for name in _referenced_names(target.symbol):
+        relate(types.get(name), "signature_type")
+
+    if parent is not None:
+        for item in flat:
+            if item.parent_stable_id == parent.symbol.stable_id or (
+                item.symbol.receiver == parent.symbol.name and item.symbol.kind in CALLABLE_KINDS
+            ):
+                relate(item, "sibling")
+
+    if graph is not None:
+        for callee in graph.get(stable_id, []):
+            relate(by_id.get(callee), "calls")
+        for caller, callees in graph.items():
+            if stable_id in callees:
+                relate(by_id.get(caller), "called_by")
+
+    words = set(_WORD.findall(target.symbol.name))
+    order = {f.symbol.stable_id: i for i, f in enumerate(flat)}
+    ranked = []
+    for related_id, found in relations.items():
+        item = by_id[related_id]
+        score = sum(RELATION_WEIGHTS[r] for r in found)
+        if "sibling" in found:
+            score += SHARED_WORD_WEIGHT * len(words & set(_WORD.findall(item.symbol.name)))
+        ranked.append(RelatedSymbol(
+            symbol=item.symbol,
+            qualified_name=item.qualified_name,
+            score=score,
+            relations=[r for r in RELATION_WEIGHTS if r in found],
+        ))
+    ranked.sort(key=lambda r: (-r.score, order[r.symbol.stable_id]))
+    return ranked[:limit] if limit is not None else ranked
+
+
+async def find_related_symbols(
+    content: str,
+    language: str,
+    stable_id: str,
+    limit: Optional[int] = None,
+    tools: Optional["AgentTools"] = None
+) -> List[RelatedSymbol]:
+    """Rank the symbols of some source by relatedness to one, call graph included.
+
+    Args:
+        content: Source code
+        language: Programming language
+        stable_id: Stable ID of the symbol
+        limit: Maximum number of results
+        tools: AgentTools instance to use (defaults to the global one)
+
+    Returns:
+        Related symbols, highest score first (see related_symbols)
+
+    Raises:
+        NotFoundError: If no symbol has the stable ID
+        ToolError: If symbols can't be extracted (e.g. unsupported language)
+    """
+    if tools is None:
+        from mcp_code_parser.api import _global_tools
+        tools = _global_tools
+
+    outline = await tools.extract_symbols(content, language)
+    outline.raise_for_error()
+    tree = await tools.parse_tree(content, language)
+    graph = call_graph(outline.symbols, tree.root_node, bytes(content, "utf8"))
+    return related_symbols(outline, stable_id, graph, limit)
+
+
+def _referenced_names(sym: Symbol) -> List[str]:
+    """Identifiers in a symbol's parameter and result types, or else its signature."""
+    texts = [p.type for p in sym.params + sym.results]
+    if not texts and sym.signature and sym.kind not in CALLABLE_KINDS:
+        texts = [sym.signature]
+    names: List[str] = []
+    for text in texts:
+        names.extend(n for n in _IDENTIFIER.findall(text) if n != sym.name)
+    return names
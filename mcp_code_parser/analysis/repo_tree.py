@@ -0,0 +1,188 @@
+"""Directory trees of a source tree annotated with languages and symbol counts."""
+
+import logging
+from dataclasses import asdict, dataclass, field
+from pathlib import Path
+from typing import TYPE_CHECKING, Any, Dict, List, Optional, Sequence
+
+from mcp_code_parser.errors import NotFoundError
+from mcp_code_parser.globs import PathFilter
+from mcp_code_parser.ignore import IgnoreRules
+from mcp_code_parser.utils import detect_language_from_file
+
+if TYPE_CHECKING:
+    from mcp_code_parser.api import AgentTools
+
+logger = logging.getLogger(__name__)
+
+
+@dataclass
+class TreeNode:
+    """A file or directory of a repo tree."""
+
+    name: str
+    # Root-relative POSIX path, "." for the root
+    path: str
+    # "file" or "directory"
+    kind: str
+    # Detected language of a file, None if it has none
+    language: Optional[str] = None
+    # Symbols in a file's outline, nested ones included; for a directory,
+    # those of every file below it
+    symbol_count: int = 0
+    # Files below a directory (1 for a file)
+    file_count: int = 0
+    # Files below a directory by language, e.g. {"go": 3, "python": 1}
+    languages: Dict[str, int] = field(default_factory=dict)
+    # A directory at max_depth: its children are left out, not its counts
+    truncated: bool = False
+    # Why a file's symbols couldn't be counted
+    error: Optional[str] = None
+    children: List["TreeNode"] = field(default_factory=list)
+
+    def to_dict(self) -> Dict[str, Any]:
+        """Convert the node (and its children) to a plain dictionary."""
+        return asdict(self)
+
+    @classmethod
+    def from_dict(cls, data: Dict[str, Any]) -> "TreeNode":
+        """Rebuild a node from to_dict output."""
+        data = dict(data)
+        data["children"] = [cls.from_dict(c) for c in data.get("children", [])]
+        return cls(**data)
+
+    def render(self) -> str:
+        """Render the tree as indented text, one line per node.
+
+        Directories end with "/" and their totals, files show their
+        language and symbol count, e.g. "  store.go  go  2 symbols".
+        """
+        lines: List[str] = []
+
+        def visit(node: "TreeNode", depth: int) -> None:
+            indent = "  " * depth
+            if node.kind == "directory":
+                more = " ..." if node.truncated else ""
+                counts = f"{_plural(node.file_count, 'file')}, {_plural(node.symbol_count, 'symbol')}"
+                lines.append(f"{indent}{node.name}/  {counts}{more}")
+            else:
+                language = node.language or "-"
+                lines.append(f"{indent}{node.name}  {language}  {_plural(node.symbol_count, 'symbol')}")
+            for child in node.children:
+                visit(child, depth + 1)
+
+        visit(self, 0)
+        return "\n".join(lines)
+
+
+async def repo_tree(
+    root: str,
+    max_depth: Optional[int] = None,
+    include: Optional[Sequence[str]] = None,
+    exclude: Optional[Sequence[str]] = None,
+    tools: Optional["AgentTools"] = None
+) -> TreeNode:
+    """Build the directory tree under root with per-file symbol counts.
+
+    Each file is outlined with tools.extract_file, so with a result_store
+    on tools files unchanged since an earlier call are counted from the
+    cache. Files of languages without an extractor are listed with no
+    symbols; files that fail to extract are listed with their error.
+    Directories total the files and symbols below them, whatever
+    max_depth leaves out. Paths ignored by .gitignore files and hidden
+    paths are skipped, and directories with no files left aren't listed.
+
+    Args:
+        root: Directory to walk
+        max_depth: Levels of children to list below root (1 lists only
+            its entries); deeper directories are marked truncated
+        include: Only list files matching one of these glob patterns
+        exclude: Skip files matching one of these glob patterns
+        tools: AgentTools instance to use (defaults to the global one)
+
+    Returns:
+        The root's node, with children sorted by name
+
+    Raises:
+        NotFoundError: If root isn't a directory
+        ValueError: If max_depth is less than 1
+    """
+    if tools is None:
+        from mcp_code_parser.api import _global_tools
+        tools = _global_tools
+    from mcp_code_parser.api import _walk_files
+
+    if not Path(root).is_dir():
+        raise NotFoundError(f"Root directory not found: {root}")
+    if max_depth is not None and max_depth < 1:
+        raise ValueError("max_depth must be at least 1")
+    root_path = Path(root).resolve()
+    paths = PathFilter(include, exclude)
+
+    tree = TreeNode(name=root_path.name, path=".", kind="directory")
+    directories: Dict[str, TreeNode] = {".": tree}
+    for path in _walk_files(root_path, IgnoreRules.load(str(root_path))):
+        rel = path.relative_to(root_path).as_posix()
+        if not paths.matches(rel):
+            continue
+        node = await _file_node(path, rel, tools)
+        parent = tree
+        for part in rel.split("/")[:-1]:
+            directory = parent.path + "/" + part if parent.path != "." else part
+            if directory not in directories:
+                directories[directory] = TreeNode(name=part, path=directory, kind="directory")
+                parent.children.append(directories[directory])
+            parent = directories[directory]
+        parent.children.append(node)
+
+    _total(tree)
+    if max_depth is not None:
+        _truncate(tree, max_depth)
+    return tree
+
+
+async def _file_node(path: Path, rel: str, tools: "AgentTools") -> TreeNode:
+    """Node of one file, with its symbols counted if it has an extractor."""
+    language = detect_language_from_file(str(path))
+    node = TreeNode(name=path.name, path=rel, kind="file", language=language, file_count=1)
+    if language is not None:
+        node.languages = {language: 1}
+    if tools.get_extractor(language or "") is None:
+        return node
+    outline = await tools.extract_file(str(path), language)
+    if not outline.success:
+        logger.debug(f"Not counting symbols of {rel}: {outline.error}")
+        node.error = outline.error
+    node.symbol_count = len(outline.flatten())
+    return node
+
+
+def _total(node: TreeNode) -> None:
+    """Fill in a directory's counts from its children."""
+    if node.kind != "directory":
+        return
+    node.children.sort(key=lambda c: c.name)
+    for child in node.children:
+        _total(child)
+        node.symbol_count += child.symbol_count
+        node.file_count += child.file_count
+        for language, count in child.languages.items():
+            node.languages[language] = node.languages.get(language, 0) + count
+    node.languages = dict(sorted(node.languages.items()))
+
+
+def _truncate(node: TreeNode, depth: int) -> None:
+    """Drop the children of directories more than depth levels below node."""
+    for child in node.children:
+        if child.kind != "directory":
+            continue
+        if depth <= 1:
+            child.truncated = bool(child.children)
+            child.children = []
+        else:
+            _truncate(child, depth - 1)
+
+
+def _plural(count: int, noun: str) -> str:
+    """Count with its noun, e.g. "1 file" or "3 files"."""
+    return f"{count} {noun}" if count == 1 else f"{count} {noun}s"
@@ -0,0 +1,566 @@
+"""Best-effort maps of the HTTP routes a service registers."""
+
+import ast
+import re
+from abc import ABC, abstractmethod
+from dataclasses import dataclass
+from pathlib import Path
+from typing import TYPE_CHECKING, Dict, List, Optional, Sequence, Tuple
+
+import tree_sitter
+
+from mcp_code_parser.analysis.base import ParsedFile, parse_files, walk
+from mcp_code_parser.extractors.base import CALLABLE_KINDS, AttributeArg, SourceFile, _collapse, node_text, walk_symbols
+from mcp_code_parser.globs import PathFilter
+from mcp_code_parser.ignore import IgnoreRules
+from mcp_code_parser.logging import get_logger
+from mcp_code_parser.utils import detect_language_from_file, safe_read_file
+
+if TYPE_CHECKING:
+    from mcp_code_parser.api import AgentTools
+
+logger = get_logger("analysis.routes")
+
+# Method of routes served whatever the request method, e.g. http.HandleFunc
+ANY_METHOD = "*"
+
+_HTTP_METHODS = ("GET", "POST", "PUT", "PATCH", "DELETE", "HEAD", "OPTIONS", "CONNECT", "TRACE")
+# Go 1.22 ServeMux patterns like "GET /users/{id}"
+_METHOD_PATTERN = re.compile(r"^([A-Z]+)\s+(\S.*)$")
+
+
+@dataclass
+class Route:
+    """An HTTP route registration."""
+
+    # Upper case request method, or ANY_METHOD
+    method: str
+    # Path pattern as the framework writes it, e.g. "/users/{id}" or
+    # "/users/:id", with the prefixes of enclosing groups and blueprints;
+    # the source text when the path isn't a literal
+    path: str
+    # Handler as written, e.g. "h.ListUsers"; None for inline handlers
+    handler: Optional[str]
+    # Stable ID of the handler and the root-relative POSIX path of its
+    # file, when the handler resolves to exactly one symbol under the root
+    handler_id: Optional[str] = None
+    handler_file: Optional[str] = None
+    # Recognised framework, e.g. "chi" or "flask"
+    framework: str = ""
+    # Root-relative POSIX path and 1-based line of the registration
+    file: str = ""
+    line: int = 0
+
+
+class RouteRecognizer(ABC):
+    """Finds the route registrations of one or more frameworks in a file."""
+
+    # Languages of the files the recognizer looks at
+    languages: Tuple[str, ...] = ()
+
+    @abstractmethod
+    def routes(self, pf: ParsedFile) -> List[Route]:
+        """Find the routes registered in a parsed file.
+
+        Routes should have method, path, handler, framework and line set;
+        handler_id may be left None for extract_routes to resolve by name.
+        """
+        pass
+
+
+class GoRoutes(RouteRecognizer):
+    """net/http, gorilla/mux, chi and gin (and echo-style) registrations.
+
+    Recognises `mux.HandleFunc("GET /users/{id}", h)` and `Handle` (which
+    chi routers share), with a gorilla `.Methods("GET")` chained on; chi's `r.Get(path, h)`, `Method`
+    and `MethodFunc`; and gin's `r.GET(path, h)`, `Any` and three-argument
+    `Handle`. Prefixes of chi `r.Route("/v1", func(r chi.Router) {...})`
+    blocks and of `v1 := r.Group("/v1")` groups are followed within a
+    function.
+    """
+
+    languages = ("go",)
+
+    def routes(self, pf: ParsedFile) -> List[Route]:
+        """Find the routes registered in a Go file."""
+        imports = _go_imports(pf)
+        routes: List[Route] = []
+        self._visit(pf.tree.root_node, pf.source, {}, imports, routes)
+        return routes
+
+    def _visit(
+        self,
+        node: tree_sitter.Node,
+        source: bytes,
+        prefixes: Dict[str, str],
+        imports: List[str],
+        routes: List[Route]
+    ) -> None:
+        """Collect routes under node, with the path prefixes of router variables in scope."""
+        if node.type in ("function_declaration", "method_declaration"):
+            prefixes = {}
+        if node.type == "call_expression":
+            nested = self._route_block(node, source, prefixes)
+            if nested is not None:
+                body, inner = nested
+                self._visit(body, source, inner, imports, routes)
+                return
+            route = self._registration(node, source, prefixes, imports)
+            if route is not None:
+                routes.append(route)
+        if node.type in ("short_var_declaration", "assignment_statement"):
+            _record_group(node, source, prefixes)
+
+        for child in node.children:
+            self._visit(child, source, prefixes, imports, routes)
+
+    def _route_block(
+        self,
+        call: tree_sitter.Node,
+        source: bytes,
+        prefixes: Dict[str, str]
+    ) -> Optional[Tuple[tree_sitter.Node, Dict[str, str]]]:
+        """The body of a chi `r.Route(path, func(r chi.Router) {...})` and its prefixes."""
+        receiver, name, args = _go_call(call, source)
+        if name != "Route" or len(args) != 2 or args[1].type != "func_literal":
+            return None
+        path = _go_string(args[0], source)
+        params = args[1].child_by_field_name("parameters")
+        body = args[1].child_by_field_name("body")
+        if path is None or params is None or body is None:
+            return None
+        inner = dict(prefixes)
+        for param in (p for p in params.named_children if p.type == "parameter_declaration"):
+            for ident in param.children_by_field_name("name"):
+                inner[node_text(ident, source)] = _join(prefixes.get(receiver, ""), path)
+        return body, inner
+
+    def _registration(
+        self,
+        call: tree_sitter.Node,
+        source: bytes,
+        prefixes: Dict[str, str],
+        imports: List[str]
+    ) -> Optional[Route]:
+        """Route registered by a call, or None for other calls."""
+        receiver, name, args = _go_call(call, source)
+        if name is None:
+            return None
+
+        method: Optional[str] = None
+        path_node: Optional[tree_sitter.Node] = None
+        handlers = args[1:]
+        if name in ("HandleFunc", "Handle") and len(args) == 2:
+            path_node = args[0]
+            framework = "gorilla" if _imports_module(imports, "github.com/gorilla/mux") else "net/http"
+            method = _chained_methods(call, source) or ANY_METHOD
+        elif name in ("Method", "MethodFunc", "Handle") and len(args) >= 3:
+            method = (_go_string(args[0], source) or "").upper() or None
+            path_node = args[1]
+            handlers = args[2:]
+            framework = "chi" if name != "Handle" else _go_upper_framework(imports)
+        elif name.upper() in _HTTP_METHODS and name == name.title() and len(args) == 2:
+            method = name.upper()
+            path_node = args[0]
+            framework = "chi"
+        elif (name in _HTTP_METHODS or name == "Any") and len(args) >= 2:
+            method = name if name != "Any" else ANY_METHOD
+            path_node = args[0]
+            framework = _go_upper_framework(imports)
+        else:
+            return None
+        if method is None or path_node is None:
+            return None
+
+        path = _go_string(path_node, source)
+        if path is None:
+            # Paths held in constants are common with ServeMux, but `cache.Get(ctx, key)`
+            # isn't a route
+            if name not in ("HandleFunc", "Handle") or path_node.type not in (
+                "identifier", "selector_expression", "binary_expression"
+            ):
+                return None
+            path = _collapse(node_text(path_node, source))
+        elif name not in ("HandleFunc", "Handle") and not path.startswith("/"):
+            return None
+        elif method == ANY_METHOD and framework == "net/http":
+            matched = _METHOD_PATTERN.match(path)
+            if matched and matched.group(1) in _HTTP_METHODS:
+                method, path = matched.group(1), matched.group(2)
+        handler = _go_handler(handlers[-1], source) if handlers else None
+        return Route(
+            method=method,
+            path=_join(prefixes.get(receiver, ""), path),
+            handler=handler,
+            framework=framework,
+            line=call.start_point[0] + 1,
+        )
+
+
+class PythonRoutes(RouteRecognizer):
+    """Flask and FastAPI route decorators.
+
+    Recognises `@app.route(path, methods=[...])` (GET when methods isn't
+    given), `@router.api_route` and the `@app.get(path)` family, adding the
+    `url_prefix` of a Blueprint or the `prefix` of an APIRouter assigned to
+    the decorator's object at module level.
+    """
+
+    languages = ("python",)
+
+    def routes(self, pf: ParsedFile) -> List[Route]:
+        """Find the routes registered in a Python file."""
+        framework = "fastapi" if re.search(rb"^\s*(from|import)\s+fastapi\b", pf.source, re.M) else "flask"
+        prefixes = _python_prefixes(pf)
+        routes = []
+        for sym in walk_symbols(pf.symbols):
+            if sym.kind not in CALLABLE_KINDS:
+                continue
+            for attribute in sym.attributes:
+                owner, _, decorator = attribute.name.rpartition(".")
+                if not owner or not attribute.arguments:
+                    continue
+                methods = _decorator_methods(decorator, attribute.arguments)
+                if methods is None:
+                    continue
+                path_arg = next(
+                    (a for a in attribute.arguments if a.keyword in (None, "rule", "path")),
+                    None,
+                )
+                if path_arg is None:
+                    continue
+                path = path_arg.value if path_arg.literal and isinstance(path_arg.value, str) else path_arg.raw
+                for method in methods:
+                    routes.append(Route(
+                        method=method,
+                        path=_join(prefixes.get(owner, ""), path),
+                        handler=sym.name,
+                        handler_id=sym.stable_id,
+                        framework=framework,
+                        line=sym.start_line,
+                    ))
+        return routes
+
+
+class ExpressRoutes(RouteRecognizer):
+    """Express (and Express-like) `app.get(path, ...handlers)` registrations.
+
+    Recognises `get`, `post`, `put`, `patch`, `delete`, `head`, `options`
+    and `all` on any object when the first argument is a literal path, and
+    chains like `app.route("/books").get(list).post(create)`. Prefixes of
+    routers mounted with `app.use("/api", router)` aren't followed.
+    """
+
+    languages = ("javascript", "typescript")
+
+    def routes(self, pf: ParsedFile) -> List[Route]:
+        """Find the routes registered in a JavaScript or TypeScript file."""
+        routes = []
+        for node in walk(pf.tree.root_node):
+            if node.type != "call_expression":
+                continue
+            function = node.child_by_field_name("function")
+            if function is None or function.type != "member_expression":
+                continue
+            prop = function.child_by_field_name("property")
+            obj = function.child_by_field_name("object")
+            name = node_text(prop, pf.source) if prop is not None else ""
+            if name.upper() not in _HTTP_METHODS and name != "all":
+                continue
+            args = _js_arguments(node)
+            path = _chained_route(obj, pf.source)
+            if path is None:
+                if len(args) < 2:
+                    continue
+                path = _js_string(args[0], pf.source)
+                args = args[1:]
+            # `config.get("key", fallback)` isn't a route
+            if path is None or not path.startswith(("/", "*")) or not args:
+                continue
+            routes.append(Route(
+                method=name.upper() if name != "all" else ANY_METHOD,
+                path=path,
+                handler=_js_handler(args[-1], pf.source),
+                framework="express",
+                line=node.start_point[0] + 1,
+            ))
+        return routes
+
+
+DEFAULT_RECOGNIZERS: Tuple[RouteRecognizer, ...] = (GoRoutes(), PythonRoutes(), ExpressRoutes())
+
+
+async def extract_routes(
+    root: str,
+    recognizers: Optional[Sequence[RouteRecognizer]] = None,
+    include: Optional[List[str]] = None,
+    exclude: Optional[List[str]] = None,
+    tools: Optional["AgentTools"] = None
+) -> List[Route]:
+    """List the HTTP routes registered by the code under root.
+
+    This is pattern matching on route registration calls and decorators,
+    not evaluation: routes built in loops, through helper functions or
+    from non-literal paths may be missed or reported with the source text
+    of their path. Handlers named by an identifier or selector are resolved
+    by name to the symbols declared in files of the same language, and left
+    unresolved if there are none or several. Paths ignored by .gitignore
+    files and hidden paths are skipped, as are files that can't be read or
+    parsed.
+
+    Args:
+        root: Directory to search
+        recognizers: Framework recognizers to apply (DEFAULT_RECOGNIZERS if
+            None); subclass RouteRecognizer to add frameworks
+        include: Only search files matching one of these glob patterns
+        exclude: Skip files matching one of these glob patterns
+        tools: AgentTools instance to use (defaults to the global one)
+
+    Returns:
+        Routes in file then source order
+    """
+    if tools is None:
+        from mcp_code_parser.api import _global_tools
+        tools = _global_tools
+    from mcp_code_parser.api import _walk_files
+
+    recognizers = DEFAULT_RECOGNIZERS if recognizers is None else recognizers
+    languages = {language for r in recognizers for language in r.languages}
+    paths = PathFilter(include, exclude)
+    parsed: Dict[str, List[ParsedFile]] = {}
+    found: List[Tuple[str, Route]] = []
+    for path in _walk_files(Path(root), IgnoreRules.load(root)):
+        language = detect_language_from_file(str(path))
+        rel = path.relative_to(root).as_posix()
+        if language not in languages or not paths.matches(rel):
+            continue
+        try:
+            content = safe_read_file(str(path), detector=tools.binary_detector)
+            pf = (await parse_files([SourceFile(rel, content)], language, tools=tools))[0]
+        except Exception as e:
+            logger.debug(f"Not searching {rel} for routes: {e}")
+            continue
+        parsed.setdefault(language, []).append(pf)
+        for recognizer in recognizers:
+            if language not in recognizer.languages:
+                continue
+            for route in recognizer.routes(pf):
+                route.file = rel
+                if route.handler_id is not None and route.handler_file is None:
+                    route.handler_file = rel
+                found.append((language, route))
+
+    # Handlers may be declared in files walked after their registration
+    for language, route in found:
+        if route.handler_id is None and route.handler is not None:
+            match = _resolve_handler(route.handler, parsed.get(language, []))
+            if match is not None:
+                route.handler_file, route.handler_id = match
+    return [route for _, route in found]
+
+
+def _resolve_handler(handler: str, parsed: List[ParsedFile]) -> Optional[Tuple[str, str]]:
+    """File and stable ID of the only callable a handler expression names."""
+    # `s.handleUsers()` returns the handler; `h.List` and `List` name it
+    name = re.sub(r"\(\s*\)$", "", handler).rsplit(".", 1)[-1]
+    candidates = [
+        (pf.file.path, sym) for pf in parsed for sym in walk_symbols(pf.symbols)
+        if sym.name == name and sym.kind in CALLABLE_KINDS and sym.stable_id
+    ]
+    # Plain names call functions; selectors call methods or package functions
+    preferred = [c for c in candidates if (c[1].kind == "method") == ("." in handler)]
+    matches = preferred or candidates
+    if len(matches) != 1:
+        return None
+    file, sym = matches[0]
+    return file, sym.stable_id
+
+
+def _join(prefix: str, path: str) -> str:
+    """Append a route path to a group prefix, with one slash between them."""
+    if not prefix:
+        return path
+    if not path or path == "/":
+        return prefix
+    return prefix.rstrip("/") + "/" + path.lstrip("/")
+
+
+# Go
+
+
+def _go_imports(pf: ParsedFile) -> List[str]:
+    """Import paths of a Go file."""
+    imports = []
+    for node in walk(pf.tree.root_node):
+        if node.type == "import_spec":
+            path = node.child_by_field_name("path")
+            value = _go_string(path, pf.source) if path is not None else None
+            if value is not None:
+                imports.append(value)
+    return imports
+
+
+def _imports_module(imports: List[str], module: str) -> bool:
+    """Whether a Go file imports a module or one of its versions or packages."""
+    return any(i == module or i.startswith(module + "/") for i in imports)
+
+
+def _go_upper_framework(imports: List[str]) -> str:
+    """Framework of a `r.GET(path, h)` style registration."""
+    return "echo" if _imports_module(imports, "github.com/labstack/echo") else "gin"
+
+
+def _go_call(
+    call: tree_sitter.Node,
+    source: bytes
+) -> Tuple[Optional[str], Optional[str], List[tree_sitter.Node]]:
+    """Receiver text, method name and arguments of a `recv.Method(args)` call."""
+    function = call.child_by_field_name("function")
+    if function is None or function.type != "selector_expression":
+        return None, None, []
+    operand = function.child_by_field_name("operand")
+    name = function.child_by_field_name("field")
+    arguments = call.child_by_field_name("arguments")
+    args = [a for a in arguments.named_children if a.type != "comment"] if arguments is not None else []
+    receiver = _collapse(node_text(operand, source)) if operand is not None else None
+    return receiver, node_text(name, source) if name is not None else None, args
+
+
+def _go_string(node: tree_sitter.Node, source: bytes) -> Optional[str]:
+    """Value of a Go string literal, or None for other expressions."""
+    text = node_text(node, source)
+    if node.type == "raw_string_literal":
+        return text[1:-1]
+    if node.type == "interpreted_string_literal":
+        try:
+            value = ast.literal_eval(text)
+        except (ValueError, SyntaxError):
+            return text[1:-1]
+        return value if isinstance(value, str) else None
+    return None
+
+
+def _go_handler(node: tree_sitter.Node, source: bytes) -> Optional[str]:
+    """Handler expression of a registration, unwrapping `http.HandlerFunc(h)`."""
+    if node.type == "func_literal":
+        return None
+    if node.type == "call_expression":
+        function = node.child_by_field_name("function")
+        arguments = node.child_by_field_name("arguments")
+        args = [a for a in arguments.named_children if a.type != "comment"] if arguments is not None else []
+        if function is not None and node_text(function, source) == "http.HandlerFunc" and len(args) == 1:
+            return _go_handler(args[0], source)
+    return _collapse(node_text(node, source))
+
+
+def _chained_methods(call: tree_sitter.Node, source: bytes) -> Optional[str]:
+    """Methods of a gorilla registration with `.Methods("GET", ...)` chained on."""
+    selector = call.parent
+    outer = selector.parent if selector is not None else None
+    if selector is None or selector.type != "selector_expression" or outer is None:
+        return None
+    field_node = selector.child_by_field_name("field")
+    if field_node is None or node_text(field_node, source) != "Methods":
+        return None
+    _, _, args = _go_call(outer, source)
+    methods = [_go_string(a, source) for a in args]
+    return ",".join(m.upper() for m in methods if m) or None
+
+
+def _record_group(node: tree_sitter.Node, source: bytes, prefixes: Dict[str, str]) -> None:
+    """Record the prefix of a `v1 := r.Group("/v1")` router group."""
+    left = node.child_by_field_name("left")
+    right = node.child_by_field_name("right")
+    if left is None or right is None or len(left.named_children) != 1 or len(right.named_children) != 1:
+        return
+    call = right.named_children[0]
+    if call.type != "call_expression":
+        return
+    receiver, name, args = _go_call(call, source)
+    path = _go_string(args[0], source) if name == "Group" and args else None
+    if path is not None:
+        prefixes[node_text(left.named_children[0], source)] = _join(prefixes.get(receiver, ""), path)
+
+
+# Python
+
+
+def _decorator_methods(decorator: str, arguments: List[AttributeArg]) -> Optional[List[str]]:
+    """Request methods of a route decorator, or None if it isn't one."""
+    if decorator.upper() in _HTTP_METHODS:
+        return [decorator.upper()]
+    if decorator not in ("route", "api_route"):
+        return None
+    methods = next((a for a in arguments if a.keyword == "methods"), None)
+    if methods is None:
+        return ["GET"]
+    if methods.literal and isinstance(methods.value, list):
+        return [str(m).upper() for m in methods.value]
+    return [ANY_METHOD]
+
+
+def _python_prefixes(pf: ParsedFile) -> Dict[str, str]:
+    """Prefixes of module-level blueprints and routers, e.g. {"bp": "/users"}."""
+    prefixes = {}
+    for statement in pf.tree.root_node.named_children:
+        if statement.type != "expression_statement" or not statement.named_children:
+            continue
+        assignment = statement.named_children[0]
+        if assignment.type != "assignment":
+            continue
+        left = assignment.child_by_field_name("left")
+        right = assignment.child_by_field_name("right")
+        if left is None or left.type != "identifier" or right is None or right.type != "call":
+            continue
+        arguments = right.child_by_field_name("arguments")
+        for arg in arguments.named_children if arguments is not None else []:
+            name = arg.child_by_field_name("name") if arg.type == "keyword_argument" else None
+            value = arg.child_by_field_name("value") if name is not None else None
+            if name is None or value is None or node_text(name, pf.source) not in ("url_prefix", "prefix"):
+                continue
+            try:
+                prefix = ast.literal_eval(node_text(value, pf.source))
+            except (ValueError, SyntaxError):
+                continue
+            if isinstance(prefix, str):
+                prefixes[node_text(left, pf.source)] = prefix
+    return prefixes
+
+
+# JavaScript and TypeScript
+
+
+def _js_arguments(call: tree_sitter.Node) -> List[tree_sitter.Node]:
+    """Argument nodes of a call."""
+    arguments = call.child_by_field_name("arguments")
+    return [a for a in arguments.named_children if a.type != "comment"] if arguments is not None else []
+
+
+def _js_string(node: tree_sitter.Node, source: bytes) -> Optional[str]:
+    """Value of a string literal (or template without substitutions), else None."""
+    if node.type not in ("string", "template_string"):
+        return None
+    if any(c.type == "template_substitution" for c in node.named_children):
+        return None
+    return node_text(node, source)[1:-1]
+
+
+def _js_handler(node: tree_sitter.Node, source: bytes) -> Optional[str]:
+    """Handler expression of a registration; None for inline functions."""
+    if node.type in ("arrow_function", "function_expression", "function"):
+        return None
+    return _collapse(node_text(node, source))
+
+
+def _chained_route(obj: Optional[tree_sitter.Node], source: bytes) -> Optional[str]:
+    """Path of an `app.route(path)` a method registration is chained onto."""
+    while obj is not None and obj.type == "call_expression":
+        function = obj.child_by_field_name("function")
+        if function is None or function.type != "member_expression":
+            return None
+        prop = function.child_by_field_name("property")
+        if prop is not None and node_text(prop, source) == "route":
+            args = _js_arguments(obj)
+            return _js_string(args[0], source) if len(args) == 1 else None
+        obj = function.child_by_field_name("object")
+    return None
@@ -0,0 +1,216 @@
+"""Slicing a symbol out of its package together with what it depends on."""
+
+from dataclasses import dataclass, field
+from typing import TYPE_CHECKING, Dict, List, Optional, Set
+
+import tree_sitter
+
+from mcp_code_parser.analysis.base import ParsedFile, parse_files, walk
+from mcp_code_parser.chunking import _comment_start
+from mcp_code_parser.errors import NotFoundError
+from mcp_code_parser.extractors.base import SourceFile, node_text
+from mcp_code_parser.parsers.base import LanguageNotSupportedError
+
+if TYPE_CHECKING:
+    from mcp_code_parser.api import AgentTools
+
+# Node types naming a package-level declaration or an import
+_NAME_NODES = ("identifier", "type_identifier", "package_identifier")
+
+# Top-level nodes that aren't declarations to slice
+_SKIPPED_NODES = ("package_clause", "import_declaration", "comment")
+
+
+@dataclass
+class _Declaration:
+    """A top-level declaration of the package and the names it uses."""
+
+    file: int
+    node: tree_sitter.Node
+    # Names it declares (for methods, "Receiver.Name")
+    names: List[str]
+    # Receiver type of a method
+    receiver: Optional[str] = None
+    # Identifiers and package qualifiers it mentions
+    uses: Set[str] = field(default_factory=set)
+    # Names after a `.`, which may be methods of the package's types
+    selectors: Set[str] = field(default_factory=set)
+
+
+async def slice_symbol(
+    files: List[SourceFile],
+    stable_id: str,
+    language: str = "go",
+    tools: Optional["AgentTools"] = None
+) -> str:
+    """Cut a symbol out of its package as a self-contained snippet.
+
+    The snippet holds the symbol's declaration and, transitively, the
+    package-level types, functions, constants and variables it names, the
+    methods of those types it calls, and the imports they use, each with its
+    doc comment and in file then source order. Names are matched without
+    type checking, so a local variable sharing a declaration's name pulls it
+    in too: the result aims to compile but may carry extra declarations.
+
+    Args:
+        files: Files making up the package
+        stable_id: Stable ID of the symbol, e.g. "method:UserService.GetUser"
+        language: Programming language (only "go" is supported)
+        tools: AgentTools instance to use (defaults to the global one)
+
+    Returns:
+        Go source starting with the package clause, with secrets masked
+        when tools has a redactor
+
+    Raises:
+        LanguageNotSupportedError: If the language isn't Go
+        NotFoundError: If no file declares the stable ID
+    """
+    if language != "go":
+        raise LanguageNotSupportedError(f"Symbol slicing not supported for {language}")
+    if tools is None:
+        from mcp_code_parser.api import _global_tools
+        tools = _global_tools
+
+    parsed = await parse_files(files, language, tools=tools)
+    declarations = _declarations(parsed)
+    target = _find(parsed, declarations, stable_id)
+
+    by_name: Dict[str, List[_Declaration]] = {}
+    methods: Dict[str, List[_Declaration]] = {}
+    for decl in declarations:
+        if decl.receiver is not None:
+            methods.setdefault(decl.receiver, []).append(decl)
+        else:
+            for name in decl.names:
+                by_name.setdefault(name, []).append(decl)
+
+    included = [target]
+    pending = [target]
+    while pending:
+        decl = pending.pop()
+        found = [d for name in decl.uses for d in by_name.get(name, [])]
+        # Methods of included types that the included code calls
+        selectors = set().union(*(d.selectors for d in included))
+        for owner in included:
+            for type_name in owner.names:
+                found.extend(m for m in methods.get(type_name, []) if _method_name(m) in selectors)
+        for dep in found:
+            if not any(dep is d for d in included):
+                included.append(dep)
+                pending.append(dep)
+
+    included.sort(key=lambda d: (d.file, d.node.start_byte))
+    target_file = parsed[target.file]
+    uses = set().union(*(d.uses for d in included))
+    parts = [f"package {_package_name(target_file)}"]
+    imports = sorted({
+        node_text(spec, pf.source)
+        for pf in parsed
+        for spec in _import_specs(pf)
+        if _import_alias(spec, pf.source) in uses
+    })
+    if imports:
+        parts.append("import (\n" + "".join(f"\t{line}\n" for line in imports) + ")")
+    for decl in included:
+        parts.append(_declaration_text(parsed[decl.file], decl.node))
+    snippet = "\n\n".join(parts) + "\n"
+    if tools.redactor is not None:
+        snippet, _ = tools.redactor.redact(snippet)
+    return snippet
+
+
+def _declarations(parsed: List[ParsedFile]) -> List[_Declaration]:
+    """Top-level declarations of every file, with the names they declare and use."""
+    declarations = []
+    for index, pf in enumerate(parsed):
+        for node in pf.tree.root_node.named_children:
+            if node.type in _SKIPPED_NODES:
+                continue
+            decl = _Declaration(file=index, node=node, names=[])
+            name_node = node.child_by_field_name("name")
+            if node.type == "method_declaration":
+                decl.receiver = _receiver(node, pf.source)
+                decl.names = [f"{decl.receiver}.{node_text(name_node, pf.source)}"]
+            elif node.type == "function_declaration":
+                decl.names = [node_text(name_node, pf.source)]
+            else:
+                decl.names = [
+                    node_text(n, pf.source)
+                    for spec in node.named_children
+                    for n in spec.children_by_field_name("name")
+                ]
+            for child in walk(node):
+                if child.type in _NAME_NODES:
+                    decl.uses.add(node_text(child, pf.source))
+                elif child.type == "field_identifier":
+                    decl.selectors.add(node_text(child, pf.source))
+            decl.uses.difference_update(decl.names)
+            declarations.append(decl)
+    return declarations
+
+
+def _find(parsed: List[ParsedFile], declarations: List[_Declaration], stable_id: str) -> _Declaration:
+    """Top-level declaration containing the symbol with a stable ID."""
+    for index, pf in enumerate(parsed):
+        stack = list(pf.symbols)
+        while stack:
+            sym = stack.pop()
+            if sym.stable_id == stable_id:
+                for decl in declarations:
+                    if decl.file == index and decl.node.start_byte <= sym.start_byte < decl.node.end_byte:
+                        return decl
+            stack.extend(sym.children)
+    raise NotFoundError(f"Symbol not found: {stable_id}")
+
+
+def _receiver(node: tree_sitter.Node, source: bytes) -> Optional[str]:
+    """Base type name of a method's receiver."""
+    receiver = node.child_by_field_name("receiver")
+    if receiver is None:
+        return None
+    for child in walk(receiver):
+        if child.type == "type_identifier":
+            return node_text(child, source)
+    return None
+
+
+def _package_name(pf: ParsedFile) -> str:
+    """Name in a file's package clause ("main" if it has none)."""
+    for node in pf.tree.root_node.named_children:
+        if node.type == "package_clause":
+            for child in node.named_children:
+                if child.type == "package_identifier":
+                    return node_text(child, pf.source)
+    return "main"
+
+
+def _import_specs(pf: ParsedFile) -> List[tree_sitter.Node]:
+    """Import spec nodes of a file."""
+    specs = []
+    for node in pf.tree.root_node.named_children:
+        if node.type == "import_declaration":
+            specs.extend(spec for spec in walk(node) if spec.type == "import_spec")
+    return specs
+
+
+def _import_alias(spec: tree_sitter.Node, source: bytes) -> Optional[str]:
+    """Name code refers to an import by (its alias, else the last path element)."""
+    name = spec.child_by_field_name("name")
+    if name is not None:
+        return node_text(name, source)
+    path = spec.child_by_field_name("path")
+    if path is None:
+        return None
+    return node_text(path, source).strip("\"`").rsplit("/", 1)[-1]
+
+
+def _method_name(decl: _Declaration) -> str:
+    """A method declaration's name without its receiver."""
+    return decl.names[0].rpartition(".")[2]
+
+
+def _declaration_text(pf: ParsedFile, node: tree_sitter.Node) -> str:
+    """Source of a top-level declaration with the comment lines directly above it."""
+    start = _comment_start(pf.source, node.start_byte)
+    return pf.source[start:node.end_byte].decode("utf8", errors="replace")
@@ -0,0 +1,81 @@
+"""TODO-style notes left in source comments."""
+
+import re
+from dataclasses import dataclass
+from typing import TYPE_CHECKING, List, Optional, Sequence
+
+from mcp_code_parser.comments import comment_ranges
+
+if TYPE_CHECKING:
+    from mcp_code_parser.api import AgentTools
+
+DEFAULT_TAGS = ("TODO", "FIXME", "HACK", "XXX")
+
+# Tokens closing a block comment, left off the end of a note
+_BLOCK_ENDS = ("*/", "-->", "-}", "*)")
+
+
+@dataclass
+class Todo:
+    """A tagged note in a comment, e.g. `// TODO(alice): handle timeouts`."""
+
+    # The tag as written, e.g. "TODO"
+    tag: str
+    # Text after the tag, e.g. "handle timeouts"
+    text: str
+    line: int
+    # 1-based byte column of the tag, like Diagnostic.column
+    column: int
+    # Who the note is for, from `TODO(alice)`
+    owner: Optional[str] = None
+
+
+async def find_todos(
+    content: str,
+    language: str,
+    tags: Sequence[str] = DEFAULT_TAGS,
+    tools: Optional["AgentTools"] = None
+) -> List[Todo]:
+    """Find notes tagged TODO, FIXME and the like in a file's comments.
+
+    Tags match as whole, case-sensitive words, so "todo" in prose doesn't
+    count; a note runs to the end of its line. Comments are found with the
+    language's grammar or, for languages registered with a CommentSyntax,
+    with its tokens, so tags in strings or code are skipped.
+
+    Args:
+        content: Source code
+        language: Programming language
+        tags: Words marking a note
+        tools: AgentTools instance to use (defaults to the global one)
+
+    Returns:
+        Notes in source order
+
+    Raises:
+        ToolError: If the language has neither a grammar nor a CommentSyntax
+    """
+    if not tags:
+        return []
+    pattern = re.compile(
+        rb"(?<![\w$])(" + b"|".join(re.escape(t.encode("utf8")) for t in tags) + rb")(?![\w$])"
+        rb"(?:\(([^)\n]*)\))?:?[ \t]*([^\n]*)"
+    )
+    source = content.encode("utf8")
+    todos = []
+    for start, end in await comment_ranges(content, language, tools):
+        for match in pattern.finditer(source, start, end):
+            text = match.group(3).decode("utf8", errors="replace").strip()
+            for close in _BLOCK_ENDS:
+                if text.endswith(close):
+                    text = text[:-len(close)].rstrip()
+            line_start = source.rfind(b"\n", 0, match.start()) + 1
+            owner = match.group(2).decode("utf8", errors="replace").strip() if match.group(2) else None
+            todos.append(Todo(
+                tag=match.group(1).decode("utf8"),
+                text=text,
+                line=source.count(b"\n", 0, match.start()) + 1,
+                column=match.start() - line_start + 1,
+                owner=owner or None,
+            ))
+    return todos
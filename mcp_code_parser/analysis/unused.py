@@ -0,0 +1,135 @@
+"""Heuristic detection of unused package-level declarations."""
+
+import re
+from collections import defaultdict
+from dataclasses import dataclass
+from typing import TYPE_CHECKING, Dict, List, Optional, Tuple
+
+from mcp_code_parser.analysis.base import ParsedFile, parse_files, walk
+from mcp_code_parser.extractors.base import SourceFile, Symbol, node_text
+from mcp_code_parser.parsers.base import LanguageNotSupportedError
+
+if TYPE_CHECKING:
+    from mcp_code_parser.api import AgentTools
+
+# Node types that can refer to a declaration by name
+_REFERENCE_NODES = ("identifier", "type_identifier", "field_identifier")
+
+_STRING_NODES = ("interpreted_string_literal", "raw_string_literal")
+
+# Kinds that can be dead code
+_CANDIDATE_KINDS = ("function", "method", "struct", "interface", "type", "constant", "variable")
+
+# Functions the toolchain calls without an in-package reference
+_ENTRY_POINTS = ("main", "init")
+
+# Confidence levels for the different reasons a symbol might still be used
+CONFIDENCE_HIGH = 0.9
+# Unexported methods can still satisfy an interface declared in the package
+CONFIDENCE_METHOD = 0.6
+# The name appears in a string literal, e.g. looked up via reflection
+CONFIDENCE_NAMED_IN_STRING = 0.3
+
+
+@dataclass
+class UnusedSymbol:
+    """A declaration with no references found in its package."""
+
+    symbol: Symbol
+    file: str
+    confidence: float
+    reason: str
+
+
+async def find_unused_symbols(
+    files: List[SourceFile],
+    language: str = "go",
+    tools: Optional["AgentTools"] = None
+) -> List[UnusedSymbol]:
+    """Find unexported package-level declarations that nothing in the package uses.
+
+    All files are treated as one package. References are matched by name, so
+    results err on the side of reporting too little: a local variable or a
+    field sharing a declaration's name counts as a use. Exported symbols,
+    `main`, `init` and go test functions are never reported.
+
+    Args:
+        files: Files making up the package
+        language: Programming language (only "go" is supported)
+        tools: AgentTools instance to use (defaults to the global one)
+
+    Returns:
+        Candidates in file and source order, each with a confidence in (0, 1]
+    """
+    if language != "go":
+        raise LanguageNotSupportedError(f"Unused symbol detection not supported for {language}")
+
+    parsed = await parse_files(files, language, tools=tools)
+    references = _collect_references(parsed)
+    strings = _collect_strings(parsed)
+
+    unused = []
+    for pf in parsed:
+        for sym in _candidates(pf.symbols):
+            refs = [
+                (path, offset) for path, offset in references.get(sym.name, [])
+                if not (path == pf.file.path and sym.start_byte <= offset < sym.end_byte)
+            ]
+            if refs:
+                continue
+
+            named = re.compile(rf"\b{re.escape(sym.name)}\b")
+            if any(named.search(text) for text in strings):
+                confidence, reason = CONFIDENCE_NAMED_IN_STRING, "named in a string literal"
+            elif sym.kind == "method":
+                confidence, reason = CONFIDENCE_METHOD, "may satisfy an interface"
+            else:
+                confidence, reason = CONFIDENCE_HIGH, "no references in package"
+            unused.append(UnusedSymbol(
+                symbol=sym,
+                file=pf.file.path,
+                confidence=confidence,
+                reason=reason,
+            ))
+    return unused
+
+
+def _candidates(symbols: List[Symbol]) -> List[Symbol]:
+    """Package-level declarations (and methods) that may be reported."""
+    found = []
+    for sym in symbols:
+        if sym.kind in _CANDIDATE_KINDS and _reportable(sym):
+            found.append(sym)
+        # Methods are nested under their receiver type
+        found.extend(c for c in sym.children if c.kind == "method" and c.receiver and _reportable(c))
+    return found
+
+
+def _reportable(sym: Symbol) -> bool:
+    """Check whether a symbol can be dead code at all."""
+    return (
+        not sym.exported
+        and sym.name not in _ENTRY_POINTS
+        and sym.name != "_"
+        and sym.test_kind is None
+    )
+
+
+def _collect_references(parsed: List[ParsedFile]) -> Dict[str, List[Tuple[str, int]]]:
+    """Map each identifier to the (file, byte offset) of every occurrence."""
+    references: Dict[str, List[Tuple[str, int]]] = defaultdict(list)
+    for pf in parsed:
+        for node in walk(pf.tree.root_node):
+            if node.type in _REFERENCE_NODES:
+                references[node_text(node, pf.source)].append((pf.file.path, node.start_byte))
+    return references
+
+
+def _collect_strings(parsed: List[ParsedFile]) -> List[str]:
+    """Collect the text of every string literal in the package."""
+    return [
+        node_text(node, pf.source)
+        for pf in parsed
+        for node in walk(pf.tree.root_node)
+        if node.type in _STRING_NODES
+    ]
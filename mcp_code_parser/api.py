@@ -1,28 +1,250 @@
 """High-level API for mcp-code-parser."""
 
-from typing import Dict, List, Optional, Type
+import asyncio
+import inspect
+import itertools
+import json
+from collections import deque
+from dataclasses import asdict, dataclass, replace
+from pathlib import Path
+from typing import (
+    Any,
+    AsyncIterator,
+    Callable,
+    Deque,
+    Dict,
+    Iterator,
+    List,
+    Optional,
+    Sequence,
+    TextIO,
+    Tuple,
+    Type,
+    Union,
+)
 
-from mcp_code_parser.parsers.base import BaseParser, ParseResult
+import tree_sitter
+
+from mcp_code_parser.__version__ import __version__
+from mcp_code_parser.binary import BinaryDetector
+from mcp_code_parser.blame import annotate_blame, blame_file
+from mcp_code_parser.blobs import BlobStore, blob_digest, result_key
+from mcp_code_parser.errors import (
+    Diagnostic,
+    LimitExceededError,
+    NotFoundError,
+    PathTraversalError,
+    TooLargeError,
+    UnsupportedLanguageError,
+    error_code,
+    syntax_diagnostics,
+)
+from mcp_code_parser.extractors.base import (
+    BaseExtractor,
+    ExtractOptions,
+    Outline,
+    OutputOptions,
+    SymbolKindConfig,
+    assign_global_ids,
+    attach_utf16_ranges,
+    assign_stable_ids,
+    filter_attributes,
+    filter_kinds,
+)
+from mcp_code_parser.extractors.cpp import CppExtractor
+from mcp_code_parser.extractors.dart import DartExtractor
+from mcp_code_parser.extractors.elixir import ElixirExtractor
+from mcp_code_parser.extractors.go import GoExtractor
+from mcp_code_parser.extractors.javascript import JavaScriptExtractor
+from mcp_code_parser.extractors.python import PythonExtractor
+from mcp_code_parser.extractors.sfc import SvelteExtractor, VueExtractor
+from mcp_code_parser.generated import is_generated, line_directives, map_line_directives
+from mcp_code_parser.globs import PathFilter
+from mcp_code_parser.ignore import IgnoreRules
+from mcp_code_parser.parsers.base import BaseParser, ParseResult, ParserError
+from mcp_code_parser.parsers.queries import QueryCapture, execute_query
 from mcp_code_parser.parsers.tree_sitter import TreeSitterParser
+from mcp_code_parser.pool import adaptive_read_workers
+from mcp_code_parser.positions import DEFAULT_TAB_WIDTH
+from mcp_code_parser.redact import Redactor
+from mcp_code_parser.registry import language_registry
+from mcp_code_parser.sourcemap import load_source_map, map_symbols
+from mcp_code_parser.utils import (
+    FileTooLargeError,
+    detect_language_from_file,
+    is_test_file,
+    read_stream,
+    repo_relative_path,
+    resolve_within,
+    safe_read_file,
+)
+
+
+@dataclass
+class SourceRoot:
+    """One of several directories extracted together by extract_roots."""
+
+    path: str
+    # Prefix of this root's result keys (defaults to the directory name)
+    name: Optional[str] = None
+    # Glob patterns over root-relative POSIX paths; when set, these replace
+    # the include/exclude passed to extract_roots for this root
+    include: Optional[List[str]] = None
+    exclude: Optional[List[str]] = None
+
+    @property
+    def label(self) -> str:
+        """Prefix of this root's result keys."""
+        return self.name or Path(self.path).resolve().name
 
 
 class AgentTools:
     """Main API class for mcp-code-parser."""
     
-    def __init__(self):
+    def __init__(
+        self,
+        max_file_size: Optional[int] = None,
+        tab_width: int = DEFAULT_TAB_WIDTH,
+        binary_detector: Optional[BinaryDetector] = None,
+        result_store: Optional[BlobStore] = None,
+        symbol_kinds: Optional[Dict[str, SymbolKindConfig]] = None,
+        redactor: Optional[Redactor] = None
+    ):
+        """Create the API facade.
+        
+        Args:
+            max_file_size: Optional limit in bytes for files read by
+                parse_file (extraction uses ExtractOptions.max_file_size)
+            tab_width: Tab stop spacing for the visual columns of parse
+                diagnostics (extraction uses ExtractOptions.tab_width)
+            binary_detector: Detector used to reject binary files and
+                streams (defaults to DefaultBinaryDetector)
+            result_store: Optional store caching extract_symbols results by
+                content, language, path, options and version, so unchanged
+                sources aren't re-extracted (even across restarts with a
+                FileBlobStore)
+            symbol_kinds: Per-language symbol kind configs, e.g.
+                {"go": {"type_alias": "alias"}}, applied to every extraction
+                (ExtractOptions.symbol_kinds overrides them per call)
+            redactor: Optional redactor masking secrets in the file content
+                that tools return: chunks, symbol descriptions and slices,
+                packed context and the lines of references
+        
+        Raises:
+            ValueError: If a symbol kind config is for a language without an
+                extractor or names node types its extractor doesn't emit
+        """
         self._parsers: Dict[str, BaseParser] = {}
         self._default_parser: Optional[BaseParser] = None
+        self._extractors: Dict[str, BaseExtractor] = {}
+        self.binary_detector = binary_detector
+        self.result_store = result_store
+        self.redactor = redactor
+        self._tree_sitter = TreeSitterParser(
+            max_file_size=max_file_size,
+            tab_width=tab_width,
+            binary_detector=binary_detector,
+        )
         
-        # Register default parsers
+        # Register default parsers and extractors
         self._register_default_parsers()
+        self._register_default_extractors()
+        
+        self.symbol_kinds = symbol_kinds or {}
+        for language, config in self.symbol_kinds.items():
+            extractor = self.get_extractor(language)
+            if extractor is None:
+                raise ValueError(f"Symbol kind config for {language}, which has no extractor")
+            extractor.check_symbol_kinds(config)
     
     def _register_default_parsers(self) -> None:
         """Register default parser implementations."""
         # Tree-sitter as default
-        tree_sitter = TreeSitterParser()
-        self.register_parser("tree-sitter", tree_sitter)
+        self.register_parser("tree-sitter", self._tree_sitter)
         self.set_default_parser("tree-sitter")
     
+    def _register_default_extractors(self) -> None:
+        """Register default symbol extractors."""
+        self.register_extractor("go", GoExtractor())
+        self.register_extractor("python", PythonExtractor())
+        self.register_extractor("cpp", CppExtractor())
+        self.register_extractor("dart", DartExtractor())
+        self.register_extractor("elixir", ElixirExtractor())
+        javascript = JavaScriptExtractor()
+        self.register_extractor("javascript", javascript)
+        self.register_extractor("typescript", javascript)
+        self.register_extractor("vue", VueExtractor(javascript))
+        self.register_extractor("svelte", SvelteExtractor(javascript))
+    
+    def register_extractor(self, language: str, extractor: BaseExtractor) -> None:
+        """Register a symbol extractor for a language on this instance.
+        
+        To add a language for every AgentTools instance, with its file
+        extensions and grammar, use mcp_code_parser.register_language.
+        """
+        self._extractors[language] = extractor
+    
+    def get_extractor(self, language: str) -> Optional[BaseExtractor]:
+        """Get the symbol extractor for a language, if any.
+        
+        Backends registered with register_language take precedence over
+        this instance's extractors.
+        """
+        backend = language_registry().get(language)
+        if backend is not None:
+            return backend.extractor
+        return self._extractors.get(language)
+    
+    async def parse_tree(self, content: str, language: str) -> tree_sitter.Tree:
+        """Parse code content into a raw tree-sitter tree."""
+        return await self._tree_sitter.parse_tree(content, language)
+    
+    async def run_query(self, content: str, language: str, query: str) -> List[QueryCapture]:
+        """Run a tree-sitter S-expression query over code content.
+        
+        Compiled queries are cached per (language, query text), so repeating
+        a query only pays for parsing and matching.
+        
+        Args:
+            content: Source code
+            language: Programming language
+            query: Query source, e.g. "(function_declaration name: (identifier) @name)"
+            
+        Returns:
+            Captured nodes in source order
+            
+        Raises:
+            ParserError: If the language or its grammar is unavailable
+            Exception: Whatever tree-sitter raises for an invalid query
+        """
+        compiled = await self._tree_sitter.compile_query(language, query)
+        tree = await self._tree_sitter.parse_tree(content, language)
+        return execute_query(compiled, tree.root_node, bytes(content, "utf8"))
+    
+    async def validate(self, content: str, language: str) -> List[Diagnostic]:
+        """Check code content for syntax errors without extracting symbols.
+        
+        Only parses, so it's a cheap check that an edit still parses.
+        Formats that embed other languages (e.g. Vue components) have no
+        grammar of their own; use extract_symbols for their diagnostics.
+        
+        Args:
+            content: Source code
+            language: Programming language
+            
+        Returns:
+            Syntax diagnostics, empty if the content parses cleanly
+            
+        Raises:
+            ParserError: If the language or its grammar is unavailable
+        """
+        tree = await self._tree_sitter.parse_tree(content, language)
+        return syntax_diagnostics(
+            tree.root_node,
+            source=bytes(content, "utf8"),
+            tab_width=self._tree_sitter.tab_width,
+        )
+    
     def register_parser(self, name: str, parser: BaseParser) -> None:
         """Register a new parser implementation."""
         self._parsers[name] = parser
@@ -100,6 +322,531 @@ class AgentTools:
     def list_parsers(self) -> List[str]:
         """List all registered parsers."""
         return list(self._parsers.keys())
+    
+    async def extract_symbols(
+        self,
+        content: str,
+        language: str,
+        options: Optional[ExtractOptions] = None,
+        path: Optional[str] = None
+    ) -> Outline:
+        """Extract a symbol outline from code content.
+        
+        Args:
+            content: Source code to analyse
+            language: Programming language
+            options: Optional extraction options
+            path: Optional file path the content came from, used by
+                path-sensitive analysis such as Go internal packages
+            
+        Returns:
+            Outline with the top-level symbols; an extraction over
+            options.limits fails with code "limit_exceeded", the limit's
+            name in metadata["limit"] and the symbols declared before it
+            
+        Raises:
+            ValueError: If options.symbol_kinds names node types the
+                language's extractor doesn't emit
+        """
+        extractor = self.get_extractor(language)
+        if not extractor:
+            return Outline(
+                language=language,
+                symbols=[],
+                metadata={},
+                error=f"Symbol extraction not supported for {language}",
+                error_code=UnsupportedLanguageError.code,
+            )
+        
+        symbol_kinds = self.symbol_kinds.get(language)
+        if options is not None and options.symbol_kinds:
+            extractor.check_symbol_kinds(options.symbol_kinds)
+            symbol_kinds = {**(symbol_kinds or {}), **options.symbol_kinds}
+        if symbol_kinds:
+            options = replace(options or ExtractOptions(), symbol_kinds=symbol_kinds)
+        
+        cache_key = None
+        if self.result_store is not None:
+            cache_key = self._result_key(extractor, content, language, options, path)
+            cached = self.result_store.get_tagged(cache_key)
+            if cached is not None:
+                return Outline.from_dict(json.loads(cached))
+        
+        try:
+            outline = await extractor.extract_content(
+                content,
+                language,
+                options or ExtractOptions(),
+                path,
+                self._tree_sitter.parse_tree,
+            )
+        except ParserError as e:
+            return Outline(
+                language=language,
+                symbols=[],
+                metadata={},
+                error=str(e),
+                error_code=error_code(e),
+            )
+        except LimitExceededError as e:
+            # Keep the symbols gathered before the limit was hit
+            outline = e.partial if isinstance(e.partial, Outline) else Outline(language=language, symbols=[], metadata={})
+            outline.error = str(e)
+            outline.error_code = e.code
+            outline.metadata["limit"] = e.limit
+        
+        if options is not None and options.kind_filter is not None:
+            # Extractors skip unwanted nodes where they can; this covers the rest
+            outline.symbols = filter_kinds(outline.symbols, options.kind_filter)
+        if options is not None and (options.include_attributes or options.exclude_attributes):
+            outline.symbols = filter_attributes(outline.symbols, options.include_attributes, options.exclude_attributes)
+        assign_stable_ids(outline.symbols, extractor.overloads)
+        if options is not None and options.global_ids and path is not None:
+            assign_global_ids(outline.symbols, repo_relative_path(path), language)
+        if options is not None and options.utf16_ranges:
+            attach_utf16_ranges(outline, bytes(content, "utf8"))
+        outline.generated = is_generated(content)
+        directives = line_directives(content, language)
+        if directives:
+            map_line_directives(outline.symbols, directives)
+        if cache_key is not None and outline.success:
+            self.result_store.put_tagged(cache_key, json.dumps(outline.to_dict(), sort_keys=True).encode("utf8"))
+        return outline
+    
+    def _result_key(
+        self,
+        extractor: BaseExtractor,
+        content: str,
+        language: str,
+        options: Optional[ExtractOptions],
+        path: Optional[str]
+    ) -> str:
+        """Name of the cached extract_symbols result for a request."""
+        return result_key("extract_symbols", {
+            "version": __version__,
+            "extractor": f"{type(extractor).__module__}.{type(extractor).__qualname__}",
+            "language": language,
+            "content": blob_digest(content.encode("utf8", "surrogatepass")),
+            "options": asdict(options or ExtractOptions()),
+            "path": path,
+        })
+    
+    async def extract_file(
+        self,
+        file_path: str,
+        language: Optional[str] = None,
+        options: Optional[ExtractOptions] = None
+    ) -> Outline:
+        """Extract a symbol outline from a file.
+        
+        Args:
+            file_path: Path to source file
+            language: Optional language override
+            options: Optional extraction options
+            
+        Returns:
+            Outline with the top-level symbols
+        """
+        options = options or ExtractOptions()
+        language = language or detect_language_from_file(file_path)
+        read = self._read_file(file_path, language, options)
+        return await self._extract_read(file_path, language, options, read)
+    
+    def _read_file(self, file_path: str, language: Optional[str], options: ExtractOptions) -> Union[str, Outline]:
+        """Read a file for extract_file: its content, or the outline reporting why it can't be extracted.
+
+        Safe to run in a worker thread.
+        """
+        try:
+            content = safe_read_file(
+                file_path,
+                max_size=options.max_file_size,
+                truncate=options.truncate_oversized,
+                detector=self.binary_detector,
+            )
+        except FileTooLargeError as e:
+            return Outline(
+                language=language or "unknown",
+                symbols=[],
+                metadata={"file": file_path, "size": e.size},
+                skipped=True,
+                skip_reason=str(e),
+                # Not an error, but lets callers branch on why it was skipped
+                error_code=TooLargeError.code,
+            )
+        except Exception as e:
+            return Outline(
+                language=language or "unknown",
+                symbols=[],
+                metadata={"file": file_path},
+                error=f"Error reading file: {str(e)}",
+                error_code=error_code(e),
+            )
+        
+        if not language:
+            return Outline(
+                language="unknown",
+                symbols=[],
+                metadata={"file": file_path},
+                error="Could not detect language from file extension",
+                error_code=UnsupportedLanguageError.code,
+            )
+        return content
+    
+    async def _extract_read(
+        self,
+        file_path: str,
+        language: Optional[str],
+        options: ExtractOptions,
+        read: Union[str, Outline]
+    ) -> Outline:
+        """Extract a file read by _read_file (see extract_file)."""
+        if isinstance(read, Outline):
+            return read
+        content = read
+        outline = await self.extract_symbols(content, language, options, file_path)
+        outline.metadata["file"] = file_path
+        if options.source_maps and outline.success:
+            try:
+                source_map = load_source_map(file_path, content)
+            except ValueError as e:
+                # Positions stay those of the generated file
+                outline.metadata["source_map_error"] = str(e)
+            else:
+                if source_map is not None:
+                    map_symbols(outline.symbols, source_map, bytes(content, "utf8"))
+        if options.blame and outline.success:
+            try:
+                annotate_blame(outline.symbols, await blame_file(file_path))
+            except ValueError as e:
+                # Symbols keep no blame fields, e.g. outside a git repo
+                outline.metadata["blame_error"] = str(e)
+        if options.truncate_oversized and options.max_file_size is not None:
+            outline.metadata["truncated"] = Path(file_path).stat().st_size > options.max_file_size
+        return outline
+    
+    async def extract_reader(
+        self,
+        reader: Any,
+        language: str,
+        options: Optional[ExtractOptions] = None,
+        path: Optional[str] = None
+    ) -> Outline:
+        """Extract a symbol outline from a stream.
+        
+        The stream is read in chunks and reading stops once
+        options.max_file_size is exceeded; the grammar then parses the
+        buffered content in one go.
+        
+        Args:
+            reader: Text or binary stream with a (possibly async) read(n) method
+            language: Programming language
+            options: Optional extraction options
+            path: Optional file path the content came from
+            
+        Returns:
+            Outline with the top-level symbols
+        """
+        options = options or ExtractOptions()
+        try:
+            content = await read_stream(
+                reader,
+                max_size=options.max_file_size,
+                truncate=options.truncate_oversized,
+                detector=self.binary_detector,
+            )
+        except TooLargeError as e:
+            return Outline(
+                language=language,
+                symbols=[],
+                metadata={},
+                skipped=True,
+                skip_reason=str(e),
+                error_code=TooLargeError.code,
+            )
+        except Exception as e:
+            return Outline(
+                language=language,
+                symbols=[],
+                metadata={},
+                error=f"Error reading stream: {str(e)}",
+                error_code=error_code(e),
+            )
+        
+        return await self.extract_symbols(content, language, options, path)
+    
+    async def extract_dir(
+        self,
+        root: str,
+        options: Optional[ExtractOptions] = None
+    ) -> Dict[str, Outline]:
+        """Extract outlines for every supported file under a directory.
+        
+        Hidden files and directories are ignored. Files that cannot be
+        processed appear in the result with an error, and oversized files
+        are marked as skipped rather than read. Symlinks resolving outside
+        root are reported with a traversal error and not read.
+        
+        Args:
+            root: Directory to walk
+            options: Optional extraction options applied to every file
+            
+        Returns:
+            Mapping of root-relative POSIX paths to outlines, in path order
+        """
+        return {rel: outline async for rel, outline in self.iter_extract_dir(root, options)}
+    
+    async def iter_extract_dir(
+        self,
+        root: str,
+        options: Optional[ExtractOptions] = None
+    ) -> AsyncIterator[Tuple[str, Outline]]:
+        """Extract outlines under a directory, yielding each file as it completes.
+        
+        Same traversal as extract_dir, but only one outline is held at a time.
+        
+        Yields:
+            (root-relative POSIX path, outline) pairs in path order
+        """
+        async for item in self._iter_extract(root, options):
+            yield item
+    
+    async def extract_dir_each(
+        self,
+        root: str,
+        callback: Callable[[str, Outline], Any],
+        options: Optional[ExtractOptions] = None
+    ) -> int:
+        """Extract outlines under a directory, passing each to a callback as it completes.
+        
+        Same traversal as extract_dir, but nothing is kept once the callback
+        returns, so memory use doesn't grow with the size of the tree. If
+        the callback raises, the walk stops and the exception propagates.
+        
+        Args:
+            root: Directory to walk
+            callback: Called with (root-relative POSIX path, outline) for
+                each file; may be a coroutine function
+            options: Optional extraction options applied to every file
+            
+        Returns:
+            Number of files passed to the callback
+        """
+        count = 0
+        async for rel, outline in self._iter_extract(root, options):
+            result = callback(rel, outline)
+            if inspect.isawaitable(result):
+                await result
+            count += 1
+        return count
+
+    async def extract_roots(
+        self,
+        roots: Sequence[Union[str, SourceRoot]],
+        options: Optional[ExtractOptions] = None,
+        include: Optional[List[str]] = None,
+        exclude: Optional[List[str]] = None
+    ) -> Dict[str, Outline]:
+        """Extract outlines under several directories into one result.
+
+        Keys are "<root label>/<root-relative path>", so same-named files in
+        different roots don't collide. Each root is walked like extract_dir.
+        A root that doesn't exist is reported under its label with a
+        not_found error and the other roots are still extracted.
+
+        Args:
+            roots: Directories, as paths or SourceRoots with their own
+                label and filters
+            options: Optional extraction options applied to every file
+            include: Only extract files matching one of these glob patterns
+                (e.g. "src/**/*.{ts,tsx}"; see mcp_code_parser.globs)
+            exclude: Skip files matching one of these glob patterns
+
+        Returns:
+            Mapping of qualified paths to outlines, in root then path order
+
+        Raises:
+            ValueError: If two roots have the same label
+        """
+        return {key: outline async for key, outline in self.iter_extract_roots(roots, options, include, exclude)}
+
+    async def iter_extract_roots(
+        self,
+        roots: Sequence[Union[str, SourceRoot]],
+        options: Optional[ExtractOptions] = None,
+        include: Optional[List[str]] = None,
+        exclude: Optional[List[str]] = None
+    ) -> AsyncIterator[Tuple[str, Outline]]:
+        """Extract outlines under several directories, yielding each file as it completes.
+
+        Same traversal and keys as extract_roots.
+        """
+        source_roots = [r if isinstance(r, SourceRoot) else SourceRoot(path=r) for r in roots]
+        labels = [r.label for r in source_roots]
+        duplicates = sorted({label for label in labels if labels.count(label) > 1})
+        if duplicates:
+            raise ValueError(f"Roots must have distinct names: {', '.join(duplicates)}")
+
+        for root in source_roots:
+            if not Path(root.path).is_dir():
+                yield root.label, Outline(
+                    language="unknown",
+                    symbols=[],
+                    metadata={"root": root.path},
+                    error=f"Root directory not found: {root.path}",
+                    error_code=NotFoundError.code,
+                )
+                continue
+            root_include = root.include if root.include is not None else include
+            root_exclude = root.exclude if root.exclude is not None else exclude
+            async for rel, outline in self._iter_extract(root.path, options, root_include, root_exclude):
+                yield f"{root.label}/{rel}", outline
+
+    async def _iter_extract(
+        self,
+        root: str,
+        options: Optional[ExtractOptions],
+        include: Optional[List[str]] = None,
+        exclude: Optional[List[str]] = None
+    ) -> AsyncIterator[Tuple[str, Outline]]:
+        """Walk one root for iter_extract_dir and iter_extract_roots.
+
+        Files are read in worker threads, up to options.read_workers ahead of
+        the one being extracted, and extracted one at a time in path order.
+        """
+        if options is not None and options.read_workers is not None and options.read_workers < 1:
+            raise ValueError("read_workers must be at least 1")
+        paths = PathFilter(include, exclude)
+        walked: List[_WalkedFile] = []
+        for path in _walk_files(Path(root)):
+            language = detect_language_from_file(str(path))
+            if self.get_extractor(language or "") is None:
+                continue
+            rel = path.relative_to(root).as_posix()
+            if not paths.matches(rel):
+                continue
+            is_test = None
+            if options is not None and (options.classify_tests or options.exclude_tests):
+                is_test = is_test_file(rel, language, options.test_patterns)
+                if is_test and options.exclude_tests:
+                    continue
+            try:
+                resolve_within(root, rel)
+            except PathTraversalError as e:
+                walked.append(_WalkedFile(rel, path, language, is_test, Outline(
+                    language=language,
+                    symbols=[],
+                    metadata={"file": str(path)},
+                    error=str(e),
+                    error_code=e.code,
+                )))
+                continue
+            walked.append(_WalkedFile(rel, path, language, is_test))
+
+        workers = options.read_workers if options is not None else None
+        if workers is None:
+            workers = adaptive_read_workers([f.size() for f in walked if f.outline is None])
+        file_options = options or ExtractOptions()
+        reads: Deque[Tuple[_WalkedFile, Optional[asyncio.Future]]] = deque()
+        upcoming = iter(walked)
+        try:
+            while True:
+                for file in itertools.islice(upcoming, workers - len(reads)):
+                    read = None
+                    if file.outline is None:
+                        read = asyncio.ensure_future(
+                            asyncio.to_thread(self._read_file, str(file.path), file.language, file_options)
+                        )
+                    reads.append((file, read))
+                if not reads:
+                    break
+                file, read = reads.popleft()
+                if read is None:
+                    yield file.rel, file.outline
+                    continue
+                outline = await self._extract_read(str(file.path), file.language, file_options, await read)
+                if options is not None and options.global_ids:
+                    # Outside a repository, paths are relative to the extracted root
+                    assign_global_ids(outline.symbols, repo_relative_path(str(file.path), root), file.language)
+                if options is not None and options.classify_tests:
+                    outline.is_test = file.is_test
+                yield file.rel, outline
+        finally:
+            # Reads already running finish in their threads; their content is dropped
+            for _, read in reads:
+                if read is not None:
+                    read.cancel()
+    
+    async def extract_dir_jsonl(
+        self,
+        root: str,
+        output: TextIO,
+        options: Optional[ExtractOptions] = None,
+        output_options: Optional[OutputOptions] = None
+    ) -> int:
+        """Stream directory outlines to a text stream as JSON lines.
+        
+        Each line is a self-contained JSON object for one file: the outline
+        dictionary plus a "file" key. Files that fail carry a non-null
+        "error" field, so consumers can handle failures per line.
+        
+        Args:
+            root: Directory to walk
+            output: Writable text stream (file, sys.stdout, StringIO, ...)
+            options: Optional extraction options applied to every file
+            output_options: Optional serialization options, e.g. to keep
+                only some symbol fields
+            
+        Returns:
+            Number of lines written
+        """
+        count = 0
+        async for rel, outline in self.iter_extract_dir(root, options):
+            output.write(json.dumps({"file": rel, **outline.to_dict(output_options)}) + "\n")
+            output.flush()
+            count += 1
+        return count
+
+
+@dataclass
+class _WalkedFile:
+    """A file found by _iter_extract, before it is read."""
+
+    rel: str
+    path: Path
+    language: str
+    is_test: Optional[bool]
+    # Set for files reported without being read, e.g. links escaping the root
+    outline: Optional[Outline] = None
+
+    def size(self) -> int:
+        """Size in bytes, 0 if it can't be read."""
+        try:
+            return self.path.stat().st_size
+        except OSError:
+            return 0
+
+
+def _walk_files(root: Path, ignore: Optional[IgnoreRules] = None) -> Iterator[Path]:
+    """Yield non-hidden files under root in sorted order, leaving out ignored paths."""
+    yield from _walk_dir(root, root, ignore)
+
+
+def _walk_dir(directory: Path, root: Path, ignore: Optional[IgnoreRules]) -> Iterator[Path]:
+    """Recursive step of _walk_files."""
+    for entry in sorted(directory.iterdir()):
+        if entry.name.startswith("."):
+            continue
+        is_dir = entry.is_dir()
+        if ignore is not None and ignore.is_ignored(entry.relative_to(root).as_posix(), is_dir):
+            continue
+        if is_dir:
+            if entry.is_symlink():
+                # Don't descend into linked directories; they may escape root or cycle
+                continue
+            yield from _walk_dir(entry, root, ignore)
+        elif entry.is_file():
+            yield entry
 
 
 # Convenience functions for direct usage
@@ -124,4 +871,80 @@ def supported_languages() -> List[str]:
 
 async def is_language_available(language: str) -> bool:
     """Check if language is available."""
-    return await _global_tools.is_language_available(language)
\ No newline at end of file
+    return await _global_tools.is_language_available(language)
+
+
+async def extract_symbols(
+    content: str,
+    language: str,
+    options: Optional[ExtractOptions] = None,
+    path: Optional[str] = None
+) -> Outline:
+    """Extract a symbol outline from code content."""
+    return await _global_tools.extract_symbols(content, language, options, path)
+
+
+async def extract_file(
+    file_path: str,
+    language: Optional[str] = None,
+    options: Optional[ExtractOptions] = None
+) -> Outline:
+    """Extract a symbol outline from a file."""
+    return await _global_tools.extract_file(file_path, language, options)
+
+
+async def run_query(content: str, language: str, query: str) -> List[QueryCapture]:
+    """Run a tree-sitter query over code content."""
+    return await _global_tools.run_query(content, language, query)
+
+
+async def validate(content: str, language: str) -> List[Diagnostic]:
+    """Check code content for syntax errors without extracting symbols."""
+    return await _global_tools.validate(content, language)
+
+
+async def extract_reader(
+    reader: Any,
+    language: str,
+    options: Optional[ExtractOptions] = None,
+    path: Optional[str] = None
+) -> Outline:
+    """Extract a symbol outline from a text or binary stream."""
+    return await _global_tools.extract_reader(reader, language, options, path)
+
+
+async def extract_dir(
+    root: str,
+    options: Optional[ExtractOptions] = None
+) -> Dict[str, Outline]:
+    """Extract outlines for every supported file under a directory."""
+    return await _global_tools.extract_dir(root, options)
+
+
+async def extract_dir_each(
+    root: str,
+    callback: Callable[[str, Outline], Any],
+    options: Optional[ExtractOptions] = None
+) -> int:
+    """Extract outlines under a directory, passing each to a callback as it completes."""
+    return await _global_tools.extract_dir_each(root, callback, options)
+
+
+async def extract_roots(
+    roots: Sequence[Union[str, SourceRoot]],
+    options: Optional[ExtractOptions] = None,
+    include: Optional[List[str]] = None,
+    exclude: Optional[List[str]] = None
+) -> Dict[str, Outline]:
+    """Extract outlines under several directories, keyed by root label."""
+    return await _global_tools.extract_roots(roots, options, include, exclude)
+
+
+async def extract_dir_jsonl(
+    root: str,
+    output: TextIO,
+    options: Optional[ExtractOptions] = None,
+    output_options: Optional[OutputOptions] = None
+) -> int:
+    """Stream directory outlines to a text stream as JSON lines."""
+    return await _global_tools.extract_dir_jsonl(root, output, options, output_options)
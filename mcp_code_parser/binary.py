@@ -0,0 +1,105 @@
+"""Telling binary content apart from source text."""
+
+from abc import ABC, abstractmethod
+from typing import Optional
+
+# Signatures of common binary formats, checked against the start of the content
+MAGIC_NUMBERS = (
+    b"\x89PNG\r\n\x1a\n",
+    b"GIF87a",
+    b"GIF89a",
+    b"\xff\xd8\xff",  # JPEG
+    b"II*\x00",  # TIFF, little endian
+    b"MM\x00*",  # TIFF, big endian
+    b"RIFF",  # WAV, AVI, WebP
+    b"OggS",
+    b"%PDF-",
+    b"PK\x03\x04",  # ZIP, JAR, docx, wheels
+    b"\x1f\x8b",  # gzip
+    b"\xfd7zXZ\x00",
+    b"7z\xbc\xaf\x27\x1c",
+    b"\x7fELF",
+    b"\xcf\xfa\xed\xfe",  # Mach-O 64-bit
+    b"\xce\xfa\xed\xfe",  # Mach-O 32-bit
+    b"\xca\xfe\xba\xbe",  # Mach-O universal, Java class
+    b"\x00asm",  # WebAssembly
+    b"SQLite format 3\x00",
+)
+
+# Byte order marks of encodings that contain NUL bytes in ordinary text.
+# UTF-32 comes first because its little-endian BOM starts with UTF-16's.
+_WIDE_BOMS = (
+    (b"\xff\xfe\x00\x00", "utf-32"),
+    (b"\x00\x00\xfe\xff", "utf-32"),
+    (b"\xff\xfe", "utf-16"),
+    (b"\xfe\xff", "utf-16"),
+)
+
+# Share of NULs in alternate bytes above which BOM-less content is taken as UTF-16
+_UTF16_NUL_RATIO = 0.7
+
+# Share of control characters above which content is taken as binary
+_CONTROL_RATIO = 0.3
+# Control bytes that are normal in text: tab, newlines, form feed, escape
+_TEXT_CONTROLS = frozenset(b"\t\n\x0b\x0c\r\x1b")
+
+
+class BinaryDetector(ABC):
+    """Decides whether content is binary data rather than text.
+
+    Implementations only see the first few kilobytes of the content, so they
+    shouldn't rely on its end.
+    """
+
+    @abstractmethod
+    def is_binary(self, sample: bytes) -> bool:
+        """Check whether content starting with sample is binary."""
+        pass
+
+
+class DefaultBinaryDetector(BinaryDetector):
+    """Signature, byte order mark and byte statistics based detection.
+
+    Content is binary if it starts with a known file signature. Otherwise
+    UTF-16 and UTF-32 text (with a BOM, or BOM-less UTF-16 of mostly ASCII
+    characters) is text; remaining content is binary if it contains a NUL
+    byte or is largely control characters.
+    """
+
+    def is_binary(self, sample: bytes) -> bool:
+        """Check whether content starting with sample is binary."""
+        if wide_text_encoding(sample) is not None:
+            return False
+        if sample.startswith(MAGIC_NUMBERS):
+            return True
+        if b"\x00" in sample:
+            return True
+        if not sample:
+            return False
+        controls = sum(1 for b in sample if b < 0x20 and b not in _TEXT_CONTROLS)
+        return controls / len(sample) > _CONTROL_RATIO
+
+
+def wide_text_encoding(sample: bytes) -> Optional[str]:
+    """Get the UTF-16/UTF-32 encoding content starting with sample appears to use.
+
+    Returns:
+        A codec name ("utf-16", "utf-32", "utf-16-le" or "utf-16-be"), or
+        None for content that doesn't look like wide text
+    """
+    for bom, encoding in _WIDE_BOMS:
+        if sample.startswith(bom):
+            return encoding
+
+    # BOM-less UTF-16: one byte of every pair is NUL for ASCII characters
+    even, odd = sample[0::2], sample[1::2]
+    if len(odd) < 2:
+        return None
+    if b"\x00" not in even and odd.count(0) / len(odd) > _UTF16_NUL_RATIO:
+        return "utf-16-le"
+    if b"\x00" not in odd and even.count(0) / len(even) > _UTF16_NUL_RATIO:
+        return "utf-16-be"
+    return None
+
+
+DEFAULT_BINARY_DETECTOR = DefaultBinaryDetector()
@@ -0,0 +1,109 @@
+"""Annotating symbols with the last commit touching them, from git blame."""
+
+import asyncio
+import os
+from dataclasses import dataclass
+from datetime import datetime, timedelta, timezone
+from typing import Dict, List
+
+from mcp_code_parser.extractors.base import Symbol
+
+# Commit git blame reports for lines changed in the working tree
+_UNCOMMITTED = "0" * 40
+
+
+@dataclass
+class BlameCommit:
+    """The commit git blame attributes a line to."""
+
+    commit: str
+    author: str
+    # Commit time, Unix seconds
+    time: int
+    # Committer's UTC offset as git prints it, e.g. "+0100"
+    tz: str = "+0000"
+
+    def isoformat(self) -> str:
+        """The commit time in ISO 8601 with the committer's offset."""
+        sign = -1 if self.tz.startswith("-") else 1
+        digits = self.tz.lstrip("+-").rjust(4, "0")
+        offset = timedelta(hours=int(digits[:2]), minutes=int(digits[2:]))
+        return datetime.fromtimestamp(self.time, timezone(sign * offset)).isoformat()
+
+
+async def blame_file(file_path: str) -> Dict[int, BlameCommit]:
+    """Blame every line of a file with a single `git blame` run.
+
+    Lines changed in the working tree but not committed are left out.
+
+    Args:
+        file_path: File inside a git work tree
+
+    Returns:
+        Commit of each 1-based line
+
+    Raises:
+        ValueError: If git isn't installed, the file isn't in a git work
+            tree or it isn't tracked
+    """
+    directory, name = os.path.split(os.path.abspath(file_path))
+    try:
+        process = await asyncio.create_subprocess_exec(
+            "git", "blame", "--porcelain", "--", name,
+            cwd=directory,
+            stdout=asyncio.subprocess.PIPE,
+            stderr=asyncio.subprocess.PIPE,
+        )
+    except OSError as e:
+        raise ValueError(f"Could not run git: {e}") from e
+    stdout, stderr = await process.communicate()
+    if process.returncode != 0:
+        message = stderr.decode("utf8", errors="replace").strip().splitlines()
+        raise ValueError(f"git blame failed: {message[0] if message else process.returncode}")
+    return _parse_porcelain(stdout.decode("utf8", errors="replace"))
+
+
+def annotate_blame(symbols: List[Symbol], lines: Dict[int, BlameCommit]) -> None:
+    """Set last_commit, last_author and last_modified on symbols (and children), in place.
+
+    Each symbol gets the most recent commit among the lines it spans.
+    """
+    for sym in symbols:
+        commits = [lines[n] for n in range(sym.start_line, sym.end_line + 1) if n in lines]
+        if commits:
+            latest = max(commits, key=lambda c: c.time)
+            sym.last_commit = latest.commit
+            sym.last_author = latest.author
+            sym.last_modified = latest.isoformat()
+        annotate_blame(sym.children, lines)
+
+
+def _parse_porcelain(output: str) -> Dict[int, BlameCommit]:
+    """Line commits from `git blame --porcelain`, which gives a commit's details once."""
+    details: Dict[str, Dict[str, str]] = {}
+    lines: Dict[int, BlameCommit] = {}
+    current = None
+    final_line = 0
+    for text in output.split("\n"):
+        if text.startswith("\t"):
+            # The line's content ends its entry
+            if current != _UNCOMMITTED:
+                info = details[current]
+                lines[final_line] = BlameCommit(
+                    commit=current,
+                    author=info.get("author", ""),
+                    time=int(info.get("committer-time", "0")),
+                    tz=info.get("committer-tz", "+0000"),
+                )
+            current = None
+            continue
+        if current is None:
+            parts = text.split(" ")
+            if len(parts) < 3:
+                continue
+            current, final_line = parts[0], int(parts[2])
+            details.setdefault(current, {})
+            continue
+        key, _, value = text.partition(" ")
+        details[current][key] = value
+    return lines
@@ -0,0 +1,218 @@
+"""Content-addressed storage for serialized tool results."""
+
+import hashlib
+import json
+import os
+import tempfile
+import threading
+from abc import ABC, abstractmethod
+from pathlib import Path
+from typing import Any, Dict, Optional
+
+from mcp_code_parser.errors import AlreadyExistsError
+
+
+def blob_digest(data: bytes) -> str:
+    """Content hash a blob is stored under (SHA-256, hex)."""
+    return hashlib.sha256(data).hexdigest()
+
+
+def result_key(tool: str, request: Dict[str, Any]) -> str:
+    """Stable name for a tool's result on a request, for BlobStore.tag.
+
+    Args:
+        tool: Tool name, e.g. "extract_symbols"
+        request: JSON-serializable inputs that determine the result
+    """
+    canonical = json.dumps({"tool": tool, "request": request}, sort_keys=True, separators=(",", ":"))
+    return blob_digest(canonical.encode("utf8"))
+
+
+class BlobStore(ABC):
+    """Stores blobs by content hash, plus names pointing at blobs.
+
+    put/get make identical content share one entry. tag/lookup map a name,
+    e.g. a result_key for a tool request, to the blob holding its result.
+    tag_if_absent sets a name only once, so concurrent writers creating the
+    same name get exactly one winner.
+    """
+
+    @abstractmethod
+    def put(self, data: bytes) -> str:
+        """Store a blob and return its content hash."""
+        pass
+
+    @abstractmethod
+    def get(self, digest: str) -> Optional[bytes]:
+        """Get the blob with a content hash, or None."""
+        pass
+
+    @abstractmethod
+    def tag(self, name: str, digest: str) -> None:
+        """Point a name at a stored blob, replacing any previous target."""
+        pass
+
+    @abstractmethod
+    def tag_if_absent(self, name: str, digest: str) -> None:
+        """Point a name at a stored blob unless it already points at one.
+
+        Raises:
+            AlreadyExistsError: If the name is already set
+        """
+        pass
+
+    @abstractmethod
+    def lookup(self, name: str) -> Optional[str]:
+        """Get the content hash a name points at, or None."""
+        pass
+
+    def __contains__(self, digest: str) -> bool:
+        """Check whether a blob is stored."""
+        return self.get(digest) is not None
+
+    def get_tagged(self, name: str) -> Optional[bytes]:
+        """Get the blob a name points at, or None if either is missing."""
+        digest = self.lookup(name)
+        return self.get(digest) if digest is not None else None
+
+    def put_tagged(self, name: str, data: bytes) -> str:
+        """Store a blob and point a name at it; returns the content hash."""
+        digest = self.put(data)
+        self.tag(name, digest)
+        return digest
+
+    def put_if_absent(self, name: str, data: bytes) -> str:
+        """Store a blob and point a new name at it; returns the content hash.
+
+        The blob is stored even if the name is taken, but the name keeps
+        its target.
+
+        Raises:
+            AlreadyExistsError: If the name is already set
+        """
+        digest = self.put(data)
+        self.tag_if_absent(name, digest)
+        return digest
+
+
+class MemoryBlobStore(BlobStore):
+    """In-process blob storage, for tests and single sessions."""
+
+    def __init__(self):
+        self._blobs: Dict[str, bytes] = {}
+        self._tags: Dict[str, str] = {}
+        self._lock = threading.Lock()
+
+    def put(self, data: bytes) -> str:
+        """Store a blob and return its content hash."""
+        digest = blob_digest(data)
+        self._blobs[digest] = bytes(data)
+        return digest
+
+    def get(self, digest: str) -> Optional[bytes]:
+        """Get the blob with a content hash, or None."""
+        return self._blobs.get(digest)
+
+    def tag(self, name: str, digest: str) -> None:
+        """Point a name at a stored blob, replacing any previous target."""
+        with self._lock:
+            self._tags[name] = digest
+
+    def tag_if_absent(self, name: str, digest: str) -> None:
+        """Point a name at a stored blob unless it already points at one."""
+        with self._lock:
+            if name in self._tags:
+                raise AlreadyExistsError(f"Name already set: {name}")
+            self._tags[name] = digest
+
+    def lookup(self, name: str) -> Optional[str]:
+        """Get the content hash a name points at, or None."""
+        return self._tags.get(name)
+
+
+class FileBlobStore(BlobStore):
+    """Blobs as files under a directory, shared between processes.
+
+    Blobs live at objects/<2 hex chars>/<rest of hash> and names at
+    tags/<hash of name>, both written atomically, so concurrent writers of
+    the same content never expose partial files.
+    """
+
+    def __init__(self, root: str):
+        """Use (and create if needed) the store directory at root."""
+        self.root = Path(root)
+        (self.root / "objects").mkdir(parents=True, exist_ok=True)
+        (self.root / "tags").mkdir(parents=True, exist_ok=True)
+
+    def put(self, data: bytes) -> str:
+        """Store a blob and return its content hash."""
+        digest = blob_digest(data)
+        path = self._object_path(digest)
+        if not path.exists():
+            path.parent.mkdir(exist_ok=True)
+            _write_atomic(path, data)
+        return digest
+
+    def get(self, digest: str) -> Optional[bytes]:
+        """Get the blob with a content hash, or None."""
+        if not _is_digest(digest):
+            return None
+        try:
+            return self._object_path(digest).read_bytes()
+        except FileNotFoundError:
+            return None
+
+    def tag(self, name: str, digest: str) -> None:
+        """Point a name at a stored blob, replacing any previous target."""
+        _write_atomic(self._tag_path(name), digest.encode("ascii"))
+
+    def tag_if_absent(self, name: str, digest: str) -> None:
+        """Point a name at a stored blob unless it already points at one.
+
+        The tag is written to a temporary file and hard-linked into place,
+        which fails if the name exists, so of several processes setting the
+        same name one wins and the others get AlreadyExistsError, and a
+        crash never leaves a partial tag behind.
+        """
+        path = self._tag_path(name)
+        fd, tmp = tempfile.mkstemp(dir=path.parent, prefix=".tmp-")
+        try:
+            with os.fdopen(fd, "wb") as f:
+                f.write(digest.encode("ascii"))
+            os.link(tmp, path)
+        except FileExistsError:
+            raise AlreadyExistsError(f"Name already set: {name}") from None
+        finally:
+            Path(tmp).unlink(missing_ok=True)
+
+    def lookup(self, name: str) -> Optional[str]:
+        """Get the content hash a name points at, or None."""
+        try:
+            return self._tag_path(name).read_text(encoding="ascii").strip()
+        except FileNotFoundError:
+            return None
+
+    def _object_path(self, digest: str) -> Path:
+        """Path of the file holding a blob."""
+        return self.root / "objects" / digest[:2] / digest[2:]
+
+    def _tag_path(self, name: str) -> Path:
+        """Path of the file holding a name's target (names may contain any characters)."""
+        return self.root / "tags" / blob_digest(name.encode("utf8"))
+
+
+def _is_digest(value: str) -> bool:
+    """Check that a value looks like a SHA-256 hex digest, so it is safe as a path."""
+    return len(value) == 64 and all(c in "0123456789abcdef" for c in value)
+
+
+def _write_atomic(path: Path, data: bytes) -> None:
+    """Write a file via a temporary file in the same directory and a rename."""
+    fd, tmp = tempfile.mkstemp(dir=path.parent, prefix=".tmp-")
+    try:
+        with os.fdopen(fd, "wb") as f:
+            f.write(data)
+        os.replace(tmp, path)
+    except BaseException:
+        Path(tmp).unlink(missing_ok=True)
+        raise
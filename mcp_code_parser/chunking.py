@@ -0,0 +1,197 @@
+"""Split source files into symbol-aligned chunks for retrieval indexing."""
+
+from dataclasses import asdict, dataclass, field
+from typing import TYPE_CHECKING, Any, Dict, List, Optional
+
+from mcp_code_parser.context import estimate_tokens
+from mcp_code_parser.errors import UnsupportedLanguageError, error_code, error_for_code
+from mcp_code_parser.extractors.base import ExtractOptions, FlatSymbol
+from mcp_code_parser.utils import detect_language_from_file, safe_read_file
+
+if TYPE_CHECKING:
+    from mcp_code_parser.api import AgentTools
+
+
+@dataclass
+class Chunk:
+    """A run of whole top-level declarations from one file."""
+
+    text: str
+    # 1-based, inclusive
+    start_line: int
+    end_line: int
+    start_byte: int
+    end_byte: int
+    tokens: int
+    # Qualified names of the symbols declared in the chunk, in source order
+    symbols: List[str] = field(default_factory=list)
+    # A single declaration over the token budget
+    oversized: bool = False
+    path: Optional[str] = None
+    # Secrets masked in text by AgentTools.redactor, by rule; the byte range
+    # and token estimate are those of the original text
+    redactions: Dict[str, int] = field(default_factory=dict)
+
+    def to_dict(self) -> Dict[str, Any]:
+        """Convert to a plain dictionary."""
+        return asdict(self)
+
+
+@dataclass
+class _Unit:
+    """A declaration that is never split, with the symbols nested in it."""
+
+    start_byte: int
+    end_byte: int
+    names: List[str]
+
+
+def chunk_symbols(
+    flat: List[FlatSymbol],
+    source: str,
+    max_tokens: int,
+    path: Optional[str] = None
+) -> List[Chunk]:
+    """Group a file's declarations into chunks that fit a token budget.
+
+    Each outermost declaration (with the comment lines directly above it) is
+    kept whole; consecutive declarations are added to a chunk while it stays
+    within max_tokens. A declaration larger than the budget on its own gets a
+    chunk of its own marked oversized. Text between declarations in a chunk,
+    such as comments, is kept; text outside every declaration is dropped.
+
+    Args:
+        flat: Flattened outline of the file (see Outline.flatten)
+        source: Source the outline was extracted from
+        max_tokens: Token budget per chunk, as counted by estimate_tokens
+        path: File path to record on the chunks
+
+    Returns:
+        Chunks in source order
+
+    Raises:
+        ValueError: If max_tokens isn't positive
+    """
+    if max_tokens <= 0:
+        raise ValueError(f"max_tokens must be positive, got {max_tokens}")
+    data = source.encode("utf8")
+
+    chunks: List[Chunk] = []
+    pending: List[_Unit] = []
+    for unit in _units(flat, data):
+        if pending and _tokens(data, pending[0].start_byte, unit.end_byte) > max_tokens:
+            chunks.append(_chunk(data, pending, max_tokens, path))
+            pending = []
+        pending.append(unit)
+    if pending:
+        chunks.append(_chunk(data, pending, max_tokens, path))
+    return chunks
+
+
+async def chunk_file(
+    path: str,
+    max_tokens: int,
+    options: Optional[ExtractOptions] = None,
+    language: Optional[str] = None,
+    tools: Optional["AgentTools"] = None
+) -> List[Chunk]:
+    """Split a file into symbol-aligned chunks (see chunk_symbols).
+
+    Secrets in the chunks' text are masked when tools has a redactor.
+
+    Args:
+        path: Source file
+        max_tokens: Token budget per chunk
+        options: Extraction options
+        language: Language override (detected from the path by default)
+        tools: AgentTools instance to use (defaults to the global one)
+
+    Returns:
+        Chunks in source order
+
+    Raises:
+        ValueError: If max_tokens isn't positive
+        ToolError: If the file can't be read or its symbols extracted
+    """
+    if max_tokens <= 0:
+        raise ValueError(f"max_tokens must be positive, got {max_tokens}")
+    if tools is None:
+        from mcp_code_parser.api import _global_tools
+        tools = _global_tools
+
+    language = language or detect_language_from_file(path)
+    if not language:
+        raise UnsupportedLanguageError(f"Could not detect language of {path}")
+    try:
+        content = safe_read_file(path, detector=tools.binary_detector)
+    except Exception as e:
+        code = error_code(e)
+        if code is None:
+            raise
+        raise error_for_code(code, f"Error reading file: {e}") from e
+
+    outline = await tools.extract_symbols(content, language, options, path)
+    outline.raise_for_error()
+    chunks = chunk_symbols(outline.flatten(), content, max_tokens, path)
+    if tools.redactor is not None:
+        for chunk in chunks:
+            chunk.text, chunk.redactions = tools.redactor.redact(chunk.text)
+    return chunks
+
+
+def _units(flat: List[FlatSymbol], data: bytes) -> List[_Unit]:
+    """Outermost declarations by byte range, each with the names of the symbols inside it.
+
+    Ranges rather than outline nesting decide what is outermost, so Go
+    methods (grouped under their type but declared apart from it) are units
+    of their own.
+    """
+    units: List[_Unit] = []
+    for item in sorted(flat, key=lambda f: (f.symbol.start_byte, -f.symbol.end_byte)):
+        sym = item.symbol
+        if units and sym.start_byte < units[-1].end_byte:
+            units[-1].names.append(item.qualified_name)
+            units[-1].end_byte = max(units[-1].end_byte, sym.end_byte)
+            continue
+        units.append(_Unit(_comment_start(data, sym.start_byte), sym.end_byte, [item.qualified_name]))
+    return units
+
+
+def _comment_start(data: bytes, start_byte: int) -> int:
+    """Start of the comment lines directly above the line containing start_byte, or of that line."""
+    start = data.rfind(b"\n", 0, start_byte) + 1
+    in_block = False
+    while start > 0:
+        previous = data.rfind(b"\n", 0, start - 1) + 1
+        line = data[previous:start - 1].strip()
+        if in_block:
+            in_block = not line.startswith(b"/*")
+        elif line.endswith(b"*/"):
+            in_block = b"/*" not in line
+        elif not line.startswith(b"//"):
+            break
+        start = previous
+    return start
+
+
+def _tokens(data: bytes, start: int, end: int) -> int:
+    """Estimated tokens of a byte span."""
+    return estimate_tokens(data[start:end].decode("utf8", errors="replace"))
+
+
+def _chunk(data: bytes, units: List[_Unit], max_tokens: int, path: Optional[str]) -> Chunk:
+    """Chunk spanning a run of units."""
+    start, end = units[0].start_byte, units[-1].end_byte
+    text = data[start:end].decode("utf8", errors="replace")
+    tokens = estimate_tokens(text)
+    return Chunk(
+        text=text,
+        start_line=data.count(b"\n", 0, start) + 1,
+        end_line=data.count(b"\n", 0, end) + 1,
+        start_byte=start,
+        end_byte=end,
+        tokens=tokens,
+        symbols=[name for unit in units for name in unit.names],
+        oversized=tokens > max_tokens,
+        path=path,
+    )
@@ -7,7 +7,8 @@ from pathlib import Path
 
 import click
 
-from mcp_code_parser import parse_file, supported_languages
+from mcp_code_parser import extract_file, parse_file, supported_languages
+from mcp_code_parser.render import render_terminal, use_color
 
 
 @click.group()
@@ -55,6 +56,22 @@ def parse(file_path: str, language: str, output: str, format: str):
     asyncio.run(_parse())
 
 
+@cli.command()
+@click.argument("file_path", type=click.Path(exists=True))
+@click.option("--language", "-l", help="Override language detection")
+@click.option("--color/--no-color", default=None,
+              help="Force colors on or off (default: on for terminals unless NO_COLOR is set)")
+def outline(file_path: str, language: str, color: bool):
+    """Print the symbol outline of a source file as a tree."""
+    
+    async def _outline():
+        result = await extract_file(file_path, language)
+        colored = use_color(sys.stdout, color)
+        click.echo(render_terminal(result, color=colored), color=colored)
+    
+    asyncio.run(_outline())
+
+
 @cli.command()
 def languages():
     """List supported programming languages."""
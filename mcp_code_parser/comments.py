@@ -0,0 +1,111 @@
+"""Finding the comments of a source file.
+
+Comments come from a language's registered CommentSyntax when it has one,
+so languages without a grammar still work with comment-based tools, and
+otherwise from its tree-sitter grammar.
+"""
+
+from typing import TYPE_CHECKING, List, Optional, Tuple
+
+import tree_sitter
+
+from mcp_code_parser.registry import CommentSyntax, language_registry
+
+if TYPE_CHECKING:
+    from mcp_code_parser.api import AgentTools
+
+
+def comment_syntax(language: str) -> Optional[CommentSyntax]:
+    """The CommentSyntax registered for a language, if any."""
+    backend = language_registry().get(language)
+    return backend.comments if backend is not None else None
+
+
+async def comment_ranges(
+    content: str,
+    language: str,
+    tools: Optional["AgentTools"] = None
+) -> List[Tuple[int, int]]:
+    """Find the comments of a file.
+
+    Args:
+        content: Source code
+        language: Programming language
+        tools: AgentTools instance to use (defaults to the global one)
+
+    Returns:
+        (start byte, end byte) of each comment, in source order
+
+    Raises:
+        ToolError: If the language has neither a registered CommentSyntax
+            nor a grammar
+    """
+    syntax = comment_syntax(language)
+    if syntax is not None:
+        return scan_comments(content, syntax)
+    if tools is None:
+        from mcp_code_parser.api import _global_tools
+        tools = _global_tools
+    tree = await tools.parse_tree(content, language)
+    return [(node.start_byte, node.end_byte) for node in comment_nodes(tree.root_node)]
+
+
+def scan_comments(content: str, syntax: CommentSyntax) -> List[Tuple[int, int]]:
+    """Find comments by their tokens, skipping tokens inside string literals.
+
+    Block comments don't nest, and one left open runs to the end of the
+    content, as does an unterminated string.
+
+    Returns:
+        (start byte, end byte) of each comment, in source order
+    """
+    source = content.encode("utf8")
+    # At each position the longest token wins, so `--[[` opens a block before `--` starts a line
+    tokens = [(t.encode("utf8"), "line", None) for t in syntax.line if t]
+    tokens += [(o.encode("utf8"), "block", c.encode("utf8")) for o, c in syntax.block if o and c]
+    tokens += [(q.encode("utf8"), "string", q.encode("utf8")) for q in syntax.strings if q]
+    tokens.sort(key=lambda t: len(t[0]), reverse=True)
+    escape = syntax.escape.encode("utf8") if syntax.escape else None
+
+    ranges = []
+    i = 0
+    while i < len(source):
+        match = next((t for t in tokens if source.startswith(t[0], i)), None)
+        if match is None:
+            i += 1
+            continue
+        token, kind, close = match
+        if kind == "line":
+            end = source.find(b"\n", i)
+            end = len(source) if end == -1 else end
+            ranges.append((i, end))
+        elif kind == "block":
+            end = source.find(close, i + len(token))
+            end = len(source) if end == -1 else end + len(close)
+            ranges.append((i, end))
+        else:
+            end = i + len(token)
+            while end < len(source):
+                if escape is not None and source.startswith(escape, end):
+                    end += len(escape) + 1
+                elif source.startswith(close, end):
+                    end += len(close)
+                    break
+                else:
+                    end += 1
+        i = end
+    return ranges
+
+
+def comment_nodes(root: tree_sitter.Node) -> List[tree_sitter.Node]:
+    """Comment nodes under root, outermost only."""
+    comments = []
+    stack = [root]
+    while stack:
+        node = stack.pop()
+        if "comment" in node.type:
+            comments.append(node)
+            continue
+        stack.extend(node.children)
+    return sorted(comments, key=lambda n: n.start_byte)
+
@@ -0,0 +1,137 @@
+"""Fit symbols and files into an LLM context window."""
+
+from dataclasses import dataclass, field, replace
+from typing import TYPE_CHECKING, Dict, List, Optional, Tuple
+
+from mcp_code_parser.extractors.base import Symbol
+from mcp_code_parser.redact import Redactor, merge_counts
+
+if TYPE_CHECKING:
+    from mcp_code_parser.api import AgentTools
+
+# Rough average for source code with common BPE tokenizers
+CHARS_PER_TOKEN = 4
+
+# Packing strategies
+EXPORTED_FIRST = "exported-first"
+SIGNATURES_ONLY = "signatures-only"
+RECENTLY_CHANGED_FIRST = "recently-changed-first"
+STRATEGIES = (EXPORTED_FIRST, SIGNATURES_ONLY, RECENTLY_CHANGED_FIRST)
+
+
+def estimate_tokens(text: str) -> int:
+    """Estimate how many tokens text takes up in a model's context.
+
+    This is a cheap character-count heuristic, not a tokenizer; it errs on
+    the high side for typical source code.
+    """
+    return -(-len(text) // CHARS_PER_TOKEN)
+
+
+@dataclass
+class Packable:
+    """A piece of content that can be packed into a context window."""
+
+    name: str
+    # Content included when the item is packed in full
+    text: str
+    # Short form used by the signatures-only strategy (falls back to text)
+    signature: str = ""
+    exported: bool = False
+    # Last modification time (e.g. a file mtime); larger is more recent
+    modified: float = 0.0
+    path: Optional[str] = None
+    # Secrets masked in text and signature by AgentTools.redactor, by rule
+    redactions: Dict[str, int] = field(default_factory=dict)
+
+    @property
+    def tokens(self) -> int:
+        """Estimated token cost of text."""
+        return estimate_tokens(self.text)
+
+    @classmethod
+    def from_symbol(
+        cls,
+        symbol: Symbol,
+        source: str,
+        path: Optional[str] = None,
+        modified: float = 0.0
+    ) -> "Packable":
+        """Build an item from an extracted symbol and the source it came from."""
+        data = source.encode("utf8")
+        return cls(
+            name=symbol.name,
+            text=data[symbol.start_byte:symbol.end_byte].decode("utf8", errors="replace"),
+            signature=symbol.signature or "",
+            exported=symbol.exported,
+            modified=modified,
+            path=path,
+        )
+
+
+def pack_context(
+    items: List[Packable],
+    budget_tokens: int,
+    strategy: str = EXPORTED_FIRST,
+    tools: Optional["AgentTools"] = None
+) -> Tuple[List[Packable], int]:
+    """Select the items that fit in a token budget.
+
+    Items are considered in the strategy's priority order and added greedily:
+    one that doesn't fit is skipped, and smaller items after it may still be
+    packed. When tools has a redactor, secrets in the items' text and
+    signature are masked before their cost is estimated.
+
+    Args:
+        items: Candidate items
+        budget_tokens: Maximum estimated tokens to use
+        strategy: One of STRATEGIES:
+            "exported-first": exported items first, then the rest, each group
+                in input order
+            "signatures-only": every item reduced to its signature, in input
+                order
+            "recently-changed-first": most recently modified items first
+        tools: AgentTools instance to use (defaults to the global one)
+
+    Returns:
+        Tuple of (packed items in priority order, estimated tokens used).
+        With signatures-only, or secrets masked, the packed items are copies
+        whose text is the signature or masked.
+
+    Raises:
+        ValueError: If strategy is unknown
+    """
+    if tools is None:
+        from mcp_code_parser.api import _global_tools
+        tools = _global_tools
+    if tools.redactor is not None:
+        items = [_redact(item, tools.redactor) for item in items]
+
+    if strategy == EXPORTED_FIRST:
+        ordered = sorted(items, key=lambda item: not item.exported)
+    elif strategy == SIGNATURES_ONLY:
+        ordered = [replace(item, text=item.signature or item.text) for item in items]
+    elif strategy == RECENTLY_CHANGED_FIRST:
+        ordered = sorted(items, key=lambda item: item.modified, reverse=True)
+    else:
+        raise ValueError(f"Unknown packing strategy: {strategy!r} (expected one of {', '.join(STRATEGIES)})")
+
+    packed = []
+    used = 0
+    for item in ordered:
+        cost = item.tokens
+        if used + cost > budget_tokens:
+            continue
+        packed.append(item)
+        used += cost
+    return packed, used
+
+
+def _redact(item: Packable, redactor: Redactor) -> Packable:
+    """Copy of item with the secrets in its text and signature masked."""
+    text, counts = redactor.redact(item.text)
+    signature, signature_counts = redactor.redact(item.signature)
+    redactions = dict(item.redactions)
+    merge_counts(redactions, counts)
+    merge_counts(redactions, signature_counts)
+    return replace(item, text=text, signature=signature, redactions=redactions)
@@ -0,0 +1,199 @@
+"""Fetch one symbol's source and documentation without reading a whole file."""
+
+import ast
+import textwrap
+from dataclasses import asdict, dataclass, field
+from typing import TYPE_CHECKING, Any, Dict, Optional, Tuple
+
+from mcp_code_parser.context import estimate_tokens
+from mcp_code_parser.errors import NotFoundError, UnsupportedLanguageError, error_code, error_for_code
+from mcp_code_parser.extractors.base import ExtractOptions, Symbol, leading_comment
+from mcp_code_parser.redact import merge_counts
+from mcp_code_parser.utils import detect_language_from_file, safe_read_file
+
+if TYPE_CHECKING:
+    from mcp_code_parser.api import AgentTools
+
+# Line comment marker of languages not using `//`, for elision markers
+_LINE_COMMENTS = {"python": "#", "elixir": "#"}
+
+
+@dataclass
+class ElisionOptions:
+    """When and how to shorten the source of an oversized symbol.
+
+    A symbol over max_bytes or max_tokens (whichever is set and reached
+    first) keeps its first head_lines lines, which hold its signature, and
+    its last tail_lines lines; the lines between are replaced by a comment
+    such as `// ... 120 lines elided ...`.
+    """
+
+    max_bytes: Optional[int] = None
+    # Estimated with context.estimate_tokens
+    max_tokens: Optional[int] = None
+    head_lines: int = 10
+    tail_lines: int = 3
+
+    def __post_init__(self):
+        if self.head_lines < 1 or self.tail_lines < 0:
+            raise ValueError("head_lines must be at least 1 and tail_lines at least 0")
+
+    def exceeded_by(self, text: str) -> bool:
+        """Whether text is over either limit."""
+        if self.max_bytes is not None and len(text.encode("utf8")) > self.max_bytes:
+            return True
+        return self.max_tokens is not None and estimate_tokens(text) > self.max_tokens
+
+
+@dataclass
+class SymbolDescription:
+    """A symbol's source, documentation and surrounding lines."""
+
+    stable_id: str
+    name: str
+    kind: str
+    path: str
+    language: str
+    start_line: int
+    end_line: int
+    signature: Optional[str]
+    # Doc comment above the symbol (docstring for Python), markers stripped
+    doc: str
+    # Exact source of the symbol
+    source: str
+    # Up to context_lines lines before and after the symbol, doc comment included
+    leading_context: str = ""
+    trailing_context: str = ""
+    # Lines of source replaced by an elision comment (see ElisionOptions)
+    elided_lines: int = 0
+    # Secrets masked in the signature, doc, source and context by
+    # AgentTools.redactor, by rule
+    redactions: Dict[str, int] = field(default_factory=dict)
+
+    def to_dict(self) -> Dict[str, Any]:
+        """Convert to a plain dictionary."""
+        return asdict(self)
+
+
+async def describe_symbol(
+    path: str,
+    stable_id: str,
+    context_lines: int = 0,
+    language: Optional[str] = None,
+    options: Optional[ExtractOptions] = None,
+    elision: Optional[ElisionOptions] = None,
+    tools: Optional["AgentTools"] = None
+) -> SymbolDescription:
+    """Describe the symbol with a given stable ID.
+
+    Args:
+        path: Source file
+        stable_id: Stable ID of the symbol, e.g. "method:UserService.GetUser"
+        context_lines: Lines of surrounding source to include on each side
+        language: Language override (detected from the path by default)
+        options: Extraction options
+        elision: Shorten the source if the symbol is over a size limit
+        tools: AgentTools instance to use (defaults to the global one)
+
+    Returns:
+        The symbol's description, with secrets masked when tools has a
+        redactor
+
+    Raises:
+        NotFoundError: If the file or the symbol doesn't exist
+        ToolError: If the file can't be read or its symbols extracted
+    """
+    if tools is None:
+        from mcp_code_parser.api import _global_tools
+        tools = _global_tools
+
+    language = language or detect_language_from_file(path)
+    if not language:
+        raise UnsupportedLanguageError(f"Could not detect language of {path}")
+    try:
+        content = safe_read_file(path, detector=tools.binary_detector)
+    except Exception as e:
+        code = error_code(e)
+        if code is None:
+            raise
+        raise error_for_code(code, f"Error reading file: {e}") from e
+
+    outline = await tools.extract_symbols(content, language, options, path)
+    outline.raise_for_error()
+    symbol = next((f.symbol for f in outline.flatten() if f.symbol.stable_id == stable_id), None)
+    if symbol is None:
+        raise NotFoundError(f"Symbol not found in {path}: {stable_id}")
+
+    source = content.encode("utf8")
+    lines = content.split("\n")
+    first, last = symbol.start_line - 1, symbol.end_line
+    before = lines[max(0, first - context_lines):first] if context_lines > 0 else []
+    after = lines[last:last + context_lines] if context_lines > 0 else []
+    text = source[symbol.start_byte:symbol.end_byte].decode("utf8", errors="replace")
+    elided = 0
+    if elision is not None and elision.exceeded_by(text):
+        text, elided = elide(text, language, elision.head_lines, elision.tail_lines)
+    signature = symbol.signature
+    doc = _python_docstring(symbol, source) if language == "python" else leading_comment(source, symbol.start_byte)
+    leading, trailing = "\n".join(before), "\n".join(after)
+    redactions: Dict[str, int] = {}
+    if tools.redactor is not None:
+        masked = []
+        for value in (signature or "", doc, text, leading, trailing):
+            value, counts = tools.redactor.redact(value)
+            masked.append(value)
+            merge_counts(redactions, counts)
+        signature = masked[0] if signature is not None else None
+        doc, text, leading, trailing = masked[1:]
+
+    return SymbolDescription(
+        stable_id=stable_id,
+        name=symbol.name,
+        kind=symbol.kind,
+        path=path,
+        language=language,
+        start_line=symbol.start_line,
+        end_line=symbol.end_line,
+        signature=signature,
+        doc=doc,
+        source=text,
+        leading_context=leading,
+        trailing_context=trailing,
+        elided_lines=elided,
+        redactions=redactions,
+    )
+
+
+def elide(text: str, language: str, head_lines: int, tail_lines: int) -> Tuple[str, int]:
+    """Replace the middle lines of text with a comment saying how many were left out.
+
+    The comment is indented like the first line it replaces. Text with no
+    more than head_lines + tail_lines + 1 lines is returned unchanged, as
+    the comment would save nothing.
+
+    Returns:
+        The shortened text and the number of lines elided
+    """
+    lines = text.split("\n")
+    elided = len(lines) - head_lines - tail_lines
+    if elided <= 1:
+        return text, 0
+    first = lines[head_lines]
+    indent = first[:len(first) - len(first.lstrip())]
+    marker = f"{indent}{_LINE_COMMENTS.get(language, '//')} ... {elided} lines elided ..."
+    tail = lines[len(lines) - tail_lines:] if tail_lines else []
+    return "\n".join(lines[:head_lines] + [marker] + tail), elided
+
+
+def _python_docstring(symbol: Symbol, source: bytes) -> str:
+    """Docstring of a Python function or class symbol, or "" if it has none."""
+    # Start at the line so nested definitions dedent cleanly
+    line_start = source.rfind(b"\n", 0, symbol.start_byte) + 1
+    text = source[line_start:symbol.end_byte].decode("utf8", errors="replace")
+    try:
+        module = ast.parse(textwrap.dedent(text))
+    except SyntaxError:
+        return ""
+    if not module.body or not isinstance(module.body[0], (ast.FunctionDef, ast.AsyncFunctionDef, ast.ClassDef)):
+        return ""
+    return ast.get_docstring(module.body[0]) or ""
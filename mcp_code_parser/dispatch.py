@@ -0,0 +1,400 @@
+"""Calling tools by name with JSON arguments, validated before dispatch.
+
+A model calling a tool with bad arguments should learn what to fix rather
+than get an error from deep inside the tool. ToolRegistry generates a JSON
+Schema for each tool from its signature and docstring, checks every call's
+arguments against it, and raises InvalidArgumentsError listing each bad
+field with the reason before the tool runs.
+"""
+
+import collections.abc
+import dataclasses
+import enum
+import inspect
+import json
+import re
+import typing
+from dataclasses import dataclass
+from difflib import get_close_matches
+from typing import Any, Dict, List, Optional, Tuple, Union
+
+from mcp_code_parser.errors import ArgumentProblem, InvalidArgumentsError, NotFoundError
+from mcp_code_parser.middleware import Tool
+
+# JSON Schema type of each JSON value Python decodes to
+_JSON_TYPES: List[Tuple[type, str]] = [
+    (bool, "boolean"),
+    (int, "integer"),
+    (float, "number"),
+    (str, "string"),
+    (list, "array"),
+    (dict, "object"),
+    (type(None), "null"),
+]
+_SCALARS = {str: "string", bool: "boolean", int: "integer", float: "number"}
+
+# `name: description` at the start of an `Args:` entry
+_ARG_LINE = re.compile(r"^(\*{0,2}\w+)\s*(?:\([^)]*\))?:\s*(.*)$")
+
+
+@dataclass
+class _Entry:
+    """A registered tool with its schema and parameter types."""
+
+    tool: Tool
+    schema: Dict[str, Any]
+    types: Dict[str, Any]
+
+
+class ToolRegistry:
+    """Tools callable by name with JSON arguments.
+
+    Example:
+        registry = ToolRegistry()
+        registry.register(where_is_symbol)
+        try:
+            found = await registry.call("where_is_symbol", '{"root": "repo"}')
+        except InvalidArgumentsError as e:
+            e.problems  # [ArgumentProblem(field="name", reason="required")]
+    """
+
+    def __init__(self):
+        """Create an empty registry."""
+        self._tools: Dict[str, _Entry] = {}
+
+    def register(self, tool: Tool, name: Optional[str] = None) -> None:
+        """Make a tool callable by name.
+
+        Parameters whose types have no JSON form, such as `tools` or an
+        Index, are left out of the schema and take their defaults.
+
+        Args:
+            tool: Async function, possibly wrapped in middleware
+            name: Name to call it by (defaults to the function's name)
+
+        Raises:
+            ValueError: If the name is taken, or a required parameter's type
+                has no JSON form
+        """
+        name = name or tool.__name__
+        if name in self._tools:
+            raise ValueError(f"Tool {name} is already registered")
+        schema, types = tool_schema(tool)
+        self._tools[name] = _Entry(tool=tool, schema=schema, types=types)
+
+    def names(self) -> List[str]:
+        """Names of the registered tools, sorted."""
+        return sorted(self._tools)
+
+    def schema(self, name: str) -> Dict[str, Any]:
+        """The JSON Schema of a tool's arguments.
+
+        Raises:
+            NotFoundError: If no tool has the name
+        """
+        return self._entry(name).schema
+
+    async def call(self, name: str, arguments: Union[str, bytes, Dict[str, Any], None] = None) -> Any:
+        """Validate arguments against a tool's schema, then call it.
+
+        Args:
+            name: Name of the tool
+            arguments: JSON object of arguments, encoded or decoded
+
+        Returns:
+            What the tool returns
+
+        Raises:
+            NotFoundError: If no tool has the name
+            InvalidArgumentsError: If the arguments don't match the schema;
+                the tool isn't called
+        """
+        entry = self._entry(name)
+        if isinstance(arguments, (str, bytes)):
+            try:
+                arguments = json.loads(arguments)
+            except ValueError as e:
+                raise InvalidArgumentsError(problems=[ArgumentProblem("", f"invalid JSON: {e}")])
+        if arguments is None:
+            arguments = {}
+        problems = validate_arguments(entry.schema, arguments)
+        if problems:
+            raise InvalidArgumentsError(f"Invalid arguments for {name}: " + "; ".join(
+                f"{p.field}: {p.reason}" if p.field else p.reason for p in problems
+            ), problems)
+        kwargs = {key: _convert(value, entry.types[key]) for key, value in arguments.items()}
+        return await entry.tool(**kwargs)
+
+    def _entry(self, name: str) -> _Entry:
+        """The registration of a tool."""
+        entry = self._tools.get(name)
+        if entry is None:
+            known = ", ".join(self.names()) or "none"
+            raise NotFoundError(f"Unknown tool {name}; registered: {known}")
+        return entry
+
+
+def tool_schema(tool: Tool) -> Tuple[Dict[str, Any], Dict[str, Any]]:
+    """Generate the JSON Schema of a tool's arguments from its signature.
+
+    Descriptions come from the `Args:` section of the docstring. Parameters
+    without a default are required.
+
+    Returns:
+        The schema, and the type of each parameter in it
+
+    Raises:
+        ValueError: If a required parameter's type has no JSON form
+    """
+    signature = inspect.signature(tool)
+    function = inspect.unwrap(tool)
+    descriptions = _arg_descriptions(inspect.getdoc(function) or "")
+    hints = _hints(function)
+    properties: Dict[str, Any] = {}
+    required: List[str] = []
+    types: Dict[str, Any] = {}
+    for param in signature.parameters.values():
+        if param.kind in (param.VAR_POSITIONAL, param.VAR_KEYWORD):
+            continue
+        annotation = hints.get(param.name, Any)
+        schema = _type_schema(annotation)
+        if schema is None:
+            if param.default is param.empty:
+                raise ValueError(f"Parameter {param.name} of {tool.__name__} has no JSON form")
+            continue
+        if param.name in descriptions:
+            schema = {**schema, "description": descriptions[param.name]}
+        properties[param.name] = schema
+        types[param.name] = annotation
+        if param.default is param.empty:
+            required.append(param.name)
+    schema = {"type": "object", "properties": properties, "required": required, "additionalProperties": False}
+    summary = (inspect.getdoc(function) or "").split("\n\n", 1)[0].strip()
+    if summary:
+        schema["description"] = summary
+    return schema, types
+
+
+def validate_arguments(schema: Dict[str, Any], arguments: Any) -> List[ArgumentProblem]:
+    """Check arguments against a JSON Schema.
+
+    Supports the subset tool_schema generates: type, enum, anyOf,
+    properties, required, additionalProperties and items.
+
+    Returns:
+        The problems found, in argument order; empty if the arguments are valid
+    """
+    problems: List[ArgumentProblem] = []
+    _check(schema, arguments, "", problems)
+    return problems
+
+
+def _check(schema: Dict[str, Any], value: Any, path: str, problems: List[ArgumentProblem]) -> None:
+    """Add the problems of a value at path to problems."""
+    if "anyOf" in schema:
+        options = schema["anyOf"]
+        # Report against the one option matching the value's type, if any
+        matching = [o for o in options if _type_matches(o, value)]
+        if not matching:
+            expected = " or ".join(_describe(o) for o in options)
+            problems.append(ArgumentProblem(path, f"expected {expected}, got {_json_type(value)}"))
+            return
+        schema = matching[0]
+    if not _type_matches(schema, value):
+        problems.append(ArgumentProblem(path, f"expected {_describe(schema)}, got {_json_type(value)}"))
+        return
+    if "enum" in schema and value not in schema["enum"]:
+        allowed = ", ".join(json.dumps(v) for v in schema["enum"])
+        problems.append(ArgumentProblem(path, f"expected one of {allowed}, got {json.dumps(value)}"))
+        return
+    if isinstance(value, dict):
+        properties = schema.get("properties", {})
+        for key in schema.get("required", []):
+            if key not in value:
+                problems.append(ArgumentProblem(_join(path, key), "required"))
+        extra = schema.get("additionalProperties", True)
+        for key, item in value.items():
+            if key in properties:
+                _check(properties[key], item, _join(path, key), problems)
+            elif extra is False:
+                problems.append(ArgumentProblem(_join(path, key), _unknown(key, properties)))
+            elif isinstance(extra, dict):
+                _check(extra, item, _join(path, key), problems)
+    elif isinstance(value, list) and "items" in schema:
+        for i, item in enumerate(value):
+            _check(schema["items"], item, f"{path}[{i}]", problems)
+
+
+def _type_matches(schema: Dict[str, Any], value: Any) -> bool:
+    """Whether a value has one of the schema's types (any, if it has none)."""
+    expected = schema.get("type")
+    if expected is None:
+        return True
+    names = expected if isinstance(expected, list) else [expected]
+    actual = _json_type(value)
+    # JSON doesn't tell 1 from 1.0
+    return actual in names or (actual == "integer" and "number" in names)
+
+
+def _json_type(value: Any) -> str:
+    """JSON Schema type name of a decoded JSON value."""
+    for cls, name in _JSON_TYPES:
+        if isinstance(value, cls):
+            return name
+    return type(value).__name__
+
+
+def _describe(schema: Dict[str, Any]) -> str:
+    """What a schema expects, e.g. "string" or "array of string"."""
+    expected = schema.get("type")
+    if expected is None:
+        return "any value"
+    if expected == "array" and "items" in schema:
+        return f"array of {_describe(schema['items'])}"
+    return " or ".join(expected) if isinstance(expected, list) else expected
+
+
+def _unknown(key: str, properties: Dict[str, Any]) -> str:
+    """Reason for an unknown field, suggesting a close known one."""
+    close = get_close_matches(key, list(properties), n=1)
+    if close:
+        return f"unknown field; did you mean {close[0]}?"
+    if properties:
+        return "unknown field; expected one of " + ", ".join(properties)
+    return "unknown field"
+
+
+def _join(path: str, key: str) -> str:
+    """Path of a key of the object at path."""
+    return f"{path}.{key}" if path else key
+
+
+def _hints(function: Any) -> Dict[str, Any]:
+    """Resolved parameter annotations of a function; None for those that don't resolve.
+
+    Annotations like Optional["AgentTools"] name types only imported for
+    type checking, so each is resolved on its own when not all of them can be.
+    """
+    namespace = getattr(function, "__globals__", {})
+    try:
+        return typing.get_type_hints(function, namespace)
+    except Exception:
+        pass
+    hints: Dict[str, Any] = {}
+    for name, annotation in getattr(function, "__annotations__", {}).items():
+        probe = type("_Probe", (), {"__annotations__": {name: annotation}})
+        try:
+            hints[name] = typing.get_type_hints(probe, namespace)[name]
+        except Exception:
+            hints[name] = None
+    return hints
+
+
+def _type_schema(tp: Any) -> Optional[Dict[str, Any]]:
+    """JSON Schema of a type, or None if it has no JSON form."""
+    if tp is None:
+        return None
+    if tp is Any:
+        return {}
+    if tp is type(None):
+        return {"type": "null"}
+    if tp in _SCALARS:
+        return {"type": _SCALARS[tp]}
+    if isinstance(tp, type) and issubclass(tp, enum.Enum):
+        return {"enum": [member.value for member in tp]}
+    if dataclasses.is_dataclass(tp) and isinstance(tp, type):
+        return _dataclass_schema(tp)
+    origin = typing.get_origin(tp)
+    args = typing.get_args(tp)
+    if origin is Union:
+        options = [_type_schema(a) for a in args]
+        if any(o is None for o in options):
+            return None
+        non_null = [o for o in options if o != {"type": "null"}]
+        if len(non_null) == 1 and len(options) == 2 and "type" in non_null[0] and isinstance(non_null[0]["type"], str):
+            return {**non_null[0], "type": [non_null[0]["type"], "null"]}
+        return {"anyOf": options}
+    if origin is typing.Literal:
+        return {"enum": list(args)}
+    if origin is tuple and not (len(args) == 2 and args[1] is Ellipsis):
+        # Fixed-length tuples
+        return None
+    if tp in (list, tuple) or origin in (list, tuple, collections.abc.Sequence, collections.abc.Iterable):
+        items = _type_schema(args[0]) if args else {}
+        return None if items is None else {"type": "array", "items": items}
+    if tp is dict or origin in (dict, collections.abc.Mapping):
+        if args and args[0] is not str:
+            return None
+        values = _type_schema(args[1]) if args else {}
+        return None if values is None else {"type": "object", "additionalProperties": values}
+    return None
+
+
+def _dataclass_schema(cls: type) -> Optional[Dict[str, Any]]:
+    """Schema of a dataclass given as a JSON object of its fields."""
+    try:
+        hints = typing.get_type_hints(cls)
+    except Exception:
+        return None
+    properties: Dict[str, Any] = {}
+    required: List[str] = []
+    for f in dataclasses.fields(cls):
+        schema = _type_schema(hints.get(f.name))
+        if schema is None:
+            if f.default is dataclasses.MISSING and f.default_factory is dataclasses.MISSING:
+                return None
+            continue
+        properties[f.name] = schema
+        if f.default is dataclasses.MISSING and f.default_factory is dataclasses.MISSING:
+            required.append(f.name)
+    return {"type": "object", "properties": properties, "required": required, "additionalProperties": False}
+
+
+def _convert(value: Any, tp: Any) -> Any:
+    """A validated JSON value as the parameter's type: dataclasses, enums and tuples built."""
+    if value is None:
+        return None
+    if isinstance(tp, type) and issubclass(tp, enum.Enum):
+        return tp(value)
+    if dataclasses.is_dataclass(tp) and isinstance(tp, type):
+        hints = typing.get_type_hints(tp)
+        return tp(**{key: _convert(item, hints.get(key, Any)) for key, item in value.items()})
+    origin = typing.get_origin(tp)
+    args = typing.get_args(tp)
+    if origin is Union:
+        options = [a for a in args if a is not type(None)]
+        return _convert(value, options[0]) if len(options) == 1 else value
+    if isinstance(value, list) and args:
+        items = [_convert(item, args[0]) for item in value]
+        return tuple(items) if origin is tuple else items
+    if isinstance(value, dict) and len(args) == 2:
+        return {key: _convert(item, args[1]) for key, item in value.items()}
+    return value
+
+
+def _arg_descriptions(doc: str) -> Dict[str, str]:
+    """Parameter descriptions from the `Args:` section of a Google-style docstring."""
+    descriptions: Dict[str, str] = {}
+    lines = doc.splitlines()
+    try:
+        start = next(i for i, line in enumerate(lines) if line.strip() == "Args:")
+    except StopIteration:
+        return descriptions
+    current: Optional[str] = None
+    indent: Optional[int] = None
+    for line in lines[start + 1:]:
+        if not line.strip():
+            current = None
+            continue
+        depth = len(line) - len(line.lstrip())
+        if indent is None:
+            indent = depth
+        if depth < indent:
+            break
+        match = _ARG_LINE.match(line.strip())
+        if depth == indent and match is not None:
+            current = match.group(1).lstrip("*")
+            descriptions[current] = match.group(2).strip()
+        elif current is not None:
+            descriptions[current] = f"{descriptions[current]} {line.strip()}".strip()
+    return descriptions
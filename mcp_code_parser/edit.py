@@ -0,0 +1,213 @@
+"""Helpers for locating where generated code should be spliced into a file,
+and for describing edits as data to apply elsewhere."""
+
+from dataclasses import asdict, dataclass
+from typing import TYPE_CHECKING, Any, Dict, List, Optional, Tuple
+
+from mcp_code_parser.errors import ConflictError, GeneratedFileError, NotFoundError
+from mcp_code_parser.extractors.base import CALLABLE_KINDS, Symbol, walk_symbols
+from mcp_code_parser.incremental import TextEdit
+
+if TYPE_CHECKING:
+    from mcp_code_parser.api import AgentTools
+
+# Symbol kinds that methods can be added to
+TYPE_KINDS = ("class", "struct", "interface", "type", "union", "enum")
+
+
+@dataclass
+class EditOperation:
+    """One change to a file's content, described rather than applied.
+
+    Offsets are character offsets into the content before any of the
+    operations; `before` is the text they cover, so an executor can check
+    the file hasn't changed since.
+    """
+
+    # "replace", "insert" (start == end) or "delete" (after is "")
+    op: str
+    start: int
+    end: int
+    before: str
+    after: str
+    # Root-relative path of the file, for operations spanning files
+    file: Optional[str] = None
+
+    def to_dict(self) -> Dict[str, Any]:
+        """Convert to a JSON-serializable dictionary."""
+        return asdict(self)
+
+    @classmethod
+    def from_dict(cls, data: Dict[str, Any]) -> "EditOperation":
+        """Rebuild an operation from to_dict() output."""
+        return cls(**data)
+
+
+def edit_operations(content: str, edits: List[TextEdit], file: Optional[str] = None) -> List[EditOperation]:
+    """Describe text edits to content as operations.
+
+    Args:
+        content: Content the edits' offsets are into
+        edits: Non-overlapping edits
+        file: Path to record on the operations
+
+    Returns:
+        One operation per edit that changes something, in offset order
+    """
+    operations = []
+    for edit in sorted(edits, key=lambda e: (e.start, e.end)):
+        before = content[edit.start:edit.end]
+        if before == edit.text:
+            continue
+        if edit.start == edit.end:
+            op = "insert"
+        elif not edit.text:
+            op = "delete"
+        else:
+            op = "replace"
+        operations.append(EditOperation(op, edit.start, edit.end, before, edit.text, file))
+    return operations
+
+
+def apply_operations(content: str, operations: List[EditOperation]) -> str:
+    """Apply operations to content, all or none.
+
+    Raises:
+        ConflictError: If operations overlap or content doesn't have the
+            `before` text an operation expects
+    """
+    ordered = sorted(operations, key=lambda o: (o.start, o.end))
+    position = 0
+    for operation in ordered:
+        if operation.start < position:
+            raise ConflictError(f"Operation at {operation.start} overlaps the one before it")
+        if content[operation.start:operation.end] != operation.before:
+            raise ConflictError(f"Content at {operation.start}-{operation.end} changed since the operation was computed")
+        position = operation.end
+
+    parts = []
+    position = 0
+    for operation in ordered:
+        parts.append(content[position:operation.start])
+        parts.append(operation.after)
+        position = operation.end
+    parts.append(content[position:])
+    return "".join(parts)
+
+
+async def replace_symbol(
+    content: str,
+    language: str,
+    stable_id: str,
+    text: str,
+    tools: Optional["AgentTools"] = None,
+    force: bool = False
+) -> List[EditOperation]:
+    """Describe replacing a symbol's source with new text (see apply_operations).
+
+    Args:
+        content: Source code
+        language: Programming language
+        stable_id: Stable ID of the symbol, e.g. "method:UserService.GetUser"
+        text: New source for the symbol's span; "" deletes it
+        tools: AgentTools instance to use (defaults to the global one)
+        force: Describe the edit even if content is a generated file
+
+    Returns:
+        The operations (none if text is the symbol's current source)
+
+    Raises:
+        GeneratedFileError: If content is marked as generated and force isn't set
+        NotFoundError: If no symbol has the stable ID
+        ToolError: If symbols can't be extracted (e.g. unsupported language)
+    """
+    if tools is None:
+        from mcp_code_parser.api import _global_tools
+        tools = _global_tools
+
+    outline = await tools.extract_symbols(content, language)
+    outline.raise_for_error()
+    if outline.generated and not force:
+        raise GeneratedFileError("Refusing to edit a generated file; regenerate it instead or pass force=True")
+    target = next((s for s in walk_symbols(outline.symbols) if s.stable_id == stable_id), None)
+    if target is None:
+        raise NotFoundError(f"Symbol not found: {stable_id}")
+
+    source = content.encode("utf8")
+    start = len(source[:target.start_byte].decode("utf8", errors="replace"))
+    end = start + len(source[target.start_byte:target.end_byte].decode("utf8", errors="replace"))
+    return edit_operations(content, [TextEdit(start=start, end=end, text=text)])
+
+
+async def method_insertion_point(
+    content: str,
+    language: str,
+    type_name: str,
+    tools: Optional["AgentTools"] = None,
+    force: bool = False
+) -> Tuple[int, str]:
+    """Find where a new method of a type should go.
+
+    The insertion point is the start of the line after the type's last
+    method. If the type has no methods yet it is after the type's last member
+    (for class-like languages) or after the type declaration itself (Go,
+    where methods live outside the type).
+
+    Args:
+        content: Source code
+        language: Programming language
+        type_name: Name of the type, e.g. "UserService"
+        tools: AgentTools instance to use (defaults to the global one)
+        force: Find the point even if content is a generated file
+
+    Returns:
+        Tuple of (byte offset into the UTF-8 encoded content, indentation of
+        the neighbouring declaration to use for the new method)
+
+    Raises:
+        GeneratedFileError: If content is marked as generated and force isn't set
+        NotFoundError: If the type isn't declared in content
+        ToolError: If symbols can't be extracted (e.g. unsupported language)
+    """
+    if tools is None:
+        from mcp_code_parser.api import _global_tools
+        tools = _global_tools
+
+    outline = await tools.extract_symbols(content, language)
+    outline.raise_for_error()
+    if outline.generated and not force:
+        raise GeneratedFileError("Refusing to edit a generated file; regenerate it instead or pass force=True")
+
+    owner = next(
+        (s for s in walk_symbols(outline.symbols) if s.name == type_name and s.kind in TYPE_KINDS),
+        None,
+    )
+    if owner is None:
+        raise NotFoundError(f"Type not found: {type_name}")
+
+    # Go extractors nest methods under their type, but a method can also be
+    # left at the top level with a receiver when the nesting didn't apply
+    methods = [c for c in owner.children if c.kind in CALLABLE_KINDS]
+    methods += [s for s in outline.symbols if s.kind == "method" and s.receiver == type_name]
+    if methods:
+        anchor = max(methods, key=lambda s: s.end_byte)
+    elif owner.children and language != "go":
+        anchor = max(owner.children, key=lambda s: s.end_byte)
+    else:
+        anchor = owner
+
+    source = content.encode("utf8")
+    return _next_line_start(source, anchor.end_byte), _indent_at(source, anchor.start_byte)
+
+
+def _next_line_start(source: bytes, offset: int) -> int:
+    """Offset of the line after the one containing offset."""
+    newline = source.find(b"\n", offset)
+    return len(source) if newline == -1 else newline + 1
+
+
+def _indent_at(source: bytes, offset: int) -> str:
+    """Leading whitespace of the line containing offset."""
+    line_start = source.rfind(b"\n", 0, offset) + 1
+    line = source[line_start:offset].decode("utf8", errors="replace")
+    return line[:len(line) - len(line.lstrip(" \t"))]
@@ -0,0 +1,294 @@
+"""Normalized error taxonomy shared by all tools.
+
+Every failure the tools report belongs to one of a small set of categories,
+each with an exception class and a stable string code. Exceptions can be
+caught by category (``except NotFoundError``), and result objects that carry
+an error instead of raising expose the same category through ``error_code``
+and ``raise_for_error()``:
+
+    result = await parse_file("missing.py")
+    if result.error_code == NotFoundError.code:
+        ...
+    result.raise_for_error()  # raises NotFoundError
+"""
+
+import errno
+from dataclasses import dataclass
+from typing import Any, Dict, List, Optional, Type
+
+from mcp_code_parser.positions import DEFAULT_TAB_WIDTH, Utf16Range, visual_column
+
+# Stop collecting syntax diagnostics after this many so huge broken files stay cheap
+MAX_DIAGNOSTICS = 50
+
+
+@dataclass
+class Diagnostic:
+    """A problem found in the source (lines and columns are 1-based).
+
+    Syntax errors have no ``rule``; findings of optional checks such as
+    ExtractOptions.lint_context carry the ID of the rule that fired.
+
+    ``column`` counts UTF-8 bytes from the start of the line. ``visual_column``
+    is where an editor renders the position, with tabs expanded to the
+    configured tab width and multi-byte characters counted once; it is None
+    when the source wasn't available to compute it.
+    """
+
+    line: int
+    column: int
+    end_line: int
+    end_column: int
+    message: str
+    visual_column: Optional[int] = None
+    end_visual_column: Optional[int] = None
+    # Rule ID of a lint finding, e.g. "go-context-in-struct"
+    rule: Optional[str] = None
+    # Set with ExtractOptions.utf16_ranges: the range in UTF-16 code units
+    utf16: Optional[Utf16Range] = None
+
+
+class ToolError(Exception):
+    """Base class for all categorized tool errors."""
+
+    code = "error"
+
+
+class NotFoundError(ToolError):
+    """A file or directory does not exist."""
+
+    code = "not_found"
+
+
+class UnsupportedLanguageError(ToolError):
+    """A language is unknown, undetectable, or has no grammar/extractor."""
+
+    code = "unsupported_language"
+
+
+class TooLargeError(ToolError):
+    """Input exceeds a configured size limit."""
+
+    code = "too_large"
+
+
+class BinaryFileError(ToolError):
+    """A file looks like binary data rather than source text."""
+
+    code = "binary"
+
+
+class ParseFailedError(ToolError):
+    """Source could not be parsed.
+
+    Attributes:
+        diagnostics: Syntax problems that caused or accompanied the failure
+    """
+
+    code = "parse"
+
+    def __init__(self, message: str = "", diagnostics: Optional[List[Diagnostic]] = None):
+        super().__init__(message)
+        self.diagnostics = diagnostics or []
+
+
+class PermissionDeniedError(ToolError):
+    """The process is not allowed to read a path."""
+
+    code = "permission"
+
+
+class PathTraversalError(ToolError):
+    """A path resolves outside the root it was required to stay within."""
+
+    code = "traversal"
+
+
+class GeneratedFileError(ToolError):
+    """An edit targets a generated file, which the next generation would overwrite."""
+
+    code = "generated"
+
+
+class ConflictError(ToolError):
+    """A change would clash with existing code, e.g. a rename to a name already in use."""
+
+    code = "conflict"
+
+
+class AlreadyExistsError(ToolError):
+    """A name to create is taken, e.g. a store tag set by another writer first."""
+
+    code = "already_exists"
+
+
+class LimitExceededError(ToolError):
+    """A call exceeded one of its resource limits (see limits.Limits).
+
+    Attributes:
+        limit: Name of the limit hit: "max_parse_time", "max_nodes" or
+            "max_memory_bytes"
+        partial: What was gathered before the limit was hit (for extraction,
+            an Outline of the symbols declared before the limit), or None
+    """
+
+    code = "limit_exceeded"
+
+    def __init__(self, message: str = "", limit: Optional[str] = None, partial: Any = None):
+        super().__init__(message)
+        self.limit = limit
+        self.partial = partial
+
+
+@dataclass
+class ArgumentProblem:
+    """Why one argument of a tool call was rejected."""
+
+    # Path of the argument, e.g. "name" or "options.kind_filter[0]"; "" for
+    # the arguments as a whole
+    field: str
+    # What is wrong with it, e.g. "required" or "expected string, got integer"
+    reason: str
+
+
+class InvalidArgumentsError(ToolError):
+    """A tool was called with arguments that don't match its schema (see dispatch.ToolRegistry).
+
+    Attributes:
+        problems: Each rejected argument and why, in argument order
+    """
+
+    code = "invalid_arguments"
+
+    def __init__(self, message: str = "", problems: Optional[List[ArgumentProblem]] = None):
+        problems = problems or []
+        if not message:
+            message = "; ".join(f"{p.field}: {p.reason}" if p.field else p.reason for p in problems)
+        super().__init__(message)
+        self.problems = problems
+
+    def to_dict(self) -> Dict[str, Any]:
+        """Convert to a dictionary a model can act on: the message, code and problems."""
+        return {
+            "error": str(self),
+            "error_code": self.code,
+            "problems": [{"field": p.field, "reason": p.reason} for p in self.problems],
+        }
+
+
+ERROR_TYPES: Dict[str, Type[ToolError]] = {
+    cls.code: cls
+    for cls in (
+        NotFoundError,
+        UnsupportedLanguageError,
+        TooLargeError,
+        BinaryFileError,
+        ParseFailedError,
+        PermissionDeniedError,
+        PathTraversalError,
+        GeneratedFileError,
+        ConflictError,
+        AlreadyExistsError,
+        LimitExceededError,
+        InvalidArgumentsError,
+    )
+}
+
+
+def error_code(exc: BaseException) -> Optional[str]:
+    """Get the taxonomy code for an exception, or None if it is uncategorized.
+
+    Builtin OS errors are mapped to their category so callers don't need to
+    wrap every file operation.
+    """
+    if isinstance(exc, ToolError):
+        return exc.code
+    if isinstance(exc, FileNotFoundError) or getattr(exc, "errno", None) == errno.ENOTDIR:
+        return NotFoundError.code
+    if isinstance(exc, PermissionError):
+        return PermissionDeniedError.code
+    return None
+
+
+def error_for_code(
+    code: Optional[str],
+    message: str,
+    diagnostics: Optional[List[Diagnostic]] = None
+) -> ToolError:
+    """Build the exception for a result's error code.
+
+    Unknown or missing codes produce a plain ToolError.
+    """
+    cls = ERROR_TYPES.get(code or "", ToolError)
+    if cls is ParseFailedError:
+        return ParseFailedError(message, diagnostics)
+    return cls(message)
+
+
+def syntax_diagnostics(
+    root: Any,
+    limit: int = MAX_DIAGNOSTICS,
+    source: Optional[bytes] = None,
+    tab_width: int = DEFAULT_TAB_WIDTH
+) -> List[Diagnostic]:
+    """Collect ERROR and MISSING nodes under a tree-sitter node as diagnostics.
+
+    Args:
+        root: Node to search
+        limit: Maximum number of diagnostics
+        source: Parsed source bytes, needed to compute visual columns
+        tab_width: Tab stop spacing for visual columns
+    """
+    if not root.has_error:
+        return []
+
+    lines = source.split(b"\n") if source is not None else None
+    diagnostics = []
+    stack = [root]
+    while stack and len(diagnostics) < limit:
+        node = stack.pop()
+        if node.is_missing:
+            message = f"missing {node.type}"
+        elif node.type == "ERROR":
+            message = "syntax error"
+        else:
+            if node.has_error:
+                stack.extend(reversed(node.children))
+            continue
+        diagnostics.append(node_diagnostic(node, message, lines, tab_width))
+    return diagnostics
+
+
+def node_diagnostic(
+    node: Any,
+    message: str,
+    lines: Optional[List[bytes]] = None,
+    tab_width: int = DEFAULT_TAB_WIDTH,
+    rule: Optional[str] = None
+) -> Diagnostic:
+    """Build a diagnostic spanning a tree-sitter node.
+
+    Args:
+        node: Node the problem is at
+        message: Description of the problem
+        lines: Lines of the parsed source (split on b"\\n"), needed to
+            compute visual columns
+        tab_width: Tab stop spacing for visual columns
+        rule: Rule ID, for lint findings
+    """
+
+    def visual(point: Any) -> Optional[int]:
+        if lines is None or point[0] >= len(lines):
+            return None
+        return visual_column(lines[point[0]], point[1], tab_width) + 1
+
+    return Diagnostic(
+        line=node.start_point[0] + 1,
+        column=node.start_point[1] + 1,
+        end_line=node.end_point[0] + 1,
+        end_column=node.end_point[1] + 1,
+        message=message,
+        visual_column=visual(node.start_point),
+        end_visual_column=visual(node.end_point),
+        rule=rule,
+    )
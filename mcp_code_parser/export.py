@@ -0,0 +1,77 @@
+"""Tabular (CSV and TSV) export of flattened outlines."""
+
+import csv
+import json
+from dataclasses import replace
+from typing import Any, Iterable, Mapping, Optional, TextIO, Tuple, Union
+
+from mcp_code_parser.extractors.base import Outline, OutputOptions
+
+# Symbol fields written when no OutputOptions.fields allowlist is given
+DEFAULT_COLUMNS = ["kind", "exported", "start_line", "end_line"]
+
+Outlines = Union[Mapping[str, Outline], Iterable[Tuple[str, Outline]]]
+
+
+def format_csv(
+    outlines: Outlines,
+    output: TextIO,
+    output_options: Optional[OutputOptions] = None,
+    delimiter: str = ","
+) -> int:
+    """Write every symbol of some outlines as a CSV row.
+
+    Columns are file and qualified_name, then the symbol fields allowed by
+    output_options (DEFAULT_COLUMNS if none are given; "children" is
+    ignored). Values are quoted per RFC 4180 where needed, booleans are
+    written as true/false, None as an empty cell and lists or nested values
+    as JSON. Outlines that failed have no symbols, so add no rows. With
+    output_options.collapse_accessors, properties and their accessors get a
+    row each, and with output_options.relative_lines members' lines are
+    relative to their parent's.
+
+    Args:
+        outlines: Outlines by file, e.g. from extract_dir, or (file, outline) pairs
+        output: Writable text stream; open files with newline="" so line
+            endings are written as given
+        output_options: Field allowlist selecting the symbol columns
+        delimiter: Cell separator
+
+    Returns:
+        Number of rows written, not counting the header
+    """
+    fields = output_options.fields if output_options is not None and output_options.fields is not None else DEFAULT_COLUMNS
+    columns = [f for f in fields if f != "children"]
+    writer = csv.writer(output, delimiter=delimiter, lineterminator="\r\n")
+    writer.writerow(["file", "qualified_name"] + columns)
+
+    items = outlines.items() if isinstance(outlines, Mapping) else outlines
+    count = 0
+    for file, outline in items:
+        if output_options is not None:
+            outline = replace(outline, symbols=outline.output_symbols(output_options))
+        for flat in outline.flatten():
+            data = flat.symbol.to_dict(columns)
+            writer.writerow([file, flat.qualified_name] + [_cell(data[c]) for c in columns])
+            count += 1
+    return count
+
+
+def format_tsv(
+    outlines: Outlines,
+    output: TextIO,
+    output_options: Optional[OutputOptions] = None
+) -> int:
+    """Write every symbol of some outlines as a tab-separated row (see format_csv)."""
+    return format_csv(outlines, output, output_options, delimiter="\t")
+
+
+def _cell(value: Any) -> str:
+    """Text of a value in a table cell."""
+    if value is None:
+        return ""
+    if isinstance(value, bool):
+        return "true" if value else "false"
+    if isinstance(value, (list, dict)):
+        return json.dumps(value, separators=(",", ":"))
+    return str(value)
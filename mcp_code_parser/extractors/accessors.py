@@ -0,0 +1,173 @@
+"""Grouping getter and setter methods into properties.
+
+Which methods are accessors is a per-language naming convention:
+
+- Go: `GetName()` and `SetName(v)`, and `Name()` when a `SetName` exists
+- C++: `getName()`/`get_name()` and `setName(v)`/`set_name(v)`
+- Python: `@property` methods and their `@name.setter`
+- JavaScript and TypeScript: `get name()` and `set name(v)` accessors
+- Dart: getters and setters
+"""
+
+import re
+from dataclasses import replace
+from typing import Callable, Dict, List, Optional, Tuple
+
+from mcp_code_parser.extractors.base import Symbol
+
+# (property name, "get" or "set", whether it's only an accessor with a setter)
+_Accessor = Tuple[str, str, bool]
+
+_JS_ACCESSOR = re.compile(
+    r"^(?:(?:static|public|private|protected|readonly|override|abstract|declare)\s+)*(get|set)\s+([#\w$]+)\s*\("
+)
+
+
+def collapse_accessors(symbols: List[Symbol], language: str) -> List[Symbol]:
+    """Replace getter and setter methods by property symbols.
+
+    Each group of accessors of one name (and receiver) becomes a `property`
+    symbol spanning them, `readable` if it has a getter and `writable` if it
+    has a setter, with the accessors as its children so the raw methods
+    stay available. The property takes the place of its first accessor.
+
+    Returns:
+        New symbol lists; the given symbols are left unchanged
+    """
+    rule = _RULES.get(language)
+    if rule is None:
+        return symbols
+    return _collapse(symbols, rule)
+
+
+def _collapse(symbols: List[Symbol], rule: Callable[[Symbol], Optional[_Accessor]]) -> List[Symbol]:
+    """Collapse accessors among siblings, and recursively among their children."""
+    groups: Dict[Tuple[Optional[str], str], List[Tuple[Symbol, str, bool]]] = {}
+    for sym in symbols:
+        accessor = rule(sym)
+        if accessor is not None:
+            name, role, needs_setter = accessor
+            groups.setdefault((sym.receiver, name), []).append((sym, role, needs_setter))
+
+    properties: Dict[int, Symbol] = {}
+    for (_, name), members in groups.items():
+        writable = any(role == "set" for _, role, _ in members)
+        accessors = [sym for sym, _, needs_setter in members if writable or not needs_setter]
+        if not accessors:
+            continue
+        readable = any(role == "get" for sym, role, needs_setter in members if writable or not needs_setter)
+        prop = _property(name, accessors, readable, writable)
+        for sym in accessors:
+            properties[id(sym)] = prop
+
+    collapsed = []
+    emitted = set()
+    for sym in symbols:
+        prop = properties.get(id(sym))
+        if prop is None:
+            collapsed.append(replace(sym, children=_collapse(sym.children, rule)) if sym.children else sym)
+        elif id(prop) not in emitted:
+            emitted.add(id(prop))
+            collapsed.append(prop)
+    return collapsed
+
+
+def _property(name: str, accessors: List[Symbol], readable: bool, writable: bool) -> Symbol:
+    """Build the property symbol grouping some accessors."""
+    accessors = sorted(accessors, key=lambda s: s.start_byte)
+    first = accessors[0]
+    last = max(accessors, key=lambda s: s.end_byte)
+    stable_id = None
+    if first.stable_id:
+        # "method:Person.GetName" gives "property:Person.Name"
+        path = first.stable_id.split(":", 1)[-1].split("(", 1)[0]
+        scope = path.rsplit(".", 1)[0] + "." if "." in path else ""
+        stable_id = f"property:{scope}{name}"
+    return Symbol(
+        name=name,
+        kind="property",
+        start_line=first.start_line,
+        end_line=last.end_line,
+        start_byte=first.start_byte,
+        end_byte=last.end_byte,
+        exported=any(s.exported for s in accessors),
+        receiver=first.receiver,
+        stable_id=stable_id,
+        readable=readable,
+        writable=writable,
+        children=accessors,
+    )
+
+
+def _go_accessor(sym: Symbol) -> Optional[_Accessor]:
+    """Go: GetX() and SetX(v), and X() with a SetX."""
+    if sym.kind != "method":
+        return None
+    match = re.match(r"^(Get|Set)([A-Z]\w*)$", sym.name)
+    if match is not None:
+        if match.group(1) == "Get" and not sym.params and sym.results:
+            return match.group(2), "get", False
+        if match.group(1) == "Set" and len(sym.params) == 1:
+            return match.group(2), "set", False
+        return None
+    if sym.name[:1].isupper() and not sym.params and len(sym.results) == 1:
+        return sym.name, "get", True
+    return None
+
+
+def _cpp_accessor(sym: Symbol) -> Optional[_Accessor]:
+    """C++: getX()/get_x() and setX(v)/set_x(v)."""
+    if sym.kind != "method":
+        return None
+    match = re.match(r"^(get|set)(?:_([a-z]\w*)|([A-Z]\w*))$", sym.name)
+    if match is None:
+        return None
+    name = match.group(2) or match.group(3)
+    if match.group(1) == "get" and not sym.params:
+        return name, "get", False
+    if match.group(1) == "set" and len(sym.params) == 1:
+        return name, "set", False
+    return None
+
+
+def _python_accessor(sym: Symbol) -> Optional[_Accessor]:
+    """Python: @property (or cached_property) methods and @name.setter."""
+    if sym.kind != "method":
+        return None
+    for attribute in sym.attributes:
+        if attribute.name in ("property", "cached_property", "functools.cached_property"):
+            return sym.name, "get", False
+        if attribute.name == f"{sym.name}.setter":
+            return sym.name, "set", False
+    return None
+
+
+def _js_accessor(sym: Symbol) -> Optional[_Accessor]:
+    """JavaScript and TypeScript: `get x()` and `set x(v)` accessors."""
+    if sym.kind != "method" or not sym.signature:
+        return None
+    match = _JS_ACCESSOR.match(sym.signature)
+    if match is None or match.group(2) != sym.name:
+        return None
+    return sym.name, match.group(1), False
+
+
+def _dart_accessor(sym: Symbol) -> Optional[_Accessor]:
+    """Dart: getters and setters."""
+    if sym.kind == "getter":
+        return sym.name, "get", False
+    if sym.kind == "setter":
+        return sym.name, "set", False
+    return None
+
+
+_RULES: Dict[str, Callable[[Symbol], Optional[_Accessor]]] = {
+    "go": _go_accessor,
+    "cpp": _cpp_accessor,
+    "python": _python_accessor,
+    "javascript": _js_accessor,
+    "typescript": _js_accessor,
+    "vue": _js_accessor,
+    "svelte": _js_accessor,
+    "dart": _dart_accessor,
+}
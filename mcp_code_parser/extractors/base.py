@@ -0,0 +1,1197 @@
+"""Base extractor interface and symbol data model."""
+
+import posixpath
+import re
+from abc import ABC, abstractmethod
+from dataclasses import asdict, dataclass, field, fields, is_dataclass, replace
+from typing import Any, Awaitable, Callable, Dict, Iterator, List, Optional, Sequence, Tuple
+
+import tree_sitter
+
+from mcp_code_parser.errors import Diagnostic, LimitExceededError, error_for_code, syntax_diagnostics
+from mcp_code_parser.extractors.names import native_separator, qualified_name, qualify
+from mcp_code_parser.limits import Limits, LimitGuard
+from mcp_code_parser.partial import complete_buffer
+from mcp_code_parser.positions import DEFAULT_TAB_WIDTH, Utf16Offsets, Utf16Range
+from mcp_code_parser.utils import safe_read_file
+
+# Parses (content, language) into a tree; supplied to extractors by the API
+ParseFunc = Callable[[str, str], Awaitable[tree_sitter.Tree]]
+
+
+@dataclass
+class SourceFile:
+    """A source file's path and content, for multi-file analysis."""
+
+    path: str
+    content: str
+
+    @classmethod
+    def read(cls, path: str) -> "SourceFile":
+        """Read a source file from disk."""
+        return cls(path=path, content=safe_read_file(path))
+
+
+# Grammar node type -> kind its symbols are emitted as, e.g. {"const_spec": "constant"}
+SymbolKindConfig = Dict[str, str]
+
+
+@dataclass
+class ExtractOptions:
+    """Options controlling symbol extraction."""
+
+    # Discover `t.Run("name", ...)` style subtests as children of test functions
+    include_subtests: bool = False
+    # Files larger than this many bytes are skipped (None means no limit)
+    max_file_size: Optional[int] = None
+    # Extract the first max_file_size bytes of oversized files instead of skipping
+    truncate_oversized: bool = False
+    # Record the underlying type of locally declared aliases/defined types on params
+    resolve_aliases: bool = False
+    # Record whether functions return errors and where they construct them
+    analyze_errors: bool = False
+    # Flag directly and mutually recursive functions, from calls within the file
+    analyze_recursion: bool = False
+    # Record where Go functions call panic and recover
+    analyze_panics: bool = False
+    # Flag goroutines started with no obvious way to stop (a heuristic)
+    analyze_goroutines: bool = False
+    # Tab stop spacing used for the visual columns of diagnostics
+    tab_width: int = DEFAULT_TAB_WIDTH
+    # GOARCH ("amd64", "arm64", "386", "arm") to estimate Go struct layouts for
+    layout_arch: Optional[str] = None
+    # Only extract symbols of these kinds, e.g. ["function", "method"] (None
+    # means all). Members of dropped symbols take their place, so methods of
+    # an unwanted struct become top-level.
+    kind_filter: Optional[List[str]] = None
+    # Only keep symbols carrying one of these attributes, e.g. ["Test"], and
+    # the symbols containing them (see Symbol.has_attribute)
+    include_attributes: Optional[List[str]] = None
+    # Drop symbols carrying any of these attributes, e.g. ["Deprecated"],
+    # together with their members
+    exclude_attributes: Optional[List[str]] = None
+    # Map symbol positions of generated files (e.g. compiled JavaScript) back
+    # to the original source with the file's source map, if it has one
+    source_maps: bool = False
+    # Report Go context.Context misuse (contexts stored in structs, nil
+    # contexts, unused cancel funcs) as diagnostics with a rule ID
+    lint_context: bool = False
+    # Emit symbols declared by these grammar node types as other kinds, e.g.
+    # {"type_alias": "alias"}, on top of any AgentTools(symbol_kinds=...)
+    # config (see BaseExtractor.symbol_kinds)
+    symbol_kinds: Optional[SymbolKindConfig] = None
+    # Decompose Go param, result and field types into TypeRefs
+    type_refs: bool = False
+    # Record the types Go functions assert interface values to, in type
+    # assertions and type switch cases
+    type_assertions: bool = False
+    # Record the last commit touching each symbol with git blame (files in
+    # a git work tree only)
+    blame: bool = False
+    # Set Outline.is_test on files extracted from a directory, by their
+    # language's test file naming conventions (see TEST_FILE_PATTERNS)
+    classify_tests: bool = False
+    # Leave test files out of directory extraction
+    exclude_tests: bool = False
+    # Per-language test file patterns replacing the defaults, e.g.
+    # {"python": ["*_spec.py"]}
+    test_patterns: Optional[Dict[str, List[str]]] = None
+    # Attach the S-expression of each symbol's defining node as
+    # Symbol.raw_node, for diagnosing misclassified symbols
+    include_raw_node: bool = False
+    # Set Symbol.global_id from the repository-relative path, language and
+    # stable ID, for symbol graphs spanning many files and repositories
+    global_ids: bool = False
+    # Give symbol and diagnostic ranges in UTF-16 code units too (see
+    # Symbol.utf16), for browser-based consumers indexing JavaScript strings
+    utf16_ranges: bool = False
+    # Files directory walks read at once, ahead of extracting them in path
+    # order; None sizes it from the file sizes and CPU count (see
+    # pool.adaptive_read_workers). Results don't depend on it.
+    read_workers: Optional[int] = None
+    # Extract sources with syntax errors as completed by closing what their
+    # end leaves open (see partial.complete_buffer), so a declaration cut
+    # off mid-edit is still outlined; diagnostics are of the source as is
+    complete_partial: bool = True
+    # Caps on parse time, syntax tree nodes and estimated memory; an
+    # extraction over one fails with LimitExceededError, keeping the
+    # symbols declared before the limit
+    limits: Optional[Limits] = None
+    # Emit string literals spanning at least this many lines (raw strings,
+    # triple-quoted strings, template literals) as `string_literal` symbols
+    # under the symbol containing them (None means none)
+    string_literal_lines: Optional[int] = None
+
+    def wants(self, kind: str) -> bool:
+        """Check whether symbols of a kind pass kind_filter."""
+        return self.kind_filter is None or kind in self.kind_filter
+
+
+@dataclass
+class OutputOptions:
+    """Options controlling how outlines are serialized."""
+
+    # Symbol fields to include, e.g. ["name", "kind", "start_line"] (None
+    # means all). Children are only included when "children" is listed, and
+    # then with the same fields.
+    fields: Optional[List[str]] = None
+    # Group getter and setter methods into `property` symbols, with the
+    # methods as their children, by the language's naming conventions (see
+    # mcp_code_parser.extractors.accessors)
+    collapse_accessors: bool = False
+    # Give children's start and end lines relative to their parent's start
+    # line (its first line is 1), with the file line they count from in
+    # Symbol.line_offset, for rendering a symbol's subtree on its own
+    relative_lines: bool = False
+
+    def __post_init__(self) -> None:
+        """Reject field names that Symbol doesn't have."""
+        if self.fields is None:
+            return
+        known = [f.name for f in fields(Symbol)]
+        unknown = [name for name in self.fields if name not in known]
+        if unknown:
+            raise ValueError(f"Unknown symbol field(s) {', '.join(unknown)}; expected some of {', '.join(known)}")
+
+
+@dataclass
+class TypeRef:
+    """A type expression decomposed into its parts, e.g. map[string]chan int."""
+
+    # "named", "pointer", "slice", "array", "map", "chan", "func", "struct"
+    # or "interface"
+    kind: str
+    # The type as written
+    text: str
+    # Named types: the possibly package-qualified name and type arguments
+    name: Optional[str] = None
+    args: List["TypeRef"] = field(default_factory=list)
+    # Maps: the key type
+    key: Optional["TypeRef"] = None
+    # Pointers, slices, arrays and channels: the element type; maps: the value type
+    elem: Optional["TypeRef"] = None
+    # Arrays: the length expression as written, e.g. "4" or "..."
+    length: Optional[str] = None
+    # Channels: "both", "send" or "receive"
+    direction: Optional[str] = None
+    # Func types: one per parameter and result
+    params: List["TypeRef"] = field(default_factory=list)
+    results: List["TypeRef"] = field(default_factory=list)
+
+    @classmethod
+    def from_dict(cls, data: Dict[str, Any]) -> "TypeRef":
+        """Rebuild a type (and its subtypes) from asdict() output."""
+        data = dict(data)
+        for name in ("key", "elem"):
+            if data.get(name) is not None:
+                data[name] = cls.from_dict(data[name])
+        for name in ("args", "params", "results"):
+            data[name] = [cls.from_dict(t) for t in data.get(name, [])]
+        return cls(**data)
+
+
+@dataclass
+class Param:
+    """A function parameter or result."""
+
+    name: Optional[str]
+    type: str
+    # Underlying type when `type` refers to a local alias or defined type
+    resolved_type: Optional[str] = None
+    # Default-value expression as written, e.g. "None" or "[]"
+    default: Optional[str] = None
+    # Callers may omit the argument (it has a default, is `x?` or is variadic)
+    optional: bool = False
+    # Set with ExtractOptions.type_refs
+    type_ref: Optional[TypeRef] = None
+
+    @classmethod
+    def from_dict(cls, data: Dict[str, Any]) -> "Param":
+        """Rebuild a parameter from asdict() output."""
+        data = dict(data)
+        if data.get("type_ref") is not None:
+            data["type_ref"] = TypeRef.from_dict(data["type_ref"])
+        return cls(**data)
+
+
+@dataclass
+class AttributeArg:
+    """One argument of a decorator call, e.g. `methods=["POST"]`."""
+
+    # Keyword of a keyword argument, None for positional ones
+    keyword: Optional[str]
+    # Source text of the value, e.g. `["POST"]`
+    raw: str
+    # The value itself when it is a literal (strings, numbers, booleans,
+    # None/null, and lists and dicts of literals), else None
+    value: Any = None
+    # Whether value was parsed, telling a None literal from an expression
+    literal: bool = False
+
+
+@dataclass
+class Attribute:
+    """A decorator, annotation, attribute or struct tag attached to a symbol."""
+
+    name: str
+    args: List[str] = field(default_factory=list)
+    # Source form as written, e.g. `@app.route("/")` or `json:"id,omitempty"`
+    raw: str = ""
+    # Python and JavaScript/TypeScript decorator calls: args split into
+    # positional and keyword arguments, with literal values parsed
+    arguments: List[AttributeArg] = field(default_factory=list)
+
+    @classmethod
+    def from_dict(cls, data: Dict[str, Any]) -> "Attribute":
+        """Rebuild an attribute from asdict() output."""
+        data = dict(data)
+        data["arguments"] = [AttributeArg(**a) for a in data.get("arguments", [])]
+        return cls(**data)
+
+
+@dataclass
+class EmbeddedExternal:
+    """A type embedded from another package, whose members can't be seen locally."""
+
+    # Import path when the qualifier matches an import, else the qualifier
+    package: str
+    name: str
+
+
+@dataclass
+class ErrorSite:
+    """A place where a function constructs an error value."""
+
+    # "errors.New", "fmt.Errorf" or "custom" (a literal of an error type)
+    kind: str
+    # Source text of the construction, e.g. `errors.New("not found")`
+    expression: str
+    line: int
+    # Error type for custom sites, e.g. "ValidationError"
+    type: Optional[str] = None
+    # fmt.Errorf with a %w verb, so the cause stays inspectable with errors.Is/As
+    wraps: bool = False
+
+
+@dataclass
+class PanicSite:
+    """A `panic(...)` or `recover()` call in a Go function."""
+
+    # Source text of the call, e.g. `panic("unreachable")`
+    expression: str
+    line: int
+    # Made in a deferred closure, the only place recover() stops a panic
+    deferred: bool = False
+
+
+@dataclass
+class Implementation:
+    """An interface a Go type is asserted to satisfy, as in `var _ Cache = (*InMemoryCache)(nil)`."""
+
+    # As written, e.g. "Cache" or "io.Closer"
+    interface: str
+    # Asserted of a pointer to the type, so only its pointer satisfies it
+    pointer: bool = False
+    line: int = 0
+    # "high": the author declared it and the compiler checks it, unlike a
+    # guess from matching method sets
+    confidence: str = "high"
+
+
+@dataclass
+class GoroutineLeak:
+    """A goroutine started with no obvious way to stop, so it may leak.
+
+    This is a heuristic hint for review, not a proof: the goroutine may be
+    stopped in ways the check doesn't follow.
+    """
+
+    # First line of the go statement, e.g. "go func() {" or "go poll(url)"
+    expression: str
+    line: int
+    # Why it was flagged
+    reason: str
+    # "high" for a loop with no way out at all, "medium" for one that only
+    # exits on its own conditions, such as an error
+    confidence: str
+
+
+@dataclass
+class TypeSetElement:
+    """One term of a Go constraint union, e.g. `~int` in `~int | ~float64`."""
+
+    type: str
+    # Written `~T`: admits every type whose underlying type is T
+    approximate: bool = False
+
+
+@dataclass
+class TypeParam:
+    """A type parameter of a generic function or type, e.g. `T Number`."""
+
+    name: str
+    # Constraint as written, e.g. "Number" or "interface{ String() string }"
+    constraint: str
+    # Methods the constraint requires, e.g. ["String() string"], including
+    # those of the local and well-known interfaces it embeds
+    methods: List[str] = field(default_factory=list)
+
+
+@dataclass
+class FieldLayout:
+    """Placement of one struct field in memory."""
+
+    name: str
+    type: str
+    # None when the field's size can't be determined (or follows such a field)
+    offset: Optional[int] = None
+    size: Optional[int] = None
+    align: Optional[int] = None
+    # Bytes inserted before the field to satisfy its alignment
+    padding: int = 0
+
+
+@dataclass
+class LayoutInfo:
+    """Estimated memory layout of a struct on one target architecture."""
+
+    arch: str
+    # Total size including trailing padding; None if any field size is unknown
+    size: Optional[int] = None
+    align: int = 1
+    # Padding bytes, between fields and at the end
+    padding: Optional[int] = None
+    # Size when fields are reordered by decreasing alignment
+    optimal_size: Optional[int] = None
+    fields: List[FieldLayout] = field(default_factory=list)
+    # Field types whose size isn't known, e.g. types from other packages
+    unknown_types: List[str] = field(default_factory=list)
+
+
+@dataclass
+class Symbol:
+    """A named declaration found in source code."""
+
+    name: str
+    kind: str
+    start_line: int
+    end_line: int
+    start_byte: int
+    end_byte: int
+    signature: Optional[str] = None
+    exported: bool = False
+    receiver: Optional[str] = None
+    test_kind: Optional[str] = None
+    import_scope: Optional[str] = None
+    # Identity that survives edits and file moves, e.g. "method:UserService.GetUser"
+    stable_id: Optional[str] = None
+    # Set with ExtractOptions.global_ids: stable_id qualified by language and
+    # repository-relative path (see assign_global_ids), e.g.
+    # "go:pkg/users/service.go#method:UserService.GetUser"
+    global_id: Optional[str] = None
+    # File the symbol was extracted from, set when outlines are merged
+    file: Optional[str] = None
+    # Position in the original source, for generated code with a source map
+    # (see ExtractOptions.source_maps); 1-based, the column in UTF-16 units
+    original_file: Optional[str] = None
+    original_line: Optional[int] = None
+    original_column: Optional[int] = None
+    # Set with ExtractOptions.utf16_ranges: start_byte to end_byte in UTF-16
+    # code units, with the 0-based UTF-16 columns of both ends
+    utf16: Optional[Utf16Range] = None
+    # Most recent commit among the symbol's lines (see ExtractOptions.blame):
+    # its hash, author and ISO 8601 commit time
+    last_commit: Optional[str] = None
+    last_author: Optional[str] = None
+    last_modified: Optional[str] = None
+    # Set with ExtractOptions.include_raw_node: the tree-sitter S-expression
+    # of the node the symbol was built from, e.g. "(function_declaration ...)"
+    raw_node: Optional[str] = None
+    # Members may be missing because an embedded type is defined elsewhere
+    unresolved: bool = False
+    # The declaration runs past the end of a source cut off mid-edit, so
+    # its signature may end with brackets added to complete it (see
+    # ExtractOptions.complete_partial)
+    partial: bool = False
+    embedded_external: List[EmbeddedExternal] = field(default_factory=list)
+    # Methods an embedded field or interface adds to its container's method
+    # set, as "Name(params) results" (only those declared in the file or of
+    # well-known interfaces)
+    promoted: List[str] = field(default_factory=list)
+    # Dart `abstract` classes and bodiless members, `static` members
+    abstract: bool = False
+    static: bool = False
+    deprecated: bool = False
+    # Explanation given with the deprecation, e.g. "Use NewClient instead."
+    deprecation_note: Optional[str] = None
+    # Set with ExtractOptions.analyze_errors
+    returns_error: bool = False
+    error_sites: List[ErrorSite] = field(default_factory=list)
+    # Set with ExtractOptions.analyze_recursion: stable IDs of the callables
+    # calling back into this one, itself included
+    recursive: bool = False
+    recursion_cycle: List[str] = field(default_factory=list)
+    # Set with ExtractOptions.analyze_panics: the function's panic and
+    # recover calls, closures included
+    panics: List[PanicSite] = field(default_factory=list)
+    recovers: List[PanicSite] = field(default_factory=list)
+    # Set with ExtractOptions.analyze_goroutines: goroutines the function
+    # starts, closures included, that may never stop
+    goroutine_leaks: List[GoroutineLeak] = field(default_factory=list)
+    # Set with ExtractOptions.type_assertions: `*User` for `val.(*User)`
+    type_assertions: List[TypeRef] = field(default_factory=list)
+    # Terms of a Go type-set element, or of a constraint's only union
+    type_set: List[TypeSetElement] = field(default_factory=list)
+    # Type parameters of generic Go functions and types
+    type_params: List[TypeParam] = field(default_factory=list)
+    # Set on structs with ExtractOptions.layout_arch
+    layout: Optional[LayoutInfo] = None
+    # Set on Go fields with ExtractOptions.type_refs: the field's type
+    type_ref: Optional[TypeRef] = None
+    # Go enums from `iota` const blocks: the constants' declared type on the
+    # enum, e.g. "Color", the type it's defined as when declared in the same
+    # file, e.g. "int" for `type Color int`, and each member's computed value
+    # (None if it isn't plain integer arithmetic)
+    enum_type: Optional[str] = None
+    underlying_type: Optional[str] = None
+    value: Optional[int] = None
+    # Go constructor-style functions (`NewWorkerPool() *WorkerPool`): the
+    # type they build, and on the type the names of its constructors
+    constructs: Optional[str] = None
+    constructors: List[str] = field(default_factory=list)
+    # Go interface satisfaction assertions: on a type the interfaces it's
+    # asserted to satisfy, on an interface the types asserted to satisfy
+    # it, e.g. "*InMemoryCache"
+    implements: List[Implementation] = field(default_factory=list)
+    implemented_by: List[str] = field(default_factory=list)
+    # Properties from OutputOptions.collapse_accessors: whether a getter
+    # and a setter were found
+    readable: bool = False
+    writable: bool = False
+    # Set by OutputOptions.relative_lines: the line start_line and end_line
+    # count from, so the file line is start_line + line_offset (0 for
+    # top-level symbols)
+    line_offset: int = 0
+    params: List[Param] = field(default_factory=list)
+    results: List[Param] = field(default_factory=list)
+    attributes: List[Attribute] = field(default_factory=list)
+    children: List["Symbol"] = field(default_factory=list)
+
+    def has_attribute(self, name: str) -> bool:
+        """Check whether the symbol carries an attribute, e.g. "Test" or "@Test".
+
+        Names are compared exactly, ignoring a leading "@". "deprecated" (in
+        any case) also matches symbols flagged deprecated, so it covers Go
+        doc comments and JSDoc tags as well as annotations.
+        """
+        name = name.lstrip("@")
+        if name.lower() == "deprecated" and self.deprecated:
+            return True
+        return any(a.name.lstrip("@") == name for a in self.attributes)
+
+    def breadcrumb(
+        self,
+        sep: str = " > ",
+        ancestors: Sequence["Symbol"] = (),
+        package: Optional[str] = None
+    ) -> str:
+        """Render the symbol's path, e.g. "main > UserService > GetUser".
+
+        Symbols don't link to their parents, so pass the enclosing symbols
+        as ancestors (outermost first), or use Outline.breadcrumb.
+        """
+        return breadcrumb([*ancestors, self], sep, package)
+
+    def to_dict(self, fields: Optional[Sequence[str]] = None) -> Dict[str, Any]:
+        """Convert symbol (and its children) to a plain dictionary.
+
+        Args:
+            fields: Only include these fields, in declaration order (see
+                OutputOptions.fields); None includes all of them
+        """
+        if fields is None:
+            return asdict(self)
+        data: Dict[str, Any] = {}
+        for name in _SYMBOL_FIELDS:
+            if name not in fields:
+                continue
+            if name == "children":
+                data[name] = [c.to_dict(fields) for c in self.children]
+            else:
+                data[name] = _plain(getattr(self, name))
+        return data
+
+    @classmethod
+    def from_dict(cls, data: Dict[str, Any]) -> "Symbol":
+        """Rebuild a symbol (and its children) from to_dict() output."""
+        data = dict(data)
+        data["params"] = [Param.from_dict(p) for p in data.get("params", [])]
+        data["results"] = [Param.from_dict(p) for p in data.get("results", [])]
+        data["attributes"] = [Attribute.from_dict(a) for a in data.get("attributes", [])]
+        data["embedded_external"] = [EmbeddedExternal(**e) for e in data.get("embedded_external", [])]
+        data["error_sites"] = [ErrorSite(**e) for e in data.get("error_sites", [])]
+        data["implements"] = [Implementation(**i) for i in data.get("implements", [])]
+        data["panics"] = [PanicSite(**p) for p in data.get("panics", [])]
+        data["recovers"] = [PanicSite(**r) for r in data.get("recovers", [])]
+        data["goroutine_leaks"] = [GoroutineLeak(**g) for g in data.get("goroutine_leaks", [])]
+        data["type_set"] = [TypeSetElement(**t) for t in data.get("type_set", [])]
+        data["type_params"] = [TypeParam(**t) for t in data.get("type_params", [])]
+        if data.get("layout") is not None:
+            layout = dict(data["layout"])
+            layout["fields"] = [FieldLayout(**f) for f in layout.get("fields", [])]
+            data["layout"] = LayoutInfo(**layout)
+        if data.get("type_ref") is not None:
+            data["type_ref"] = TypeRef.from_dict(data["type_ref"])
+        if data.get("utf16") is not None:
+            data["utf16"] = Utf16Range(**data["utf16"])
+        data["type_assertions"] = [TypeRef.from_dict(t) for t in data.get("type_assertions", [])]
+        data["children"] = [cls.from_dict(c) for c in data.get("children", [])]
+        return cls(**data)
+
+
+_SYMBOL_FIELDS = [f.name for f in fields(Symbol)]
+
+
+def _plain(value: Any) -> Any:
+    """Convert dataclasses (and lists of them) to plain values like asdict."""
+    if is_dataclass(value):
+        return asdict(value)
+    if isinstance(value, list):
+        return [_plain(v) for v in value]
+    return value
+
+
+@dataclass
+class FlatSymbol:
+    """A symbol's place in a flattened outline (see Outline.flatten)."""
+
+    symbol: Symbol
+    # 0 for top-level symbols
+    depth: int
+    # Dotted name path, e.g. "UserService.GetUser" (see mcp_code_parser.extractors.names)
+    qualified_name: str
+    parent_stable_id: Optional[str] = None
+
+    def to_dict(self) -> Dict[str, Any]:
+        """Convert to a single-level dictionary: the symbol's fields without children."""
+        data = self.symbol.to_dict()
+        del data["children"]
+        data["depth"] = self.depth
+        data["qualified_name"] = self.qualified_name
+        data["parent_stable_id"] = self.parent_stable_id
+        return data
+
+
+@dataclass
+class Outline:
+    """Result of symbol extraction for a single source."""
+
+    language: str
+    symbols: List[Symbol]
+    metadata: Dict[str, Any]
+    error: Optional[str] = None
+    skipped: bool = False
+    skip_reason: Optional[str] = None
+    # Taxonomy code of the error (see mcp_code_parser.errors)
+    error_code: Optional[str] = None
+    # Syntax problems in the source, on which symbols are still extracted from
+    # the partial tree, then any lint findings (which have a rule)
+    diagnostics: List[Diagnostic] = field(default_factory=list)
+    # Package the source declares, e.g. "main" (None for languages without one)
+    package: Optional[str] = None
+    # The source is marked as generated (e.g. "// Code generated ... DO NOT
+    # EDIT."), so edits would be overwritten by the next generation
+    generated: bool = False
+    # The file follows its language's test naming conventions (None when not
+    # classified, see ExtractOptions.classify_tests)
+    is_test: Optional[bool] = None
+
+    @property
+    def success(self) -> bool:
+        """Check if extraction was successful."""
+        return self.error is None
+
+    def raise_for_error(self) -> None:
+        """Raise the categorized ToolError for a failed outline."""
+        if self.error is not None:
+            error = error_for_code(self.error_code, self.error, self.diagnostics)
+            if isinstance(error, LimitExceededError):
+                error.limit = self.metadata.get("limit")
+                error.partial = self
+            raise error
+
+    def flatten(self) -> List[FlatSymbol]:
+        """List every symbol depth-first, parents before children, in source order."""
+        flat: List[FlatSymbol] = []
+
+        def visit(symbols: List[Symbol], depth: int, scope: Optional[str], parent: Optional[Symbol]) -> None:
+            # Some extractors group children by kind; order siblings by position
+            for sym in sorted(symbols, key=lambda s: s.start_byte):
+                qualified = qualify(sym, scope)
+                flat.append(FlatSymbol(
+                    symbol=sym,
+                    depth=depth,
+                    qualified_name=qualified,
+                    parent_stable_id=parent.stable_id if parent is not None else None,
+                ))
+                visit(sym.children, depth + 1, qualified, sym)
+
+        visit(self.symbols, 0, None, None)
+        return flat
+
+    def qualified_name(self, symbol: Symbol, native: bool = False) -> Optional[str]:
+        """A symbol's qualified name, e.g. "UserService.GetUser".
+
+        Args:
+            symbol: A symbol of the outline
+            native: Join scopes with the language's own separator, e.g.
+                "geo::Point" in C++, instead of "."
+
+        Returns:
+            The name, or None if symbol isn't in the outline
+        """
+        chain = _ancestry(self.symbols, symbol)
+        if chain is None:
+            return None
+        return qualified_name(chain, native_separator(self.language)) if native else qualified_name(chain)
+
+    def breadcrumb(self, symbol: Symbol, sep: str = " > ") -> Optional[str]:
+        """Render a symbol's path from the outline's package, e.g. "main > UserService > GetUser".
+
+        Returns:
+            The breadcrumb, or None if symbol isn't in the outline
+        """
+        chain = _ancestry(self.symbols, symbol)
+        return breadcrumb(chain, sep, self.package) if chain is not None else None
+
+    def symbol_at(self, line: int) -> Optional[Symbol]:
+        """Innermost symbol whose lines include a 1-based line, or None."""
+        return _innermost(self.symbols, line, lambda sym: True)
+
+    def enclosing_function(self, line: int) -> Optional[Symbol]:
+        """Innermost function, method or other callable containing a 1-based line.
+
+        Types and other containers are skipped, so a line in a method gives
+        the method rather than its struct or class; None for lines outside
+        any callable, such as imports.
+        """
+        return _innermost(self.symbols, line, lambda sym: sym.kind in CALLABLE_KINDS or sym.kind in _ACCESSOR_KINDS)
+
+    def output_symbols(self, output: Optional[OutputOptions] = None) -> List[Symbol]:
+        """The top-level symbols as serialized with some output options.
+
+        With output.collapse_accessors, getter and setter methods are
+        grouped into properties, and with output.relative_lines copies with
+        relative lines are returned; otherwise these are the outline's
+        symbols.
+        """
+        symbols = self.symbols
+        if output is not None and output.collapse_accessors:
+            from mcp_code_parser.extractors.accessors import collapse_accessors
+            symbols = collapse_accessors(symbols, self.language)
+        if output is not None and output.relative_lines:
+            symbols = relative_lines(symbols)
+        return symbols
+
+    def to_dict(self, output: Optional[OutputOptions] = None) -> Dict[str, Any]:
+        """Convert outline to a plain dictionary.
+
+        Args:
+            output: Serialization options, e.g. a symbol field allowlist
+        """
+        symbol_fields = output.fields if output is not None else None
+        return {
+            "success": self.success,
+            "language": self.language,
+            "package": self.package,
+            "generated": self.generated,
+            "is_test": self.is_test,
+            "symbols": [s.to_dict(symbol_fields) for s in self.output_symbols(output)],
+            "metadata": self.metadata,
+            "error": self.error,
+            "error_code": self.error_code,
+            "diagnostics": [asdict(d) for d in self.diagnostics],
+            "skipped": self.skipped,
+            "skip_reason": self.skip_reason,
+        }
+
+    @classmethod
+    def from_dict(cls, data: Dict[str, Any]) -> "Outline":
+        """Rebuild an outline from to_dict() output."""
+        return cls(
+            language=data["language"],
+            package=data.get("package"),
+            generated=data.get("generated", False),
+            is_test=data.get("is_test"),
+            symbols=[Symbol.from_dict(s) for s in data.get("symbols", [])],
+            metadata=data.get("metadata", {}),
+            error=data.get("error"),
+            skipped=data.get("skipped", False),
+            skip_reason=data.get("skip_reason"),
+            error_code=data.get("error_code"),
+            diagnostics=[_diagnostic_from_dict(d) for d in data.get("diagnostics", [])],
+        )
+
+
+def _diagnostic_from_dict(data: Dict[str, Any]) -> Diagnostic:
+    """Rebuild a diagnostic from asdict() output."""
+    data = dict(data)
+    if data.get("utf16") is not None:
+        data["utf16"] = Utf16Range(**data["utf16"])
+    return Diagnostic(**data)
+
+
+def _innermost(symbols: List[Symbol], line: int, wanted: Callable[[Symbol], bool]) -> Optional[Symbol]:
+    """Deepest wanted symbol whose lines include line."""
+    for sym in symbols:
+        # Children can lie outside their parent, like Go methods of a struct
+        inner = _innermost(sym.children, line, wanted)
+        if inner is not None:
+            return inner
+        if wanted(sym) and sym.start_line <= line <= sym.end_line:
+            return sym
+    return None
+
+
+class BaseExtractor(ABC):
+    """Abstract base class for language-specific symbol extractors."""
+
+    # Whether the language overloads callables by parameter types, in which
+    # case stable IDs include the parameter types to tell overloads apart
+    overloads = False
+
+    # Grammar node types whose symbols' kind can be configured, with the kind
+    # each is emitted as by default. Extractors keep using the default kinds
+    # internally and apply ExtractOptions.symbol_kinds to their output.
+    symbol_kinds: SymbolKindConfig = {}
+
+    # Grammar node types of the string literals ExtractOptions.string_literal_lines reports
+    string_literal_types: Tuple[str, ...] = ()
+
+    def check_symbol_kinds(self, config: SymbolKindConfig) -> None:
+        """Validate a symbol kind config against the node types this extractor emits.
+
+        Raises:
+            ValueError: If the config names an unknown node type or an empty kind
+        """
+        unknown = sorted(t for t in config if t not in self.symbol_kinds)
+        if unknown:
+            known = ", ".join(sorted(self.symbol_kinds)) or "none"
+            raise ValueError(f"Unknown node type(s) {', '.join(unknown)} in symbol kind config; configurable: {known}")
+        empty = sorted(t for t, kind in config.items() if not kind)
+        if empty:
+            raise ValueError(f"Empty symbol kind for node type(s) {', '.join(empty)}")
+
+    @abstractmethod
+    def extract(
+        self,
+        tree: tree_sitter.Tree,
+        source: bytes,
+        options: ExtractOptions,
+        path: Optional[str] = None
+    ) -> List[Symbol]:
+        """Extract symbols from a parsed syntax tree.
+
+        Args:
+            tree: Tree-sitter tree for the source
+            source: Source bytes the tree was parsed from
+            options: Extraction options
+            path: Path of the source file, when known
+
+        Returns:
+            Top-level symbols in source order
+        """
+        pass
+
+    async def extract_content(
+        self,
+        content: str,
+        language: str,
+        options: ExtractOptions,
+        path: Optional[str],
+        parse: ParseFunc
+    ) -> Outline:
+        """Parse content and extract its outline.
+
+        Extractors for formats that embed other languages (e.g. single-file
+        components) override this to parse each region with its own grammar.
+
+        Args:
+            content: Source code
+            language: Language the extractor was selected for
+            options: Extraction options
+            path: Path of the source file, when known
+            parse: Coroutine turning (content, language) into a tree-sitter tree
+        """
+        source = bytes(content, "utf8")
+        guard = LimitGuard(options.limits, len(source)) if options.limits is not None else None
+        if guard is not None:
+            parse = guard.timed(parse)
+        tree = await parse(content, language)
+        if guard is not None:
+            cut = guard.check_tree(tree.root_node)
+            if cut is not None:
+                error = guard.tree_error()
+                # Outline what the leading declarations within budget declare
+                error.partial = await self.extract_content(
+                    source[:cut].decode("utf8"), language, replace(options, limits=None), path, parse
+                )
+                raise error
+        extracted, extracted_source = tree, source
+        if tree.root_node.has_error and options.complete_partial:
+            completed = await complete_buffer(content, language, tree, parse)
+            if completed is not None:
+                extracted, extracted_source = completed
+        symbols = self.extract(extracted, extracted_source, options, path)
+        if options.string_literal_lines is not None and options.wants("string_literal"):
+            # Imported here: the strings module builds on this one
+            from mcp_code_parser.extractors.strings import attach_string_literals
+            attach_string_literals(
+                symbols, extracted.root_node, extracted_source, self.string_literal_types, options.string_literal_lines
+            )
+        if options.include_raw_node:
+            attach_raw_nodes(symbols, extracted.root_node)
+        if options.analyze_recursion:
+            # Imported here: the analysis package builds on this module
+            from mcp_code_parser.analysis.calls import mark_recursion
+            assign_stable_ids(symbols, self.overloads)
+            mark_recursion(symbols, extracted.root_node, extracted_source)
+        metadata: Dict[str, Any] = {"has_errors": tree.root_node.has_error}
+        if extracted is not tree:
+            symbols = clip_symbols(symbols, source)
+            metadata["completed"] = True
+        return Outline(
+            language=language,
+            symbols=symbols,
+            metadata=metadata,
+            diagnostics=(
+                syntax_diagnostics(tree.root_node, source=source, tab_width=options.tab_width)
+                + self.lint(tree.root_node, source, options)
+            ),
+            package=self.package_name(tree.root_node, source),
+        )
+
+    def lint(self, root: tree_sitter.Node, source: bytes, options: ExtractOptions) -> List[Diagnostic]:
+        """Findings of the optional checks enabled in options, e.g. lint_context."""
+        return []
+
+    def package_name(self, root: tree_sitter.Node, source: bytes) -> Optional[str]:
+        """Package the file declares, for languages with a package clause."""
+        return None
+
+
+# Kinds whose stable IDs carry parameter types in languages with overloading
+CALLABLE_KINDS = ("function", "method", "constructor", "destructor", "operator", "init", "macro")
+
+# Kinds a scope may declare several times under one name (Go `func init`,
+# Elixir module attributes and function clauses); the second and later get
+# an ordinal in their stable ID: "init:init#2"
+REPEATABLE_KINDS = ("init", "attribute", "clause")
+
+# Callables that read or write a property (Dart `get`/`set`)
+_ACCESSOR_KINDS = ("getter", "setter")
+
+
+def assign_stable_ids(symbols: List[Symbol], overloads: bool = False, scope: Optional[str] = None) -> None:
+    """Set stable_id on symbols and their children in place.
+
+    IDs are the kind plus the qualified name, e.g. "method:UserService.GetUser"
+    (see mcp_code_parser.extractors.names). Top-level symbols with a
+    receiver (methods declared apart from their type) are qualified by it,
+    so they get the same ID wherever they are declared. With overloads,
+    callables append their parameter types:
+    "operator:Vector.operator+(const Vector&)".
+    """
+    seen: Dict[str, int] = {}
+    for sym in symbols:
+        qualified = qualify(sym, scope)
+        stable_id = f"{sym.kind}:{qualified}"
+        if overloads and sym.kind in CALLABLE_KINDS:
+            stable_id += "(" + ",".join(p.type for p in sym.params) + ")"
+        if sym.kind in REPEATABLE_KINDS:
+            seen[stable_id] = seen.get(stable_id, 0) + 1
+            if seen[stable_id] > 1:
+                stable_id += f"#{seen[stable_id]}"
+        sym.stable_id = stable_id
+        assign_stable_ids(sym.children, overloads, qualified)
+
+
+def breadcrumb(chain: Sequence[Symbol], sep: str = " > ", package: Optional[str] = None) -> str:
+    """Render a symbol's path from its ancestor chain, outermost first.
+
+    The package (when given) comes first, and a top-level symbol with a
+    receiver, like a Go method declared apart from its type, is placed
+    under the receiver. Segments follow the qualified name rules (see
+    mcp_code_parser.extractors.names), so symbols without a name appear as
+    "<anonymous KIND>".
+
+    Args:
+        chain: The symbol's ancestors, then the symbol
+        sep: Separator between path segments
+        package: Package the symbols are declared in, e.g. Outline.package
+    """
+    return sep.join(part for part in (package, qualified_name(chain, sep)) if part)
+
+
+def _ancestry(symbols: List[Symbol], target: Symbol) -> Optional[List[Symbol]]:
+    """The chain of symbols from the top level down to target, or None if it's not among them."""
+    for sym in symbols:
+        if sym is target:
+            return [sym]
+        chain = _ancestry(sym.children, target)
+        if chain is not None:
+            return [sym, *chain]
+    return None
+
+
+def assign_global_ids(symbols: List[Symbol], path: str, language: str) -> None:
+    """Set global_id on symbols and their children in place, from their stable_id.
+
+    IDs are "<language>:<path>#<stable_id>", normalized so equal symbols get
+    equal IDs wherever they are extracted: the language is lower case; the
+    path uses forward slashes with `.` segments, repeated and leading
+    slashes removed and `..` resolved, and `%` and `#` in it percent-encoded.
+    Symbols whose stable IDs collide within the file (e.g. a Python function
+    defined twice) get "~2", "~3", ... after the first, in source order.
+    Call assign_stable_ids first.
+
+    Args:
+        symbols: Top-level symbols of one file
+        path: The file's path relative to its repository root
+        language: Language the file was extracted as
+    """
+    normalized = posixpath.normpath(path.replace("\\", "/")).lstrip("/")
+    prefix = f"{language.lower()}:{normalized.replace('%', '%25').replace('#', '%23')}#"
+    seen: Dict[str, int] = {}
+    for sym in walk_symbols(symbols):
+        if sym.stable_id is None:
+            continue
+        seen[sym.stable_id] = seen.get(sym.stable_id, 0) + 1
+        count = seen[sym.stable_id]
+        sym.global_id = prefix + sym.stable_id + (f"~{count}" if count > 1 else "")
+
+
+def walk_symbols(symbols: Sequence[Symbol]) -> Iterator[Symbol]:
+    """Yield symbols and their descendants, depth first in source order."""
+    for sym in symbols:
+        yield sym
+        yield from walk_symbols(sym.children)
+
+
+def leading_comment(source: bytes, start_byte: int) -> str:
+    """Text of the comment lines directly above the line containing start_byte.
+
+    Recognises `//` line comments and `/* ... */` (including `/** ... */`)
+    blocks; comment markers and leading `*` are stripped. A blank line ends
+    the comment, as it does for Go doc comments.
+    """
+    line_start = source.rfind(b"\n", 0, start_byte) + 1
+    lines = source[:line_start].decode("utf8", errors="replace").split("\n")[:-1]
+    collected: List[str] = []
+    in_block = False
+    for line in reversed(lines):
+        text = line.strip()
+        if in_block:
+            in_block = not text.startswith("/*")
+            collected.append(text.lstrip("/*").strip())
+        elif text.startswith("//"):
+            collected.append(text[2:].strip())
+        elif text.endswith("*/"):
+            body = text[:-2]
+            in_block = "/*" not in body
+            collected.append(body.lstrip("/*").strip())
+        else:
+            break
+    return "\n".join(reversed(collected)).strip()
+
+
+# `Deprecated: ...` paragraph (Go) or `@deprecated ...` tag (JSDoc, TSDoc)
+_DEPRECATED_PARAGRAPH = re.compile(r"^Deprecated:[ \t]*(.*?)(?:\n\s*\n|\Z)", re.MULTILINE | re.DOTALL)
+_DEPRECATED_TAG = re.compile(r"@deprecated\b[ \t]*(.*?)(?:\n\s*@|\n\s*\n|\Z)", re.DOTALL)
+# C++14 `[[deprecated]]` / `[[deprecated("reason")]]`
+_DEPRECATED_ATTRIBUTE = re.compile(r'\[\[\s*deprecated\s*(?:\(\s*"((?:[^"\\]|\\.)*)"\s*\))?\s*\]\]')
+
+
+def mark_deprecated(symbols: List[Symbol], source: bytes) -> None:
+    """Set deprecated and deprecation_note from comments and attributes, in place.
+
+    Handles Go `// Deprecated:` doc paragraphs, JSDoc `@deprecated` tags and
+    C++ `[[deprecated("...")]]` attributes in the signature.
+    """
+    for sym in symbols:
+        doc = leading_comment(source, sym.start_byte)
+        match = _DEPRECATED_PARAGRAPH.search(doc) or _DEPRECATED_TAG.search(doc)
+        if match is None and sym.signature:
+            match = _DEPRECATED_ATTRIBUTE.search(sym.signature)
+        if match is not None:
+            sym.deprecated = True
+            note = " ".join((match.group(1) or "").split())
+            sym.deprecation_note = note or None
+        mark_deprecated(sym.children, source)
+
+
+def filter_kinds(symbols: List[Symbol], kinds: Sequence[str]) -> List[Symbol]:
+    """Keep symbols of the given kinds, replacing the others by their kept descendants."""
+    kept = []
+    for sym in symbols:
+        children = filter_kinds(sym.children, kinds)
+        if sym.kind in kinds:
+            sym.children = children
+            kept.append(sym)
+        else:
+            kept.extend(children)
+    return kept
+
+
+def filter_attributes(
+    symbols: List[Symbol],
+    include: Optional[Sequence[str]] = None,
+    exclude: Optional[Sequence[str]] = None
+) -> List[Symbol]:
+    """Filter symbols by attribute (see ExtractOptions.include_attributes).
+
+    A symbol with an excluded attribute is dropped with its members. With
+    include, a symbol is kept if it has one of the attributes (with all its
+    remaining members) or contains a symbol that does (with just those).
+    """
+    kept = []
+    for sym in symbols:
+        if exclude and any(sym.has_attribute(name) for name in exclude):
+            continue
+        if include and not any(sym.has_attribute(name) for name in include):
+            sym.children = filter_attributes(sym.children, include, exclude)
+            if sym.children:
+                kept.append(sym)
+            continue
+        sym.children = filter_attributes(sym.children, None, exclude)
+        kept.append(sym)
+    return kept
+
+
+def clip_symbols(symbols: List[Symbol], source: bytes) -> List[Symbol]:
+    """Fit symbols extracted from a completed buffer (see partial.complete_buffer) to the buffer.
+
+    Symbols running past the end of source end with it and are flagged
+    partial; symbols wholly in the completion are dropped.
+
+    Returns:
+        The symbols that start within source, clipped in place
+    """
+    end_line = source.count(b"\n", 0, max(len(source) - 1, 0)) + 1
+    kept = []
+    for sym in symbols:
+        if sym.start_byte >= len(source) and source:
+            continue
+        if sym.end_byte > len(source):
+            sym.end_byte = len(source)
+            sym.end_line = end_line
+            sym.partial = True
+        sym.children = clip_symbols(sym.children, source)
+        kept.append(sym)
+    return kept
+
+
+def shift_symbols(symbols: List[Symbol], line_delta: int, byte_delta: int) -> None:
+    """Move symbols (and their children) by a line and byte offset in place.
+
+    Used when a region of a larger file was extracted on its own.
+    """
+    for sym in symbols:
+        sym.start_line += line_delta
+        sym.end_line += line_delta
+        sym.start_byte += byte_delta
+        sym.end_byte += byte_delta
+        shift_symbols(sym.children, line_delta, byte_delta)
+
+
+def relative_lines(symbols: List[Symbol], line_offset: int = 0) -> List[Symbol]:
+    """Copy symbols with their children's lines relative to their parent's start.
+
+    Lines of Go methods declared above their type come out zero or negative.
+
+    Args:
+        symbols: Symbols with file lines
+        line_offset: Line to count the symbols' own lines from
+
+    Returns:
+        Copies of symbols and their children with line_offset set
+    """
+    return [
+        replace(
+            sym,
+            start_line=sym.start_line - line_offset,
+            end_line=sym.end_line - line_offset,
+            line_offset=line_offset,
+            children=relative_lines(sym.children, sym.start_line - 1),
+        )
+        for sym in symbols
+    ]
+
+
+def attach_raw_nodes(symbols: List[Symbol], root: tree_sitter.Node) -> None:
+    """Set raw_node on symbols (and their children) from the tree they were extracted from.
+
+    The defining node is the outermost node below root spanning exactly the
+    symbol's bytes, or else the smallest node containing them (for symbols
+    spanning several sibling nodes, such as a Dart member and its
+    annotations).
+    """
+    for sym in symbols:
+        node = root.descendant_for_byte_range(sym.start_byte, sym.end_byte)
+        while (
+            node is not None
+            and node.parent is not None
+            and node.parent.parent is not None
+            and (node.parent.start_byte, node.parent.end_byte) == (node.start_byte, node.end_byte)
+        ):
+            node = node.parent
+        if node is not None:
+            # Node.sexp() was removed in py-tree-sitter 0.23, where str() gives the S-expression
+            sym.raw_node = node.sexp() if hasattr(node, "sexp") else str(node)
+        attach_raw_nodes(sym.children, root)
+
+
+def node_text(node: tree_sitter.Node, source: bytes) -> str:
+    """Get the source text covered by a node."""
+    return source[node.start_byte:node.end_byte].decode("utf8", errors="replace")
+
+
+def _collapse(text: str) -> str:
+    """Collapse runs of whitespace into single spaces."""
+    return " ".join(text.split())
+
+
+def make_symbol(node: tree_sitter.Node, name: str, kind: str, **kwargs: Any) -> Symbol:
+    """Create a symbol spanning the given node (lines are 1-based)."""
+    return Symbol(
+        name=name,
+        kind=kind,
+        start_line=node.start_point[0] + 1,
+        end_line=node.end_point[0] + 1,
+        start_byte=node.start_byte,
+        end_byte=node.end_byte,
+        **kwargs,
+    )
+
+
+def attach_utf16_ranges(outline: Outline, source: bytes) -> None:
+    """Set the utf16 range of an outline's symbols and diagnostics.
+
+    Args:
+        outline: Outline extracted from source
+        source: UTF-8 source the outline's byte positions refer to
+    """
+    offsets = Utf16Offsets(source)
+
+    def visit(symbols: List[Symbol]) -> None:
+        for sym in symbols:
+            sym.utf16 = offsets.range(sym.start_byte, sym.end_byte)
+            visit(sym.children)
+
+    visit(outline.symbols)
+    for diagnostic in outline.diagnostics:
+        # Diagnostic lines and byte columns are 1-based
+        diagnostic.utf16 = offsets.range(
+            offsets.byte_offset(diagnostic.line - 1, diagnostic.column - 1),
+            offsets.byte_offset(diagnostic.end_line - 1, diagnostic.end_column - 1),
+        )
@@ -0,0 +1,450 @@
+"""Symbol extraction for C++ source."""
+
+from typing import Dict, List, Optional, Tuple
+
+import tree_sitter
+
+from mcp_code_parser.extractors.base import (
+    BaseExtractor,
+    ExtractOptions,
+    Param,
+    Symbol,
+    _collapse,
+    make_symbol,
+    mark_deprecated,
+    node_text,
+)
+
+_CLASS_SPECIFIERS = ("class_specifier", "struct_specifier", "union_specifier")
+_TYPE_SPECIFIERS = _CLASS_SPECIFIERS + ("enum_specifier",)
+# Declarator wrappers between a declaration and its function_declarator
+_DECLARATOR_WRAPPERS = (
+    "pointer_declarator",
+    "reference_declarator",
+    "parenthesized_declarator",
+    "attributed_declarator",
+    "init_declarator",
+)
+
+
+class CppExtractor(BaseExtractor):
+    """Extract namespaces, classes, functions and members from C++.
+
+    Constructors, destructors and operator overloads get their own kinds, and
+    each overload is a separate symbol with its full signature. Out-of-class
+    member definitions (`Vector::length() const {...}`) are nested under their
+    class when it is declared in the same file, replacing the in-class
+    prototype. Members are exported when their access is public.
+    """
+
+    overloads = True
+
+    string_literal_types = ("raw_string_literal",)
+
+    def extract(
+        self,
+        tree: tree_sitter.Tree,
+        source: bytes,
+        options: ExtractOptions,
+        path: Optional[str] = None
+    ) -> List[Symbol]:
+        """Extract top-level C++ symbols."""
+        classes: Dict[str, Symbol] = {}
+        symbols = _attach_out_of_class(self._items(tree.root_node, source, classes, scope=""), classes)
+        mark_deprecated(symbols, source)
+        return symbols
+
+    def _items(
+        self,
+        block: tree_sitter.Node,
+        source: bytes,
+        classes: Dict[str, Symbol],
+        scope: str
+    ) -> List[Symbol]:
+        """Extract the declarations in a translation unit or namespace body."""
+        symbols = []
+        for node in block.named_children:
+            inner = node
+            if node.type == "template_declaration":
+                inner = _template_body(node)
+                if inner is None:
+                    continue
+
+            if inner.type == "namespace_definition":
+                symbols.append(self._namespace(inner, source, classes, scope))
+                continue
+            if inner.type == "preproc_include":
+                path = inner.child_by_field_name("path")
+                if path is not None:
+                    symbols.append(make_symbol(
+                        inner,
+                        node_text(path, source).strip('<>"'),
+                        "import",
+                        signature=_collapse(node_text(inner, source)),
+                    ))
+                continue
+
+            for sym in self._declaration(inner, source, classes, scope, None):
+                if inner is not node:
+                    _span_from(sym, node, inner, source)
+                symbols.append(sym)
+        return symbols
+
+    def _namespace(
+        self,
+        node: tree_sitter.Node,
+        source: bytes,
+        classes: Dict[str, Symbol],
+        scope: str
+    ) -> Symbol:
+        """Build a namespace symbol with its declarations as children."""
+        name_node = node.child_by_field_name("name")
+        name = node_text(name_node, source) if name_node is not None else ""
+        sym = make_symbol(node, name, "namespace", exported=name_node is not None)
+        body = node.child_by_field_name("body")
+        if body is not None:
+            inner_scope = f"{scope}{name}::" if name_node is not None else scope
+            sym.children = _attach_out_of_class(
+                self._items(body, source, classes, inner_scope), classes
+            )
+        return sym
+
+    def _declaration(
+        self,
+        node: tree_sitter.Node,
+        source: bytes,
+        classes: Dict[str, Symbol],
+        scope: str,
+        owner: Optional[str]
+    ) -> List[Symbol]:
+        """Extract the symbols a declaration introduces.
+
+        Args:
+            node: Declaration, definition or type specifier node
+            source: Source bytes
+            classes: Classes found so far, by qualified name
+            scope: Enclosing namespace prefix, e.g. "geo::"
+            owner: Name of the enclosing class for member declarations
+        """
+        if node.type in _CLASS_SPECIFIERS:
+            sym = self._class(node, source, classes, scope)
+            return [sym] if sym is not None else []
+        if node.type == "enum_specifier":
+            sym = self._enum(node, source)
+            return [sym] if sym is not None else []
+        if node.type == "alias_declaration":
+            return [self._type_alias(node, node.child_by_field_name("name"), source)]
+        if node.type == "type_definition":
+            return [
+                self._type_alias(node, d, source)
+                for d in node.children_by_field_name("declarator")
+            ]
+        if node.type not in ("function_definition", "declaration", "field_declaration"):
+            return []
+
+        symbols = []
+        type_node = node.child_by_field_name("type")
+        if type_node is not None and type_node.type in _TYPE_SPECIFIERS:
+            # `struct Point { ... } origin;` or a nested type in a class body
+            symbols.extend(self._declaration(type_node, source, classes, scope, owner))
+
+        for declarator in node.children_by_field_name("declarator"):
+            function = _function_declarator(declarator)
+            if function is not None:
+                symbols.append(self._function(node, declarator, function, source, owner))
+                continue
+            name_node = _declarator_name(declarator)
+            if name_node is None:
+                continue
+            symbols.append(make_symbol(
+                node,
+                node_text(name_node, source),
+                "field" if owner else "variable",
+                signature=_collapse(node_text(node, source)).rstrip(";"),
+                exported=True,
+            ))
+        return symbols
+
+    def _function(
+        self,
+        node: tree_sitter.Node,
+        declarator: tree_sitter.Node,
+        function: tree_sitter.Node,
+        source: bytes,
+        owner: Optional[str]
+    ) -> Symbol:
+        """Build a function, method, constructor, destructor or operator symbol."""
+        if function.type == "operator_cast":
+            # `operator bool() const`: the name is the target type
+            name = _collapse(f"operator {node_text(function.child_by_field_name('type'), source)}")
+            receiver = None
+            params_node = _parameters(function.child_by_field_name("declarator"))
+        else:
+            receiver, name = _split_qualified(function.child_by_field_name("declarator"), source)
+            params_node = function.child_by_field_name("parameters")
+
+        cls = owner or (receiver.split("::")[-1] if receiver else None)
+        if name.startswith("~"):
+            kind = "destructor"
+        elif name.startswith("operator"):
+            kind = "operator"
+        elif cls is not None and name == _strip_template(cls):
+            kind = "constructor"
+        elif cls is not None:
+            kind = "method"
+        else:
+            kind = "function"
+
+        return make_symbol(
+            node,
+            name,
+            kind,
+            signature=_signature(node, source),
+            exported=True,
+            receiver=receiver,
+            params=_param_list(params_node, source),
+            results=_return_type(node, declarator, function, source),
+        )
+
+    def _class(
+        self,
+        node: tree_sitter.Node,
+        source: bytes,
+        classes: Dict[str, Symbol],
+        scope: str
+    ) -> Optional[Symbol]:
+        """Build a class/struct/union symbol; forward declarations are skipped."""
+        name_node = node.child_by_field_name("name")
+        body = node.child_by_field_name("body")
+        if name_node is None or body is None:
+            return None
+        if name_node.type == "template_type":
+            # Partial specialisations: `class Serializer<T, ...>`
+            name_node = name_node.child_by_field_name("name") or name_node
+        name = node_text(name_node, source)
+        kind = {"class_specifier": "class", "struct_specifier": "struct"}.get(node.type, "union")
+        sym = make_symbol(node, name, kind, signature=_signature(node, source), exported=True)
+
+        access = "private" if kind == "class" else "public"
+        for member in body.named_children:
+            if member.type == "access_specifier":
+                access = node_text(member, source).rstrip(":").strip()
+                continue
+            inner = member
+            if member.type == "template_declaration":
+                inner = _template_body(member)
+                if inner is None:
+                    continue
+            for child in self._declaration(inner, source, classes, f"{scope}{name}::", name):
+                child.exported = access == "public"
+                # Members are reached through the class, not a scope prefix
+                child.receiver = None
+                if inner is not member:
+                    _span_from(child, member, inner, source)
+                sym.children.append(child)
+
+        classes[f"{scope}{name}"] = sym
+        classes.setdefault(name, sym)
+        return sym
+
+    def _enum(self, node: tree_sitter.Node, source: bytes) -> Optional[Symbol]:
+        """Build an enum symbol with its enumerators."""
+        name_node = node.child_by_field_name("name")
+        body = node.child_by_field_name("body")
+        if name_node is None or body is None:
+            return None
+        sym = make_symbol(node, node_text(name_node, source), "enum", exported=True)
+        for enumerator in body.named_children:
+            if enumerator.type == "enumerator":
+                sym.children.append(make_symbol(
+                    enumerator,
+                    node_text(enumerator.child_by_field_name("name"), source),
+                    "enum_member",
+                    signature=_collapse(node_text(enumerator, source)),
+                    exported=True,
+                ))
+        return sym
+
+    def _type_alias(
+        self,
+        node: tree_sitter.Node,
+        name_node: Optional[tree_sitter.Node],
+        source: bytes
+    ) -> Symbol:
+        """Build a symbol for `using X = ...` or `typedef ... X`."""
+        name = node_text(name_node, source) if name_node is not None else ""
+        return make_symbol(
+            node,
+            name,
+            "type",
+            signature=_collapse(node_text(node, source)).rstrip(";"),
+            exported=True,
+        )
+
+
+def _attach_out_of_class(symbols: List[Symbol], classes: Dict[str, Symbol]) -> List[Symbol]:
+    """Move `Class::member` definitions under their class, replacing prototypes."""
+    remaining = []
+    for sym in symbols:
+        receiver = _strip_template(sym.receiver or "")
+        cls = classes.get(receiver) or classes.get(receiver.split("::")[-1])
+        if cls is None or sym.kind not in ("method", "constructor", "destructor", "operator"):
+            remaining.append(sym)
+            continue
+        sym.receiver = None
+        key = _overload_key(sym)
+        for i, member in enumerate(cls.children):
+            if _overload_key(member) == key:
+                cls.children[i] = sym
+                break
+        else:
+            cls.children.append(sym)
+    return remaining
+
+
+def _overload_key(sym: Symbol) -> Tuple[str, str, Tuple[str, ...]]:
+    """Identity of a callable among its overloads."""
+    return sym.kind, sym.name, tuple(p.type for p in sym.params)
+
+
+def _span_from(sym: Symbol, template: tree_sitter.Node, inner: tree_sitter.Node, source: bytes) -> None:
+    """Start a symbol at its template<...> header and include it in the signature."""
+    sym.start_line = template.start_point[0] + 1
+    sym.start_byte = template.start_byte
+    if sym.signature:
+        header = source[template.start_byte:inner.start_byte].decode("utf8", errors="replace")
+        sym.signature = _collapse(f"{header} {sym.signature}")
+
+
+def _template_body(node: tree_sitter.Node) -> Optional[tree_sitter.Node]:
+    """The declaration wrapped by a template_declaration."""
+    for child in node.named_children:
+        if child.type not in ("template_parameter_list", "requires_clause", "comment"):
+            return child
+    return None
+
+
+def _function_declarator(declarator: Optional[tree_sitter.Node]) -> Optional[tree_sitter.Node]:
+    """Find the function_declarator (or operator_cast) under pointer/reference wrappers."""
+    node = declarator
+    while node is not None:
+        if node.type in ("function_declarator", "operator_cast"):
+            return node
+        if node.type not in _DECLARATOR_WRAPPERS:
+            return None
+        inner = node.child_by_field_name("declarator")
+        if inner is None:
+            inner = next((c for c in node.named_children if c.type != "type_qualifier"), None)
+        node = inner
+    return None
+
+
+def _declarator_name(declarator: Optional[tree_sitter.Node]) -> Optional[tree_sitter.Node]:
+    """Innermost identifier of a (possibly pointer/array/initialized) declarator."""
+    node = declarator
+    while node is not None:
+        if node.type in ("identifier", "field_identifier", "type_identifier"):
+            return node
+        inner = node.child_by_field_name("declarator")
+        if inner is None:
+            return None
+        node = inner
+    return None
+
+
+def _parameters(declarator: Optional[tree_sitter.Node]) -> Optional[tree_sitter.Node]:
+    """Parameter list of an (abstract) function declarator."""
+    if declarator is None:
+        return None
+    return declarator.child_by_field_name("parameters")
+
+
+def _split_qualified(name_node: Optional[tree_sitter.Node], source: bytes) -> Tuple[Optional[str], str]:
+    """Split `ns::Class::name` into ("ns::Class", "name")."""
+    if name_node is None:
+        return None, ""
+    node = name_node
+    while node.type == "qualified_identifier":
+        inner = node.child_by_field_name("name")
+        if inner is None:
+            break
+        node = inner
+    name = _collapse(node_text(node, source))
+    if node is name_node:
+        return None, name
+    scope = source[name_node.start_byte:node.start_byte].decode("utf8", errors="replace")
+    return _collapse(scope).rstrip(":").rstrip() or None, name
+
+
+def _strip_template(name: str) -> str:
+    """Drop template arguments: `Box<T>` -> `Box`."""
+    return name.split("<", 1)[0]
+
+
+def _signature(node: tree_sitter.Node, source: bytes) -> str:
+    """Source text of a declaration up to (not including) its body."""
+    body = node.child_by_field_name("body")
+    end = body.start_byte if body is not None else node.end_byte
+    text = source[node.start_byte:end].decode("utf8", errors="replace")
+    return _collapse(text).rstrip(";").rstrip()
+
+
+def _param_list(parameters: Optional[tree_sitter.Node], source: bytes) -> List[Param]:
+    """Build Params from a parameter list; types keep their qualifiers and `&`/`*`."""
+    if parameters is None:
+        return []
+    params = []
+    for param in parameters.named_children:
+        if param.type == "variadic_parameter_declaration" or node_text(param, source) == "...":
+            params.append(Param(name=None, type="...", optional=True))
+            continue
+        if param.type not in ("parameter_declaration", "optional_parameter_declaration"):
+            continue
+        end = param.end_byte
+        default = param.child_by_field_name("default_value")
+        if default is not None:
+            end = default.start_byte
+        name_node = _declarator_name(param.child_by_field_name("declarator"))
+        if name_node is not None:
+            text = source[param.start_byte:name_node.start_byte] + source[name_node.end_byte:end]
+            name = node_text(name_node, source)
+        else:
+            text = source[param.start_byte:end]
+            name = None
+        type_text = _collapse(text.decode("utf8", errors="replace")).rstrip("=").rstrip()
+        params.append(Param(
+            name=name,
+            type=_tighten(type_text),
+            default=_collapse(node_text(default, source)) if default is not None else None,
+            optional=default is not None,
+        ))
+    return params
+
+
+def _return_type(
+    node: tree_sitter.Node,
+    declarator: tree_sitter.Node,
+    function: tree_sitter.Node,
+    source: bytes
+) -> List[Param]:
+    """Declared return type, including `&`/`*` from wrapping declarators."""
+    type_node = node.child_by_field_name("type")
+    if type_node is None or function.type == "operator_cast":
+        return []
+    # `const` before the type is a sibling of the type node, not part of it
+    qualifiers = [
+        node_text(c, source) for c in node.named_children
+        if c.type == "type_qualifier" and c.start_byte < type_node.start_byte
+    ]
+    modifiers = source[declarator.start_byte:function.start_byte].decode("utf8", errors="replace")
+    type_text = _tighten(_collapse(" ".join(qualifiers + [node_text(type_node, source), modifiers])))
+    if type_text == "void":
+        return []
+    return [Param(name=None, type=type_text)]
+
+
+def _tighten(type_text: str) -> str:
+    """Attach `&`, `&&` and `*` to the type they modify: `Vector &` -> `Vector&`."""
+    for mark in ("&&", "&", "*"):
+        type_text = type_text.replace(f" {mark}", mark)
+    return type_text.strip()
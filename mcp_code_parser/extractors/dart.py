@@ -0,0 +1,389 @@
+"""Symbol extraction for Dart source."""
+
+from typing import List, Optional
+
+import tree_sitter
+
+from mcp_code_parser.extractors.base import (
+    Attribute,
+    BaseExtractor,
+    ExtractOptions,
+    Param,
+    Symbol,
+    _collapse,
+    make_symbol,
+    mark_deprecated,
+    node_text,
+)
+
+_TYPE_DECLARATIONS = {
+    "class_definition": "class",
+    "mixin_declaration": "mixin",
+    "enum_declaration": "enum",
+    "extension_declaration": "extension",
+}
+_CONSTRUCTOR_SIGNATURES = (
+    "constructor_signature",
+    "constant_constructor_signature",
+    "factory_constructor_signature",
+    "redirecting_factory_constructor_signature",
+)
+_SIGNATURES = _CONSTRUCTOR_SIGNATURES + (
+    "function_signature",
+    "getter_signature",
+    "setter_signature",
+    "operator_signature",
+)
+# Nodes that wrap a member's signature or field list in class bodies
+_MEMBER_WRAPPERS = ("method_signature", "declaration")
+_FIELD_LISTS = ("initialized_identifier_list", "static_final_declaration_list")
+_ANNOTATIONS = ("annotation", "marker_annotation")
+_COMMENTS = ("comment", "documentation_comment")
+# Nodes that are a whole declaration on their own
+_COMPLETE_ITEMS = tuple(_TYPE_DECLARATIONS) + ("enum_constant",)
+# Top-level directives that don't declare symbols
+_DIRECTIVES = ("library_name", "import_or_export", "part_directive", "part_of_directive", "script_tag")
+
+
+class DartExtractor(BaseExtractor):
+    """Extract classes, mixins, enums, extensions, functions and fields from Dart.
+
+    Members are nested under their type. Getters and setters get their own
+    kinds, and each named constructor (`Point.origin`) is a separate
+    constructor symbol. Annotations are recorded as attributes, and the
+    `abstract` and `static` modifiers as flags. Names starting with an
+    underscore are library-private and reported as not exported.
+    """
+
+    string_literal_types = ("string_literal",)
+
+    def extract(
+        self,
+        tree: tree_sitter.Tree,
+        source: bytes,
+        options: ExtractOptions,
+        path: Optional[str] = None
+    ) -> List[Symbol]:
+        """Extract top-level Dart symbols."""
+        symbols = self._items(tree.root_node, source, owner=None)
+        mark_deprecated(symbols, source)
+        _apply_deprecated_annotations(symbols)
+        return symbols
+
+    def _items(
+        self,
+        container: tree_sitter.Node,
+        source: bytes,
+        owner: Optional[Symbol]
+    ) -> List[Symbol]:
+        """Extract the declarations in a program or type body.
+
+        The grammar leaves most declarations unwrapped (a signature followed
+        by its body, or modifiers, a type and a name list followed by `;`),
+        so sibling nodes are grouped into declarations first.
+        """
+        symbols = []
+        for group in _declaration_groups(container):
+            symbols.extend(self._declaration(group, source, owner))
+        return symbols
+
+    def _declaration(
+        self,
+        group: List[tree_sitter.Node],
+        source: bytes,
+        owner: Optional[Symbol]
+    ) -> List[Symbol]:
+        """Build the symbols for one group of sibling nodes."""
+        annotations = [n for n in group if n.type in _ANNOTATIONS]
+        parts = [n for n in group if n.type not in _ANNOTATIONS]
+        if not parts or parts[0].type in _DIRECTIVES:
+            return []
+
+        first = parts[0]
+        if first.type in _TYPE_DECLARATIONS:
+            symbols = [self._type(first, source)]
+        elif first.type == "enum_constant":
+            symbols = [self._enum_constant(first, source)]
+        else:
+            symbols = self._member(parts, source, owner)
+
+        attributes = _annotations(annotations, source)
+        for sym in symbols:
+            if attributes:
+                # The symbol spans its annotations, as an editor would fold it
+                sym.start_line = group[0].start_point[0] + 1
+                sym.start_byte = group[0].start_byte
+                sym.attributes = attributes + sym.attributes
+        return symbols
+
+    def _type(self, node: tree_sitter.Node, source: bytes) -> Symbol:
+        """Build a class, mixin, enum or extension symbol with its members."""
+        kind = _TYPE_DECLARATIONS[node.type]
+        name_node = _name(node)
+        name = node_text(name_node, source) if name_node is not None else ""
+        body = node.child_by_field_name("body") or next(
+            (c for c in node.named_children if c.type.endswith("_body")), None
+        )
+        start = next((c for c in node.children if c.type not in _ANNOTATIONS + _COMMENTS), node)
+        end = body.start_byte if body is not None else node.end_byte
+        header = source[start.start_byte:name_node.start_byte if name_node is not None else end]
+
+        sym = make_symbol(
+            node,
+            name,
+            kind,
+            signature=_collapse(source[start.start_byte:end].decode("utf8", errors="replace")),
+            exported=name_node is not None and not name.startswith("_"),
+            abstract="abstract" in header.decode("utf8", errors="replace").split(),
+            attributes=_annotations([c for c in node.named_children if c.type in _ANNOTATIONS], source),
+        )
+        if body is not None:
+            sym.children = self._items(body, source, owner=sym)
+        return sym
+
+    def _enum_constant(self, node: tree_sitter.Node, source: bytes) -> Symbol:
+        """Build an enum value symbol."""
+        name_node = _name(node)
+        name = node_text(name_node, source) if name_node is not None else ""
+        return make_symbol(
+            node,
+            name,
+            "enum_member",
+            signature=_collapse(node_text(node, source)),
+            exported=not name.startswith("_"),
+            attributes=_annotations([c for c in node.named_children if c.type in _ANNOTATIONS], source),
+        )
+
+    def _member(
+        self,
+        parts: List[tree_sitter.Node],
+        source: bytes,
+        owner: Optional[Symbol]
+    ) -> List[Symbol]:
+        """Build the symbols for a function, accessor, constructor or field declaration."""
+        nodes = []
+        for node in parts:
+            nodes.extend(node.named_children if node.type in _MEMBER_WRAPPERS else [node])
+        body = next((n for n in nodes if n.type == "function_body"), None)
+        start, end = parts[0], parts[-1]
+        text = _collapse(source[start.start_byte:end.end_byte].decode("utf8", errors="replace"))
+
+        signature = next((n for n in nodes if n.type in _SIGNATURES), None)
+        if signature is not None:
+            return [self._callable(signature, start, end, body, source, owner)]
+
+        symbols = []
+        for field_list in (n for n in nodes if n.type in _FIELD_LISTS):
+            modifiers = source[start.start_byte:field_list.start_byte].decode("utf8", errors="replace").split()
+            for item in field_list.named_children:
+                name_node = _name(item)
+                if name_node is None:
+                    continue
+                name = node_text(name_node, source)
+                symbols.append(_spanning(
+                    start,
+                    end,
+                    name,
+                    "field" if owner is not None else "variable",
+                    signature=text.rstrip(";").rstrip(),
+                    exported=not name.startswith("_"),
+                    static="static" in modifiers,
+                ))
+        return symbols
+
+    def _callable(
+        self,
+        signature: tree_sitter.Node,
+        start: tree_sitter.Node,
+        end: tree_sitter.Node,
+        body: Optional[tree_sitter.Node],
+        source: bytes,
+        owner: Optional[Symbol]
+    ) -> Symbol:
+        """Build a function, method, getter, setter, operator or constructor symbol."""
+        params_node = next((c for c in signature.named_children if c.type == "formal_parameter_list"), None)
+        if signature.type in _CONSTRUCTOR_SIGNATURES:
+            kind = "constructor"
+            # `Point.origin` for named constructors, `Point` otherwise
+            stop = params_node.start_byte if params_node is not None else signature.end_byte
+            name = ".".join(
+                node_text(c, source) for c in signature.named_children
+                if c.type == "identifier" and c.end_byte <= stop
+            )
+        elif signature.type == "operator_signature":
+            kind = "operator"
+            operator = next((c for c in signature.children if c.type.endswith("operator")), None)
+            name = _collapse(f"operator {node_text(operator, source) if operator is not None else ''}")
+        else:
+            kind = {"getter_signature": "getter", "setter_signature": "setter"}.get(
+                signature.type, "method" if owner is not None else "function"
+            )
+            name_node = _name(signature)
+            name = node_text(name_node, source) if name_node is not None else ""
+
+        modifiers = source[start.start_byte:signature.start_byte].decode("utf8", errors="replace").split()
+        header_end = body.start_byte if body is not None else end.end_byte
+        header = _collapse(source[start.start_byte:header_end].decode("utf8", errors="replace"))
+        # Bodiless instance members of classes and mixins are abstract
+        abstract = (
+            body is None
+            and kind in ("method", "getter", "setter", "operator")
+            and owner is not None
+            and owner.kind in ("class", "mixin")
+            and "external" not in modifiers
+        )
+        return _spanning(
+            start,
+            end,
+            name,
+            kind,
+            signature=header.rstrip(";").rstrip(),
+            exported=not name.split(".")[-1].startswith("_"),
+            static="static" in modifiers,
+            abstract=abstract,
+            params=_param_list(params_node, source),
+            results=_return_type(signature, source) if kind in ("function", "method", "getter", "operator") else [],
+        )
+
+
+def _declaration_groups(container: tree_sitter.Node) -> List[List[tree_sitter.Node]]:
+    """Split a container's children into declarations.
+
+    A declaration ends with a function body, a `;`, or is a type declaration
+    or enum value by itself; annotations before it belong to it. Comments,
+    braces and separators are dropped.
+    """
+    groups: List[List[tree_sitter.Node]] = []
+    current: List[tree_sitter.Node] = []
+    for child in container.children:
+        if child.type in _COMMENTS or child.type in ("{", "}", ","):
+            continue
+        if child.type == ";":
+            if current:
+                current.append(child)
+                groups.append(current)
+                current = []
+            continue
+        current.append(child)
+        if child.type in _COMPLETE_ITEMS or child.type == "function_body" or child.type in _DIRECTIVES:
+            groups.append(current)
+            current = []
+    if current:
+        groups.append(current)
+    return groups
+
+
+def _spanning(
+    start: tree_sitter.Node,
+    end: tree_sitter.Node,
+    name: str,
+    kind: str,
+    **kwargs
+) -> Symbol:
+    """Create a symbol spanning from the start of one node to the end of another."""
+    sym = make_symbol(start, name, kind, **kwargs)
+    sym.end_line = end.end_point[0] + 1
+    sym.end_byte = end.end_byte
+    return sym
+
+
+def _name(node: tree_sitter.Node) -> Optional[tree_sitter.Node]:
+    """The name field of a node, or its first identifier."""
+    name = node.child_by_field_name("name")
+    if name is not None:
+        return name
+    return next((c for c in node.named_children if c.type == "identifier"), None)
+
+
+def _annotations(nodes: List[tree_sitter.Node], source: bytes) -> List[Attribute]:
+    """Build attributes for annotations like `@override` or `@Deprecated('Use x')`."""
+    attributes = []
+    for node in nodes:
+        arguments = next((c for c in node.named_children if c.type == "arguments"), None)
+        end = arguments.start_byte if arguments is not None else node.end_byte
+        args = []
+        if arguments is not None:
+            args = [
+                _collapse(node_text(arg, source))
+                for arg in arguments.named_children
+                if arg.type not in _COMMENTS
+            ]
+        attributes.append(Attribute(
+            name=_collapse(source[node.start_byte:end].decode("utf8", errors="replace")).lstrip("@"),
+            args=args,
+            raw=_collapse(node_text(node, source)),
+        ))
+    return attributes
+
+
+def _apply_deprecated_annotations(symbols: List[Symbol]) -> None:
+    """Mark symbols annotated `@deprecated` or `@Deprecated('...')`, in place."""
+    for sym in symbols:
+        for attribute in sym.attributes:
+            if attribute.name not in ("deprecated", "Deprecated"):
+                continue
+            sym.deprecated = True
+            if attribute.args:
+                sym.deprecation_note = attribute.args[0].strip("'\"") or None
+        _apply_deprecated_annotations(sym.children)
+
+
+def _param_list(parameters: Optional[tree_sitter.Node], source: bytes) -> List[Param]:
+    """Build Params from a formal parameter list, including `[...]` and `{...}` groups."""
+    if parameters is None:
+        return []
+    params = []
+    for group in parameters.named_children:
+        if group.type == "formal_parameter":
+            params.append(_param(group, None, False, source))
+            continue
+        if group.type != "optional_formal_parameters":
+            continue
+        # Defaults follow each parameter as `= value` (or `: value`) siblings
+        children = group.children
+        for i, child in enumerate(children):
+            if child.type != "formal_parameter":
+                continue
+            default = None
+            if i + 2 < len(children) and children[i + 1].type in ("=", ":"):
+                default = children[i + 2]
+            params.append(_param(child, default, True, source))
+    return params
+
+
+def _param(
+    node: tree_sitter.Node,
+    default: Optional[tree_sitter.Node],
+    in_optional_group: bool,
+    source: bytes
+) -> Param:
+    """Build a Param from a formal_parameter; `this.x` and `super.x` have no type."""
+    words = _collapse(node_text(node, source)).split(" ")
+    required = words[0] == "required"
+    if required:
+        words = words[1:]
+    name = words[-1] if words else ""
+    for prefix in ("this.", "super."):
+        if name.startswith(prefix):
+            name = name[len(prefix):]
+    return Param(
+        name=name or None,
+        type=" ".join(words[:-1]),
+        default=_collapse(node_text(default, source)) if default is not None else None,
+        optional=in_optional_group and not required,
+    )
+
+
+def _return_type(signature: tree_sitter.Node, source: bytes) -> List[Param]:
+    """Declared return type of a signature, the text before its name or `get`/`operator`."""
+    stop = next(
+        (c for c in signature.children if c.type in ("identifier", "get", "operator")),
+        None,
+    )
+    if stop is None:
+        return []
+    words = source[signature.start_byte:stop.start_byte].decode("utf8", errors="replace").split()
+    words = [w for w in words if w not in ("static", "external")]
+    if not words or words == ["void"]:
+        return []
+    return [Param(name=None, type=" ".join(words))]
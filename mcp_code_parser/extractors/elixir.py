@@ -0,0 +1,293 @@
+"""Symbol extraction for Elixir source."""
+
+from typing import List, Optional, Tuple
+
+import tree_sitter
+
+from mcp_code_parser.extractors.base import (
+    Attribute,
+    BaseExtractor,
+    ExtractOptions,
+    Param,
+    Symbol,
+    _collapse,
+    make_symbol,
+    node_text,
+)
+
+_MODULES = {"defmodule": "module", "defprotocol": "protocol", "defimpl": "impl"}
+_FUNCTIONS = {"def": "function", "defp": "function", "defmacro": "macro", "defmacrop": "macro"}
+_PRIVATE = ("defp", "defmacrop")
+# Module attributes describing the function after them rather than the module
+_FUNCTION_ATTRIBUTES = ("doc", "spec", "impl", "deprecated")
+
+
+class ElixirExtractor(BaseExtractor):
+    """Extract modules, protocols, implementations, functions, macros and structs from Elixir.
+
+    Functions and macros are named by name and arity (`get_user/2`) and
+    nested under their module. Consecutive clauses of one function are a
+    single symbol spanning them all, with each clause as a `clause` child
+    named by its head. `@doc`, `@spec`, `@impl` and `@deprecated` are
+    recorded as attributes of the function after them and `@moduledoc` as
+    one of its module; other module attributes are `attribute` symbols.
+    `defp` and `defmacrop` definitions are reported as not exported.
+    """
+
+    def extract(
+        self,
+        tree: tree_sitter.Tree,
+        source: bytes,
+        options: ExtractOptions,
+        path: Optional[str] = None
+    ) -> List[Symbol]:
+        """Extract top-level Elixir symbols."""
+        return self._body(tree.root_node.named_children, source, owner=None)
+
+    def _body(
+        self,
+        nodes: List[tree_sitter.Node],
+        source: bytes,
+        owner: Optional[Symbol]
+    ) -> List[Symbol]:
+        """Extract the definitions among the expressions of a file or do block."""
+        symbols: List[Symbol] = []
+        # Function attributes waiting for the definition they describe
+        pending: List[tree_sitter.Node] = []
+        for node in nodes:
+            attribute = _module_attribute(node, source)
+            if attribute is not None:
+                if attribute.name == "moduledoc" and owner is not None:
+                    owner.attributes.append(attribute)
+                elif attribute.name in _FUNCTION_ATTRIBUTES:
+                    pending.append(node)
+                else:
+                    symbols.append(make_symbol(node, attribute.name, "attribute", signature=attribute.raw))
+                continue
+
+            keyword = _call_name(node, source)
+            if keyword in _MODULES:
+                sym = self._module(node, keyword, source)
+            elif keyword == "defstruct":
+                sym = _struct(node, source)
+            elif keyword in _FUNCTIONS:
+                sym = _function(node, keyword, source, owner, symbols[-1] if symbols else None)
+            else:
+                continue
+            if sym is None:
+                continue
+            if pending and keyword in _FUNCTIONS:
+                _describe(sym, pending, source)
+            pending = []
+            if not symbols or sym is not symbols[-1]:
+                symbols.append(sym)
+
+        for sym in symbols:
+            # A function with one clause is just that clause
+            if sym.kind in _FUNCTIONS.values() and len(sym.children) == 1:
+                sym.children = []
+        return symbols
+
+    def _module(self, node: tree_sitter.Node, keyword: str, source: bytes) -> Optional[Symbol]:
+        """Build a module, protocol or implementation symbol with its definitions."""
+        arguments = _child(node, "arguments")
+        if arguments is None or not arguments.named_children:
+            return None
+        name = node_text(arguments.named_children[0], source)
+        # `defimpl Size, for: Map` defines the module Size.Map
+        target = _keyword_value(arguments, "for", source)
+        if keyword == "defimpl" and target is not None:
+            name = f"{name}.{target}"
+
+        body = _child(node, "do_block")
+        end = body.start_byte if body is not None else node.end_byte
+        sym = make_symbol(
+            node,
+            name,
+            _MODULES[keyword],
+            signature=_collapse(source[node.start_byte:end].decode("utf8", errors="replace")),
+            exported=True,
+        )
+        if body is not None:
+            sym.children = self._body(body.named_children, source, owner=sym)
+        return sym
+
+
+def _function(
+    node: tree_sitter.Node,
+    keyword: str,
+    source: bytes,
+    owner: Optional[Symbol],
+    previous: Optional[Symbol]
+) -> Optional[Symbol]:
+    """Build a function or macro clause, adding it to previous when it's the same function.
+
+    Returns:
+        The function the clause belongs to, or None if the head isn't a plain call
+    """
+    head = _head(node, source)
+    if head is None:
+        return None
+    call, guarded = head
+    if call.type == "identifier":
+        name, args = node_text(call, source), []
+    else:
+        name = node_text(call.child_by_field_name("target"), source)
+        arguments = _child(call, "arguments")
+        args = arguments.named_children if arguments is not None else []
+
+    params = [_param(arg, source) for arg in args]
+    kind = _FUNCTIONS[keyword]
+    exported = keyword not in _PRIVATE
+    clause = make_symbol(
+        node,
+        _collapse(source[guarded.start_byte:guarded.end_byte].decode("utf8", errors="replace")),
+        "clause",
+        signature=_collapse(source[node.start_byte:guarded.end_byte].decode("utf8", errors="replace")),
+        exported=exported,
+        params=params,
+    )
+
+    qualified = f"{name}/{len(params)}"
+    if previous is not None and (previous.kind, previous.name, previous.exported) == (kind, qualified, exported):
+        previous.children.append(clause)
+        previous.end_line = clause.end_line
+        previous.end_byte = clause.end_byte
+        return previous
+
+    bodiless = _child(node, "do_block") is None and _keyword_value(_child(node, "arguments"), "do", source) is None
+    return make_symbol(
+        node,
+        qualified,
+        kind,
+        signature=clause.signature,
+        exported=exported,
+        # Protocols declare their functions without bodies
+        abstract=bodiless and owner is not None and owner.kind == "protocol",
+        params=params,
+        children=[clause],
+    )
+
+
+def _head(node: tree_sitter.Node, source: bytes) -> Optional[Tuple[tree_sitter.Node, tree_sitter.Node]]:
+    """The call (or bare name) a definition defines, and the head including any `when` guard."""
+    arguments = _child(node, "arguments")
+    if arguments is None or not arguments.named_children:
+        return None
+    guarded = arguments.named_children[0]
+    call = guarded
+    if guarded.type == "binary_operator" and _operator(guarded, source) == "when":
+        call = guarded.child_by_field_name("left")
+    if call is None:
+        return None
+    if call.type == "identifier":
+        return call, guarded
+    if call.type == "call":
+        target = call.child_by_field_name("target")
+        if target is not None and target.type == "identifier":
+            return call, guarded
+    return None
+
+
+def _param(node: tree_sitter.Node, source: bytes) -> Param:
+    """Build a Param from an argument: a name, a pattern, or `name \\\\ default`."""
+    if node.type == "binary_operator" and _operator(node, source) == "\\\\":
+        left = node.child_by_field_name("left")
+        right = node.child_by_field_name("right")
+        return Param(
+            name=_collapse(node_text(left, source)) if left is not None else None,
+            type="",
+            default=_collapse(node_text(right, source)) if right is not None else None,
+            optional=True,
+        )
+    return Param(name=_collapse(node_text(node, source)), type="")
+
+
+def _struct(node: tree_sitter.Node, source: bytes) -> Symbol:
+    """Build the struct symbol of a `defstruct`, with its fields as children."""
+    sym = make_symbol(node, "__struct__", "struct", signature=_collapse(node_text(node, source)), exported=True)
+    arguments = _child(node, "arguments")
+    items = []
+    for arg in arguments.named_children if arguments is not None else []:
+        items.extend(arg.named_children if arg.type == "list" else [arg])
+    for item in items:
+        # `[:id, name: "guest"]` lists bare fields then fields with defaults
+        fields = item.named_children if item.type == "keywords" else [item]
+        for f in fields:
+            if f.type == "atom":
+                name = node_text(f, source).lstrip(":")
+            elif f.type == "pair":
+                key = f.child_by_field_name("key")
+                name = node_text(key, source).strip().rstrip(":") if key is not None else ""
+            else:
+                continue
+            sym.children.append(make_symbol(f, name, "field", signature=_collapse(node_text(f, source)), exported=True))
+    return sym
+
+
+def _describe(sym: Symbol, attributes: List[tree_sitter.Node], source: bytes) -> None:
+    """Attach function attributes to a function, which then spans them if they start it."""
+    described = [_module_attribute(node, source) for node in attributes]
+    sym.attributes.extend(a for a in described if a is not None)
+    if attributes[0].start_byte < sym.start_byte:
+        sym.start_line = attributes[0].start_point[0] + 1
+        sym.start_byte = attributes[0].start_byte
+    for attribute in described:
+        if attribute is not None and attribute.name == "deprecated":
+            sym.deprecated = True
+            if attribute.args:
+                sym.deprecation_note = attribute.args[0].strip("'\"") or None
+
+
+def _module_attribute(node: tree_sitter.Node, source: bytes) -> Optional[Attribute]:
+    """The attribute a `@name value` expression sets, or None for other expressions."""
+    if node.type != "unary_operator" or _operator(node, source) != "@":
+        return None
+    operand = node.child_by_field_name("operand")
+    if operand is None:
+        return None
+    raw = _collapse(node_text(node, source))
+    if operand.type == "identifier":
+        return Attribute(name=node_text(operand, source), raw=raw)
+    if operand.type != "call":
+        return None
+    target = operand.child_by_field_name("target")
+    if target is None or target.type != "identifier":
+        return None
+    arguments = _child(operand, "arguments")
+    args = [_collapse(node_text(a, source)) for a in arguments.named_children] if arguments is not None else []
+    return Attribute(name=node_text(target, source), args=args, raw=raw)
+
+
+def _call_name(node: tree_sitter.Node, source: bytes) -> Optional[str]:
+    """Name a call like `def ...` or `defmodule ...` calls, or None for other expressions."""
+    if node.type != "call":
+        return None
+    target = node.child_by_field_name("target")
+    if target is None or target.type != "identifier":
+        return None
+    return node_text(target, source)
+
+
+def _keyword_value(arguments: Optional[tree_sitter.Node], key: str, source: bytes) -> Optional[str]:
+    """Text of a keyword argument, such as the `for: Map` of a defimpl."""
+    if arguments is None:
+        return None
+    for keywords in (c for c in arguments.named_children if c.type == "keywords"):
+        for pair in keywords.named_children:
+            key_node = pair.child_by_field_name("key")
+            value = pair.child_by_field_name("value")
+            if key_node is not None and value is not None and node_text(key_node, source).strip() == f"{key}:":
+                return _collapse(node_text(value, source))
+    return None
+
+
+def _operator(node: tree_sitter.Node, source: bytes) -> Optional[str]:
+    """Operator of a unary or binary operator node."""
+    operator = node.child_by_field_name("operator")
+    return node_text(operator, source) if operator is not None else None
+
+
+def _child(node: tree_sitter.Node, node_type: str) -> Optional[tree_sitter.Node]:
+    """First named child of a type."""
+    return next((c for c in node.named_children if c.type == node_type), None)
@@ -0,0 +1,1376 @@
+"""Symbol extraction for Go source."""
+
+import re
+from pathlib import PurePath
+from typing import Dict, List, Optional, Set, Tuple
+
+import tree_sitter
+
+from mcp_code_parser.errors import Diagnostic
+from mcp_code_parser.extractors.base import (
+    Attribute,
+    BaseExtractor,
+    EmbeddedExternal,
+    ErrorSite,
+    ExtractOptions,
+    Implementation,
+    PanicSite,
+    Param,
+    Symbol,
+    SymbolKindConfig,
+    TypeParam,
+    TypeRef,
+    TypeSetElement,
+    _collapse,
+    make_symbol,
+    mark_deprecated,
+    node_text,
+)
+from mcp_code_parser.extractors.go_context import context_diagnostics
+from mcp_code_parser.extractors.go_enums import iota_enum
+from mcp_code_parser.extractors.go_goroutines import mark_goroutine_leaks
+from mcp_code_parser.extractors.go_layout import GoLayout
+from mcp_code_parser.extractors.go_types import parse_type
+
+# Kinds of the members a struct or interface symbol can hold
+_MEMBER_KINDS = {"struct": ("field", "embedded"), "interface": ("method", "type_set", "embedded")}
+
+# Function name prefixes recognised by `go test`, with the TestKind we report
+# and the parameter type the function must take (None means no parameters).
+_TEST_PREFIXES = [
+    ("Benchmark", "benchmark", "*testing.B"),
+    ("Example", "example", None),
+    ("Fuzz", "fuzz", "*testing.F"),
+    ("Test", "test", "*testing.T"),
+]
+
+_STRING_LITERALS = ("interpreted_string_literal", "raw_string_literal")
+
+# One `key:"value"` pair of a struct tag, following reflect.StructTag conventions
+_TAG_PAIR = re.compile(r'([^\s:"]+):"((?:[^"\\]|\\.)*)"')
+
+# An embedded `pkg.Name` (optionally instantiated: `pkg.Name[T]`)
+_QUALIFIED_EMBED = re.compile(r"^(\w+)\.(\w+)(?:\[.*\])?$")
+# `func (r *T) ` before a method's name in its signature
+_RECEIVER = re.compile(r"^func\s*\([^)]*\)\s*")
+# Values of `var _ I = ...` interface assertions: `(*T)(nil)`, `&T{...}`
+# and `new(T)` of a pointer, `T{...}` and `T(nil)` of a value
+_ASSERTED_POINTER = re.compile(
+    r"^\(\s*\*\s*([\w.]+(?:\[.*\])?)\s*\)\s*\(\s*nil\s*\)$"
+    r"|^&([\w.]+(?:\[.*\])?)\s*\{.*\}$"
+    r"|^new\(\s*([\w.]+(?:\[.*\])?)\s*\)$"
+)
+_ASSERTED_VALUE = re.compile(r"^([\w.]+(?:\[.*\])?)\s*(?:\{.*\}|\(\s*nil\s*\))$")
+
+# Import scopes: who outside the package can use a symbol
+IMPORT_SCOPE_PUBLIC = "public"
+IMPORT_SCOPE_PACKAGE_PRIVATE = "package_private"
+IMPORT_SCOPE_MODULE_INTERNAL = "module_internal"
+
+# Kinds that are not declarations other packages could refer to
+_UNSCOPED_KINDS = ("import", "subtest")
+
+
+class GoExtractor(BaseExtractor):
+    """Extract functions, methods, types, values and imports from Go."""
+
+    symbol_kinds = {
+        "function_declaration": "function",
+        "method_declaration": "method",
+        "type_spec": "type",
+        "type_alias": "type",
+        "struct_type": "struct",
+        "interface_type": "interface",
+        "const_spec": "constant",
+        "var_spec": "variable",
+        "import_spec": "import",
+    }
+
+    string_literal_types = ("raw_string_literal", "interpreted_string_literal")
+
+    def extract(
+        self,
+        tree: tree_sitter.Tree,
+        source: bytes,
+        options: ExtractOptions,
+        path: Optional[str] = None
+    ) -> List[Symbol]:
+        """Extract Go symbols, nesting methods under their receiver type.
+
+        Declarations of kinds outside options.kind_filter aren't built.
+        """
+        symbols: List[Symbol] = []
+        types: Dict[str, Symbol] = {}
+        methods: List[Symbol] = []
+        underlying = _local_underlying_types(tree.root_node, source)
+
+        for node in tree.root_node.named_children:
+            if node.type == "function_declaration":
+                if self._wants(options, node.type, _function_kind(node, source)):
+                    symbols.append(self._function(node, source, options))
+            elif node.type == "method_declaration":
+                if self._wants(options, node.type, "method"):
+                    methods.append(self._method(node, source))
+            elif node.type == "type_declaration":
+                for spec in node.named_children:
+                    if spec.type not in ("type_spec", "type_alias"):
+                        continue
+                    kind = _type_kind(spec)
+                    # An unwanted type is still built for wanted members, which replace it after filtering
+                    members = _MEMBER_KINDS.get(kind, ())
+                    if self._wants(options, _kind_node(spec), kind) or any(options.wants(k) for k in members):
+                        sym = self._type(spec, source, options)
+                        types[sym.name] = sym
+                        symbols.append(sym)
+            elif node.type in ("const_declaration", "var_declaration"):
+                enum = iota_enum(node, source) if node.type == "const_declaration" else None
+                if enum is not None:
+                    wanted = options.wants("enum")
+                    if enum.enum_type in underlying:
+                        enum.underlying_type = _resolve_type(enum.enum_type, underlying)
+                elif node.type == "const_declaration":
+                    wanted = self._wants(options, "const_spec", "constant")
+                else:
+                    wanted = self._wants(options, "var_spec", "variable")
+                if wanted:
+                    symbols.extend([enum] if enum is not None else self._values(node, source))
+            elif node.type == "import_declaration":
+                if self._wants(options, "import_spec", "import"):
+                    symbols.extend(self._imports(node, source))
+
+        for method in methods:
+            parent = types.get(method.receiver or "")
+            if parent:
+                parent.children.append(method)
+            else:
+                symbols.append(method)
+
+        symbols.sort(key=lambda s: s.start_byte)
+        aliases = _import_aliases(tree.root_node, source)
+        _record_external_embeds(symbols, aliases)
+        _promoted_methods(symbols, aliases)
+        _type_params(tree.root_node, source, symbols, aliases)
+        _set_import_scopes(symbols, _in_internal_package(path))
+        _link_constructors(symbols, types)
+        _interface_assertions(tree.root_node, source, types)
+        if options.resolve_aliases:
+            _resolve_param_types(symbols, underlying)
+        if options.analyze_errors:
+            _analyze_errors(tree.root_node, source, symbols)
+        if options.analyze_panics:
+            _panic_sites(tree.root_node, source, symbols)
+        if options.analyze_goroutines:
+            mark_goroutine_leaks(tree.root_node, source, _functions_by_start(symbols))
+        if options.layout_arch:
+            _struct_layouts(tree.root_node, source, symbols, options.layout_arch)
+        if options.type_refs:
+            _type_refs(symbols)
+        if options.type_assertions:
+            _type_assertions(tree.root_node, source, symbols)
+        mark_deprecated(symbols, source)
+        if options.symbol_kinds:
+            _configure_kinds(tree.root_node, symbols, options.symbol_kinds, self.symbol_kinds)
+        return symbols
+
+    def _wants(self, options: ExtractOptions, node_type: str, kind: str) -> bool:
+        """Check kind_filter against the kind a declaration is emitted as, after symbol_kinds."""
+        if options.symbol_kinds and kind == self.symbol_kinds.get(node_type):
+            kind = options.symbol_kinds.get(node_type, kind)
+        return options.wants(kind)
+
+    def package_name(self, root: tree_sitter.Node, source: bytes) -> Optional[str]:
+        """Name in the file's `package` clause."""
+        for node in root.named_children:
+            if node.type == "package_clause":
+                ident = next((c for c in node.named_children if c.type == "package_identifier"), None)
+                return node_text(ident, source) if ident is not None else None
+        return None
+
+    def lint(self, root: tree_sitter.Node, source: bytes, options: ExtractOptions) -> List[Diagnostic]:
+        """Context misuse findings, with options.lint_context."""
+        if not options.lint_context:
+            return []
+        aliases = {alias for alias, path in _import_aliases(root, source).items() if path == "context"}
+        return context_diagnostics(root, source, aliases, options.tab_width)
+
+    def _function(
+        self,
+        node: tree_sitter.Node,
+        source: bytes,
+        options: ExtractOptions
+    ) -> Symbol:
+        """Build a symbol for a top-level function declaration (kind "init" for `func init`)."""
+        name = node_text(node.child_by_field_name("name"), source)
+        sym = make_symbol(
+            node,
+            name,
+            _function_kind(node, source),
+            signature=_signature(node, source),
+            exported=_is_exported(name),
+            params=_param_list(node.child_by_field_name("parameters"), source),
+            results=_result_list(node.child_by_field_name("result"), source),
+        )
+
+        sym.test_kind = _test_kind(node, name, source)
+        if options.include_subtests and sym.test_kind in ("test", "benchmark"):
+            param = _single_param_name(node.child_by_field_name("parameters"), source)
+            body = node.child_by_field_name("body")
+            if param and body:
+                sym.children.extend(_subtests(body, param, body, source))
+
+        return sym
+
+    def _method(self, node: tree_sitter.Node, source: bytes) -> Symbol:
+        """Build a symbol for a method declaration."""
+        name = node_text(node.child_by_field_name("name"), source)
+        return make_symbol(
+            node,
+            name,
+            "method",
+            signature=_signature(node, source),
+            exported=_is_exported(name),
+            receiver=_receiver_type(node.child_by_field_name("receiver"), source),
+            params=_param_list(node.child_by_field_name("parameters"), source),
+            results=_result_list(node.child_by_field_name("result"), source),
+        )
+
+    def _type(self, spec: tree_sitter.Node, source: bytes, options: ExtractOptions) -> Symbol:
+        """Build a symbol for a type spec, including struct fields and interface methods."""
+        name = node_text(spec.child_by_field_name("name"), source)
+        type_node = spec.child_by_field_name("type")
+        kind = _type_kind(spec)
+
+        sym = make_symbol(
+            spec,
+            name,
+            kind,
+            signature=_collapse(f"type {node_text(spec, source)}") if kind == "type" else None,
+            exported=_is_exported(name),
+        )
+
+        if kind == "struct":
+            if options.wants("field") or options.wants("embedded"):
+                sym.children.extend(_struct_fields(type_node, source))
+        elif kind == "interface":
+            sym.children.extend(_interface_elems(type_node, source))
+            unions = [c for c in sym.children if c.kind == "type_set"]
+            # Several type-set lines intersect, which a flat list can't express
+            if len(unions) == 1:
+                sym.type_set = list(unions[0].type_set)
+
+        return sym
+
+    def _values(self, node: tree_sitter.Node, source: bytes) -> List[Symbol]:
+        """Build symbols for every name in a const or var declaration."""
+        kind = "constant" if node.type == "const_declaration" else "variable"
+        symbols = []
+        for spec in _descendants_of_type(node, ("const_spec", "var_spec"), max_depth=2):
+            for name_node in spec.children_by_field_name("name"):
+                name = node_text(name_node, source)
+                if name == "_":
+                    continue
+                symbols.append(make_symbol(
+                    spec,
+                    name,
+                    kind,
+                    signature=_collapse(node_text(spec, source)),
+                    exported=_is_exported(name),
+                    children=_anonymous_structs(spec, source) if kind == "variable" else [],
+                ))
+        return symbols
+
+    def _imports(self, node: tree_sitter.Node, source: bytes) -> List[Symbol]:
+        """Build a symbol per import spec, named by import path."""
+        symbols = []
+        for spec in _descendants_of_type(node, ("import_spec",), max_depth=2):
+            path = spec.child_by_field_name("path")
+            if path is None:
+                continue
+            symbols.append(make_symbol(
+                spec,
+                _unquote(node_text(path, source)),
+                "import",
+                signature=node_text(spec, source),
+            ))
+        return symbols
+
+
+def _function_kind(node: tree_sitter.Node, source: bytes) -> str:
+    """Kind of a function declaration: package initializers can't be called
+    and may be declared any number of times, so they get a kind of their own."""
+    return "init" if node_text(node.child_by_field_name("name"), source) == "init" else "function"
+
+
+def _import_aliases(root: tree_sitter.Node, source: bytes) -> Dict[str, str]:
+    """Map the name each import is referred to by to its import path.
+
+    Unaliased imports are assumed to use the last path element as their
+    package name, which holds for everything but unusual module layouts.
+    Dot and blank imports are left out.
+    """
+    aliases = {}
+    for spec in _descendants_of_type(root, ("import_spec",), max_depth=3):
+        path_node = spec.child_by_field_name("path")
+        if path_node is None:
+            continue
+        path = _unquote(node_text(path_node, source))
+        name_node = spec.child_by_field_name("name")
+        alias = node_text(name_node, source) if name_node is not None else path.rsplit("/", 1)[-1]
+        if alias not in (".", "_"):
+            aliases[alias] = path
+    return aliases
+
+
+def _link_constructors(symbols: List[Symbol], types: Dict[str, Symbol]) -> None:
+    """Link constructor-style functions and the types they build.
+
+    A function is a constructor of a type declared in the file if it is
+    named `New` or `Make`, alone or followed by the type's name, and its
+    first result is the type or a pointer to it: `NewWorkerPool(n int)
+    *WorkerPool` or `New() (*Client, error)`.
+    """
+    for sym in symbols:
+        match = re.match(r"^(?:New|Make)(\w*)$", sym.name)
+        if sym.kind != "function" or match is None or not sym.results:
+            continue
+        type_name = re.sub(r"\[.*\]$", "", sym.results[0].type.lstrip("*").strip())
+        target = types.get(type_name)
+        if target is None or match.group(1) not in ("", type_name):
+            continue
+        sym.constructs = type_name
+        target.constructors.append(sym.name)
+
+
+def _interface_assertions(root: tree_sitter.Node, source: bytes, types: Dict[str, Symbol]) -> None:
+    """Record `var _ I = ...` assertions that a type satisfies an interface.
+
+    The asserted value is `(*T)(nil)`, `&T{...}` or `new(T)` for a pointer,
+    or `T{...}` or `T(nil)`. The relation is recorded on T and on I
+    when they are declared in the file, generic types by their bare name.
+    """
+    for decl in root.named_children:
+        if decl.type != "var_declaration":
+            continue
+        for spec in _descendants_of_type(decl, ("var_spec",), max_depth=2):
+            names = [node_text(n, source) for n in spec.children_by_field_name("name")]
+            interface_node = spec.child_by_field_name("type")
+            value = spec.child_by_field_name("value")
+            if names != ["_"] or interface_node is None or value is None:
+                continue
+            values = value.named_children if value.type == "expression_list" else [value]
+            asserted = _asserted_type(_collapse(node_text(values[0], source))) if len(values) == 1 else None
+            if asserted is None:
+                continue
+            type_name, pointer = asserted
+            interface = _collapse(node_text(interface_node, source))
+            target = types.get(_TYPE_ARGS.sub("", type_name))
+            if target is not None:
+                target.implements.append(Implementation(
+                    interface=interface,
+                    pointer=pointer,
+                    line=spec.start_point[0] + 1,
+                ))
+            declared = types.get(_TYPE_ARGS.sub("", interface))
+            if declared is not None and declared.kind == "interface":
+                declared.implemented_by.append(("*" if pointer else "") + type_name)
+
+
+def _asserted_type(value: str) -> Optional[Tuple[str, bool]]:
+    """Type of the value in an interface assertion and whether it's a pointer, or None."""
+    match = _ASSERTED_POINTER.match(value)
+    if match is not None:
+        return next(g for g in match.groups() if g is not None), True
+    match = _ASSERTED_VALUE.match(value)
+    return (match.group(1), False) if match is not None else None
+
+
+def _record_external_embeds(symbols: List[Symbol], aliases: Dict[str, str]) -> None:
+    """Record interface embeds of package-qualified types as external.
+
+    Their method sets are defined in another package, so the interface is
+    flagged unresolved: it has more methods than its children show.
+    """
+    for sym in symbols:
+        if sym.kind != "interface":
+            continue
+        for child in sym.children:
+            if child.kind != "embedded":
+                continue
+            match = _QUALIFIED_EMBED.match(child.name)
+            if match is None:
+                continue
+            qualifier, name = match.groups()
+            sym.embedded_external.append(EmbeddedExternal(package=aliases.get(qualifier, qualifier), name=name))
+            sym.unresolved = True
+
+
+def _promoted_methods(symbols: List[Symbol], aliases: Dict[str, str]) -> None:
+    """Record the methods each embedded field or interface promotes, in place.
+
+    Only embeds (fields without a name) promote methods; a named field whose
+    type is an interface is an ordinary field.
+    """
+    types = {s.name: s for s in symbols if s.kind in ("struct", "interface", "type")}
+    interfaces = {name: sym for name, sym in types.items() if sym.kind == "interface"}
+    for sym in types.values():
+        for child in sym.children:
+            if child.kind == "embedded":
+                child.promoted = _embedded_methods(child.signature or child.name, types, interfaces, aliases, set())
+
+
+def _embedded_methods(
+    type_text: str,
+    types: Dict[str, Symbol],
+    interfaces: Dict[str, Symbol],
+    aliases: Dict[str, str],
+    seen: Set[str]
+) -> List[str]:
+    """Methods of an embedded type: an interface's method set, or a local type's
+    methods plus those promoted through its own embeds."""
+    name = _TYPE_ARGS.sub("", type_text.lstrip("*"))
+    target = types.get(name)
+    if target is None or target.kind == "interface":
+        return _interface_methods(name, interfaces, aliases, set())
+    if name in seen:
+        return []
+    seen.add(name)
+    methods = []
+    for child in target.children:
+        if child.kind == "method":
+            methods.append(_RECEIVER.sub("", child.signature or ""))
+        elif child.kind == "embedded":
+            methods.extend(_embedded_methods(child.signature or child.name, types, interfaces, aliases, seen))
+    return _unique(methods)
+
+
+def _is_exported(name: str) -> bool:
+    """Go exports identifiers starting with an upper-case letter."""
+    return bool(name) and name[0].isupper()
+
+
+def _in_internal_package(path: Optional[str]) -> bool:
+    """Check whether a file lives under an `internal/` directory.
+
+    The go tool only allows such packages to be imported from within the
+    tree rooted at the parent of `internal`.
+    """
+    if not path:
+        return False
+    return "internal" in PurePath(path).parent.parts
+
+
+def _set_import_scopes(symbols: List[Symbol], internal: bool) -> None:
+    """Classify symbols as public, package-private or module-internal."""
+    for sym in symbols:
+        if sym.kind not in _UNSCOPED_KINDS:
+            if not sym.exported:
+                sym.import_scope = IMPORT_SCOPE_PACKAGE_PRIVATE
+            elif internal:
+                sym.import_scope = IMPORT_SCOPE_MODULE_INTERNAL
+            else:
+                sym.import_scope = IMPORT_SCOPE_PUBLIC
+        _set_import_scopes(sym.children, internal)
+
+
+def _signature(node: tree_sitter.Node, source: bytes) -> str:
+    """Source text of a declaration up to (not including) its body."""
+    body = node.child_by_field_name("body")
+    end = body.start_byte if body is not None else node.end_byte
+    return _collapse(source[node.start_byte:end].decode("utf8", errors="replace"))
+
+
+def _unquote(text: str) -> str:
+    """Strip the quotes from a Go string literal."""
+    if len(text) >= 2 and text[0] in "\"`" and text[-1] == text[0]:
+        return text[1:-1]
+    return text
+
+
+def _descendants_of_type(
+    node: tree_sitter.Node,
+    types: tuple,
+    max_depth: int
+) -> List[tree_sitter.Node]:
+    """Find named descendants of the given types within max_depth levels."""
+    found = []
+    for child in node.named_children:
+        if child.type in types:
+            found.append(child)
+        elif max_depth > 1:
+            found.extend(_descendants_of_type(child, types, max_depth - 1))
+    return found
+
+
+def _kind_node(spec: tree_sitter.Node) -> str:
+    """Node type deciding the kind of a declaration spec: the struct or interface type, else the spec."""
+    type_node = spec.child_by_field_name("type")
+    if spec.type == "type_spec" and type_node is not None and type_node.type in ("struct_type", "interface_type"):
+        return type_node.type
+    return spec.type
+
+
+def _configure_kinds(
+    root: tree_sitter.Node,
+    symbols: List[Symbol],
+    config: SymbolKindConfig,
+    defaults: SymbolKindConfig
+) -> None:
+    """Re-kind declarations per a symbol kind config, in place.
+
+    Only symbols still of their node type's default kind change, so e.g.
+    `func init` stays "init" when function declarations are re-kinded.
+    """
+    origins: Dict[int, str] = {}
+    for node in root.named_children:
+        if node.type in ("function_declaration", "method_declaration"):
+            origins[node.start_byte] = node.type
+        elif node.type in ("type_declaration", "const_declaration", "var_declaration", "import_declaration"):
+            specs = ("type_spec", "type_alias", "const_spec", "var_spec", "import_spec")
+            for spec in _descendants_of_type(node, specs, max_depth=2):
+                origins[spec.start_byte] = _kind_node(spec)
+
+    def visit(syms: List[Symbol]) -> None:
+        for sym in syms:
+            node_type = origins.get(sym.start_byte)
+            if node_type in config and sym.kind == defaults[node_type]:
+                sym.kind = config[node_type]
+            visit(sym.children)
+
+    visit(symbols)
+
+
+def _type_kind(spec: tree_sitter.Node) -> str:
+    """Kind of the symbol a type_spec or type_alias declares."""
+    type_node = spec.child_by_field_name("type")
+    if type_node is not None and spec.type == "type_spec":
+        if type_node.type == "struct_type":
+            return "struct"
+        if type_node.type == "interface_type":
+            return "interface"
+    return "type"
+
+
+def _receiver_type(receiver: Optional[tree_sitter.Node], source: bytes) -> Optional[str]:
+    """Get the base type name of a method receiver, e.g. `(p *Pool[T])` -> `Pool`."""
+    if receiver is None:
+        return None
+    for param in receiver.named_children:
+        if param.type == "parameter_declaration":
+            type_node = param.child_by_field_name("type")
+            if type_node is not None:
+                match = re.match(r"\*?\s*([A-Za-z_]\w*)", node_text(type_node, source))
+                if match:
+                    return match.group(1)
+    return None
+
+
+def _struct_fields(struct_type: tree_sitter.Node, source: bytes) -> List[Symbol]:
+    """Build symbols for the fields of a struct type."""
+    fields = []
+    for decl in _descendants_of_type(struct_type, ("field_declaration",), max_depth=2):
+        type_node = decl.child_by_field_name("type")
+        type_text = node_text(type_node, source) if type_node is not None else ""
+        tag = decl.child_by_field_name("tag")
+        attributes = _tag_attributes(node_text(tag, source)) if tag is not None else []
+        names = decl.children_by_field_name("name")
+        if not names:
+            # Embedded field: named after its (unqualified, uninstantiated) type
+            embedded = _TYPE_ARGS.sub("", type_text.lstrip("*")).split(".")[-1]
+            fields.append(make_symbol(
+                decl,
+                embedded,
+                "embedded",
+                signature=type_text,
+                exported=_is_exported(embedded),
+                attributes=attributes,
+            ))
+            continue
+        for name_node in names:
+            name = node_text(name_node, source)
+            fields.append(make_symbol(
+                decl,
+                name,
+                "field",
+                signature=_collapse(f"{name} {type_text}"),
+                exported=_is_exported(name),
+                attributes=list(attributes),
+                children=_anonymous_structs(type_node, source) if type_node is not None else [],
+            ))
+    return fields
+
+
+def _anonymous_structs(node: tree_sitter.Node, source: bytes) -> List[Symbol]:
+    """Symbols for the outermost struct types written inline under node, e.g. in
+    `var cases = []struct{ in, want string }{...}`, with their fields as children.
+
+    Structs nested in these become children of their fields. Function
+    literals' local types aren't declarations of the file and are left out.
+    """
+    structs = []
+    for child in node.named_children:
+        if child.type == "struct_type":
+            structs.append(make_symbol(
+                child,
+                "struct",
+                "anonymous_struct",
+                signature=_collapse(node_text(child, source)),
+                children=_struct_fields(child, source),
+            ))
+        elif child.type not in ("func_literal", "type_spec", "type_alias"):
+            structs.extend(_anonymous_structs(child, source))
+    return structs
+
+
+def _tag_attributes(tag_literal: str) -> List[Attribute]:
+    """Split a struct tag literal into one attribute per key.
+
+    `json:"id,omitempty" db:"user_id"` becomes json(id, omitempty) and
+    db(user_id); the raw form of each is its `key:"value"` pair.
+    """
+    tag = _unquote(tag_literal)
+    if tag_literal.startswith('"'):
+        # Interpreted literals escape the inner quotes: "json:\"id\""
+        tag = tag.replace('\\"', '"')
+    attributes = []
+    for match in _TAG_PAIR.finditer(tag):
+        key, value = match.groups()
+        attributes.append(Attribute(
+            name=key,
+            args=value.split(",") if value else [],
+            raw=match.group(0),
+        ))
+    return attributes
+
+
+def _interface_elems(interface_type: tree_sitter.Node, source: bytes) -> List[Symbol]:
+    """Build symbols for interface methods and embedded interfaces."""
+    elems = []
+    for child in interface_type.named_children:
+        if child.type in ("method_elem", "method_spec"):
+            name = node_text(child.child_by_field_name("name"), source)
+            elems.append(make_symbol(
+                child,
+                name,
+                "method",
+                signature=_collapse(node_text(child, source)),
+                exported=_is_exported(name),
+                params=_param_list(child.child_by_field_name("parameters"), source),
+                results=_result_list(child.child_by_field_name("result"), source),
+            ))
+        elif child.type in ("type_elem", "constraint_elem", "interface_type_name"):
+            text = _collapse(node_text(child, source))
+            terms = _type_set_terms(child, source)
+            if _is_type_set(terms):
+                elems.append(make_symbol(child, text, "type_set", signature=text, type_set=terms))
+                continue
+            elems.append(make_symbol(
+                child,
+                text,
+                "embedded",
+                signature=text,
+                exported=_is_exported(text.split(".")[-1]),
+            ))
+    return elems
+
+
+# Type arguments of an instantiated generic type, e.g. `[T]` in `Container[T]`
+_TYPE_ARGS = re.compile(r"\[.*\]$")
+
+# Methods of interfaces from outside the file that constraints commonly embed,
+# by import path and name
+_KNOWN_INTERFACE_METHODS = {
+    "error": ["Error() string"],
+    "fmt.Stringer": ["String() string"],
+    "fmt.GoStringer": ["GoString() string"],
+    "io.Reader": ["Read(p []byte) (n int, err error)"],
+    "io.Writer": ["Write(p []byte) (n int, err error)"],
+    "io.Closer": ["Close() error"],
+    "encoding.TextMarshaler": ["MarshalText() (text []byte, err error)"],
+    "encoding.TextUnmarshaler": ["UnmarshalText(text []byte) error"],
+    "sort.Interface": ["Len() int", "Less(i, j int) bool", "Swap(i, j int)"],
+}
+
+
+def _type_params(
+    root: tree_sitter.Node,
+    source: bytes,
+    symbols: List[Symbol],
+    aliases: Dict[str, str]
+) -> None:
+    """Set type_params on generic top-level functions and types, in place."""
+    by_start = {s.start_byte: s for s in symbols if s.kind != "method"}
+    interfaces = {s.name: s for s in symbols if s.kind == "interface"}
+    for node in root.named_children:
+        if node.type == "function_declaration":
+            declarations = [node]
+        elif node.type == "type_declaration":
+            declarations = [c for c in node.named_children if c.type in ("type_spec", "type_alias")]
+        else:
+            continue
+        for decl in declarations:
+            sym = by_start.get(decl.start_byte)
+            params = decl.child_by_field_name("type_parameters")
+            if sym is None or params is None:
+                continue
+            for param in params.named_children:
+                if param.type not in ("type_parameter_declaration", "parameter_declaration"):
+                    continue
+                constraint = param.child_by_field_name("type")
+                if constraint is None:
+                    continue
+                methods = _constraint_methods(constraint, source, interfaces, aliases)
+                for name in param.children_by_field_name("name"):
+                    sym.type_params.append(TypeParam(
+                        name=node_text(name, source),
+                        constraint=_collapse(node_text(constraint, source)),
+                        methods=list(methods),
+                    ))
+
+
+def _constraint_methods(
+    constraint: tree_sitter.Node,
+    source: bytes,
+    interfaces: Dict[str, Symbol],
+    aliases: Dict[str, str]
+) -> List[str]:
+    """Methods a type parameter constraint requires.
+
+    Only a constraint made of a single term can require methods: an inline
+    interface, or the name of a local or well-known interface. Unions never do.
+    """
+    terms = [c for c in constraint.named_children if c.type != "comment"]
+    if constraint.type not in ("type_constraint", "type_elem", "constraint_elem"):
+        terms = [constraint]
+    if len(terms) != 1:
+        return []
+    term = terms[0]
+    if term.type == "interface_type":
+        methods: List[str] = []
+        for elem in _interface_elems(term, source):
+            if elem.kind == "method":
+                methods.append(elem.signature)
+            elif elem.kind == "embedded":
+                methods.extend(_interface_methods(elem.name, interfaces, aliases, set()))
+        return _unique(methods)
+    return _interface_methods(_collapse(node_text(term, source)), interfaces, aliases, set())
+
+
+def _interface_methods(
+    name: str,
+    interfaces: Dict[str, Symbol],
+    aliases: Dict[str, str],
+    seen: Set[str]
+) -> List[str]:
+    """Methods of a named interface, following embedded interfaces."""
+    name = _TYPE_ARGS.sub("", name)
+    if name in seen:
+        return []
+    seen.add(name)
+    qualifier, _, base = name.rpartition(".")
+    if qualifier:
+        return list(_KNOWN_INTERFACE_METHODS.get(f"{aliases.get(qualifier, qualifier)}.{base}", []))
+    if name not in interfaces:
+        return list(_KNOWN_INTERFACE_METHODS.get(name, []))
+    methods: List[str] = []
+    for child in interfaces[name].children:
+        if child.kind == "method":
+            methods.append(child.signature)
+        elif child.kind == "embedded":
+            methods.extend(_interface_methods(child.name, interfaces, aliases, seen))
+    return _unique(methods)
+
+
+def _unique(items: List[str]) -> List[str]:
+    """Items without repeats, in first-seen order."""
+    return list(dict.fromkeys(items))
+
+
+# Predeclared types that can't be embedded as interfaces, so always form a type set
+_PREDECLARED_TYPES = frozenset((
+    "bool", "string", "int", "int8", "int16", "int32", "int64",
+    "uint", "uint8", "uint16", "uint32", "uint64", "uintptr",
+    "byte", "rune", "float32", "float64", "complex64", "complex128",
+))
+
+# A possibly qualified or instantiated type name, which may be an embedded interface
+_TYPE_NAME_TERM = re.compile(r"^[\w.]+(\[.*\])?$")
+
+
+def _type_set_terms(elem: tree_sitter.Node, source: bytes) -> List[TypeSetElement]:
+    """Split an interface element into its union terms."""
+    if elem.type == "interface_type_name":
+        return [TypeSetElement(type=_collapse(node_text(elem, source)))]
+    terms = []
+    for term in elem.named_children:
+        if term.type == "comment":
+            continue
+        # `~T` is a negated_type (constraint_term in older grammars) wrapping T
+        approximate = any(c.type == "~" for c in term.children)
+        base = term.named_children[-1] if approximate and term.named_children else term
+        terms.append(TypeSetElement(type=_collapse(node_text(base, source)), approximate=approximate))
+    return terms
+
+
+def _is_type_set(terms: List[TypeSetElement]) -> bool:
+    """Whether an interface element restricts the type set rather than embedding an interface.
+
+    A lone, plain type name is ambiguous without type information and is
+    reported as embedded unless it's a predeclared non-interface type.
+    """
+    if len(terms) != 1:
+        return bool(terms)
+    term = terms[0]
+    if term.approximate or term.type in _PREDECLARED_TYPES:
+        return True
+    # Literal types such as []byte or map[string]int can only be type-set terms
+    return not _TYPE_NAME_TERM.match(term.type)
+
+
+def _params(parameters: Optional[tree_sitter.Node]) -> List[tree_sitter.Node]:
+    """Parameter declarations of a parameter list."""
+    if parameters is None:
+        return []
+    return [
+        p for p in parameters.named_children
+        if p.type in ("parameter_declaration", "variadic_parameter_declaration")
+    ]
+
+
+def _param_list(parameters: Optional[tree_sitter.Node], source: bytes) -> List[Param]:
+    """Build Params for a parameter list, one per declared name."""
+    params = []
+    for decl in _params(parameters):
+        type_node = decl.child_by_field_name("type")
+        type_text = _collapse(node_text(type_node, source)) if type_node is not None else ""
+        if decl.type == "variadic_parameter_declaration":
+            type_text = f"...{type_text}"
+        names = decl.children_by_field_name("name")
+        if not names:
+            params.append(Param(name=None, type=type_text))
+        for name_node in names:
+            params.append(Param(name=node_text(name_node, source), type=type_text))
+    return params
+
+
+def _result_list(result: Optional[tree_sitter.Node], source: bytes) -> List[Param]:
+    """Build Params for a function result (a single type or a parameter list)."""
+    if result is None:
+        return []
+    if result.type == "parameter_list":
+        return _param_list(result, source)
+    return [Param(name=None, type=_collapse(node_text(result, source)))]
+
+
+def _local_underlying_types(root: tree_sitter.Node, source: bytes) -> Dict[str, str]:
+    """Map locally declared alias and defined type names to their underlying types.
+
+    Struct and interface types are left out: for those the declared name is
+    the meaningful type and the underlying literal adds nothing.
+    """
+    underlying = {}
+    for decl in root.named_children:
+        if decl.type != "type_declaration":
+            continue
+        for spec in decl.named_children:
+            if spec.type not in ("type_spec", "type_alias"):
+                continue
+            if spec.child_by_field_name("type_parameters") is not None:
+                continue
+            name_node = spec.child_by_field_name("name")
+            type_node = spec.child_by_field_name("type")
+            if name_node is None or type_node is None:
+                continue
+            if type_node.type in ("struct_type", "interface_type"):
+                continue
+            underlying[node_text(name_node, source)] = _collapse(node_text(type_node, source))
+    return underlying
+
+
+# Identifiers in a type expression that are not package-qualified selectors
+_TYPE_NAME = re.compile(r"(?<![\w.])([A-Za-z_]\w*)(?![\w.])")
+
+
+def _resolve_type(type_text: str, underlying: Dict[str, str]) -> str:
+    """Substitute local type names with their underlying types until stable."""
+    resolved = type_text
+    # Bounded to cope with (invalid) cyclic declarations
+    for _ in range(len(underlying) + 1):
+        replaced = _TYPE_NAME.sub(lambda m: underlying.get(m.group(1), m.group(1)), resolved)
+        if replaced == resolved:
+            break
+        resolved = replaced
+    return resolved
+
+
+def _resolve_param_types(symbols: List[Symbol], underlying: Dict[str, str]) -> None:
+    """Fill in Param.resolved_type for params using local aliases or defined types."""
+    if not underlying:
+        return
+    for sym in symbols:
+        for param in sym.params + sym.results:
+            resolved = _resolve_type(param.type, underlying)
+            if resolved != param.type:
+                param.resolved_type = resolved
+        _resolve_param_types(sym.children, underlying)
+
+
+def _type_refs(symbols: List[Symbol]) -> None:
+    """Decompose the types of params, results and struct fields into TypeRefs."""
+    for sym in symbols:
+        for param in sym.params + sym.results:
+            param.type_ref = _type_ref(param.type)
+        if sym.kind == "field" and sym.signature:
+            sym.type_ref = _type_ref(sym.signature[len(sym.name):])
+        elif sym.kind == "embedded" and sym.signature:
+            sym.type_ref = _type_ref(sym.signature)
+        _type_refs(sym.children)
+
+
+def _type_ref(type_text: str) -> Optional[TypeRef]:
+    """TypeRef of a type, or None for text that isn't one (e.g. in code with syntax errors)."""
+    try:
+        return parse_type(type_text)
+    except ValueError:
+        return None
+
+
+def _functions_by_start(symbols: List[Symbol]) -> Dict[int, Symbol]:
+    """Map start byte to each function, method and init, top-level or nested one level under a symbol."""
+    by_start = {}
+    for sym in symbols:
+        for candidate in [sym] + sym.children:
+            if candidate.kind in ("function", "method", "init"):
+                by_start[candidate.start_byte] = candidate
+    return by_start
+
+
+def _type_assertions(root: tree_sitter.Node, source: bytes, symbols: List[Symbol]) -> None:
+    """Set type_assertions on top-level functions and methods.
+
+    Types come from `x.(T)` assertions and the cases of type switches
+    anywhere in the body, closures included, each once in source order.
+    `nil` cases aren't types and are skipped.
+    """
+    by_start = _functions_by_start(symbols)
+
+    for node in root.named_children:
+        sym = by_start.get(node.start_byte)
+        body = node.child_by_field_name("body")
+        if sym is None or body is None or node.type not in ("function_declaration", "method_declaration"):
+            continue
+        seen = set()
+        stack = [body]
+        while stack:
+            current = stack.pop()
+            stack.extend(reversed(current.named_children))
+            if current.type == "type_assertion_expression":
+                asserted = current.children_by_field_name("type")
+            elif current.type == "type_case":
+                asserted = current.children_by_field_name("type")
+            else:
+                continue
+            for type_node in asserted:
+                text = _collapse(node_text(type_node, source))
+                type_ref = _type_ref(text) if text != "nil" and text not in seen else None
+                if type_ref is not None:
+                    seen.add(text)
+                    sym.type_assertions.append(type_ref)
+
+
+def _struct_layouts(root: tree_sitter.Node, source: bytes, symbols: List[Symbol], arch: str) -> None:
+    """Attach estimated memory layouts to top-level struct symbols."""
+    local_types: Dict[str, tree_sitter.Node] = {}
+    structs: Dict[int, tree_sitter.Node] = {}
+    for decl in root.named_children:
+        if decl.type != "type_declaration":
+            continue
+        for spec in decl.named_children:
+            name_node = spec.child_by_field_name("name")
+            type_node = spec.child_by_field_name("type")
+            if name_node is None or type_node is None:
+                continue
+            if spec.child_by_field_name("type_parameters") is None:
+                local_types[node_text(name_node, source)] = type_node
+            if type_node.type == "struct_type":
+                structs[spec.start_byte] = type_node
+
+    layout = GoLayout(arch, local_types, source)
+    for sym in symbols:
+        if sym.kind == "struct" and sym.start_byte in structs:
+            sym.layout = layout.struct_layout(structs[sym.start_byte])
+
+
+def _analyze_errors(root: tree_sitter.Node, source: bytes, symbols: List[Symbol]) -> None:
+    """Set returns_error and error_sites on top-level functions and methods.
+
+    Error types are `error` itself and local types with an `Error() string`
+    method. Sites are found anywhere in the body, including closures, so a
+    deferred recover handler counts towards its enclosing function.
+    """
+    error_types = _local_error_types(root, source)
+    aliases = _import_aliases(root, source)
+    by_start = _functions_by_start(symbols)
+
+    for node in root.named_children:
+        sym = by_start.get(node.start_byte)
+        if sym is None or node.type not in ("function_declaration", "method_declaration"):
+            continue
+        sym.returns_error = any(
+            r.type == "error" or r.type.lstrip("*") in error_types for r in sym.results
+        )
+        body = node.child_by_field_name("body")
+        if body is not None:
+            sym.error_sites = _error_sites(body, source, error_types, aliases)
+
+
+def _panic_sites(root: tree_sitter.Node, source: bytes, symbols: List[Symbol]) -> None:
+    """Set panics and recovers on top-level functions and methods.
+
+    Calls are found anywhere in the body, closures included. A call is
+    deferred when the innermost closure around it is the function of a
+    `defer` statement, as in `defer func() { recover() }()`.
+    """
+    by_start = _functions_by_start(symbols)
+
+    for node in root.named_children:
+        sym = by_start.get(node.start_byte)
+        body = node.child_by_field_name("body")
+        if sym is None or body is None or node.type not in ("function_declaration", "method_declaration"):
+            continue
+        stack = [body]
+        while stack:
+            current = stack.pop()
+            stack.extend(reversed(current.named_children))
+            function = current.child_by_field_name("function") if current.type == "call_expression" else None
+            if function is None or function.type != "identifier":
+                continue
+            name = node_text(function, source)
+            if name not in ("panic", "recover"):
+                continue
+            site = PanicSite(
+                expression=_collapse(node_text(current, source)),
+                line=current.start_point[0] + 1,
+                deferred=_in_deferred_closure(current, body),
+            )
+            if name == "panic":
+                sym.panics.append(site)
+            else:
+                sym.recovers.append(site)
+
+
+def _in_deferred_closure(node: tree_sitter.Node, body: tree_sitter.Node) -> bool:
+    """Check if the innermost func literal around node (within body) is deferred."""
+    current = node.parent
+    while current is not None and current != body:
+        if current.type == "func_literal":
+            call = current.parent
+            return (
+                call is not None and call.type == "call_expression" and call.child_by_field_name("function") == current
+                and call.parent is not None and call.parent.type == "defer_statement"
+            )
+        current = current.parent
+    return False
+
+
+def _local_error_types(root: tree_sitter.Node, source: bytes) -> Set[str]:
+    """Names of local types with an `Error() string` method."""
+    names = set()
+    for node in root.named_children:
+        if node.type != "method_declaration":
+            continue
+        name = node_text(node.child_by_field_name("name"), source)
+        result = node.child_by_field_name("result")
+        params = _params(node.child_by_field_name("parameters"))
+        if name == "Error" and not params and result is not None and node_text(result, source) == "string":
+            receiver = _receiver_type(node.child_by_field_name("receiver"), source)
+            if receiver:
+                names.add(receiver)
+    return names
+
+
+def _error_sites(
+    body: tree_sitter.Node,
+    source: bytes,
+    error_types: Set[str],
+    aliases: Dict[str, str]
+) -> List[ErrorSite]:
+    """Find errors.New, fmt.Errorf and error type literals under a node."""
+    sites = []
+    stack = [body]
+    while stack:
+        node = stack.pop()
+        stack.extend(reversed(node.named_children))
+        kind, type_name, wraps = _error_construction(node, source, error_types, aliases)
+        if kind is not None:
+            sites.append(ErrorSite(
+                kind=kind,
+                expression=_collapse(node_text(node, source)),
+                line=node.start_point[0] + 1,
+                type=type_name,
+                wraps=wraps,
+            ))
+    return sites
+
+
+def _error_construction(
+    node: tree_sitter.Node,
+    source: bytes,
+    error_types: Set[str],
+    aliases: Dict[str, str]
+) -> Tuple[Optional[str], Optional[str], bool]:
+    """Classify a node as an error construction: (kind, custom type, wraps)."""
+    if node.type == "composite_literal":
+        type_node = node.child_by_field_name("type")
+        type_name = node_text(type_node, source) if type_node is not None else ""
+        if type_name in error_types:
+            return "custom", type_name, False
+        return None, None, False
+
+    if node.type != "call_expression":
+        return None, None, False
+    function = node.child_by_field_name("function")
+    if function is None or function.type != "selector_expression":
+        return None, None, False
+    package = aliases.get(node_text(function.child_by_field_name("operand"), source))
+    name = node_text(function.child_by_field_name("field"), source)
+    if package == "errors" and name == "New":
+        return "errors.New", None, False
+    if package == "fmt" and name == "Errorf":
+        args = node.child_by_field_name("arguments")
+        fmt_arg = args.named_children[0] if args is not None and args.named_children else None
+        wraps = (
+            fmt_arg is not None
+            and fmt_arg.type in _STRING_LITERALS
+            and "%w" in node_text(fmt_arg, source)
+        )
+        return "fmt.Errorf", None, wraps
+    return None, None, False
+
+
+def _single_param_name(parameters: Optional[tree_sitter.Node], source: bytes) -> Optional[str]:
+    """Name of the only parameter in a list, if there is exactly one."""
+    params = _params(parameters)
+    if len(params) != 1:
+        return None
+    names = params[0].children_by_field_name("name")
+    return node_text(names[0], source) if len(names) == 1 else None
+
+
+def _test_kind(node: tree_sitter.Node, name: str, source: bytes) -> Optional[str]:
+    """Classify a function as a test, benchmark, fuzz target or example.
+
+    Follows the `go test` rules: the prefix must be followed by nothing or a
+    non-lower-case character, and the function must take the matching
+    `*testing.X` parameter (examples take none) and return nothing.
+    """
+    if node.child_by_field_name("result") is not None:
+        return None
+    if node.child_by_field_name("type_parameters") is not None:
+        return None
+
+    for prefix, kind, param_type in _TEST_PREFIXES:
+        if not name.startswith(prefix):
+            continue
+        rest = name[len(prefix):]
+        if rest and rest[0].islower():
+            return None
+
+        params = _params(node.child_by_field_name("parameters"))
+        if param_type is None:
+            return kind if not params else None
+        if len(params) != 1:
+            return None
+        type_node = params[0].child_by_field_name("type")
+        if type_node is not None and _collapse(node_text(type_node, source)) == param_type:
+            return kind
+        return None
+
+    return None
+
+
+def _subtests(
+    node: tree_sitter.Node,
+    runner: str,
+    scope: tree_sitter.Node,
+    source: bytes
+) -> List[Symbol]:
+    """Find `<runner>.Run(name, func(...))` calls beneath node.
+
+    Subtests nested inside a subtest's function literal become its children.
+    Names come from string literals or, best-effort, from the table a
+    surrounding `range` loop iterates over; anything else is reported using
+    the name expression's source text.
+    """
+    found: List[Symbol] = []
+    for child in node.named_children:
+        if child.type == "call_expression" and _is_run_call(child, runner, source):
+            found.extend(_subtest_symbols(child, scope, source))
+        else:
+            found.extend(_subtests(child, runner, scope, source))
+    return found
+
+
+def _is_run_call(call: tree_sitter.Node, runner: str, source: bytes) -> bool:
+    """Check whether a call is `<runner>.Run(...)`."""
+    func = call.child_by_field_name("function")
+    if func is None or func.type != "selector_expression":
+        return False
+    operand = func.child_by_field_name("operand")
+    field_node = func.child_by_field_name("field")
+    return (
+        operand is not None
+        and field_node is not None
+        and node_text(operand, source) == runner
+        and node_text(field_node, source) == "Run"
+    )
+
+
+def _subtest_symbols(call: tree_sitter.Node, scope: tree_sitter.Node, source: bytes) -> List[Symbol]:
+    """Build subtest symbols for a single Run call."""
+    args = call.child_by_field_name("arguments")
+    arg_nodes = [a for a in args.named_children if a.type != "comment"] if args else []
+    if not arg_nodes:
+        return []
+
+    children: List[Symbol] = []
+    if len(arg_nodes) > 1 and arg_nodes[1].type == "func_literal":
+        literal = arg_nodes[1]
+        inner = _single_param_name(literal.child_by_field_name("parameters"), source)
+        body = literal.child_by_field_name("body")
+        if inner and body:
+            children = _subtests(body, inner, scope, source)
+
+    name_node = arg_nodes[0]
+    if name_node.type in _STRING_LITERALS:
+        sym = make_symbol(call, _unquote(node_text(name_node, source)), "subtest", test_kind="test")
+        sym.children = children
+        return [sym]
+
+    table = _table_names(name_node, call, scope, source)
+    if table:
+        return [
+            make_symbol(entry, name, "subtest", test_kind="test", children=list(children))
+            for name, entry in table
+        ]
+
+    sym = make_symbol(call, node_text(name_node, source), "subtest", test_kind="test")
+    sym.children = children
+    return [sym]
+
+
+def _table_names(
+    name_node: tree_sitter.Node,
+    call: tree_sitter.Node,
+    scope: tree_sitter.Node,
+    source: bytes
+) -> List[tuple]:
+    """Resolve subtest names from a table-driven `for ... range` loop.
+
+    Handles `for _, tc := range tests { t.Run(tc.name, ...) }` over a slice
+    literal and `for name, tc := range tests { t.Run(name, ...) }` over a map
+    literal, where `tests` is the literal itself or a variable declared in the
+    enclosing function. Returns (name, entry node) pairs.
+    """
+    loop = call.parent
+    while loop is not None and loop.type != "for_statement":
+        loop = loop.parent
+    if loop is None:
+        return []
+
+    clause = next((c for c in loop.named_children if c.type == "range_clause"), None)
+    if clause is None:
+        return []
+    left = clause.child_by_field_name("left")
+    right = clause.child_by_field_name("right")
+    if left is None or right is None:
+        return []
+    loop_vars = [node_text(v, source) for v in left.named_children]
+
+    literal = right if right.type == "composite_literal" else _find_table(right, scope, source)
+    if literal is None:
+        return []
+    body = literal.child_by_field_name("body")
+    if body is None:
+        return []
+
+    names = []
+    if name_node.type == "selector_expression":
+        operand = name_node.child_by_field_name("operand")
+        field_node = name_node.child_by_field_name("field")
+        if operand is None or field_node is None or node_text(operand, source) not in loop_vars[1:]:
+            return []
+        wanted = node_text(field_node, source)
+        for entry in _elements(body):
+            value = _literal_value(entry)
+            if value is None:
+                continue
+            for keyed in value.named_children:
+                key, val = _keyed_parts(keyed)
+                if key is not None and node_text(key, source).strip() == wanted and val.type in _STRING_LITERALS:
+                    names.append((_unquote(node_text(val, source)), entry))
+    elif name_node.type == "identifier" and loop_vars and node_text(name_node, source) == loop_vars[0]:
+        for entry in _elements(body):
+            key, _ = _keyed_parts(entry)
+            if key is not None and key.type in _STRING_LITERALS:
+                names.append((_unquote(node_text(key, source)), entry))
+    return names
+
+
+def _find_table(
+    ident: tree_sitter.Node,
+    scope: tree_sitter.Node,
+    source: bytes
+) -> Optional[tree_sitter.Node]:
+    """Find the composite literal assigned to a variable within scope."""
+    if ident.type != "identifier":
+        return None
+    wanted = node_text(ident, source)
+    for decl in _descendants_of_type(scope, ("short_var_declaration", "var_spec"), max_depth=3):
+        if decl.type == "short_var_declaration":
+            names = decl.child_by_field_name("left")
+            values = decl.child_by_field_name("right")
+            name_nodes = names.named_children if names is not None else []
+        else:
+            values = decl.child_by_field_name("value")
+            name_nodes = decl.children_by_field_name("name")
+        if values is None or not name_nodes or node_text(name_nodes[0], source) != wanted:
+            continue
+        value = values.named_children[0] if values.named_children else None
+        if value is not None and value.type == "composite_literal":
+            return value
+    return None
+
+
+def _elements(literal_value: tree_sitter.Node) -> List[tree_sitter.Node]:
+    """Elements of a literal value, skipping comments."""
+    return [c for c in literal_value.named_children if c.type != "comment"]
+
+
+def _unwrap(node: tree_sitter.Node) -> tree_sitter.Node:
+    """Unwrap grammar wrapper nodes around literal elements."""
+    while node.type in ("literal_element", "element") and node.named_children:
+        node = node.named_children[0]
+    return node
+
+
+def _literal_value(entry: tree_sitter.Node) -> Optional[tree_sitter.Node]:
+    """Get the `{...}` literal value of a table entry."""
+    entry = _unwrap(entry)
+    if entry.type == "literal_value":
+        return entry
+    if entry.type == "composite_literal":
+        return entry.child_by_field_name("body")
+    if entry.type == "keyed_element":
+        _, value = _keyed_parts(entry)
+        return _literal_value(value) if value is not None else None
+    return None
+
+
+def _keyed_parts(node: tree_sitter.Node) -> tuple:
+    """Split a keyed element into its (key, value) nodes."""
+    if node.type != "keyed_element":
+        return None, None
+    parts = [c for c in node.named_children if c.type != "comment"]
+    if len(parts) < 2:
+        return None, None
+    return _unwrap(parts[0]), _unwrap(parts[-1])
@@ -0,0 +1,180 @@
+"""Detection of common context.Context misuse in Go source."""
+
+import re
+from typing import List, Optional, Set
+
+import tree_sitter
+
+from mcp_code_parser.analysis.base import walk
+from mcp_code_parser.errors import Diagnostic, node_diagnostic
+from mcp_code_parser.extractors.base import node_text
+
+RULE_CONTEXT_IN_STRUCT = "go-context-in-struct"
+RULE_NIL_CONTEXT = "go-nil-context"
+RULE_LOST_CANCEL = "go-lost-cancel"
+
+# context functions returning a derived context and the func that releases it
+_CANCEL_FUNCS = (
+    "WithCancel", "WithCancelCause",
+    "WithDeadline", "WithDeadlineCause",
+    "WithTimeout", "WithTimeoutCause",
+)
+# context functions taking a parent context first
+_DERIVE_FUNCS = _CANCEL_FUNCS + ("WithValue", "WithoutCancel", "AfterFunc")
+# Standard library convention: FooContext(ctx, ...) is Foo with a context
+_CONTEXT_SUFFIX = re.compile(r"Context$")
+
+_FUNCTIONS = ("function_declaration", "method_declaration", "func_literal")
+
+
+def context_diagnostics(
+    root: tree_sitter.Node,
+    source: bytes,
+    context_aliases: Set[str],
+    tab_width: int
+) -> List[Diagnostic]:
+    """Find context misuse: contexts in struct fields, nil contexts and discarded cancel funcs.
+
+    Args:
+        root: Root node of the file's syntax tree
+        source: Source bytes the tree was parsed from
+        context_aliases: Names the "context" package is imported as
+        tab_width: Tab stop spacing for visual columns
+
+    Returns:
+        Diagnostics with a rule ID, in source order
+    """
+    if not context_aliases:
+        return []
+    lines = source.split(b"\n")
+    context_params = _context_first_functions(root, source, context_aliases)
+    diagnostics = []
+    for node in walk(root):
+        if node.type == "field_declaration":
+            diagnostic = _context_field(node, source, context_aliases)
+        elif node.type == "call_expression":
+            diagnostic = _nil_context(node, source, context_aliases, context_params)
+        elif node.type in ("short_var_declaration", "assignment_statement", "var_spec"):
+            diagnostic = _lost_cancel(node, source, context_aliases)
+        else:
+            continue
+        if diagnostic is not None:
+            at, message, rule = diagnostic
+            diagnostics.append(node_diagnostic(at, message, lines, tab_width, rule))
+    return diagnostics
+
+
+def _context_field(node: tree_sitter.Node, source: bytes, aliases: Set[str]):
+    """Finding for a struct field (named or embedded) of type context.Context."""
+    type_node = node.child_by_field_name("type")
+    if type_node is None or not _is_context_type(node_text(type_node, source), aliases):
+        return None
+    names = [node_text(n, source) for n in node.children_by_field_name("name")]
+    field = ", ".join(names) if names else "embedded context.Context"
+    return (
+        node,
+        f"context.Context stored in struct field {field}; pass it as the first parameter of the functions that need it",
+        RULE_CONTEXT_IN_STRUCT,
+    )
+
+
+def _nil_context(node: tree_sitter.Node, source: bytes, aliases: Set[str], context_params: Set[str]):
+    """Finding for nil passed where a call takes a context first."""
+    arguments = node.child_by_field_name("arguments")
+    function = node.child_by_field_name("function")
+    if arguments is None or function is None:
+        return None
+    args = [a for a in arguments.named_children if a.type != "comment"]
+    if not args or args[0].type != "nil":
+        return None
+
+    callee = node_text(function, source)
+    name = callee.rsplit(".", 1)[-1]
+    qualifier = callee.rsplit(".", 1)[0] if "." in callee else None
+    takes_context = (
+        (qualifier in aliases and name in _DERIVE_FUNCS)
+        or name in context_params
+        or (qualifier is not None and _CONTEXT_SUFFIX.search(name) is not None)
+    )
+    if not takes_context:
+        return None
+    return (
+        args[0],
+        f"nil passed as the context of {callee}; use context.TODO() or context.Background()",
+        RULE_NIL_CONTEXT,
+    )
+
+
+def _lost_cancel(node: tree_sitter.Node, source: bytes, aliases: Set[str]):
+    """Finding for a cancel func from a context.With* call that is discarded or never used."""
+    if node.type == "var_spec":
+        left = node.children_by_field_name("name")
+        right = node.child_by_field_name("value")
+    else:
+        left_list = node.child_by_field_name("left")
+        left = left_list.named_children if left_list is not None else []
+        right = node.child_by_field_name("right")
+    if right is None or len(left) != 2 or len(right.named_children) != 1:
+        return None
+
+    call = right.named_children[0]
+    function = call.child_by_field_name("function") if call.type == "call_expression" else None
+    if function is None or function.type != "selector_expression":
+        return None
+    operand = node_text(function.child_by_field_name("operand"), source)
+    name = node_text(function.child_by_field_name("field"), source)
+    if operand not in aliases or name not in _CANCEL_FUNCS:
+        return None
+
+    cancel = left[1]
+    cancel_name = node_text(cancel, source)
+    if cancel_name != "_":
+        scope = _enclosing_function(node)
+        if scope is None or _is_used(scope, cancel, cancel_name, source):
+            return None
+    return (
+        cancel,
+        f"the cancel function returned by context.{name} is not used; call it (e.g. defer {_suggested(cancel_name)}()) to release resources",
+        RULE_LOST_CANCEL,
+    )
+
+
+def _context_first_functions(root: tree_sitter.Node, source: bytes, aliases: Set[str]) -> Set[str]:
+    """Names of functions and methods in the file whose first parameter is a context.Context."""
+    names = set()
+    for node in root.named_children:
+        if node.type not in ("function_declaration", "method_declaration"):
+            continue
+        params = node.child_by_field_name("parameters")
+        first = next((p for p in (params.named_children if params is not None else []) if p.type == "parameter_declaration"), None)
+        type_node = first.child_by_field_name("type") if first is not None else None
+        if type_node is not None and _is_context_type(node_text(type_node, source), aliases):
+            names.add(node_text(node.child_by_field_name("name"), source))
+    return names
+
+
+def _is_context_type(text: str, aliases: Set[str]) -> bool:
+    """Check whether a type expression is context.Context under one of the package's names."""
+    qualifier, _, name = "".join(text.split()).partition(".")
+    return name == "Context" and qualifier in aliases
+
+
+def _enclosing_function(node: tree_sitter.Node) -> Optional[tree_sitter.Node]:
+    """Innermost function declaration or literal containing a node."""
+    current = node.parent
+    while current is not None and current.type not in _FUNCTIONS:
+        current = current.parent
+    return current
+
+
+def _is_used(scope: tree_sitter.Node, declared: tree_sitter.Node, name: str, source: bytes) -> bool:
+    """Check whether an identifier is referred to in a scope other than where it is declared."""
+    for node in walk(scope):
+        if node.type == "identifier" and node.start_byte != declared.start_byte and node_text(node, source) == name:
+            return True
+    return False
+
+
+def _suggested(name: str) -> str:
+    """Name to suggest calling the cancel func by."""
+    return name if name != "_" else "cancel"
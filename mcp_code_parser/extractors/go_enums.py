@@ -0,0 +1,142 @@
+"""Go `const` blocks counting with `iota`, the language's idiom for enums."""
+
+from typing import Dict, List, Optional
+
+import tree_sitter
+
+from mcp_code_parser.extractors.base import Symbol, make_symbol, node_text
+
+
+def iota_enum(node: tree_sitter.Node, source: bytes) -> Optional[Symbol]:
+    """Build an enum symbol for a const declaration whose values use iota.
+
+    The enum is named by the type written on its constants (`Color` in
+    `Red Color = iota`), or else by its first constant, and has a child
+    `enum_member` per constant with its computed value. As in Go, a spec
+    without values repeats the previous expressions with iota counting up,
+    and blank `_` names take a value but get no member. Values are computed
+    for integer arithmetic on literals, iota, earlier constants of the block
+    and conversions such as `Weekday(iota)`; other expressions leave them
+    None.
+
+    Returns:
+        The enum, or None for const declarations not using iota
+    """
+    specs = [c for c in node.named_children if c.type == "const_spec"]
+    if not any(_uses_iota(spec.child_by_field_name("value")) for spec in specs):
+        return None
+
+    values: Dict[str, int] = {}
+    members: List[Symbol] = []
+    expressions: List[tree_sitter.Node] = []
+    enum_type: Optional[str] = None
+    for iota, spec in enumerate(specs):
+        value_list = spec.child_by_field_name("value")
+        type_node = spec.child_by_field_name("type")
+        if value_list is not None:
+            expressions = value_list.named_children
+        if enum_type is None and type_node is not None:
+            enum_type = node_text(type_node, source)
+        for i, name_node in enumerate(spec.children_by_field_name("name")):
+            name = node_text(name_node, source)
+            value = _evaluate(expressions[i], source, iota, values) if i < len(expressions) else None
+            if name == "_":
+                continue
+            if value is not None:
+                values[name] = value
+            members.append(make_symbol(
+                spec,
+                name,
+                "enum_member",
+                signature=" ".join(node_text(spec, source).split()),
+                exported=name[:1].isupper(),
+                value=value,
+            ))
+
+    if not members:
+        return None
+    name = enum_type or members[0].name
+    return make_symbol(
+        node,
+        name,
+        "enum",
+        exported=name[:1].isupper(),
+        enum_type=enum_type,
+        children=members,
+    )
+
+
+def _uses_iota(node: Optional[tree_sitter.Node]) -> bool:
+    """Check whether an expression refers to iota."""
+    if node is None:
+        return False
+    if node.type == "iota" or (node.type == "identifier" and node.text == b"iota"):
+        return True
+    return any(_uses_iota(c) for c in node.named_children)
+
+
+def _evaluate(node: tree_sitter.Node, source: bytes, iota: int, values: Dict[str, int]) -> Optional[int]:
+    """Integer value of a constant expression, or None if it isn't integer arithmetic."""
+    if node.type == "int_literal":
+        return _int_literal(node_text(node, source))
+    if node.type == "iota" or node.type == "identifier":
+        name = node_text(node, source)
+        return iota if name == "iota" else values.get(name)
+    if node.type == "parenthesized_expression" and node.named_children:
+        return _evaluate(node.named_children[0], source, iota, values)
+    if node.type == "call_expression":
+        # A conversion like Weekday(iota + 1) keeps the value
+        arguments = node.child_by_field_name("arguments")
+        if arguments is not None and len(arguments.named_children) == 1:
+            return _evaluate(arguments.named_children[0], source, iota, values)
+        return None
+    if node.type == "unary_expression":
+        operand = node.child_by_field_name("operand")
+        value = _evaluate(operand, source, iota, values) if operand is not None else None
+        operator = node_text(node.child_by_field_name("operator"), source)
+        if value is None:
+            return None
+        return {"+": value, "-": -value, "^": ~value}.get(operator)
+    if node.type == "binary_expression":
+        left = _evaluate(node.child_by_field_name("left"), source, iota, values)
+        right = _evaluate(node.child_by_field_name("right"), source, iota, values)
+        if left is None or right is None:
+            return None
+        return _binary(node_text(node.child_by_field_name("operator"), source), left, right)
+    return None
+
+
+def _binary(operator: str, left: int, right: int) -> Optional[int]:
+    """Apply a Go integer operator; division truncates toward zero as in Go."""
+    if operator in ("/", "%"):
+        if right == 0:
+            return None
+        quotient = abs(left) // abs(right) * (1 if (left < 0) == (right < 0) else -1)
+        return quotient if operator == "/" else left - right * quotient
+    if operator in ("<<", ">>") and right < 0:
+        return None
+    operations = {
+        "+": lambda: left + right,
+        "-": lambda: left - right,
+        "*": lambda: left * right,
+        "<<": lambda: left << right,
+        ">>": lambda: left >> right,
+        "&": lambda: left & right,
+        "|": lambda: left | right,
+        "^": lambda: left ^ right,
+        "&^": lambda: left & ~right,
+    }
+    operation = operations.get(operator)
+    return operation() if operation is not None else None
+
+
+def _int_literal(text: str) -> Optional[int]:
+    """Value of a Go integer literal, e.g. 0x1F, 0o17, 017 or 1_000."""
+    digits = text.replace("_", "")
+    try:
+        if len(digits) > 1 and digits[0] == "0" and digits[1].isdigit():
+            # Legacy octal
+            return int(digits, 8)
+        return int(digits, 0)
+    except ValueError:
+        return None
@@ -0,0 +1,152 @@
+"""Heuristic detection of Go goroutines that may never stop."""
+
+import re
+from typing import Dict, Iterator, Optional, Tuple
+
+import tree_sitter
+
+from mcp_code_parser.analysis.base import walk
+from mcp_code_parser.extractors.base import GoroutineLeak, Symbol, node_text
+
+# Channels named for telling their readers to stop, e.g. done, quit or m.stopCh
+_STOP_CHANNEL = re.compile(r"done|quit|stop|exit|closing|shutdown|cancel", re.IGNORECASE)
+# Calls that end the goroutine (or the program) from inside a loop
+_EXIT_CALLS = ("panic", "os.Exit", "runtime.Goexit", "log.Fatal", "log.Fatalf", "log.Fatalln")
+
+_UNSTOPPED = "loops forever without checking ctx.Done() or whether a channel is closed"
+_SELF_STOPPING = (
+    "loops without checking ctx.Done() or whether a channel is closed, so it only stops on its own conditions"
+)
+
+
+def mark_goroutine_leaks(root: tree_sitter.Node, source: bytes, by_start: Dict[int, Symbol]) -> None:
+    """Set goroutine_leaks on top-level functions and methods.
+
+    A goroutine is flagged when its body, or the body of the file's function
+    or method it runs (`go poll(url)`, `go p.worker(ctx)`), has a `for {}`
+    loop and nothing telling it to stop: no `.Done()` call (as in
+    `case <-ctx.Done():`), no receive checking whether a channel is closed
+    (`v, ok := <-ch`) and no receive from a channel named like a stop
+    signal (`<-done`, `<-m.stopCh`). Closures inside the goroutine are
+    separate and not looked into. Loops over a range end when their channel
+    is closed and aren't flagged, nor are goroutines running functions
+    declared elsewhere.
+
+    by_start maps start bytes to the symbols of the file's functions and
+    methods.
+    """
+    functions: Dict[str, tree_sitter.Node] = {}
+    methods: Dict[str, tree_sitter.Node] = {}
+    for node in root.named_children:
+        name = node.child_by_field_name("name")
+        body = node.child_by_field_name("body")
+        if name is None or body is None:
+            continue
+        if node.type == "function_declaration":
+            functions.setdefault(node_text(name, source), body)
+        elif node.type == "method_declaration":
+            methods.setdefault(node_text(name, source), body)
+
+    for node in root.named_children:
+        sym = by_start.get(node.start_byte)
+        body = node.child_by_field_name("body")
+        if sym is None or body is None or node.type not in ("function_declaration", "method_declaration"):
+            continue
+        for statement in walk(body):
+            if statement.type != "go_statement":
+                continue
+            target = _goroutine_body(statement, source, functions, methods)
+            leak = _leak(target, source) if target is not None else None
+            if leak is None:
+                continue
+            reason, confidence = leak
+            sym.goroutine_leaks.append(GoroutineLeak(
+                expression=" ".join(node_text(statement, source).split("\n")[0].split()),
+                line=statement.start_point[0] + 1,
+                reason=reason,
+                confidence=confidence,
+            ))
+
+
+def _goroutine_body(
+    statement: tree_sitter.Node,
+    source: bytes,
+    functions: Dict[str, tree_sitter.Node],
+    methods: Dict[str, tree_sitter.Node]
+) -> Optional[tree_sitter.Node]:
+    """Body of the code a go statement runs, when it is in the file."""
+    call = next((c for c in statement.named_children if c.type == "call_expression"), None)
+    function = call.child_by_field_name("function") if call is not None else None
+    if function is None:
+        return None
+    if function.type == "func_literal":
+        return function.child_by_field_name("body")
+    if function.type == "identifier":
+        return functions.get(node_text(function, source))
+    if function.type == "selector_expression":
+        field = function.child_by_field_name("field")
+        return methods.get(node_text(field, source)) if field is not None else None
+    return None
+
+
+def _leak(body: tree_sitter.Node, source: bytes) -> Optional[Tuple[str, str]]:
+    """Reason and confidence a goroutine body may never stop, or None."""
+    own = list(_own_nodes(body))
+    loops = [n for n in own if n.type == "for_statement" and _is_infinite(n)]
+    if not loops or any(_is_stop_signal(n, source) for n in own):
+        return None
+    if all(_exits(loop, source) for loop in loops):
+        return _SELF_STOPPING, "medium"
+    return _UNSTOPPED, "high"
+
+
+def _is_infinite(loop: tree_sitter.Node) -> bool:
+    """Whether a for loop has no clause, condition or range: `for { ... }`."""
+    return all(c.type in ("block", "comment") for c in loop.named_children)
+
+
+def _exits(loop: tree_sitter.Node, source: bytes) -> bool:
+    """Whether anything in a loop can leave it or end the goroutine."""
+    for node in _own_nodes(loop):
+        if node.type in ("return_statement", "break_statement", "goto_statement"):
+            return True
+        if node.type == "call_expression":
+            function = node.child_by_field_name("function")
+            if function is not None and node_text(function, source) in _EXIT_CALLS:
+                return True
+    return False
+
+
+def _is_stop_signal(node: tree_sitter.Node, source: bytes) -> bool:
+    """Whether node checks for being told to stop: a Done() call, a closed-channel check or a stop channel receive."""
+    if node.type == "call_expression":
+        function = node.child_by_field_name("function")
+        arguments = node.child_by_field_name("arguments")
+        field = function.child_by_field_name("field") if function is not None else None
+        return (
+            field is not None and function.type == "selector_expression" and node_text(field, source) == "Done"
+            and (arguments is None or not arguments.named_children)
+        )
+    if node.type in ("short_var_declaration", "assignment_statement", "receive_statement"):
+        left = node.child_by_field_name("left")
+        right = node.child_by_field_name("right")
+        # `v, ok := <-ch`
+        return (
+            left is not None and right is not None and len(left.named_children) == 2
+            and node_text(right, source).lstrip().startswith("<-")
+        )
+    if node.type == "unary_expression":
+        operator = node.child_by_field_name("operator")
+        operand = node.child_by_field_name("operand")
+        if operator is None or operand is None or node_text(operator, source) != "<-":
+            return False
+        return _STOP_CHANNEL.search(node_text(operand, source).rsplit(".", 1)[-1]) is not None
+    return False
+
+
+def _own_nodes(node: tree_sitter.Node) -> Iterator[tree_sitter.Node]:
+    """Nodes below node in source order, not entering closures."""
+    for child in node.named_children:
+        yield child
+        if child.type != "func_literal":
+            yield from _own_nodes(child)
@@ -0,0 +1,208 @@
+"""Go struct memory layout estimates, following the gc compiler's alignment rules."""
+
+from typing import Dict, List, Optional, Set, Tuple
+
+import tree_sitter
+
+from mcp_code_parser.extractors.base import FieldLayout, LayoutInfo, node_text
+
+# Word size of each supported GOARCH
+ARCH_WORD_SIZES = {"amd64": 8, "arm64": 8, "386": 4, "arm": 4}
+
+# (size, align) of predeclared types that don't depend on the word size.
+# On 32-bit targets 64-bit values are only 4-byte aligned; see _basic().
+_FIXED_SIZES = {
+    "bool": 1, "int8": 1, "uint8": 1, "byte": 1,
+    "int16": 2, "uint16": 2,
+    "int32": 4, "uint32": 4, "rune": 4, "float32": 4,
+    "int64": 8, "uint64": 8, "float64": 8, "complex64": 8,
+    "complex128": 16,
+}
+_WORD_TYPES = ("int", "uint", "uintptr", "unsafe.Pointer")
+_INTERFACE_TYPES = ("error", "any", "comparable")
+
+# Standard library types, described by the types of their (unexported) fields
+_STDLIB_STRUCTS = {
+    "time.Time": ["uint64", "int64", "uintptr"],
+    "sync.Mutex": ["int32", "uint32"],
+    "sync.RWMutex": ["sync.Mutex", "uint32", "uint32", "int32", "int32"],
+    "sync.Once": ["uint32", "sync.Mutex"],
+    "sync.WaitGroup": ["atomic.Uint64", "uint32"],
+    "atomic.Int32": ["int32"],
+    "atomic.Uint32": ["uint32"],
+    "atomic.Bool": ["uint32"],
+}
+# sync/atomic's 64-bit types embed an align64 marker, so unlike plain 64-bit
+# integers they are 8-byte aligned on every GOARCH
+_ALIGNED_64 = ("atomic.Int64", "atomic.Uint64")
+_STDLIB_ALIASES = {"time.Duration": "int64", "time.Month": "int", "time.Weekday": "int"}
+_STDLIB_INTERFACES = ("context.Context", "io.Reader", "io.Writer", "io.Closer", "fmt.Stringer")
+
+
+class GoLayout:
+    """Compute sizes and alignments of Go types declared in one file.
+
+    Sizes are those of the gc toolchain. Types from other packages are only
+    known for a few common standard library types; anything else makes the
+    containing layout incomplete.
+    """
+
+    def __init__(self, arch: str, local_types: Dict[str, tree_sitter.Node], source: bytes):
+        """Create a layout calculator.
+
+        Args:
+            arch: Target GOARCH, one of ARCH_WORD_SIZES
+            local_types: Type expression node of each type declared in the file
+            source: File source
+
+        Raises:
+            ValueError: If arch is not supported
+        """
+        if arch not in ARCH_WORD_SIZES:
+            raise ValueError(f"Unsupported layout target {arch!r} (expected one of {', '.join(ARCH_WORD_SIZES)})")
+        self.arch = arch
+        self.word = ARCH_WORD_SIZES[arch]
+        self._local_types = local_types
+        self._source = source
+        self._resolving: Set[str] = set()
+
+    def struct_layout(self, struct_type: tree_sitter.Node) -> LayoutInfo:
+        """Lay out the fields of a struct type in declaration order."""
+        fields: List[FieldLayout] = []
+        unknown: List[str] = []
+        offset: Optional[int] = 0
+        max_align = 1
+        for name, type_node in self._fields(struct_type):
+            type_text = " ".join(node_text(type_node, self._source).split())
+            size_align = self.size_align(type_node)
+            if size_align is None:
+                unknown.append(type_text)
+                fields.append(FieldLayout(name=name, type=type_text))
+                offset = None
+                continue
+            size, align = size_align
+            max_align = max(max_align, align)
+            field = FieldLayout(name=name, type=type_text, size=size, align=align)
+            if offset is not None:
+                field.offset = _align_up(offset, align)
+                field.padding = field.offset - offset
+                offset = field.offset + size
+            fields.append(field)
+
+        layout = LayoutInfo(arch=self.arch, align=max_align, fields=fields, unknown_types=unknown)
+        if offset is not None:
+            layout.size = _align_up(offset, max_align)
+            layout.padding = layout.size - sum(f.size or 0 for f in fields)
+            layout.optimal_size = _optimal_size([(f.size or 0, f.align or 1) for f in fields], max_align)
+        return layout
+
+    def size_align(self, node: tree_sitter.Node) -> Optional[Tuple[int, int]]:
+        """Get (size, align) of a type expression, or None if it can't be determined."""
+        t = node.type
+        if t in ("pointer_type", "map_type", "channel_type", "function_type"):
+            return self.word, self.word
+        if t == "slice_type":
+            return 3 * self.word, self.word
+        if t == "interface_type":
+            return 2 * self.word, self.word
+        if t == "parenthesized_type" and node.named_children:
+            return self.size_align(node.named_children[0])
+        if t == "array_type":
+            length = node.child_by_field_name("length")
+            element = node.child_by_field_name("element")
+            if length is None or element is None:
+                return None
+            try:
+                count = int(node_text(length, self._source), 0)
+            except ValueError:
+                # Named constants aren't evaluated
+                return None
+            element_size = self.size_align(element)
+            if element_size is None:
+                return None
+            return count * element_size[0], element_size[1]
+        if t == "struct_type":
+            layout = self.struct_layout(node)
+            return (layout.size, layout.align) if layout.size is not None else None
+        if t in ("type_identifier", "qualified_type"):
+            return self._named(" ".join(node_text(node, self._source).split()))
+        return None
+
+    def _named(self, name: str) -> Optional[Tuple[int, int]]:
+        """Size of a predeclared, local or known standard library type."""
+        basic = self._basic(name)
+        if basic is not None:
+            return basic
+        if name in self._local_types:
+            if name in self._resolving:
+                # Invalid recursive type
+                return None
+            self._resolving.add(name)
+            try:
+                return self.size_align(self._local_types[name])
+            finally:
+                self._resolving.discard(name)
+        if name in _STDLIB_ALIASES:
+            return self._basic(_STDLIB_ALIASES[name])
+        if name in _STDLIB_INTERFACES:
+            return 2 * self.word, self.word
+        if name in _ALIGNED_64:
+            return 8, 8
+        if name in _STDLIB_STRUCTS:
+            parts = [self._named(part) for part in _STDLIB_STRUCTS[name]]
+            if any(p is None for p in parts):
+                return None
+            offset, max_align = 0, 1
+            for size, align in parts:
+                offset = _align_up(offset, align) + size
+                max_align = max(max_align, align)
+            return _align_up(offset, max_align), max_align
+        return None
+
+    def _basic(self, name: str) -> Optional[Tuple[int, int]]:
+        """Size of a predeclared type (plus unsafe.Pointer) on this target."""
+        if name in _WORD_TYPES:
+            return self.word, self.word
+        if name == "string":
+            return 2 * self.word, self.word
+        if name in _INTERFACE_TYPES:
+            return 2 * self.word, self.word
+        size = _FIXED_SIZES.get(name)
+        if size is None:
+            return None
+        # Alignment is that of the widest component, capped at the word size
+        component = size // 2 if name.startswith("complex") else size
+        return size, min(component, self.word)
+
+    def _fields(self, struct_type: tree_sitter.Node) -> List[Tuple[str, tree_sitter.Node]]:
+        """(name, type node) for each field, with embedded fields named by type."""
+        fields = []
+        for child in struct_type.named_children:
+            if child.type != "field_declaration_list":
+                continue
+            for decl in child.named_children:
+                if decl.type != "field_declaration":
+                    continue
+                type_node = decl.child_by_field_name("type")
+                if type_node is None:
+                    continue
+                names = decl.children_by_field_name("name")
+                if not names:
+                    embedded = node_text(type_node, self._source).lstrip("*").split(".")[-1]
+                    fields.append((embedded, type_node))
+                for name_node in names:
+                    fields.append((node_text(name_node, self._source), type_node))
+        return fields
+
+
+def _align_up(offset: int, align: int) -> int:
+    """Round offset up to a multiple of align."""
+    return (offset + align - 1) // align * align
+
+
+def _optimal_size(fields: List[Tuple[int, int]], max_align: int) -> int:
+    """Struct size with fields ordered by decreasing alignment."""
+    offset = 0
+    for size, align in sorted(fields, key=lambda f: -f[1]):
+        offset = _align_up(offset, align) + size
+    return _align_up(offset, max_align)
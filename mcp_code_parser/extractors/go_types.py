@@ -0,0 +1,213 @@
+"""Decomposition of Go type expressions into structured TypeRefs."""
+
+import re
+from typing import List, Optional, Tuple
+
+from mcp_code_parser.extractors.base import TypeRef
+
+# A possibly package-qualified type name
+_NAME = re.compile(r"[A-Za-z_]\w*(?:\.[A-Za-z_]\w*)?")
+# Keywords starting a type literal rather than naming a type
+_TYPE_KEYWORDS = ("map", "chan", "func", "struct", "interface")
+
+
+def parse_type(text: str) -> TypeRef:
+    """Decompose a Go type expression, e.g. "map[string]chan int".
+
+    Variadic parameter types ("...T") are slices of T. Channels record their
+    direction, with `<-` binding to the leftmost `chan` as in the language
+    spec: "chan<- <-chan int" sends receive-only channels.
+
+    Raises:
+        ValueError: If the text isn't a type expression
+    """
+    parser = _Parser(text)
+    ref = parser.type()
+    parser.skip_space()
+    if parser.pos != len(parser.text):
+        raise ValueError(f"Unexpected {parser.text[parser.pos:]!r} in type {text!r}")
+    return ref
+
+
+class _Parser:
+    """Recursive descent over a type expression."""
+
+    def __init__(self, text: str):
+        self.text = " ".join(text.split())
+        self.pos = 0
+
+    def type(self) -> TypeRef:
+        """Parse the type starting at the current position."""
+        self.skip_space()
+        start = self.pos
+        if self.take("..."):
+            return self._ref("slice", start, elem=self.type())
+        if self.take("*"):
+            return self._ref("pointer", start, elem=self.type())
+        if self.take("("):
+            inner = self.type()
+            self.expect(")")
+            return inner
+        if self.take("<-"):
+            self.skip_space()
+            self.expect_word("chan")
+            return self._ref("chan", start, direction="receive", elem=self.type())
+        if self.take("["):
+            end = self._matching("[", "]", start)
+            length = self.text[start + 1:end].strip()
+            self.pos = end + 1
+            elem = self.type()
+            if not length:
+                return self._ref("slice", start, elem=elem)
+            return self._ref("array", start, length=length, elem=elem)
+
+        word = self.word()
+        if word == "map":
+            self.skip_space()
+            self.expect("[")
+            key = self.type()
+            self.expect("]")
+            return self._ref("map", start, key=key, elem=self.type())
+        if word == "chan":
+            # `<-` binds to the leftmost chan: `chan <-chan int` is chan<- (chan int)
+            self.skip_space()
+            direction = "send" if self.take("<-") else "both"
+            return self._ref("chan", start, direction=direction, elem=self.type())
+        if word == "func":
+            params = self._signature_list()
+            results: List[TypeRef] = []
+            if self._result_follows():
+                self.skip_space()
+                if self.text.startswith("(", self.pos):
+                    results = self._signature_list()
+                else:
+                    results = [self.type()]
+            return self._ref("func", start, params=params, results=results)
+        if word in ("struct", "interface"):
+            self.skip_space()
+            self.expect("{")
+            self.pos = self._matching("{", "}", self.pos - 1) + 1
+            return self._ref(word, start)
+
+        match = _NAME.match(self.text, start)
+        if match is None:
+            raise ValueError(f"Expected a type at {self.text[start:]!r} in {self.text!r}")
+        self.pos = match.end()
+        args: List[TypeRef] = []
+        if self.text.startswith("[", self.pos):
+            self.pos += 1
+            args = self._list("]")
+        return self._ref("named", start, name=match.group(), args=args)
+
+    def skip_space(self) -> None:
+        """Move past spaces."""
+        while self.pos < len(self.text) and self.text[self.pos] == " ":
+            self.pos += 1
+
+    def take(self, token: str) -> bool:
+        """Consume token if the text continues with it."""
+        if self.text.startswith(token, self.pos):
+            self.pos += len(token)
+            return True
+        return False
+
+    def expect(self, token: str) -> None:
+        """Consume token, which must come next (after spaces)."""
+        self.skip_space()
+        if not self.take(token):
+            raise ValueError(f"Expected {token!r} at {self.text[self.pos:]!r} in {self.text!r}")
+
+    def expect_word(self, word: str) -> None:
+        """Consume a keyword, which must come next."""
+        if self.word() != word:
+            raise ValueError(f"Expected {word!r} in {self.text!r}")
+
+    def word(self) -> str:
+        """The identifier at the current position, consuming it only if it's a keyword."""
+        match = re.compile(r"[A-Za-z_]\w*").match(self.text, self.pos)
+        if match is None or match.group() not in _TYPE_KEYWORDS:
+            return ""
+        self.pos = match.end()
+        return match.group()
+
+    def _ref(self, kind: str, start: int, **fields) -> TypeRef:
+        """TypeRef for the text from start to the current position."""
+        return TypeRef(kind=kind, text=self.text[start:self.pos].strip(), **fields)
+
+    def _matching(self, opening: str, closing: str, start: int) -> int:
+        """Position of the bracket closing the one at start."""
+        depth = 0
+        for i in range(start, len(self.text)):
+            if self.text[i] == opening:
+                depth += 1
+            elif self.text[i] == closing:
+                depth -= 1
+                if depth == 0:
+                    return i
+        raise ValueError(f"Unbalanced {opening!r} in {self.text!r}")
+
+    def _list(self, closing: str) -> List[TypeRef]:
+        """Comma-separated types up to and including closing."""
+        refs: List[TypeRef] = []
+        self.skip_space()
+        while not self.take(closing):
+            refs.append(self.type())
+            self.skip_space()
+            self.take(",")
+            self.skip_space()
+            if self.pos >= len(self.text):
+                raise ValueError(f"Missing {closing!r} in {self.text!r}")
+        return refs
+
+    def _signature_list(self) -> List[TypeRef]:
+        """Types of a parenthesized parameter or result list, one per declared name."""
+        self.expect("(")
+        end = self._matching("(", ")", self.pos - 1)
+        entries = _split_top_level(self.text[self.pos:end])
+        self.pos = end + 1
+        named = any(_declared_name(e) is not None for e in entries)
+        refs: List[TypeRef] = []
+        pending = 0
+        for entry in entries:
+            if not named:
+                refs.append(parse_type(entry))
+                continue
+            split = _declared_name(entry)
+            if split is None:
+                # A name sharing the type of the next entry: `a, b int`
+                pending += 1
+                continue
+            ref = parse_type(split[1])
+            refs.extend([ref] * (pending + 1))
+            pending = 0
+        return refs
+
+    def _result_follows(self) -> bool:
+        """Whether a func type's result comes next, rather than the end of the enclosing type."""
+        rest = self.text[self.pos:].lstrip()
+        return bool(rest) and rest[0] not in ",)]}=;"
+
+
+def _split_top_level(text: str) -> List[str]:
+    """Split on commas outside brackets, dropping empty entries."""
+    entries = []
+    depth = 0
+    start = 0
+    for i, char in enumerate(text):
+        if char in "([{":
+            depth += 1
+        elif char in ")]}":
+            depth -= 1
+        elif char == "," and depth == 0:
+            entries.append(text[start:i].strip())
+            start = i + 1
+    entries.append(text[start:].strip())
+    return [e for e in entries if e]
+
+
+def _declared_name(entry: str) -> Optional[Tuple[str, str]]:
+    """(name, type) of a `name Type` parameter entry, or None for a bare type or name."""
+    match = re.match(r"([A-Za-z_]\w*) (\S.*)$", entry)
+    if match is None or match.group(1) in _TYPE_KEYWORDS:
+        return None
+    return match.group(1), match.group(2)
@@ -0,0 +1,424 @@
+"""Symbol extraction for JavaScript and TypeScript source."""
+
+from typing import Any, List, Optional, Set
+
+import tree_sitter
+
+from mcp_code_parser.extractors.base import (
+    Attribute,
+    AttributeArg,
+    BaseExtractor,
+    ExtractOptions,
+    Param,
+    Symbol,
+    _collapse,
+    make_symbol,
+    mark_deprecated,
+    node_text,
+)
+
+_FUNCTION_VALUES = ("arrow_function", "function_expression", "function", "generator_function")
+_CLASS_DECLARATIONS = ("class_declaration", "abstract_class_declaration")
+_FIELD_DEFINITIONS = ("field_definition", "public_field_definition")
+
+
+class JavaScriptExtractor(BaseExtractor):
+    """Extract functions, classes, variables, imports and TS declarations.
+
+    The TypeScript grammar is a superset of the JavaScript one, so a single
+    extractor serves both languages.
+    """
+
+    string_literal_types = ("template_string",)
+
+    def extract(
+        self,
+        tree: tree_sitter.Tree,
+        source: bytes,
+        options: ExtractOptions,
+        path: Optional[str] = None
+    ) -> List[Symbol]:
+        """Extract top-level symbols, marking exported declarations."""
+        exported_names: Set[str] = set()
+        symbols = self._statements(tree.root_node, source, exported_names)
+        for sym in symbols:
+            if sym.name in exported_names:
+                sym.exported = True
+        mark_deprecated(symbols, source)
+        return symbols
+
+    def _statements(
+        self,
+        block: tree_sitter.Node,
+        source: bytes,
+        exported_names: Set[str]
+    ) -> List[Symbol]:
+        """Extract symbols declared directly in a program or namespace body."""
+        symbols = []
+        for node in block.named_children:
+            if node.type == "export_statement":
+                declaration = node.child_by_field_name("declaration")
+                if declaration is not None:
+                    # `@Dec export class ...` attaches decorators to the export
+                    decorators = _decorators(node, source)
+                    for sym in self._declaration(declaration, source, exported_names):
+                        sym.exported = True
+                        sym.attributes = decorators + sym.attributes
+                        symbols.append(sym)
+                    continue
+                value = node.child_by_field_name("value")
+                if value is not None:
+                    # `export default <expression>`
+                    if value.type == "identifier":
+                        exported_names.add(node_text(value, source))
+                    else:
+                        for sym in self._declaration(value, source, exported_names):
+                            sym.exported = True
+                            symbols.append(sym)
+                    continue
+                # `export { a, b as c }` marks earlier declarations as exported
+                for spec in _children_of_type(node, "export_specifier", depth=2):
+                    name = spec.child_by_field_name("name")
+                    if name is not None:
+                        exported_names.add(node_text(name, source))
+            elif node.type == "import_statement":
+                source_node = node.child_by_field_name("source")
+                if source_node is not None:
+                    symbols.append(make_symbol(
+                        node,
+                        _unquote(node_text(source_node, source)),
+                        "import",
+                        signature=_collapse(node_text(node, source)),
+                    ))
+            elif node.type == "expression_statement" and node.named_children:
+                # TS namespaces parse as expression statements in some grammar versions
+                inner = node.named_children[0]
+                if inner.type == "internal_module":
+                    symbols.extend(self._declaration(inner, source, exported_names))
+            else:
+                symbols.extend(self._declaration(node, source, exported_names))
+        return symbols
+
+    def _declaration(
+        self,
+        node: tree_sitter.Node,
+        source: bytes,
+        exported_names: Set[str]
+    ) -> List[Symbol]:
+        """Extract the symbols introduced by a single declaration node."""
+        t = node.type
+        if t in ("function_declaration", "generator_function_declaration", "function_signature"):
+            return [self._function(node, source, "function")]
+        if t in _FUNCTION_VALUES and node.child_by_field_name("name") is not None:
+            return [self._function(node, source, "function")]
+        if t in _CLASS_DECLARATIONS or t == "class":
+            return [self._class(node, source)]
+        if t in ("lexical_declaration", "variable_declaration"):
+            return self._variables(node, source)
+        if t == "interface_declaration":
+            return [self._interface(node, source)]
+        if t == "type_alias_declaration":
+            return [self._named(node, source, "type", signature=_collapse(node_text(node, source)))]
+        if t == "enum_declaration":
+            return [self._enum(node, source)]
+        if t in ("internal_module", "module"):
+            sym = self._named(node, source, "namespace")
+            body = node.child_by_field_name("body")
+            if body is not None:
+                sym.children = self._statements(body, source, set())
+            return [sym]
+        if t == "ambient_declaration":
+            # `declare function f(): void;` and friends
+            symbols = []
+            for child in node.named_children:
+                symbols.extend(self._declaration(child, source, exported_names))
+            return symbols
+        return []
+
+    def _named(self, node: tree_sitter.Node, source: bytes, kind: str, **kwargs) -> Symbol:
+        """Build a symbol named by the node's `name` field, with its decorators."""
+        name_node = node.child_by_field_name("name")
+        name = node_text(name_node, source) if name_node is not None else "default"
+        return make_symbol(node, name, kind, attributes=_decorators(node, source), **kwargs)
+
+    def _function(self, node: tree_sitter.Node, source: bytes, kind: str) -> Symbol:
+        """Build a function or method symbol with its parameters."""
+        return self._named(
+            node,
+            source,
+            kind,
+            signature=_signature(node, source),
+            params=_param_list(node.child_by_field_name("parameters"), source),
+            results=_return_type(node, source),
+        )
+
+    def _class(self, node: tree_sitter.Node, source: bytes) -> Symbol:
+        """Build a class symbol with its methods and fields."""
+        sym = self._named(node, source, "class", signature=_signature(node, source))
+        body = node.child_by_field_name("body")
+        if body is None:
+            return sym
+
+        for member in body.named_children:
+            if member.type in ("method_definition", "method_signature", "abstract_method_signature"):
+                method = self._function(member, source, "method")
+                if method.name == "constructor":
+                    method.kind = "constructor"
+                sym.children.append(method)
+            elif member.type in _FIELD_DEFINITIONS:
+                name_node = member.child_by_field_name("name") or member.child_by_field_name("property")
+                if name_node is None:
+                    continue
+                value = member.child_by_field_name("value")
+                if value is not None and value.type in _FUNCTION_VALUES:
+                    # `handle = () => {...}` class property methods
+                    method = self._function(value, source, "method")
+                    method.name = node_text(name_node, source)
+                    method.attributes = _decorators(member, source)
+                    method.start_line, method.start_byte = member.start_point[0] + 1, member.start_byte
+                    sym.children.append(method)
+                    continue
+                sym.children.append(make_symbol(
+                    member,
+                    node_text(name_node, source),
+                    "field",
+                    signature=_collapse(node_text(member, source)),
+                    attributes=_decorators(member, source),
+                ))
+        return sym
+
+    def _interface(self, node: tree_sitter.Node, source: bytes) -> Symbol:
+        """Build an interface symbol with its members."""
+        sym = self._named(node, source, "interface", signature=_signature(node, source))
+        body = node.child_by_field_name("body")
+        if body is None:
+            return sym
+        for member in body.named_children:
+            if member.type == "method_signature":
+                sym.children.append(self._function(member, source, "method"))
+            elif member.type == "property_signature":
+                sym.children.append(self._named(
+                    member,
+                    source,
+                    "field",
+                    signature=_collapse(node_text(member, source)),
+                ))
+        return sym
+
+    def _enum(self, node: tree_sitter.Node, source: bytes) -> Symbol:
+        """Build an enum symbol with its members."""
+        sym = self._named(node, source, "enum")
+        body = node.child_by_field_name("body")
+        if body is None:
+            return sym
+        for member in body.named_children:
+            if member.type == "enum_assignment":
+                name_node = member.child_by_field_name("name")
+            elif member.type in ("property_identifier", "string"):
+                name_node = member
+            else:
+                continue
+            if name_node is not None:
+                sym.children.append(make_symbol(
+                    member,
+                    _unquote(node_text(name_node, source)),
+                    "enum_member",
+                    signature=_collapse(node_text(member, source)),
+                ))
+        return sym
+
+    def _variables(self, node: tree_sitter.Node, source: bytes) -> List[Symbol]:
+        """Build symbols for each declarator in a var/let/const declaration."""
+        is_const = node_text(node, source).lstrip().startswith("const")
+        symbols = []
+        for declarator in node.named_children:
+            if declarator.type != "variable_declarator":
+                continue
+            name_node = declarator.child_by_field_name("name")
+            if name_node is None or name_node.type != "identifier":
+                # Destructuring patterns don't declare a single named symbol
+                continue
+            name = node_text(name_node, source)
+            value = declarator.child_by_field_name("value")
+
+            if value is not None and value.type in _FUNCTION_VALUES:
+                sym = self._function(value, source, "function")
+                sym.name = name
+                _span(sym, node)
+            elif value is not None and value.type == "class":
+                sym = self._class(value, source)
+                sym.name = name
+                _span(sym, node)
+            else:
+                sym = make_symbol(
+                    declarator,
+                    name,
+                    "constant" if is_const else "variable",
+                    signature=_collapse(node_text(declarator, source)),
+                )
+            symbols.append(sym)
+        return symbols
+
+
+def _span(sym: Symbol, node: tree_sitter.Node) -> None:
+    """Make a symbol cover the whole declaration node."""
+    sym.start_line = node.start_point[0] + 1
+    sym.end_line = node.end_point[0] + 1
+    sym.start_byte = node.start_byte
+    sym.end_byte = node.end_byte
+
+
+def _unquote(text: str) -> str:
+    """Strip the quotes from a JS string literal."""
+    if len(text) >= 2 and text[0] in "\"'`" and text[-1] == text[0]:
+        return text[1:-1]
+    return text
+
+
+def _signature(node: tree_sitter.Node, source: bytes) -> str:
+    """Source text of a declaration up to (not including) its body."""
+    body = node.child_by_field_name("body")
+    end = body.start_byte if body is not None else node.end_byte
+    return _collapse(source[node.start_byte:end].decode("utf8", errors="replace")).rstrip(" ;{")
+
+
+def _decorators(node: tree_sitter.Node, source: bytes) -> List[Attribute]:
+    """Build attributes for a declaration's `@decorator` nodes."""
+    attributes = []
+    for decorator in node.children_by_field_name("decorator"):
+        if not decorator.named_children:
+            continue
+        expr = decorator.named_children[0]
+        args: List[str] = []
+        parsed: List[AttributeArg] = []
+        if expr.type == "call_expression":
+            arguments = expr.child_by_field_name("arguments")
+            nodes = [a for a in arguments.named_children if a.type != "comment"] if arguments is not None else []
+            args = [_collapse(node_text(a, source)) for a in nodes]
+            parsed = [_decorator_argument(a, source) for a in nodes]
+            expr = expr.child_by_field_name("function") or expr
+        attributes.append(Attribute(
+            name=_collapse(node_text(expr, source)),
+            args=args,
+            raw=_collapse(node_text(decorator, source)),
+            arguments=parsed,
+        ))
+    return attributes
+
+
+def _decorator_argument(arg: tree_sitter.Node, source: bytes) -> AttributeArg:
+    """Build a positional AttributeArg (JavaScript has no keyword arguments)."""
+    raw = _collapse(node_text(arg, source))
+    try:
+        return AttributeArg(keyword=None, raw=raw, value=_literal(arg, source), literal=True)
+    except ValueError:
+        return AttributeArg(keyword=None, raw=raw)
+
+
+def _literal(node: tree_sitter.Node, source: bytes) -> Any:
+    """Value of a literal expression, like `{ path: ':id', roles: ['admin'] }`.
+
+    Raises:
+        ValueError: If the node isn't a literal, or holds spreads, computed
+            keys, template substitutions or other expressions
+    """
+    if node.type in ("string", "template_string"):
+        parts = []
+        for child in node.named_children:
+            if child.type == "string_fragment":
+                parts.append(node_text(child, source))
+            elif child.type == "escape_sequence":
+                parts.append(node_text(child, source).encode("utf8").decode("unicode_escape"))
+            else:
+                raise ValueError(f"Not a literal: {child.type}")
+        return "".join(parts)
+    if node.type == "number":
+        text = node_text(node, source).replace("_", "")
+        if text.endswith("n"):
+            return int(text[:-1], 0)
+        try:
+            return int(text, 0)
+        except ValueError:
+            return float(text)
+    if node.type in ("true", "false"):
+        return node.type == "true"
+    if node.type == "null":
+        return None
+    if node.type == "unary_expression" and node_text(node.child_by_field_name("operator"), source) == "-":
+        value = _literal(node.child_by_field_name("argument"), source)
+        if isinstance(value, bool) or not isinstance(value, (int, float)):
+            raise ValueError("Not a negative number")
+        return -value
+    if node.type == "array":
+        return [_literal(c, source) for c in node.named_children if c.type != "comment"]
+    if node.type == "object":
+        value = {}
+        for pair in (c for c in node.named_children if c.type != "comment"):
+            key = pair.child_by_field_name("key") if pair.type == "pair" else None
+            if key is None or key.type not in ("property_identifier", "string", "number"):
+                raise ValueError(f"Not a literal property: {pair.type}")
+            name = _literal(key, source) if key.type == "string" else node_text(key, source)
+            value[str(name)] = _literal(pair.child_by_field_name("value"), source)
+        return value
+    if node.type == "parenthesized_expression" and node.named_children:
+        return _literal(node.named_children[0], source)
+    raise ValueError(f"Not a literal: {node.type}")
+
+
+def _children_of_type(node: tree_sitter.Node, type_name: str, depth: int) -> List[tree_sitter.Node]:
+    """Find named descendants of a type within depth levels."""
+    found = []
+    for child in node.named_children:
+        if child.type == type_name:
+            found.append(child)
+        elif depth > 1:
+            found.extend(_children_of_type(child, type_name, depth - 1))
+    return found
+
+
+def _type_text(annotation: Optional[tree_sitter.Node], source: bytes) -> Optional[str]:
+    """Text of a TS type annotation without its leading colon."""
+    if annotation is None:
+        return None
+    return _collapse(node_text(annotation, source)).lstrip(":").strip()
+
+
+def _param_list(parameters: Optional[tree_sitter.Node], source: bytes) -> List[Param]:
+    """Build Params for formal parameters in either grammar."""
+    if parameters is None:
+        # Arrow functions with a single bare parameter: `x => x * 2`
+        return []
+    params = []
+    for param in parameters.named_children:
+        value = None
+        if param.type in ("required_parameter", "optional_parameter"):
+            pattern = param.child_by_field_name("pattern")
+            name = node_text(pattern, source) if pattern is not None else node_text(param, source)
+            if param.type == "optional_parameter":
+                name = name.rstrip("?")
+            type_text = _type_text(param.child_by_field_name("type"), source)
+            value = param.child_by_field_name("value")
+        elif param.type == "assignment_pattern":
+            left = param.child_by_field_name("left")
+            name = node_text(left, source) if left is not None else node_text(param, source)
+            type_text = None
+            value = param.child_by_field_name("right")
+        elif param.type in ("identifier", "rest_pattern", "object_pattern", "array_pattern"):
+            name = node_text(param, source)
+            type_text = None
+        else:
+            continue
+        default = _collapse(node_text(value, source)) if value is not None else None
+        params.append(Param(
+            name=name,
+            type=type_text or "",
+            default=default,
+            optional=default is not None or param.type == "optional_parameter" or name.startswith("..."),
+        ))
+    return params
+
+
+def _return_type(node: tree_sitter.Node, source: bytes) -> List[Param]:
+    """Declared TS return type, if any."""
+    type_text = _type_text(node.child_by_field_name("return_type"), source)
+    return [Param(name=None, type=type_text)] if type_text else []
@@ -0,0 +1,66 @@
+"""Qualified names of symbols, built by the same rules for every language.
+
+A symbol's qualified name is the path of names from the top of its file
+down to it, e.g. "UserService.GetUser" or "geo.Point.length":
+
+- Segments are joined with "." in every language, so names from different
+  backends compare and index alike; native_name() spells a path with the
+  language's own scope separator instead, e.g. "geo::Point::length" in C++.
+- A top-level symbol with a receiver, such as a Go method declared apart
+  from its type, is named under the receiver: "Store.Get".
+- Names are relative to the file; the Go package or Python module isn't
+  part of them (global IDs add the file path).
+- Symbols without a name, such as anonymous namespaces or function
+  literals, get the segment "<anonymous KIND>", e.g. "<anonymous namespace>".
+  Names extractors synthesize are kept: "struct" for a Go anonymous struct,
+  "default" for an unnamed JavaScript default export.
+- Elixir functions are named with their arity as declared, "handle/2".
+
+Stable IDs are the kind plus the qualified name (see assign_stable_ids).
+"""
+
+from typing import TYPE_CHECKING, Optional, Sequence
+
+if TYPE_CHECKING:
+    from mcp_code_parser.extractors.base import Symbol
+
+SEPARATOR = "."
+
+# Scope separators of languages that don't write "."
+_NATIVE_SEPARATORS = {"cpp": "::"}
+
+
+def segment(sym: "Symbol") -> str:
+    """A symbol's own part of its qualified name."""
+    return sym.name or f"<anonymous {sym.kind}>"
+
+
+def qualify(sym: "Symbol", parent: Optional[str] = None, separator: str = SEPARATOR) -> str:
+    """Qualified name of a symbol declared in the scope named parent.
+
+    Args:
+        sym: The symbol
+        parent: Qualified name of the symbol containing it (None at the top level)
+        separator: Separator between segments
+    """
+    if parent is None:
+        return f"{sym.receiver}{separator}{segment(sym)}" if sym.receiver else segment(sym)
+    return f"{parent}{separator}{segment(sym)}"
+
+
+def qualified_name(chain: Sequence["Symbol"], separator: str = SEPARATOR) -> str:
+    """Qualified name of a symbol from its ancestor chain, outermost first, then the symbol."""
+    name: Optional[str] = None
+    for sym in chain:
+        name = qualify(sym, name, separator)
+    return name or ""
+
+
+def native_separator(language: Optional[str]) -> str:
+    """Separator a language writes between scopes, e.g. "::" for C++."""
+    return _NATIVE_SEPARATORS.get(language or "", SEPARATOR)
+
+
+def native_name(chain: Sequence["Symbol"], language: Optional[str]) -> str:
+    """Qualified name spelled with the language's own separator, e.g. "geo::Point"."""
+    return qualified_name(chain, native_separator(language))
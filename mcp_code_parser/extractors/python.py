@@ -0,0 +1,323 @@
+"""Symbol extraction for Python source."""
+
+import ast
+import re
+from typing import Any, List, Optional
+
+import tree_sitter
+
+from mcp_code_parser.extractors.base import (
+    Attribute,
+    AttributeArg,
+    BaseExtractor,
+    ExtractOptions,
+    Param,
+    Symbol,
+    _collapse,
+    make_symbol,
+    node_text,
+)
+
+# Module-level names in this style are treated as constants
+_CONSTANT_NAME = re.compile(r"^_*[A-Z][A-Z0-9_]*$")
+
+
+class PythonExtractor(BaseExtractor):
+    """Extract functions, classes, module variables and imports from Python.
+
+    Names starting with an underscore are reported as not exported. Decorators
+    are recorded as attributes of the symbol they decorate.
+    """
+
+    string_literal_types = ("string",)
+
+    def extract(
+        self,
+        tree: tree_sitter.Tree,
+        source: bytes,
+        options: ExtractOptions,
+        path: Optional[str] = None
+    ) -> List[Symbol]:
+        """Extract top-level Python symbols, nesting methods under classes."""
+        return self._block(tree.root_node, source, in_class=False)
+
+    def _block(self, block: tree_sitter.Node, source: bytes, in_class: bool) -> List[Symbol]:
+        """Extract the definitions directly inside a module or class body."""
+        symbols = []
+        for node in block.named_children:
+            definition, attributes = node, []
+            if node.type == "decorated_definition":
+                definition = node.child_by_field_name("definition")
+                attributes = _decorators(node, source)
+                if definition is None:
+                    continue
+
+            if definition.type == "function_definition":
+                sym = self._function(definition, source, "method" if in_class else "function")
+            elif definition.type == "class_definition":
+                sym = self._class(definition, source)
+            elif node.type == "expression_statement":
+                symbols.extend(self._assignments(node, source, in_class))
+                continue
+            elif node.type in ("import_statement", "import_from_statement") and not in_class:
+                symbols.extend(self._imports(node, source))
+                continue
+            else:
+                continue
+
+            if attributes:
+                # The symbol spans its decorators, as an editor would fold it
+                sym.start_line = node.start_point[0] + 1
+                sym.start_byte = node.start_byte
+                sym.attributes = attributes
+                _apply_deprecated_decorator(sym)
+            symbols.append(sym)
+        return symbols
+
+    def _function(self, node: tree_sitter.Node, source: bytes, kind: str) -> Symbol:
+        """Build a symbol for a function or method definition."""
+        name = node_text(node.child_by_field_name("name"), source)
+        return_type = node.child_by_field_name("return_type")
+        sym = make_symbol(
+            node,
+            name,
+            kind,
+            signature=_signature(node, source),
+            exported=_is_exported(name),
+            params=_param_list(node.child_by_field_name("parameters"), source),
+            results=[Param(name=None, type=_collapse(node_text(return_type, source)))]
+            if return_type is not None else [],
+        )
+        note = _deprecation_warning(node.child_by_field_name("body"), source)
+        if note is not None:
+            sym.deprecated = True
+            sym.deprecation_note = note or None
+        return sym
+
+    def _class(self, node: tree_sitter.Node, source: bytes) -> Symbol:
+        """Build a symbol for a class, including its methods and fields."""
+        name = node_text(node.child_by_field_name("name"), source)
+        sym = make_symbol(
+            node,
+            name,
+            "class",
+            signature=_signature(node, source),
+            exported=_is_exported(name),
+        )
+        body = node.child_by_field_name("body")
+        if body is not None:
+            sym.children = self._block(body, source, in_class=True)
+        return sym
+
+    def _assignments(self, node: tree_sitter.Node, source: bytes, in_class: bool) -> List[Symbol]:
+        """Build symbols for simple `name = ...` or `name: T` assignments."""
+        symbols = []
+        for assignment in node.named_children:
+            if assignment.type != "assignment":
+                continue
+            left = assignment.child_by_field_name("left")
+            if left is None or left.type != "identifier":
+                # Tuple unpacking and attribute targets don't declare one name
+                continue
+            name = node_text(left, source)
+            if in_class:
+                kind = "field"
+            elif _CONSTANT_NAME.match(name):
+                kind = "constant"
+            else:
+                kind = "variable"
+            symbols.append(make_symbol(
+                assignment,
+                name,
+                kind,
+                signature=_collapse(node_text(assignment, source)),
+                exported=_is_exported(name),
+            ))
+        return symbols
+
+    def _imports(self, node: tree_sitter.Node, source: bytes) -> List[Symbol]:
+        """Build one import symbol per statement, named by module."""
+        if node.type == "import_from_statement":
+            module = node.child_by_field_name("module_name")
+            names = [node_text(module, source)] if module is not None else []
+        else:
+            names = []
+            for child in node.children_by_field_name("name"):
+                if child.type == "aliased_import":
+                    child = child.child_by_field_name("name")
+                names.append(node_text(child, source))
+        return [
+            make_symbol(node, name, "import", signature=_collapse(node_text(node, source)))
+            for name in names
+        ]
+
+
+def _is_exported(name: str) -> bool:
+    """By convention, Python names starting with an underscore are private."""
+    return bool(name) and not name.startswith("_")
+
+
+def _signature(node: tree_sitter.Node, source: bytes) -> str:
+    """Source text of a definition up to (not including) the colon before its body."""
+    body = node.child_by_field_name("body")
+    end = body.start_byte if body is not None else node.end_byte
+    return _collapse(source[node.start_byte:end].decode("utf8", errors="replace")).rstrip(":").rstrip()
+
+
+def _decorators(decorated: tree_sitter.Node, source: bytes) -> List[Attribute]:
+    """Build attributes for the decorators of a decorated definition.
+
+    `@app.route("/", methods=["GET"])` becomes app.route with the source text
+    of each argument, and the arguments "/" and methods=["GET"] with their
+    literal values; bare decorators like `@dataclass` have no args.
+    """
+    attributes = []
+    for decorator in decorated.named_children:
+        if decorator.type != "decorator" or not decorator.named_children:
+            continue
+        expr = decorator.named_children[0]
+        args: List[str] = []
+        parsed: List[AttributeArg] = []
+        if expr.type == "call":
+            arguments = expr.child_by_field_name("arguments")
+            nodes = [
+                arg for arg in (arguments.named_children if arguments is not None else [])
+                if arg.type != "comment"
+            ]
+            args = [_collapse(node_text(arg, source)) for arg in nodes]
+            parsed = [_decorator_argument(arg, source) for arg in nodes]
+            expr = expr.child_by_field_name("function") or expr
+        attributes.append(Attribute(
+            name=_collapse(node_text(expr, source)),
+            args=args,
+            raw=_collapse(node_text(decorator, source)),
+            arguments=parsed,
+        ))
+    return attributes
+
+
+def _decorator_argument(arg: tree_sitter.Node, source: bytes) -> AttributeArg:
+    """Build an AttributeArg, parsing the value if it is a literal."""
+    keyword = None
+    value_node = arg
+    if arg.type == "keyword_argument":
+        name = arg.child_by_field_name("name")
+        keyword = node_text(name, source) if name is not None else None
+        value_node = arg.child_by_field_name("value") or arg
+    raw = node_text(value_node, source)
+    try:
+        value = _plain_literal(ast.literal_eval(raw.strip()))
+    except (ValueError, TypeError, SyntaxError, MemoryError, RecursionError):
+        # Names, calls, f-strings, splats and the like stay raw text
+        return AttributeArg(keyword=keyword, raw=_collapse(raw))
+    return AttributeArg(keyword=keyword, raw=_collapse(raw), value=value, literal=True)
+
+
+def _plain_literal(value: Any) -> Any:
+    """A literal as JSON-compatible data (tuples become lists).
+
+    Raises:
+        ValueError: For values without a JSON form, such as bytes, sets or
+            dicts with non-string keys
+    """
+    if value is None or isinstance(value, (str, bool, int, float)):
+        return value
+    if isinstance(value, (list, tuple)):
+        return [_plain_literal(v) for v in value]
+    if isinstance(value, dict) and all(isinstance(k, str) for k in value):
+        return {k: _plain_literal(v) for k, v in value.items()}
+    raise ValueError(f"Not a plain literal: {type(value).__name__}")
+
+
+# Warning categories that mark the warning function as deprecated
+_DEPRECATION_WARNINGS = ("DeprecationWarning", "PendingDeprecationWarning", "FutureWarning")
+# Keyword arguments of deprecation decorators that carry the explanation
+_DEPRECATION_NOTE_KEYWORDS = ("reason", "details", "message", "msg")
+
+
+def _apply_deprecated_decorator(sym: Symbol) -> None:
+    """Mark symbols decorated with `@deprecated` (PEP 702 or the deprecation package)."""
+    for attribute in sym.attributes:
+        if attribute.name.split(".")[-1] != "deprecated":
+            continue
+        sym.deprecated = True
+        for arg in attribute.args:
+            keyword = re.match(r"^(\w+)\s*=\s*", arg)
+            if keyword is not None and keyword.group(1) not in _DEPRECATION_NOTE_KEYWORDS:
+                # e.g. deprecated_in="1.0"
+                continue
+            note = _string_value(arg[keyword.end():] if keyword is not None else arg)
+            if note:
+                sym.deprecation_note = note
+                break
+        return
+
+
+def _deprecation_warning(body: Optional[tree_sitter.Node], source: bytes) -> Optional[str]:
+    """Message of a `warnings.warn(..., DeprecationWarning)` statement in a function body.
+
+    Returns:
+        The message ("" if it isn't a string literal), or None if no such
+        statement is made directly in the body
+    """
+    if body is None:
+        return None
+    for statement in body.named_children:
+        if statement.type != "expression_statement" or not statement.named_children:
+            continue
+        call = statement.named_children[0]
+        if call.type != "call":
+            continue
+        function = call.child_by_field_name("function")
+        if function is None or node_text(function, source) not in ("warnings.warn", "warn"):
+            continue
+        arguments = call.child_by_field_name("arguments")
+        args = [
+            _collapse(node_text(arg, source))
+            for arg in (arguments.named_children if arguments is not None else [])
+        ]
+        if not any(arg.split("=")[-1].strip() in _DEPRECATION_WARNINGS for arg in args[1:]):
+            continue
+        return _string_value(args[0]) if args else ""
+    return None
+
+
+def _string_value(text: str) -> str:
+    """Contents of a simple string literal, or "" for anything else."""
+    match = re.match(r"""^[rRuU]?(['"])(.*)\1$""", text.strip(), re.DOTALL)
+    return match.group(2) if match else ""
+
+
+def _param_list(parameters: Optional[tree_sitter.Node], source: bytes) -> List[Param]:
+    """Build Params for a parameter list, including `*args` and `**kwargs`.
+
+    Keyword-only parameters after `*` are listed like any other; they are
+    required unless they have a default.
+    """
+    if parameters is None:
+        return []
+    params = []
+    for param in parameters.named_children:
+        type_node = param.child_by_field_name("type")
+        type_text = _collapse(node_text(type_node, source)) if type_node is not None else ""
+        default = None
+        if param.type == "identifier":
+            name = node_text(param, source)
+        elif param.type in ("default_parameter", "typed_default_parameter"):
+            name = node_text(param.child_by_field_name("name"), source)
+            default = _collapse(node_text(param.child_by_field_name("value"), source))
+        elif param.type == "typed_parameter":
+            # The name is the first child: identifier, *args or **kwargs
+            name = node_text(param.named_children[0], source)
+        elif param.type in ("list_splat_pattern", "dictionary_splat_pattern"):
+            name = node_text(param, source)
+        else:
+            # `*` and `/` separators
+            continue
+        params.append(Param(
+            name=name,
+            type=type_text,
+            default=default,
+            optional=default is not None or name.startswith("*"),
+        ))
+    return params
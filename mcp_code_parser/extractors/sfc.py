@@ -0,0 +1,579 @@
+"""Symbol extraction for Vue and Svelte single-file components.
+
+An SFC is split into its top-level `<script>`, `<template>` and `<style>`
+regions. Script regions are parsed with the JavaScript/TypeScript grammar and
+delegated to JavaScriptExtractor; the component is emitted as a single
+top-level symbol whose children are its statically detectable props, emits and
+registered components, followed by one region symbol per block.
+"""
+
+import re
+from dataclasses import dataclass
+from pathlib import PurePath
+from typing import Dict, Iterator, List, Optional, Tuple
+
+import tree_sitter
+
+from mcp_code_parser.errors import Diagnostic, syntax_diagnostics
+from mcp_code_parser.extractors.base import (
+    BaseExtractor,
+    ExtractOptions,
+    Outline,
+    ParseFunc,
+    Symbol,
+    _collapse,
+    attach_raw_nodes,
+    clip_symbols,
+    make_symbol,
+    node_text,
+    shift_symbols,
+)
+from mcp_code_parser.extractors.javascript import JavaScriptExtractor, _unquote
+from mcp_code_parser.limits import LimitGuard
+from mcp_code_parser.partial import complete_buffer
+
+_BLOCK = re.compile(r"<(script|style)(\s[^>]*)?>(.*?)</\1\s*>", re.S | re.I)
+_TEMPLATE_TAG = re.compile(r"<(/?)template\b[^>]*?(/?)>", re.I)
+_ATTR = re.compile(r"""([^\s=/>]+)(?:\s*=\s*(?:"([^"]*)"|'([^']*)'|([^\s>]+)))?""")
+_COMMENT = re.compile(r"<!--.*?-->", re.S)
+_TS_LANGS = ("ts", "typescript", "tsx")
+
+
+@dataclass
+class _Region:
+    """A top-level block of an SFC, as character offsets into the file."""
+
+    tag: str
+    attrs: Dict[str, str]
+    open_tag: str
+    start: int
+    end: int
+    content_start: int
+    content_end: int
+
+
+@dataclass
+class _ScriptInfo:
+    """Symbols found in one script region, before offsets are applied."""
+
+    symbols: List[Symbol]
+    props: List[Symbol]
+    emits: List[Symbol]
+    components: List[Symbol]
+    name: Optional[str]
+
+
+class SFCExtractor(BaseExtractor):
+    """Shared region handling for single-file component formats."""
+
+    def __init__(self, script_extractor: Optional[BaseExtractor] = None):
+        """Create an SFC extractor.
+
+        Args:
+            script_extractor: Extractor for script blocks (defaults to JavaScriptExtractor)
+        """
+        self.script_extractor = script_extractor or JavaScriptExtractor()
+
+    def extract(
+        self,
+        tree: tree_sitter.Tree,
+        source: bytes,
+        options: ExtractOptions,
+        path: Optional[str] = None
+    ) -> List[Symbol]:
+        """Not supported: an SFC has no single tree, use extract_content."""
+        raise NotImplementedError("SFC extraction parses each region separately; use extract_content")
+
+    async def extract_content(
+        self,
+        content: str,
+        language: str,
+        options: ExtractOptions,
+        path: Optional[str],
+        parse: ParseFunc
+    ) -> Outline:
+        """Split the component into regions and extract each script block."""
+        regions = self._regions(content)
+        offsets = _ByteOffsets(content)
+        guard = LimitGuard(options.limits, offsets.byte(len(content))) if options.limits is not None else None
+        if guard is not None:
+            parse = guard.timed(parse)
+
+        component = Symbol(
+            name=_stem(path) or "Component",
+            kind="component",
+            start_line=1,
+            end_line=content.count("\n") + 1,
+            start_byte=0,
+            end_byte=offsets.byte(len(content)),
+            exported=True,
+        )
+
+        has_errors = False
+        diagnostics: List[Diagnostic] = []
+        props: List[Symbol] = []
+        emits: List[Symbol] = []
+        components: List[Symbol] = []
+        region_symbols = []
+
+        for region in regions:
+            sym = Symbol(
+                name=region.tag,
+                kind="region",
+                start_line=content.count("\n", 0, region.start) + 1,
+                end_line=content.count("\n", 0, region.end) + 1,
+                start_byte=offsets.byte(region.start),
+                end_byte=offsets.byte(region.end),
+                signature=_collapse(region.open_tag) or None,
+            )
+            region_symbols.append(sym)
+            if region.tag != "script":
+                continue
+
+            script = content[region.content_start:region.content_end]
+            script_language = _script_language(region.attrs)
+            tree = await parse(script, script_language)
+            if guard is not None and guard.check_tree(tree.root_node) is not None:
+                raise guard.tree_error()
+            has_errors = has_errors or tree.root_node.has_error
+
+            script_source = bytes(script, "utf8")
+            extracted, extracted_source = tree, script_source
+            if tree.root_node.has_error and options.complete_partial:
+                completed = await complete_buffer(script, script_language, tree, parse)
+                if completed is not None:
+                    extracted, extracted_source = completed
+            info = self._script_info(extracted, extracted_source, options, path, region)
+            if options.include_raw_node:
+                attach_raw_nodes(info.symbols, extracted.root_node)
+            if extracted is not tree:
+                for group in (info.symbols, info.props, info.emits, info.components):
+                    group[:] = clip_symbols(group, script_source)
+            line_delta = content.count("\n", 0, region.content_start)
+            byte_delta = offsets.byte(region.content_start)
+            for group in (info.symbols, info.props, info.emits, info.components):
+                shift_symbols(group, line_delta, byte_delta)
+            for diagnostic in syntax_diagnostics(
+                tree.root_node, source=script_source, tab_width=options.tab_width
+            ):
+                diagnostic.line += line_delta
+                diagnostic.end_line += line_delta
+                diagnostics.append(diagnostic)
+
+            sym.children = info.symbols
+            props.extend(info.props)
+            emits.extend(info.emits)
+            components.extend(info.components)
+            if info.name:
+                component.name = info.name
+
+        component.children = props + emits + components + region_symbols
+        return Outline(
+            language=language,
+            symbols=[component],
+            metadata={"has_errors": has_errors},
+            diagnostics=diagnostics,
+        )
+
+    def _regions(self, content: str) -> List[_Region]:
+        """Find the component's top-level regions in source order."""
+        raise NotImplementedError
+
+    def _script_info(
+        self,
+        tree: tree_sitter.Tree,
+        source: bytes,
+        options: ExtractOptions,
+        path: Optional[str],
+        region: _Region
+    ) -> _ScriptInfo:
+        """Extract a script region's symbols and component-level declarations."""
+        raise NotImplementedError
+
+
+class VueExtractor(SFCExtractor):
+    """Extract Vue single-file components.
+
+    Props and emits are detected from `defineProps`/`defineEmits` macros (runtime
+    object/array arguments or TypeScript type arguments) and from the options
+    API's `props`, `emits`, `components` and `name` keys.
+    """
+
+    def _regions(self, content: str) -> List[_Region]:
+        """Find the top-level template, script and style blocks."""
+        masked = _mask_comments(content)
+        regions = []
+        template = _template_region(content, masked)
+        if template is not None:
+            regions.append(template)
+        for region in _blocks(content, masked):
+            # Blocks nested inside the template are markup, not SFC regions
+            if template is not None and template.start <= region.start < template.end:
+                continue
+            regions.append(region)
+        return sorted(regions, key=lambda r: r.start)
+
+    def _script_info(
+        self,
+        tree: tree_sitter.Tree,
+        source: bytes,
+        options: ExtractOptions,
+        path: Optional[str],
+        region: _Region
+    ) -> _ScriptInfo:
+        """Detect `<script setup>` macros and options API declarations."""
+        symbols = self.script_extractor.extract(tree, source, options, path)
+        info = _ScriptInfo(symbols=symbols, props=[], emits=[], components=[], name=None)
+        root = tree.root_node
+
+        for call in _calls(root, source, ("defineProps", "defineEmits")):
+            callee = node_text(call.child_by_field_name("function"), source)
+            kind = "prop" if callee == "defineProps" else "emit"
+            target = info.props if kind == "prop" else info.emits
+            type_args = call.child_by_field_name("type_arguments")
+            if type_args is not None:
+                target.extend(_type_members(type_args, source, kind))
+            args = call.child_by_field_name("arguments")
+            if args is not None and args.named_children:
+                target.extend(_declared_names(args.named_children[0], source, kind))
+
+        options_object = _options_object(root, source)
+        if options_object is not None:
+            for key, value in _pairs(options_object, source):
+                if key == "name" and value.type == "string":
+                    info.name = _unquote(node_text(value, source))
+                elif key == "props":
+                    info.props.extend(_declared_names(value, source, "prop"))
+                elif key == "emits":
+                    info.emits.extend(_declared_names(value, source, "emit"))
+                elif key == "components":
+                    info.components.extend(_declared_names(value, source, "registered_component"))
+
+        if "setup" in region.attrs:
+            # In <script setup>, imported components are usable without registration
+            info.components.extend(_component_imports(root, source, ".vue"))
+        return info
+
+
+class SvelteExtractor(SFCExtractor):
+    """Extract Svelte components.
+
+    Props are `export let` declarations or names destructured from `$props()`;
+    events are string literals passed to a `createEventDispatcher()` dispatcher.
+    Markup outside the script and style blocks forms the template region.
+    """
+
+    def _regions(self, content: str) -> List[_Region]:
+        """Find script and style blocks; the remaining markup is the template."""
+        masked = _mask_comments(content)
+        regions = list(_blocks(content, masked))
+
+        markup = []
+        cursor = 0
+        for region in regions:
+            markup.append((cursor, region.start))
+            cursor = region.end
+        markup.append((cursor, len(content)))
+
+        spans = []
+        for start, end in markup:
+            stripped = masked[start:end].strip()
+            if stripped:
+                first = start + masked[start:end].index(stripped[0])
+                last = start + masked[start:end].rindex(stripped[-1]) + 1
+                spans.append((first, last))
+        if spans:
+            start, end = spans[0][0], spans[-1][1]
+            regions.append(_Region(
+                tag="template",
+                attrs={},
+                open_tag="",
+                start=start,
+                end=end,
+                content_start=start,
+                content_end=end,
+            ))
+        return sorted(regions, key=lambda r: r.start)
+
+    def _script_info(
+        self,
+        tree: tree_sitter.Tree,
+        source: bytes,
+        options: ExtractOptions,
+        path: Optional[str],
+        region: _Region
+    ) -> _ScriptInfo:
+        """Detect props, dispatched events and imported child components."""
+        symbols = self.script_extractor.extract(tree, source, options, path)
+        info = _ScriptInfo(symbols=symbols, props=[], emits=[], components=[], name=None)
+        if region.attrs.get("context") == "module":
+            # Module scripts run once per component type and declare no props
+            return info
+        root = tree.root_node
+
+        dispatchers = []
+        for node in root.named_children:
+            if node.type == "export_statement":
+                declaration = node.child_by_field_name("declaration")
+                if declaration is not None and node_text(declaration, source).startswith("let"):
+                    for declarator in declaration.named_children:
+                        name = declarator.child_by_field_name("name")
+                        if declarator.type == "variable_declarator" and name is not None:
+                            info.props.append(make_symbol(declarator, node_text(name, source), "prop"))
+            elif node.type in ("lexical_declaration", "variable_declaration"):
+                for declarator in node.named_children:
+                    if declarator.type != "variable_declarator":
+                        continue
+                    name = declarator.child_by_field_name("name")
+                    value = declarator.child_by_field_name("value")
+                    callee = _callee_name(value, source)
+                    if callee == "$props" and name is not None and name.type == "object_pattern":
+                        info.props.extend(_pattern_names(name, source))
+                    elif callee == "createEventDispatcher" and name is not None:
+                        dispatchers.append(node_text(name, source))
+
+        if dispatchers:
+            for call in _calls(root, source, tuple(dispatchers)):
+                args = call.child_by_field_name("arguments")
+                event = args.named_children[0] if args is not None and args.named_children else None
+                if event is not None and event.type == "string":
+                    info.emits.append(make_symbol(call, _unquote(node_text(event, source)), "emit"))
+
+        info.components.extend(_component_imports(root, source, ".svelte"))
+        return info
+
+
+class _ByteOffsets:
+    """Convert character offsets in a string to UTF-8 byte offsets."""
+
+    def __init__(self, content: str):
+        self._content = content
+        self._ascii = content.isascii()
+
+    def byte(self, index: int) -> int:
+        """Byte offset of the character at index."""
+        if self._ascii:
+            return index
+        return len(self._content[:index].encode("utf8"))
+
+
+def _stem(path: Optional[str]) -> Optional[str]:
+    """Component name implied by the file name."""
+    return PurePath(path).stem if path else None
+
+
+def _script_language(attrs: Dict[str, str]) -> str:
+    """Grammar to parse a script block with, from its lang attribute."""
+    return "typescript" if attrs.get("lang", "").lower() in _TS_LANGS else "javascript"
+
+
+def _parse_attrs(text: str) -> Dict[str, str]:
+    """Parse the attributes of an opening tag."""
+    attrs = {}
+    for match in _ATTR.finditer(text or ""):
+        name, double, single, bare = match.groups()
+        attrs[name.lower()] = double if double is not None else single if single is not None else bare or ""
+    return attrs
+
+
+def _mask_comments(content: str) -> str:
+    """Blank out HTML comments, keeping offsets, so tags inside are ignored."""
+    return _COMMENT.sub(lambda m: " " * len(m.group(0)), content)
+
+
+def _blocks(content: str, masked: str) -> Iterator[_Region]:
+    """Yield every script and style block."""
+    for match in _BLOCK.finditer(masked):
+        yield _Region(
+            tag=match.group(1).lower(),
+            attrs=_parse_attrs(match.group(2)),
+            open_tag=content[match.start():match.start(3)],
+            start=match.start(),
+            end=match.end(),
+            content_start=match.start(3),
+            content_end=match.end(3),
+        )
+
+
+def _template_region(content: str, masked: str) -> Optional[_Region]:
+    """Find the outermost `<template>` block, tracking nested templates."""
+    depth = 0
+    opening = None
+    for match in _TEMPLATE_TAG.finditer(masked):
+        closing, self_closing = match.group(1), match.group(2)
+        if self_closing:
+            continue
+        if not closing:
+            if depth == 0:
+                opening = match
+            depth += 1
+        elif depth > 0:
+            depth -= 1
+            if depth == 0 and opening is not None:
+                return _Region(
+                    tag="template",
+                    attrs=_parse_attrs(content[opening.start() + len("<template"):opening.end() - 1]),
+                    open_tag=opening.group(0),
+                    start=opening.start(),
+                    end=match.end(),
+                    content_start=opening.end(),
+                    content_end=match.start(),
+                )
+    return None
+
+
+def _calls(root: tree_sitter.Node, source: bytes, names: Tuple[str, ...]) -> Iterator[tree_sitter.Node]:
+    """Yield call expressions whose callee is one of the given identifiers."""
+    stack = [root]
+    while stack:
+        node = stack.pop()
+        if node.type == "call_expression":
+            function = node.child_by_field_name("function")
+            if function is not None and function.type == "identifier" and node_text(function, source) in names:
+                yield node
+        stack.extend(reversed(node.named_children))
+
+
+def _callee_name(node: Optional[tree_sitter.Node], source: bytes) -> Optional[str]:
+    """Name of the function called by a plain `f(...)` expression."""
+    if node is None:
+        return None
+    if node.type == "await_expression" and node.named_children:
+        node = node.named_children[0]
+    if node.type != "call_expression":
+        return None
+    function = node.child_by_field_name("function")
+    if function is None or function.type != "identifier":
+        return None
+    return node_text(function, source)
+
+
+def _pairs(obj: tree_sitter.Node, source: bytes) -> Iterator[Tuple[str, tree_sitter.Node]]:
+    """Yield (key, value) for each keyed entry of an object literal."""
+    for entry in obj.named_children:
+        if entry.type == "pair":
+            key = entry.child_by_field_name("key")
+            value = entry.child_by_field_name("value")
+            if key is not None and value is not None:
+                yield _unquote(node_text(key, source)), value
+        elif entry.type == "shorthand_property_identifier":
+            yield node_text(entry, source), entry
+
+
+def _declared_names(node: tree_sitter.Node, source: bytes, kind: str) -> List[Symbol]:
+    """Symbols for names declared by an object's keys or an array of strings."""
+    symbols = []
+    if node.type == "object":
+        for entry in node.named_children:
+            if entry.type == "pair":
+                key = entry.child_by_field_name("key")
+                if key is not None:
+                    symbols.append(make_symbol(entry, _unquote(node_text(key, source)), kind))
+            elif entry.type == "shorthand_property_identifier":
+                symbols.append(make_symbol(entry, node_text(entry, source), kind))
+            elif entry.type == "method_definition":
+                name = entry.child_by_field_name("name")
+                if name is not None:
+                    symbols.append(make_symbol(entry, node_text(name, source), kind))
+    elif node.type == "array":
+        for element in node.named_children:
+            if element.type == "string":
+                symbols.append(make_symbol(element, _unquote(node_text(element, source)), kind))
+    return symbols
+
+
+def _type_members(type_args: tree_sitter.Node, source: bytes, kind: str) -> List[Symbol]:
+    """Symbols for the members of a `defineProps<{...}>()` style type argument.
+
+    For emits, call signatures like `(e: 'change', id: number): void` are named
+    by the literal type of their first parameter.
+    """
+    symbols = []
+    for object_type in type_args.named_children:
+        if object_type.type != "object_type":
+            continue
+        for member in object_type.named_children:
+            if member.type == "property_signature":
+                name = member.child_by_field_name("name")
+                if name is not None:
+                    symbols.append(make_symbol(
+                        member,
+                        _unquote(node_text(name, source)),
+                        kind,
+                        signature=_collapse(node_text(member, source)).rstrip(";,"),
+                    ))
+            elif member.type == "call_signature" and kind == "emit":
+                event = _first_literal_param(member, source)
+                if event is not None:
+                    symbols.append(make_symbol(
+                        member,
+                        event,
+                        kind,
+                        signature=_collapse(node_text(member, source)).rstrip(";,"),
+                    ))
+    return symbols
+
+
+def _first_literal_param(signature: tree_sitter.Node, source: bytes) -> Optional[str]:
+    """String literal type of a call signature's first parameter, if any."""
+    params = signature.child_by_field_name("parameters")
+    if params is None or not params.named_children:
+        return None
+    annotation = params.named_children[0].child_by_field_name("type")
+    if annotation is None:
+        return None
+    text = _collapse(node_text(annotation, source)).lstrip(":").strip()
+    unquoted = _unquote(text)
+    return unquoted if unquoted != text else None
+
+
+def _options_object(root: tree_sitter.Node, source: bytes) -> Optional[tree_sitter.Node]:
+    """Object literal of `export default {...}` or `export default defineComponent({...})`."""
+    for node in root.named_children:
+        if node.type != "export_statement":
+            continue
+        value = node.child_by_field_name("value")
+        if value is None:
+            continue
+        if value.type == "object":
+            return value
+        if _callee_name(value, source) == "defineComponent":
+            args = value.child_by_field_name("arguments")
+            if args is not None and args.named_children and args.named_children[0].type == "object":
+                return args.named_children[0]
+    return None
+
+
+def _pattern_names(pattern: tree_sitter.Node, source: bytes) -> List[Symbol]:
+    """Prop symbols for the names destructured by `let { a, b = 1 } = $props()`."""
+    symbols = []
+    for entry in pattern.named_children:
+        if entry.type == "shorthand_property_identifier_pattern":
+            symbols.append(make_symbol(entry, node_text(entry, source), "prop"))
+        elif entry.type == "object_assignment_pattern":
+            left = entry.child_by_field_name("left")
+            if left is not None:
+                symbols.append(make_symbol(entry, node_text(left, source), "prop"))
+        elif entry.type == "pair_pattern":
+            key = entry.child_by_field_name("key")
+            if key is not None:
+                symbols.append(make_symbol(entry, _unquote(node_text(key, source)), "prop"))
+    return symbols
+
+
+def _component_imports(root: tree_sitter.Node, source: bytes, suffix: str) -> List[Symbol]:
+    """Symbols for default imports of component files with the given suffix."""
+    symbols = []
+    for node in root.named_children:
+        if node.type != "import_statement":
+            continue
+        module = node.child_by_field_name("source")
+        if module is None or not _unquote(node_text(module, source)).endswith(suffix):
+            continue
+        for clause in node.named_children:
+            if clause.type != "import_clause":
+                continue
+            for child in clause.named_children:
+                if child.type == "identifier":
+                    symbols.append(make_symbol(node, node_text(child, source), "registered_component"))
+    return symbols
@@ -0,0 +1,76 @@
+"""Multiline string literals (raw strings, triple-quoted strings) as symbols.
+
+The grammars parse a string's content as a single token, so code-like text
+inside one never yields symbols; this only makes large strings visible in
+the outline, e.g. embedded SQL or templates.
+"""
+
+import re
+from typing import List, Sequence
+
+import tree_sitter
+
+from mcp_code_parser.extractors.base import Symbol, make_symbol, node_text
+
+# Longest preview of a string's first line used as its name
+_NAME_LENGTH = 40
+
+# Prefixes and opening delimiters: r""", b'', f"", `, R"delim(
+_OPENING = re.compile(r"""^[A-Za-z]*(?:"([^()\\\s"]{0,16})\(|\"\"\"|'''|["'`])""")
+
+
+def attach_string_literals(
+    symbols: List[Symbol],
+    root: tree_sitter.Node,
+    source: bytes,
+    node_types: Sequence[str],
+    min_lines: int
+) -> None:
+    """Add string literals spanning at least min_lines lines as `string_literal` symbols.
+
+    Each is named by the start of its first non-blank line and becomes a
+    child of the innermost symbol containing it, or a top-level symbol.
+    Strings inside other strings (template substitutions) and bare string
+    statements such as docstrings are left out. Symbols are changed in place.
+    """
+    for node in _string_nodes(root, node_types, max(min_lines, 1)):
+        _insert(symbols, make_symbol(node, _name(node_text(node, source)), "string_literal"))
+
+
+def _string_nodes(root: tree_sitter.Node, node_types: Sequence[str], min_lines: int) -> List[tree_sitter.Node]:
+    """Outermost string nodes under root spanning at least min_lines lines."""
+    found = []
+    stack = [root]
+    while stack:
+        node = stack.pop()
+        if node.type in node_types:
+            lines = node.end_point[0] - node.start_point[0] + 1
+            if lines >= min_lines and (node.parent is None or node.parent.type != "expression_statement"):
+                found.append(node)
+            continue
+        stack.extend(node.children)
+    return sorted(found, key=lambda n: n.start_byte)
+
+
+def _name(text: str) -> str:
+    """The start of a string's first non-blank line, without its delimiters."""
+    match = _OPENING.match(text)
+    if match is not None:
+        text = text[match.end():]
+    for line in text.splitlines():
+        line = line.strip().rstrip("\"'`")
+        if line:
+            return line if len(line) <= _NAME_LENGTH else line[:_NAME_LENGTH].rstrip() + "..."
+    return "string"
+
+
+def _insert(symbols: List[Symbol], sym: Symbol) -> None:
+    """Insert sym under the innermost symbol containing it, in source order."""
+    for parent in symbols:
+        if parent.start_byte <= sym.start_byte and sym.end_byte <= parent.end_byte:
+            _insert(parent.children, sym)
+            return
+    index = len(symbols)
+    while index > 0 and symbols[index - 1].start_byte > sym.start_byte:
+        index -= 1
+    symbols.insert(index, sym)
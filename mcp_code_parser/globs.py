@@ -0,0 +1,181 @@
+"""Glob patterns over root-relative POSIX paths.
+
+One matcher is shared by directory extraction filters, symbol search
+scopes, test file conventions and .gitignore rules, so a pattern means the
+same thing wherever it is given. Supported syntax:
+
+- `*` matches within one path segment and `?` one character other than `/`
+- `**` as a whole segment matches any number of directories, including none:
+  `src/**/*.ts` matches `src/a.ts` and `src/ui/forms/a.ts`
+- `[abc]`, `[a-z]` and `[!abc]` (or `[^abc]`) match one character of a class
+- `{ts,tsx}` matches any of the comma-separated alternatives, which may
+  themselves hold wildcards or nested braces
+- `\\` escapes the next character
+
+Patterns without a `/` match the file name in any directory, as in
+.gitignore files: `*_test.go` matches `pkg/store/cache_test.go`.
+"""
+
+import functools
+import re
+from dataclasses import dataclass
+from typing import List, Optional, Sequence
+
+
+def translate(pattern: str, braces: bool = True) -> str:
+    """Convert a glob to the body of a regular expression matching whole paths.
+
+    Args:
+        pattern: Glob pattern (without the name-only rule applied)
+        braces: Expand `{a,b}` alternatives; with False braces are literal,
+            as git treats them in .gitignore files
+
+    Raises:
+        ValueError: If the pattern is empty
+    """
+    if not pattern:
+        raise ValueError("Empty glob pattern")
+    return _translate(pattern, braces)
+
+
+@functools.lru_cache(maxsize=1024)
+def compile_glob(pattern: str) -> re.Pattern:
+    """Compile a glob into a regular expression matching root-relative POSIX paths.
+
+    Raises:
+        ValueError: If the pattern is empty
+    """
+    body = translate(pattern.lstrip("/"))
+    prefix = "" if "/" in pattern else "(?:.*/)?"
+    return re.compile(f"^{prefix}{body}$")
+
+
+def glob_match(path: str, pattern: str) -> bool:
+    """Check whether a root-relative POSIX path matches a glob."""
+    return compile_glob(pattern).match(path) is not None
+
+
+@dataclass
+class PathFilter:
+    """Include and exclude globs selecting files under a root.
+
+    A path is selected if it matches one of the include patterns (or there
+    are none) and none of the exclude patterns.
+    """
+
+    include: Optional[Sequence[str]] = None
+    exclude: Optional[Sequence[str]] = None
+
+    def __post_init__(self):
+        # Compile now so bad patterns fail before any file is walked
+        for pattern in list(self.include or []) + list(self.exclude or []):
+            compile_glob(pattern)
+
+    def matches(self, path: str) -> bool:
+        """Check whether a root-relative POSIX path is selected."""
+        if self.include is not None and not any(glob_match(path, p) for p in self.include):
+            return False
+        return not (self.exclude is not None and any(glob_match(path, p) for p in self.exclude))
+
+
+def _translate(pattern: str, braces: bool) -> str:
+    """Regular expression body of a glob (see translate)."""
+    out: List[str] = []
+    i = 0
+    while i < len(pattern):
+        at_segment_start = i == 0 or pattern[i - 1] == "/"
+        if pattern.startswith("**/", i) and at_segment_start:
+            out.append("(?:.*/)?")
+            i += 3
+        elif pattern.startswith("**", i) and at_segment_start and i + 2 == len(pattern):
+            out.append(".*")
+            i += 2
+        elif pattern[i] == "*":
+            out.append("[^/]*")
+            i += 1
+        elif pattern[i] == "?":
+            out.append("[^/]")
+            i += 1
+        elif pattern[i] == "[" and _class_end(pattern, i) is not None:
+            end = _class_end(pattern, i)
+            out.append(_char_class(pattern[i + 1:end]))
+            i = end + 1
+        elif pattern[i] == "{" and braces and _brace_end(pattern, i) is not None:
+            end = _brace_end(pattern, i)
+            alternatives = _split_alternatives(pattern[i + 1:end])
+            out.append("(?:" + "|".join(_translate(a, braces) if a else "" for a in alternatives) + ")")
+            i = end + 1
+        elif pattern[i] == "\\" and i + 1 < len(pattern):
+            out.append(re.escape(pattern[i + 1]))
+            i += 2
+        else:
+            out.append(re.escape(pattern[i]))
+            i += 1
+    return "".join(out)
+
+
+def _class_end(pattern: str, start: int) -> Optional[int]:
+    """Index of the `]` closing a character class opened at start, or None."""
+    i = start + 1
+    if i < len(pattern) and pattern[i] in "!^":
+        i += 1
+    # A `]` right after the opening bracket is part of the class
+    if i < len(pattern) and pattern[i] == "]":
+        i += 1
+    end = pattern.find("]", i)
+    return end if end != -1 else None
+
+
+def _char_class(chars: str) -> str:
+    """Regular expression for the inside of a `[...]` class, never matching `/`."""
+    negated = chars[:1] in ("!", "^")
+    if negated:
+        chars = chars[1:]
+    body = chars.replace("\\", "\\\\").replace("[", "\\[")
+    if body.startswith("]"):
+        body = "\\" + body
+    return f"[^/{body}]" if negated else f"[{body}]"
+
+
+def _brace_end(pattern: str, start: int) -> Optional[int]:
+    """Index of the `}` closing a brace group opened at start, or None."""
+    depth = 0
+    i = start
+    while i < len(pattern):
+        if pattern[i] == "\\":
+            i += 2
+            continue
+        if pattern[i] == "{":
+            depth += 1
+        elif pattern[i] == "}":
+            depth -= 1
+            if depth == 0:
+                return i
+        i += 1
+    return None
+
+
+def _split_alternatives(group: str) -> List[str]:
+    """Split the inside of a brace group at its top-level commas."""
+    alternatives = []
+    depth = 0
+    current = []
+    i = 0
+    while i < len(group):
+        char = group[i]
+        if char == "\\" and i + 1 < len(group):
+            current.append(group[i:i + 2])
+            i += 2
+            continue
+        if char == "{":
+            depth += 1
+        elif char == "}":
+            depth -= 1
+        if char == "," and depth == 0:
+            alternatives.append("".join(current))
+            current = []
+        else:
+            current.append(char)
+        i += 1
+    alternatives.append("".join(current))
+    return alternatives
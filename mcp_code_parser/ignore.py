@@ -0,0 +1,103 @@
+"""Matching paths against .gitignore rules."""
+
+import re
+from dataclasses import dataclass
+from pathlib import Path
+from typing import List
+
+from mcp_code_parser.globs import translate
+from mcp_code_parser.logging import get_logger
+
+logger = get_logger("ignore")
+
+
+@dataclass
+class IgnoreRule:
+    """One pattern line of a .gitignore file."""
+
+    # Root-relative POSIX directory of the .gitignore ("" for the root)
+    base: str
+    pattern: re.Pattern
+    negated: bool = False
+    dir_only: bool = False
+
+
+class IgnoreRules:
+    """The .gitignore rules of a directory tree.
+
+    Supports the commonly used parts of gitignore syntax: `#` comments, `!`
+    negation, trailing `/` for directories, patterns anchored by a `/`, and
+    the `*`, `?`, `[...]` and `**` wildcards of mcp_code_parser.globs. Rules
+    in nested .gitignore files apply below their directory and take
+    precedence over outer ones; within a file the last matching rule wins.
+    """
+
+    def __init__(self, rules: List[IgnoreRule]):
+        """Create rules (use load() to read them from a tree)."""
+        self.rules = rules
+
+    @classmethod
+    def load(cls, root: str) -> "IgnoreRules":
+        """Read every .gitignore under root, skipping hidden directories."""
+        rules: List[IgnoreRule] = []
+        root_path = Path(root)
+        for gitignore in sorted(root_path.rglob(".gitignore"), key=lambda p: len(p.parts)):
+            directory = gitignore.parent.relative_to(root_path)
+            if any(part.startswith(".") for part in directory.parts):
+                continue
+            base = directory.as_posix() if directory.parts else ""
+            try:
+                text = gitignore.read_text(encoding="utf8", errors="replace")
+            except OSError as e:
+                logger.debug(f"Skipping unreadable {gitignore}: {e}")
+                continue
+            rules.extend(parse_gitignore(text, base))
+        return cls(rules)
+
+    def is_ignored(self, path: str, is_dir: bool = False) -> bool:
+        """Check whether a root-relative POSIX path is ignored.
+
+        Only the path itself is tested; callers walking a tree should not
+        descend into ignored directories, as git doesn't.
+        """
+        ignored = False
+        for rule in self.rules:
+            if rule.dir_only and not is_dir:
+                continue
+            if rule.base:
+                if not path.startswith(rule.base + "/"):
+                    continue
+                relative = path[len(rule.base) + 1:]
+            else:
+                relative = path
+            if rule.pattern.match(relative):
+                ignored = not rule.negated
+        return ignored
+
+
+def parse_gitignore(text: str, base: str = "") -> List[IgnoreRule]:
+    """Parse the lines of a .gitignore file located at root-relative directory base."""
+    rules = []
+    for line in text.splitlines():
+        line = line.rstrip()
+        if not line or line.startswith("#"):
+            continue
+        negated = line.startswith("!")
+        if negated or line.startswith("\\"):
+            line = line[1:]
+        dir_only = line.endswith("/")
+        line = line.rstrip("/")
+        if not line:
+            continue
+        # A slash anywhere but the end anchors the pattern to the .gitignore's directory
+        anchored = "/" in line
+        # git doesn't expand braces in .gitignore patterns
+        body = translate(line.lstrip("/"), braces=False)
+        prefix = "" if anchored else "(?:.*/)?"
+        rules.append(IgnoreRule(
+            base=base,
+            pattern=re.compile(f"^{prefix}{body}$"),
+            negated=negated,
+            dir_only=dir_only,
+        ))
+    return rules
@@ -0,0 +1,167 @@
+"""Resolve import specifiers to the files they refer to."""
+
+import os
+import re
+from dataclasses import dataclass
+from pathlib import Path
+from typing import List, Optional, Tuple, Union
+
+from mcp_code_parser.errors import UnsupportedLanguageError
+from mcp_code_parser.extractors.base import Symbol
+from mcp_code_parser.utils import detect_language_from_file
+
+# Why an import wasn't resolved
+REASON_EXTERNAL = "external"
+REASON_NOT_FOUND = "not_found"
+
+# Extensions tried, in order, for an extensionless JavaScript/TypeScript specifier
+_JS_EXTENSIONS = (".ts", ".tsx", ".d.ts", ".js", ".jsx", ".mjs", ".cjs", ".vue", ".svelte")
+# TypeScript ESM code imports "./foo.js" to mean the compiled form of foo.ts
+_JS_SOURCE_EXTENSIONS = {
+    ".js": (".ts", ".tsx"),
+    ".jsx": (".tsx",),
+    ".mjs": (".mts",),
+    ".cjs": (".cts",),
+}
+
+_GO_MODULE = re.compile(r"^\s*module\s+\"?([^\s\"]+)\"?", re.MULTILINE)
+
+
+@dataclass
+class ImportResolution:
+    """Where an import points on disk."""
+
+    # Import specifier as written, e.g. "./util" or "..models"
+    spec: str
+    resolved: bool
+    # File the import resolves to (a package directory for Go)
+    path: Optional[str] = None
+    # REASON_EXTERNAL for packages outside the project (including the standard
+    # library), REASON_NOT_FOUND for local imports with no matching file
+    reason: Optional[str] = None
+
+
+def resolve_import(
+    from_file: str,
+    spec: Union[str, Symbol],
+    language: Optional[str] = None
+) -> ImportResolution:
+    """Resolve an import in from_file to the file it refers to.
+
+    Local imports are resolved: relative JavaScript/TypeScript specifiers
+    (`./foo`, `../lib/bar`), Python relative imports (`.models`) and absolute
+    imports within the same package tree, Go packages under the module
+    declared by the nearest go.mod, and quoted C/C++ includes. Anything else
+    (npm packages, the standard library, other Go modules) is reported as
+    external without touching the filesystem.
+
+    Args:
+        from_file: Path of the importing file
+        spec: Import specifier, or an "import" symbol extracted from from_file
+        language: Language of from_file (detected from its extension if None)
+
+    Returns:
+        The resolution; `resolved` is False for external or missing imports
+
+    Raises:
+        UnsupportedLanguageError: If the language can't be detected or has no
+            import resolution
+    """
+    system_include = False
+    if isinstance(spec, Symbol):
+        # Include symbols are named without their delimiters
+        system_include = (spec.signature or "").lstrip().startswith("#include") and "<" in spec.signature
+        spec = spec.name
+
+    language = language or detect_language_from_file(from_file)
+    base = Path(from_file).resolve().parent
+    if language in ("javascript", "typescript", "vue", "svelte"):
+        return _resolve_js(base, spec)
+    if language == "python":
+        return _resolve_python(base, spec)
+    if language == "go":
+        return _resolve_go(base, spec)
+    if language in ("c", "cpp"):
+        if system_include:
+            return ImportResolution(spec=spec, resolved=False, reason=REASON_EXTERNAL)
+        return _first_file(spec, [base / spec])
+    raise UnsupportedLanguageError(f"Import resolution not supported for language: {language or from_file}")
+
+
+def _resolve_js(base: Path, spec: str) -> ImportResolution:
+    """Resolve a relative module specifier the way Node and bundlers do."""
+    if not spec.startswith(("./", "../")) and spec not in (".", ".."):
+        return ImportResolution(spec=spec, resolved=False, reason=REASON_EXTERNAL)
+
+    target = Path(os.path.normpath(base / spec))
+    candidates = []
+    if spec not in (".", "..") and not spec.endswith("/"):
+        candidates.append(target)
+        source_extensions = _JS_SOURCE_EXTENSIONS.get(target.suffix)
+        if source_extensions:
+            candidates += [target.with_suffix(ext) for ext in source_extensions]
+        candidates += [target.with_name(target.name + ext) for ext in _JS_EXTENSIONS]
+    candidates += [target / f"index{ext}" for ext in _JS_EXTENSIONS]
+    return _first_file(spec, candidates)
+
+
+def _resolve_python(base: Path, spec: str) -> ImportResolution:
+    """Resolve a dotted module name, relative (leading dots) or absolute."""
+    level = len(spec) - len(spec.lstrip("."))
+    parts = [p for p in spec[level:].split(".") if p]
+    if level:
+        # One dot is the current package, each extra dot goes up a level
+        for _ in range(level - 1):
+            base = base.parent
+    else:
+        base = _python_root(base)
+
+    target = base.joinpath(*parts)
+    candidates = [target / "__init__.py"]
+    if parts:
+        candidates.insert(0, target.with_name(target.name + ".py"))
+    resolution = _first_file(spec, candidates)
+    if not level and not resolution.resolved:
+        # Not part of this package tree: assume it's installed
+        resolution.reason = REASON_EXTERNAL
+    return resolution
+
+
+def _python_root(directory: Path) -> Path:
+    """Directory that absolute imports of the package containing directory start from."""
+    while (directory / "__init__.py").is_file() and directory.parent != directory:
+        directory = directory.parent
+    return directory
+
+
+def _resolve_go(base: Path, spec: str) -> ImportResolution:
+    """Resolve an import path inside the module of the nearest go.mod."""
+    module = _go_module(base)
+    if module is None:
+        return ImportResolution(spec=spec, resolved=False, reason=REASON_EXTERNAL)
+    module_path, module_root = module
+    if spec != module_path and not spec.startswith(module_path + "/"):
+        return ImportResolution(spec=spec, resolved=False, reason=REASON_EXTERNAL)
+
+    package_dir = module_root.joinpath(*spec[len(module_path):].split("/"))
+    if package_dir.is_dir():
+        return ImportResolution(spec=spec, resolved=True, path=os.path.normpath(package_dir))
+    return ImportResolution(spec=spec, resolved=False, reason=REASON_NOT_FOUND)
+
+
+def _go_module(directory: Path) -> Optional[Tuple[str, Path]]:
+    """(module path, module root) from the closest go.mod at or above directory."""
+    for candidate in [directory, *directory.parents]:
+        go_mod = candidate / "go.mod"
+        if go_mod.is_file():
+            match = _GO_MODULE.search(go_mod.read_text(encoding="utf8", errors="replace"))
+            return (match.group(1), candidate) if match else None
+    return None
+
+
+def _first_file(spec: str, candidates: List[Path]) -> ImportResolution:
+    """Resolution for the first candidate that is an existing file."""
+    for candidate in candidates:
+        if candidate.is_file():
+            return ImportResolution(spec=spec, resolved=True, path=os.path.normpath(candidate))
+    return ImportResolution(spec=spec, resolved=False, reason=REASON_NOT_FOUND)
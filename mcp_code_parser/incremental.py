@@ -0,0 +1,208 @@
+"""Incremental re-extraction of a single document as it is edited."""
+
+import asyncio
+from dataclasses import dataclass, field
+from typing import TYPE_CHECKING, Dict, List, Optional, Tuple
+
+from mcp_code_parser.extractors.base import ExtractOptions, Outline, Symbol
+from mcp_code_parser.logging import get_logger
+
+if TYPE_CHECKING:
+    from mcp_code_parser.api import AgentTools
+
+logger = get_logger("incremental")
+
+
+@dataclass
+class TextEdit:
+    """Replace content[start:end] (character offsets) with text."""
+
+    start: int
+    end: int
+    text: str
+
+
+@dataclass
+class SymbolChanges:
+    """Symbols that differ between two extractions of a document."""
+
+    added: List[Symbol] = field(default_factory=list)
+    removed: List[Symbol] = field(default_factory=list)
+    # New versions of symbols whose source text changed
+    modified: List[Symbol] = field(default_factory=list)
+
+    @property
+    def empty(self) -> bool:
+        """Check if nothing changed."""
+        return not (self.added or self.removed or self.modified)
+
+
+class IncrementalParser:
+    """Track a document's content and report changed symbols after edits.
+
+    Symbols are matched across versions by kind and dotted name path (e.g.
+    ``method UserService.GetUser``) and compared by their source text.
+    """
+
+    def __init__(
+        self,
+        content: str,
+        language: str,
+        options: Optional[ExtractOptions] = None,
+        path: Optional[str] = None,
+        tools: Optional["AgentTools"] = None
+    ):
+        """Create a parser for a document.
+
+        Args:
+            content: Initial document content
+            language: Programming language
+            options: Optional extraction options
+            path: Optional file path of the document
+            tools: AgentTools instance to use (defaults to the global one)
+        """
+        if tools is None:
+            from mcp_code_parser.api import _global_tools
+            tools = _global_tools
+        self.content = content
+        self.language = language
+        self.options = options or ExtractOptions()
+        self.path = path
+        self.outline: Optional[Outline] = None
+        self._tools = tools
+        self._index: Dict[Tuple[str, str], Tuple[Symbol, str]] = {}
+
+    async def extract(self) -> Outline:
+        """Extract the current content and reset the baseline for diffs."""
+        self.outline = await self._tools.extract_symbols(
+            self.content, self.language, self.options, self.path
+        )
+        self._index = _index(self.outline.symbols, self.content)
+        return self.outline
+
+    async def apply(self, edits: List[TextEdit]) -> SymbolChanges:
+        """Apply edits in order, re-extract once, and diff against the last extraction.
+
+        Each edit's offsets refer to the content after the previous edits.
+        """
+        if self.outline is None:
+            await self.extract()
+        for edit in edits:
+            self.content = self.content[:edit.start] + edit.text + self.content[edit.end:]
+
+        old = self._index
+        await self.extract()
+        new = self._index
+
+        changes = SymbolChanges()
+        for key, (sym, text) in new.items():
+            if key not in old:
+                changes.added.append(sym)
+            elif old[key][1] != text:
+                changes.modified.append(sym)
+        for key, (sym, _) in old.items():
+            if key not in new:
+                changes.removed.append(sym)
+        return changes
+
+
+class Debouncer:
+    """Coalesce rapid edits into one re-extraction per quiet window.
+
+    Edits submitted less than ``window`` seconds apart are batched; once the
+    document is quiet for a full window the batch is applied with a single
+    ``IncrementalParser.apply`` call and the result is put on ``changes``.
+    Pending edits are also flushed by ``flush()`` and when the context exits,
+    including when the enclosing task is cancelled.
+
+    Example:
+        async with Debouncer(parser, window=0.2) as debouncer:
+            await debouncer.submit(TextEdit(0, 0, "// header\\n"))
+            changes = await debouncer.changes.get()
+    """
+
+    def __init__(self, parser: IncrementalParser, window: float = 0.1):
+        """Create a debouncer.
+
+        Args:
+            parser: Parser the batched edits are applied to
+            window: Quiet period in seconds before a batch is applied
+        """
+        self.parser = parser
+        self.window = window
+        self.changes: asyncio.Queue = asyncio.Queue()
+        self._pending: List[TextEdit] = []
+        self._timer: Optional[asyncio.Task] = None
+        self._lock = asyncio.Lock()
+
+    async def __aenter__(self) -> "Debouncer":
+        """Enter the context; edits can be submitted until it exits."""
+        return self
+
+    async def __aexit__(self, exc_type, exc_val, exc_tb) -> None:
+        """Flush pending edits on leaving the context."""
+        await self.close()
+
+    @property
+    def pending(self) -> int:
+        """Number of edits waiting to be applied."""
+        return len(self._pending)
+
+    async def submit(self, edit: TextEdit) -> None:
+        """Queue an edit and restart the quiet-window timer."""
+        self._pending.append(edit)
+        self._cancel_timer()
+        self._timer = asyncio.create_task(self._wait_and_flush())
+
+    async def flush(self) -> Optional[SymbolChanges]:
+        """Apply pending edits now.
+
+        Returns:
+            The coalesced changes, or None if nothing was pending
+        """
+        self._cancel_timer()
+        async with self._lock:
+            if not self._pending:
+                return None
+            batch, self._pending = self._pending, []
+            logger.debug(f"Applying {len(batch)} coalesced edits")
+            changes = await self.parser.apply(batch)
+            self.changes.put_nowait(changes)
+            return changes
+
+    async def close(self) -> None:
+        """Flush pending edits and stop the timer."""
+        await self.flush()
+
+    def _cancel_timer(self) -> None:
+        """Cancel the quiet-window timer unless it is the caller."""
+        if self._timer is not None and self._timer is not asyncio.current_task():
+            self._timer.cancel()
+        self._timer = None
+
+    async def _wait_and_flush(self) -> None:
+        """Flush after a quiet window, unless another edit restarts the timer."""
+        await asyncio.sleep(self.window)
+        # Past this point a new edit must not cancel the in-progress apply
+        self._timer = None
+        try:
+            await self.flush()
+        except Exception as e:
+            # There's no caller to raise to; leave the error in the log
+            logger.error(f"Debounced re-extraction failed: {e}")
+
+
+def _index(symbols: List[Symbol], content: str) -> Dict[Tuple[str, str], Tuple[Symbol, str]]:
+    """Map (kind, dotted name) to each symbol and its source text."""
+    source = content.encode("utf8")
+    index: Dict[Tuple[str, str], Tuple[Symbol, str]] = {}
+
+    def visit(syms: List[Symbol], prefix: str) -> None:
+        for sym in syms:
+            name = f"{prefix}{sym.name}"
+            text = source[sym.start_byte:sym.end_byte].decode("utf8", errors="replace")
+            index.setdefault((sym.kind, name), (sym, text))
+            visit(sym.children, f"{name}.")
+
+    visit(symbols, "")
+    return index
@@ -0,0 +1,292 @@
+"""Persistent repo-wide symbol index."""
+
+import json
+import sqlite3
+from abc import ABC, abstractmethod
+from dataclasses import asdict, dataclass, field
+from pathlib import Path
+from typing import TYPE_CHECKING, Any, Dict, List, Optional
+
+from mcp_code_parser.errors import PathTraversalError
+from mcp_code_parser.extractors.base import ExtractOptions, Outline, Symbol, walk_symbols
+from mcp_code_parser.extractors.names import qualify
+from mcp_code_parser.ignore import IgnoreRules
+from mcp_code_parser.logging import get_logger
+from mcp_code_parser.utils import detect_language_from_file, hash_content, resolve_within, safe_read_file
+
+if TYPE_CHECKING:
+    from mcp_code_parser.api import AgentTools
+
+logger = get_logger("index")
+
+
+class Storage(ABC):
+    """Key-value backend for the index. Values are JSON-serializable dicts."""
+
+    @abstractmethod
+    def get(self, key: str) -> Optional[Dict[str, Any]]:
+        """Get the value stored under key, or None."""
+        pass
+
+    @abstractmethod
+    def put(self, key: str, value: Dict[str, Any]) -> None:
+        """Store value under key, replacing any previous value."""
+        pass
+
+    @abstractmethod
+    def delete(self, key: str) -> None:
+        """Remove key if present."""
+        pass
+
+    @abstractmethod
+    def keys(self) -> List[str]:
+        """List all stored keys in sorted order."""
+        pass
+
+    def commit(self) -> None:
+        """Make preceding writes durable (no-op for non-persistent backends)."""
+        pass
+
+    def close(self) -> None:
+        """Release the backend."""
+        pass
+
+
+class MemoryStorage(Storage):
+    """In-process storage, for tests and throwaway indexes."""
+
+    def __init__(self):
+        self._data: Dict[str, str] = {}
+
+    def get(self, key: str) -> Optional[Dict[str, Any]]:
+        """Get the value stored under key, or None."""
+        raw = self._data.get(key)
+        return json.loads(raw) if raw is not None else None
+
+    def put(self, key: str, value: Dict[str, Any]) -> None:
+        """Store value under key, replacing any previous value."""
+        # Serialize so callers can't mutate stored entries, as with SQLite
+        self._data[key] = json.dumps(value)
+
+    def delete(self, key: str) -> None:
+        """Remove key if present."""
+        self._data.pop(key, None)
+
+    def keys(self) -> List[str]:
+        """List all stored keys in sorted order."""
+        return sorted(self._data)
+
+
+class SQLiteStorage(Storage):
+    """Storage in a single SQLite database file."""
+
+    def __init__(self, path: str):
+        """Open (or create) the database at path."""
+        self.path = path
+        self._conn = sqlite3.connect(path)
+        self._conn.execute(
+            "CREATE TABLE IF NOT EXISTS entries (key TEXT PRIMARY KEY, value TEXT NOT NULL)"
+        )
+        self._conn.commit()
+
+    def get(self, key: str) -> Optional[Dict[str, Any]]:
+        """Get the value stored under key, or None."""
+        row = self._conn.execute("SELECT value FROM entries WHERE key = ?", (key,)).fetchone()
+        return json.loads(row[0]) if row else None
+
+    def put(self, key: str, value: Dict[str, Any]) -> None:
+        """Store value under key, replacing any previous value."""
+        self._conn.execute(
+            "INSERT OR REPLACE INTO entries (key, value) VALUES (?, ?)",
+            (key, json.dumps(value)),
+        )
+
+    def delete(self, key: str) -> None:
+        """Remove key if present."""
+        self._conn.execute("DELETE FROM entries WHERE key = ?", (key,))
+
+    def keys(self) -> List[str]:
+        """List all stored keys in sorted order."""
+        return [row[0] for row in self._conn.execute("SELECT key FROM entries ORDER BY key")]
+
+    def commit(self) -> None:
+        """Commit pending writes to disk."""
+        self._conn.commit()
+
+    def close(self) -> None:
+        """Commit and close the database."""
+        self._conn.commit()
+        self._conn.close()
+
+
+@dataclass
+class IndexUpdate:
+    """Files affected by an Index.update() call (root-relative POSIX paths)."""
+
+    added: List[str] = field(default_factory=list)
+    updated: List[str] = field(default_factory=list)
+    removed: List[str] = field(default_factory=list)
+    unchanged: int = 0
+
+
+@dataclass
+class IndexMatch:
+    """A symbol found by an index query."""
+
+    file: str
+    symbol: Symbol
+    # Qualified name, e.g. "UserService.GetUser" (see mcp_code_parser.extractors.names)
+    qualified_name: str
+
+
+class Index:
+    """Outlines for every supported file under a root, cached by content hash.
+
+    Each file is stored with the hash of the content (and the options) it was
+    extracted with, so update() only re-extracts files that changed and prunes
+    entries for files that no longer exist.
+
+    Example:
+        with Index("repo", SQLiteStorage("repo.db")) as index:
+            await index.update()
+            matches = index.query(name="GetUser")
+    """
+
+    def __init__(
+        self,
+        root: str,
+        storage: Storage,
+        options: Optional[ExtractOptions] = None,
+        tools: Optional["AgentTools"] = None,
+        respect_gitignore: bool = False
+    ):
+        """Create an index over root.
+
+        Args:
+            root: Directory to index
+            storage: Backend holding the entries
+            options: Optional extraction options applied to every file
+            tools: AgentTools instance to use (defaults to the global one)
+            respect_gitignore: Leave out files ignored by .gitignore rules
+        """
+        if tools is None:
+            from mcp_code_parser.api import _global_tools
+            tools = _global_tools
+        self.root = root
+        self.storage = storage
+        self.options = options or ExtractOptions()
+        self.respect_gitignore = respect_gitignore
+        self._tools = tools
+
+    def __enter__(self) -> "Index":
+        """Enter the context; storage is closed on exit."""
+        return self
+
+    def __exit__(self, exc_type, exc_val, exc_tb) -> None:
+        """Close the storage on leaving the context."""
+        self.close()
+
+    async def update(self) -> IndexUpdate:
+        """Re-extract new and changed files and prune deleted ones."""
+        from mcp_code_parser.api import _walk_files
+
+        result = IndexUpdate()
+        options = asdict(self.options)
+        seen = set()
+        ignore = IgnoreRules.load(self.root) if self.respect_gitignore else None
+        for path in _walk_files(Path(self.root), ignore):
+            language = detect_language_from_file(str(path))
+            if self._tools.get_extractor(language or "") is None:
+                continue
+            rel = path.relative_to(self.root).as_posix()
+            try:
+                resolve_within(self.root, rel)
+                content = safe_read_file(
+                    str(path),
+                    max_size=self.options.max_file_size,
+                    detector=self._tools.binary_detector,
+                )
+            except PathTraversalError:
+                continue
+            except Exception as e:
+                # Unreadable files are left out; any previous entry is pruned below
+                logger.debug(f"Not indexing {rel}: {e}")
+                continue
+
+            seen.add(rel)
+            digest = hash_content(content)
+            entry = self.storage.get(rel)
+            # Entries extracted with different options are stale too
+            if entry is not None and entry["hash"] == digest and entry.get("options") == options:
+                result.unchanged += 1
+                continue
+
+            outline = await self._tools.extract_symbols(content, language, self.options, str(path))
+            outline.metadata["file"] = str(path)
+            self.storage.put(rel, {"hash": digest, "options": options, "outline": outline.to_dict()})
+            (result.updated if entry is not None else result.added).append(rel)
+
+        for rel in self.storage.keys():
+            if rel not in seen:
+                self.storage.delete(rel)
+                result.removed.append(rel)
+
+        self.storage.commit()
+        return result
+
+    def files(self) -> List[str]:
+        """List indexed files in path order."""
+        return self.storage.keys()
+
+    def outline(self, file: str) -> Optional[Outline]:
+        """Get the stored outline for a root-relative POSIX path."""
+        entry = self.storage.get(file)
+        return Outline.from_dict(entry["outline"]) if entry is not None else None
+
+    def query(
+        self,
+        name: Optional[str] = None,
+        kind: Optional[str] = None,
+        prefix: Optional[str] = None,
+        exported: Optional[bool] = None,
+        attribute: Optional[str] = None
+    ) -> List[IndexMatch]:
+        """Find symbols (at any depth) matching all given criteria.
+
+        Args:
+            name: Exact symbol name
+            kind: Symbol kind, e.g. "function" or "struct"
+            prefix: Name prefix
+            exported: Only exported (True) or unexported (False) symbols
+            attribute: Only symbols carrying this attribute, e.g. "Test"
+                (see Symbol.has_attribute)
+
+        Returns:
+            Matches in file then source order
+        """
+        matches = []
+        for file in self.files():
+            outline = self.outline(file)
+            if outline is None:
+                continue
+            # Qualified name of each symbol's parent, recorded when the parent is reached
+            scopes: Dict[int, str] = {}
+            for sym in walk_symbols(outline.symbols):
+                qualified = qualify(sym, scopes.get(id(sym)))
+                scopes.update((id(child), qualified) for child in sym.children)
+                if name is not None and sym.name != name:
+                    continue
+                if kind is not None and sym.kind != kind:
+                    continue
+                if prefix is not None and not sym.name.startswith(prefix):
+                    continue
+                if exported is not None and sym.exported != exported:
+                    continue
+                if attribute is not None and not sym.has_attribute(attribute):
+                    continue
+                matches.append(IndexMatch(file=file, symbol=sym, qualified_name=qualified))
+        return matches
+
+    def close(self) -> None:
+        """Close the storage."""
+        self.storage.close()
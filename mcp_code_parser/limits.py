@@ -0,0 +1,126 @@
+"""Per-call resource limits for extraction.
+
+A shared service can cap what one request may cost with
+ExtractOptions.limits. Parsing a file is one call into tree-sitter, so time
+is checked after each parse (including those completing a partial buffer)
+rather than during it; node counts and memory are checked on the tree
+before any symbols are built from it. Memory is estimated from the source
+size and node count rather than measured.
+"""
+
+import time
+from dataclasses import dataclass
+from typing import Awaitable, Callable, Optional, Tuple
+
+import tree_sitter
+
+from mcp_code_parser.errors import LimitExceededError
+
+# Approximate bytes a syntax tree holds per node, for max_memory_bytes
+NODE_BYTES = 64
+
+
+@dataclass
+class Limits:
+    """Caps on the cost of one extraction; None leaves a resource unlimited."""
+
+    # Seconds spent parsing, e.g. 0.5
+    max_parse_time: Optional[float] = None
+    # Syntax tree nodes, named or not
+    max_nodes: Optional[int] = None
+    # Estimated bytes held by the source and its tree (NODE_BYTES per node)
+    max_memory_bytes: Optional[int] = None
+
+
+class LimitGuard:
+    """Checks one extraction against its limits."""
+
+    def __init__(self, limits: Limits, source_size: int):
+        """Create a guard for an extraction of source_size bytes.
+
+        Raises:
+            LimitExceededError: If the source alone exceeds max_memory_bytes
+        """
+        self.limits = limits
+        self.source_size = source_size
+        self._parse_time = 0.0
+        if limits.max_memory_bytes is not None and source_size > limits.max_memory_bytes:
+            raise LimitExceededError(
+                f"Source of {source_size} bytes exceeds max_memory_bytes={limits.max_memory_bytes}",
+                "max_memory_bytes",
+            )
+
+    def node_budget(self) -> Optional[Tuple[int, str]]:
+        """Most nodes a tree may have, and the limit that sets it (None if unlimited)."""
+        budgets = []
+        if self.limits.max_nodes is not None:
+            budgets.append((self.limits.max_nodes, "max_nodes"))
+        if self.limits.max_memory_bytes is not None:
+            budgets.append(((self.limits.max_memory_bytes - self.source_size) // NODE_BYTES, "max_memory_bytes"))
+        return min(budgets) if budgets else None
+
+    def timed(
+        self,
+        parse: Callable[[str, str], Awaitable[tree_sitter.Tree]]
+    ) -> Callable[[str, str], Awaitable[tree_sitter.Tree]]:
+        """Wrap a parse function to add its time to the time spent parsing.
+
+        The wrapper raises LimitExceededError once parsing has taken longer
+        than max_parse_time in total.
+        """
+
+        async def timed_parse(content: str, language: str) -> tree_sitter.Tree:
+            started = time.monotonic()
+            tree = await parse(content, language)
+            self._parse_time += time.monotonic() - started
+            limit = self.limits.max_parse_time
+            if limit is not None and self._parse_time > limit:
+                raise LimitExceededError(
+                    f"Parsing took {self._parse_time:.3f}s, over max_parse_time={limit}s", "max_parse_time"
+                )
+            return tree
+
+        return timed_parse
+
+    def check_tree(self, root: tree_sitter.Node) -> Optional[int]:
+        """Check a tree against the node budget.
+
+        Returns:
+            None if the tree is within budget, else the end byte of the
+            longest run of the root's leading children that is, so what
+            precedes it can still be extracted
+        """
+        budget = self.node_budget()
+        if budget is None or node_count(root, budget[0]) <= budget[0]:
+            return None
+        # The root itself counts as one node
+        used = 1
+        end = root.start_byte
+        for child in root.children:
+            used += node_count(child, budget[0] - used)
+            if used > budget[0]:
+                break
+            end = child.end_byte
+        return end
+
+    def tree_error(self) -> LimitExceededError:
+        """The error for a tree over the node budget."""
+        count, name = self.node_budget()
+        return LimitExceededError(f"Syntax tree exceeds {name}, a budget of {count} nodes", name)
+
+
+def node_count(node: tree_sitter.Node, limit: Optional[int] = None) -> int:
+    """Number of nodes in a subtree, counting stops once it passes limit."""
+    count = getattr(node, "descendant_count", None)
+    if count is not None:
+        return count
+    # Imported here as analysis.base depends on the extractors, which depend on this module
+    from mcp_code_parser.analysis.base import walk
+
+    count = 0
+    for _ in walk(node):
+        count += 1
+        if limit is not None and count > limit:
+            break
+    return count
+
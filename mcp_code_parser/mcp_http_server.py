@@ -85,7 +85,8 @@ class MCPHandler(BaseHTTPRequestHandler):
             "language": result.language,
             "ast": result.ast_text,
             "metadata": result.metadata,
-            "error": result.error
+            "error": result.error,
+            "error_code": result.error_code
         })
     
     def _handle_parse_file(self, data: Dict[str, Any]):
@@ -106,7 +107,8 @@ class MCPHandler(BaseHTTPRequestHandler):
             "language": result.language,
             "ast": result.ast_text,
             "metadata": result.metadata,
-            "error": result.error
+            "error": result.error,
+            "error_code": result.error_code
         })
     
     def _handle_check_language(self, data: Dict[str, Any]):
@@ -31,7 +31,7 @@ async def parse_code(content: str, language: str) -> dict:
     
     Args:
         content: Source code content to parse
-        language: Programming language (python, javascript, typescript, go, cpp)
+        language: Programming language (python, javascript, typescript, go, cpp, dart, elixir)
         
     Returns:
         Dictionary with parsing results including AST
@@ -49,7 +49,8 @@ async def parse_code(content: str, language: str) -> dict:
         "language": result.language,
         "ast": result.ast_text,
         "metadata": result.metadata,
-        "error": result.error
+        "error": result.error,
+        "error_code": result.error_code
     }
 
 
@@ -77,7 +78,8 @@ async def parse_file(file_path: str, language: Optional[str] = None) -> dict:
         "language": result.language,
         "ast": result.ast_text,
         "metadata": result.metadata,
-        "error": result.error
+        "error": result.error,
+        "error_code": result.error_code
     }
 
 
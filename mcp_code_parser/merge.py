@@ -0,0 +1,97 @@
+"""Combining the outlines of declarations split across files."""
+
+import copy
+import re
+from typing import List, Optional
+
+from mcp_code_parser.extractors.base import CALLABLE_KINDS, REPEATABLE_KINDS, Outline, Symbol
+
+# Generic arguments and pointer marks around a receiver type name
+_RECEIVER_DECORATION = re.compile(r"^\*|\[.*\]$|<.*>$")
+
+
+def merge_outlines(*outlines: Outline) -> Outline:
+    """Merge outlines of one language into a single tree.
+
+    Symbols with the same stable ID are merged into the first one found,
+    with their children merged the same way, so a C# partial class or a
+    namespace declared in several files becomes one symbol. Top-level
+    callables with a receiver (Go methods, C++ out-of-class definitions) are
+    moved under the type they belong to when it is in one of the outlines.
+    Every symbol's `file` is set from its outline's "file" metadata, and
+    the package is kept when all outlines declare the same one. The inputs
+    are left unchanged.
+
+    Args:
+        outlines: Outlines to merge, e.g. from extract_file on each file of a
+            Go package
+
+    Returns:
+        The merged outline; metadata["files"] lists the merged files in order
+
+    Raises:
+        ValueError: If there are no outlines or they are of different languages
+        ToolError: If one of the outlines failed (see Outline.raise_for_error)
+    """
+    if not outlines:
+        raise ValueError("No outlines to merge")
+    languages = sorted({o.language for o in outlines})
+    if len(languages) > 1:
+        raise ValueError(f"Can't merge outlines of different languages: {', '.join(languages)}")
+    for outline in outlines:
+        outline.raise_for_error()
+
+    merged: List[Symbol] = []
+    for outline in outlines:
+        file = outline.metadata.get("file")
+        for sym in outline.symbols:
+            sym = copy.deepcopy(sym)
+            _set_file(sym, file)
+            _merge_into(merged, sym)
+
+    symbols = []
+    for sym in merged:
+        owner = _receiver_owner(merged, sym)
+        if owner is not None:
+            _merge_into(owner.children, sym)
+        else:
+            symbols.append(sym)
+
+    packages = {o.package for o in outlines}
+    return Outline(
+        language=languages[0],
+        symbols=symbols,
+        metadata={"files": [o.metadata.get("file") for o in outlines]},
+        package=packages.pop() if len(packages) == 1 else None,
+    )
+
+
+def _merge_into(symbols: List[Symbol], sym: Symbol) -> None:
+    """Add sym to symbols, merging it into a symbol with the same stable ID."""
+    existing = None
+    # Repeatable kinds (each file's `func init`) are separate declarations
+    if sym.stable_id is not None and sym.kind not in REPEATABLE_KINDS:
+        existing = next((s for s in symbols if s.stable_id == sym.stable_id), None)
+    if existing is None:
+        symbols.append(sym)
+        return
+    for child in sym.children:
+        _merge_into(existing.children, child)
+
+
+def _receiver_owner(symbols: List[Symbol], sym: Symbol) -> Optional[Symbol]:
+    """The top-level type a callable declared apart from it belongs to."""
+    if sym.receiver is None or sym.kind not in CALLABLE_KINDS:
+        return None
+    name = _RECEIVER_DECORATION.sub("", sym.receiver.strip())
+    return next(
+        (s for s in symbols if s is not sym and s.receiver is None and s.name == name and s.kind not in CALLABLE_KINDS),
+        None,
+    )
+
+
+def _set_file(sym: Symbol, file: Optional[str]) -> None:
+    """Set file on a symbol and its descendants."""
+    sym.file = file
+    for child in sym.children:
+        _set_file(child, file)
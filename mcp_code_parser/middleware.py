@@ -0,0 +1,94 @@
+"""Composable middleware for layering cross-cutting concerns onto tools.
+
+A tool is any async function, such as where_is_symbol or extract_file. A
+middleware takes a tool and returns a tool wrapping it, which can inspect or
+change the arguments before calling through, change the result, or return
+without calling the tool at all.
+"""
+
+import functools
+import logging
+import time
+from typing import Any, Awaitable, Callable, Dict, Hashable, Optional
+
+from mcp_code_parser.logging import get_logger
+
+Tool = Callable[..., Awaitable[Any]]
+ToolMiddleware = Callable[[Tool], Tool]
+# Derives a cache key from a call's arguments, e.g. lambda args, kwargs: args[1]
+CacheKey = Callable[[tuple, Dict[str, Any]], Hashable]
+
+
+def chain(tool: Tool, *middleware: ToolMiddleware) -> Tool:
+    """Wrap a tool in middleware, the first given being the outermost.
+
+    chain(search, logging_middleware(), caching_middleware()) logs every
+    call, cache hits included, while chain(search, caching_middleware(),
+    logging_middleware()) only logs the calls that reach the tool.
+    """
+    for wrap in reversed(middleware):
+        tool = wrap(tool)
+    return tool
+
+
+def logging_middleware(logger: Optional[logging.Logger] = None, level: int = logging.INFO) -> ToolMiddleware:
+    """Middleware logging each call with its arguments and duration, and any exception.
+
+    Args:
+        logger: Logger to write to (defaults to mcp_code_parser.tools)
+        level: Level of the call records; failures are logged as errors
+    """
+    log = logger or get_logger("tools")
+
+    def wrap(tool: Tool) -> Tool:
+        name = getattr(tool, "__name__", repr(tool))
+
+        @functools.wraps(tool)
+        async def logged(*args: Any, **kwargs: Any) -> Any:
+            log.log(level, f"{name} called with args={args!r} kwargs={kwargs!r}")
+            started = time.perf_counter()
+            try:
+                result = await tool(*args, **kwargs)
+            except Exception as e:
+                log.error(f"{name} failed after {time.perf_counter() - started:.3f}s: {e}")
+                raise
+            log.log(level, f"{name} returned in {time.perf_counter() - started:.3f}s")
+            return result
+
+        return logged
+
+    return wrap
+
+
+def caching_middleware(cache: Optional[Dict[Hashable, Any]] = None, key: Optional[CacheKey] = None) -> ToolMiddleware:
+    """Middleware returning the stored result of an earlier call with the same arguments.
+
+    Failed calls aren't cached. Results are shared between callers, so they
+    shouldn't be modified.
+
+    Args:
+        cache: Mapping to store results in (a new dict if None); pass one
+            to inspect or clear it
+        key: Cache key of a call (by default its arguments' reprs)
+    """
+    store: Dict[Hashable, Any] = {} if cache is None else cache
+    make_key = key or _call_key
+
+    def wrap(tool: Tool) -> Tool:
+        @functools.wraps(tool)
+        async def cached(*args: Any, **kwargs: Any) -> Any:
+            call_key = make_key(args, kwargs)
+            if call_key in store:
+                return store[call_key]
+            result = await tool(*args, **kwargs)
+            store[call_key] = result
+            return result
+
+        return cached
+
+    return wrap
+
+
+def _call_key(args: tuple, kwargs: Dict[str, Any]) -> Hashable:
+    """Key of a call from the reprs of its arguments, keyword order ignored."""
+    return tuple(repr(a) for a in args), tuple(sorted((k, repr(v)) for k, v in kwargs.items()))
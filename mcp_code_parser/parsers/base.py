@@ -1,9 +1,17 @@
 """Base parser interface for all code parsers."""
 
 from abc import ABC, abstractmethod
-from dataclasses import dataclass
+from dataclasses import dataclass, field
 from typing import Any, Dict, List, Optional
 
+from mcp_code_parser.errors import (
+    Diagnostic,
+    ParseFailedError,
+    ToolError,
+    UnsupportedLanguageError,
+    error_for_code,
+)
+
 
 @dataclass
 class ParseResult:
@@ -13,11 +21,20 @@ class ParseResult:
     ast_text: str
     metadata: Dict[str, Any]
     error: Optional[str] = None
+    # Taxonomy code of the error (see mcp_code_parser.errors)
+    error_code: Optional[str] = None
+    # Syntax problems in the source; parsing still succeeds with a partial tree
+    diagnostics: List[Diagnostic] = field(default_factory=list)
     
     @property
     def success(self) -> bool:
         """Check if parsing was successful."""
         return self.error is None
+    
+    def raise_for_error(self) -> None:
+        """Raise the categorized ToolError for a failed result."""
+        if self.error is not None:
+            raise error_for_code(self.error_code, self.error, self.diagnostics)
 
 
 class BaseParser(ABC):
@@ -78,21 +95,21 @@ class BaseParser(ABC):
         return "\n".join(lines)
 
 
-class ParserError(Exception):
+class ParserError(ToolError):
     """Base exception for parser errors."""
     pass
 
 
-class GrammarNotFoundError(ParserError):
+class GrammarNotFoundError(ParserError, UnsupportedLanguageError):
     """Raised when language grammar cannot be found or downloaded."""
     pass
 
 
-class LanguageNotSupportedError(ParserError):
+class LanguageNotSupportedError(ParserError, UnsupportedLanguageError):
     """Raised when language is not supported."""
     pass
 
 
-class ParseError(ParserError):
+class ParseError(ParserError, ParseFailedError):
     """Raised when code cannot be parsed."""
     pass
\ No newline at end of file
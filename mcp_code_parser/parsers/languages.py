@@ -3,6 +3,8 @@
 from dataclasses import dataclass
 from typing import Dict, List, Optional
 
+from mcp_code_parser.registry import language_registry
+
 
 @dataclass
 class LanguageConfig:
@@ -101,17 +103,83 @@ LANGUAGE_CONFIGS: Dict[str, LanguageConfig] = {
         ],
         file_extensions=[".cpp", ".cc", ".cxx", ".hpp", ".h", ".hxx"],
     ),
+    
+    "dart": LanguageConfig(
+        name="dart",
+        grammar_url="https://github.com/UserNobody14/tree-sitter-dart",
+        grammar_repo="UserNobody14/tree-sitter-dart",
+        node_types_to_include=[
+            "program", "class_definition", "mixin_declaration",
+            "extension_declaration", "enum_declaration", "function_signature",
+            "method_signature", "function_body", "if_statement", "for_statement",
+            "while_statement", "switch_statement", "try_statement",
+            "local_variable_declaration", "assignment_expression", "annotation",
+        ],
+        file_extensions=[".dart"],
+    ),
+    
+    "elixir": LanguageConfig(
+        name="elixir",
+        grammar_url="https://github.com/elixir-lang/tree-sitter-elixir",
+        grammar_repo="elixir-lang/tree-sitter-elixir",
+        node_types_to_include=[
+            "source", "call", "do_block", "unary_operator", "binary_operator",
+            "anonymous_function", "stab_clause", "keywords", "pair", "map",
+            "struct", "list", "tuple",
+        ],
+        file_extensions=[".ex", ".exs"],
+    ),
+    
+    "json": LanguageConfig(
+        name="json",
+        grammar_url="https://github.com/tree-sitter/tree-sitter-json",
+        grammar_repo="tree-sitter/tree-sitter-json",
+        node_types_to_include=["document", "object", "pair", "array"],
+        file_extensions=[".json"],
+    ),
+    
+    "yaml": LanguageConfig(
+        name="yaml",
+        grammar_url="https://github.com/tree-sitter-grammars/tree-sitter-yaml",
+        grammar_repo="tree-sitter-grammars/tree-sitter-yaml",
+        node_types_to_include=[
+            "stream", "document", "block_mapping", "block_mapping_pair",
+            "block_sequence", "block_sequence_item", "flow_mapping", "flow_pair",
+            "flow_sequence",
+        ],
+        file_extensions=[".yaml", ".yml"],
+    ),
 }
 
 
 def get_language_config(language: str) -> Optional[LanguageConfig]:
-    """Get configuration for a language."""
-    return LANGUAGE_CONFIGS.get(language.lower())
+    """Get configuration for a language.
+    
+    Languages registered with a grammar get a configuration built from their
+    backend; registered languages without one can't be parsed directly.
+    """
+    config = LANGUAGE_CONFIGS.get(language.lower())
+    if config is not None:
+        return config
+    backend = language_registry().get(language)
+    if backend is None or backend.grammar is None:
+        return None
+    return LanguageConfig(
+        name=language,
+        grammar_url="",
+        grammar_repo="",
+        node_types_to_include=backend.node_types_to_include,
+        file_extensions=backend.file_extensions,
+    )
 
 
 def get_supported_languages() -> List[str]:
-    """Get list of supported languages."""
-    return list(LANGUAGE_CONFIGS.keys())
+    """Get list of supported languages, including registered ones with a grammar."""
+    registered = [
+        lang for lang in language_registry().languages()
+        if lang not in LANGUAGE_CONFIGS and get_language_config(lang) is not None
+    ]
+    return list(LANGUAGE_CONFIGS.keys()) + registered
 
 
 def get_language_by_extension(file_extension: str) -> Optional[str]:
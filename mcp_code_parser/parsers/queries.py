@@ -0,0 +1,162 @@
+"""Compiled tree-sitter query cache and query execution."""
+
+import threading
+from collections import OrderedDict
+from dataclasses import dataclass
+from typing import List, Tuple
+
+import tree_sitter
+
+from mcp_code_parser.logging import get_logger
+
+logger = get_logger("queries")
+
+# Compiled queries kept by the shared cache
+DEFAULT_QUERY_CACHE_SIZE = 128
+
+
+@dataclass
+class QueryCapture:
+    """A node captured by a query."""
+
+    # Capture name without the leading "@"
+    name: str
+    node_type: str
+    text: str
+    start_line: int
+    end_line: int
+    start_byte: int
+    end_byte: int
+
+
+class QueryCache:
+    """Bounded LRU of compiled queries keyed by (language, query text).
+
+    Compiling an S-expression query is far more expensive than running it,
+    so callers that repeat queries should go through a cache. Access is
+    guarded by a lock, so one cache can be shared across threads; a miss
+    compiles under the lock, so each query is compiled once even when
+    requested concurrently.
+    """
+
+    def __init__(self, max_size: int = DEFAULT_QUERY_CACHE_SIZE):
+        """Create a cache.
+
+        Args:
+            max_size: Number of compiled queries to keep
+
+        Raises:
+            ValueError: If max_size is less than 1
+        """
+        if max_size < 1:
+            raise ValueError("max_size must be at least 1")
+        self._max_size = max_size
+        self._queries: "OrderedDict[Tuple[str, str], tree_sitter.Query]" = OrderedDict()
+        self._lock = threading.Lock()
+        self.hits = 0
+        self.misses = 0
+
+    @property
+    def max_size(self) -> int:
+        """Number of compiled queries kept."""
+        return self._max_size
+
+    @max_size.setter
+    def max_size(self, value: int) -> None:
+        """Resize the cache, evicting least recently used entries if it shrinks."""
+        if value < 1:
+            raise ValueError("max_size must be at least 1")
+        with self._lock:
+            self._max_size = value
+            self._evict()
+
+    def __len__(self) -> int:
+        """Number of cached queries."""
+        return len(self._queries)
+
+    def get(self, language: str, grammar: tree_sitter.Language, text: str) -> tree_sitter.Query:
+        """Get the compiled query, compiling and caching it on a miss.
+
+        Args:
+            language: Language name, part of the cache key
+            grammar: Grammar the query is compiled against
+            text: Query source
+
+        Raises:
+            Exception: Whatever tree-sitter raises for an invalid query
+                (failures are not cached)
+        """
+        key = (language, text)
+        with self._lock:
+            query = self._queries.get(key)
+            if query is not None:
+                self._queries.move_to_end(key)
+                self.hits += 1
+                return query
+            self.misses += 1
+            query = _compile(grammar, text)
+            self._queries[key] = query
+            self._evict()
+            return query
+
+    def clear(self) -> None:
+        """Remove all cached queries and reset the counters."""
+        with self._lock:
+            self._queries.clear()
+            self.hits = 0
+            self.misses = 0
+
+    def _evict(self) -> None:
+        """Drop least recently used entries over max_size (lock must be held)."""
+        while len(self._queries) > self._max_size:
+            (language, _), _ = self._queries.popitem(last=False)
+            logger.debug(f"Evicted compiled {language} query")
+
+
+# Shared by every parser and extractor in the process
+_shared_cache = QueryCache()
+
+
+def shared_query_cache() -> QueryCache:
+    """Get the process-wide query cache."""
+    return _shared_cache
+
+
+def execute_query(query: tree_sitter.Query, node: tree_sitter.Node, source: bytes) -> List[QueryCapture]:
+    """Run a compiled query and return the captures in source order."""
+    if hasattr(tree_sitter, "QueryCursor"):
+        # py-tree-sitter 0.25 moved execution to QueryCursor
+        raw = tree_sitter.QueryCursor(query).captures(node)
+    else:
+        raw = query.captures(node)
+
+    if isinstance(raw, dict):
+        # 0.23+: {name: [nodes]}
+        pairs = [(node, name) for name, nodes in raw.items() for node in nodes]
+    else:
+        # Before 0.23: [(node, name)]
+        pairs = list(raw)
+
+    captures = [
+        QueryCapture(
+            name=name,
+            node_type=captured.type,
+            text=source[captured.start_byte:captured.end_byte].decode("utf8", errors="replace"),
+            start_line=captured.start_point[0] + 1,
+            end_line=captured.end_point[0] + 1,
+            start_byte=captured.start_byte,
+            end_byte=captured.end_byte,
+        )
+        for captured, name in pairs
+    ]
+    captures.sort(key=lambda c: (c.start_byte, -c.end_byte, c.name))
+    return captures
+
+
+def _compile(grammar: tree_sitter.Language, text: str) -> tree_sitter.Query:
+    """Compile a query with whichever API the installed py-tree-sitter offers."""
+    try:
+        return tree_sitter.Query(grammar, text)
+    except TypeError:
+        # Older releases only construct queries through Language.query
+        return grammar.query(text)
@@ -9,8 +9,23 @@ from typing import Dict, List, Optional
 
 import tree_sitter
 
-from mcp_code_parser.parsers.base import BaseParser, ParseResult
+from mcp_code_parser.errors import (
+    ParseFailedError,
+    UnsupportedLanguageError,
+    error_code,
+    syntax_diagnostics,
+)
+from mcp_code_parser.parsers.base import (
+    BaseParser,
+    GrammarNotFoundError,
+    LanguageNotSupportedError,
+    ParseResult,
+)
 from mcp_code_parser.parsers.languages import get_language_config, get_supported_languages
+from mcp_code_parser.parsers.queries import shared_query_cache
+from mcp_code_parser.positions import DEFAULT_TAB_WIDTH
+from mcp_code_parser.registry import language_registry
+from mcp_code_parser.binary import BinaryDetector
 from mcp_code_parser.utils import safe_read_file, detect_language_from_file
 from mcp_code_parser.logging import get_logger
 
@@ -37,10 +52,25 @@ def _init_preloaded_modules():
 class TreeSitterParser(BaseParser):
     """Parser implementation using tree-sitter."""
     
-    def __init__(self):
-        """Initialize the tree-sitter parser."""
+    def __init__(
+        self,
+        max_file_size: Optional[int] = None,
+        tab_width: int = DEFAULT_TAB_WIDTH,
+        binary_detector: Optional[BinaryDetector] = None
+    ):
+        """Initialize the tree-sitter parser.
+        
+        Args:
+            max_file_size: Optional limit in bytes for files read by parse_file
+            tab_width: Tab stop spacing used for the visual columns of diagnostics
+            binary_detector: Detector rejecting binary files in parse_file
+                (defaults to DefaultBinaryDetector)
+        """
         self.parsers: Dict[str, tree_sitter.Parser] = {}
         self._language_cache: Dict[str, tree_sitter.Language] = {}
+        self.max_file_size = max_file_size
+        self.tab_width = tab_width
+        self.binary_detector = binary_detector
     
     async def __aenter__(self):
         """Enter async context."""
@@ -80,7 +110,8 @@ class TreeSitterParser(BaseParser):
                     language=language,
                     ast_text="",
                     metadata={"parser": self.name()},
-                    error=f"Language {language} not supported"
+                    error=f"Language {language} not supported",
+                    error_code=UnsupportedLanguageError.code,
                 )
             
             # Get or install language
@@ -94,7 +125,8 @@ class TreeSitterParser(BaseParser):
                     language=language,
                     ast_text="",
                     metadata={"parser": self.name()},
-                    error=str(e)
+                    error=str(e),
+                    error_code=UnsupportedLanguageError.code,
                 )
             
             # Get or create parser
@@ -108,7 +140,8 @@ class TreeSitterParser(BaseParser):
             
             # Parse the code
             logger.debug("Parsing code with tree-sitter")
-            tree = parser.parse(bytes(content, "utf8"))
+            source = bytes(content, "utf8")
+            tree = parser.parse(source)
             logger.debug(f"Parse complete, root node type: {tree.root_node.type}")
             
             # Format AST
@@ -128,7 +161,8 @@ class TreeSitterParser(BaseParser):
                     "node_count": node_count,
                     "tree_sitter_version": str(tree_sitter.LANGUAGE_VERSION)
                 },
-                error=None
+                error=None,
+                diagnostics=syntax_diagnostics(tree.root_node, source=source, tab_width=self.tab_width),
             )
             
         except Exception as e:
@@ -137,20 +171,22 @@ class TreeSitterParser(BaseParser):
                 language=language,
                 ast_text="",
                 metadata={"parser": self.name()},
-                error=f"Failed to parse: {str(e)}"
+                error=f"Failed to parse: {str(e)}",
+                error_code=ParseFailedError.code,
             )
     
     async def parse_file(self, file_path: str, language: Optional[str] = None) -> ParseResult:
         """Parse source file and return AST."""
         # Read file
         try:
-            content = safe_read_file(file_path)
+            content = safe_read_file(file_path, max_size=self.max_file_size, detector=self.binary_detector)
         except Exception as e:
             return ParseResult(
                 language=language or "unknown",
                 ast_text="",
                 metadata={"parser": self.name()},
-                error=f"Error reading file: {str(e)}"
+                error=f"Error reading file: {str(e)}",
+                error_code=error_code(e),
             )
         
         # Detect language if not provided
@@ -161,17 +197,67 @@ class TreeSitterParser(BaseParser):
                     language="unknown",
                     ast_text="",
                     metadata={"parser": self.name()},
-                    error="Could not detect language from file extension"
+                    error="Could not detect language from file extension",
+                    error_code=UnsupportedLanguageError.code,
                 )
         
         # Parse content
         return await self.parse(content, language)
     
+    async def parse_tree(self, content: str, language: str) -> tree_sitter.Tree:
+        """Parse source code and return the raw tree-sitter tree.
+        
+        Used by consumers that need to walk the syntax tree themselves
+        (e.g. symbol extraction) rather than the formatted AST text.
+        
+        Raises:
+            LanguageNotSupportedError: If the language has no configuration
+            GrammarNotFoundError: If the language grammar cannot be loaded
+        """
+        if not get_language_config(language):
+            raise LanguageNotSupportedError(f"Language {language} not supported")
+        
+        try:
+            lang = await self._get_or_install_language(language)
+        except Exception as e:
+            raise GrammarNotFoundError(str(e)) from e
+        
+        if language not in self.parsers:
+            self.parsers[language] = tree_sitter.Parser(lang)
+        
+        return self.parsers[language].parse(bytes(content, "utf8"))
+    
+    async def compile_query(self, language: str, text: str) -> tree_sitter.Query:
+        """Compile a query for a language through the shared query cache.
+        
+        Raises:
+            LanguageNotSupportedError: If the language has no configuration
+            GrammarNotFoundError: If the language grammar cannot be loaded
+        """
+        if not get_language_config(language):
+            raise LanguageNotSupportedError(f"Language {language} not supported")
+        
+        try:
+            lang = await self._get_or_install_language(language)
+        except Exception as e:
+            raise GrammarNotFoundError(str(e)) from e
+        
+        return shared_query_cache().get(language, lang, text)
+    
     async def _get_or_install_language(self, language: str) -> tree_sitter.Language:
         """Get language object, installing if necessary."""
         # Initialize preloaded modules on first use
         _init_preloaded_modules()
         
+        # Registered grammars take precedence, including over built-in ones
+        backend = language_registry().get(language)
+        if backend is not None and backend.grammar is not None:
+            if self._language_cache.get(language) is not backend.grammar:
+                # Newly registered or replaced: drop the parser built for the old grammar
+                self._language_cache[language] = backend.grammar
+                self.parsers.pop(language, None)
+            return backend.grammar
+        
         # Check cache first
         if language in self._language_cache:
             return self._language_cache[language]
@@ -183,6 +269,10 @@ class TreeSitterParser(BaseParser):
             "typescript": "tree-sitter-typescript",
             "go": "tree-sitter-go",
             "cpp": "tree-sitter-cpp",
+            "dart": "tree-sitter-dart",
+            "elixir": "tree-sitter-elixir",
+            "json": "tree-sitter-json",
+            "yaml": "tree-sitter-yaml",
         }
         
         package_name = package_map.get(language)
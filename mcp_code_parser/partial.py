@@ -0,0 +1,179 @@
+"""Best-effort completion of buffers cut off mid-edit.
+
+While code is being typed the buffer usually ends inside a declaration:
+`def load(path, mode` or `func (s *Store) Get(`. tree-sitter recovers from
+the error, but often by dropping the unfinished declaration into an ERROR
+node no extractor looks into. complete_buffer closes what the end of the
+buffer leaves open (strings, block comments, brackets, Elixir `do` blocks),
+tries a few stand-in bodies after the innermost declaration, and returns
+the parse of the candidate with the fewest syntax errors, so the
+declaration being typed is extracted along with everything before it.
+"""
+
+from dataclasses import dataclass
+from typing import Awaitable, Callable, List, Optional, Tuple
+
+import tree_sitter
+
+from mcp_code_parser.errors import syntax_diagnostics
+
+# Error nodes counted when comparing candidates
+_MAX_ERRORS = 1000
+
+_CLOSERS = {"(": ")", "[": "]", "{": "}", "do": "\nend"}
+# Openers of blocks holding declarations; stand-in bodies go inside them
+_BLOCKS = ("{", "do")
+
+_HASH_COMMENTS = ("python", "elixir")
+_BLOCK_COMMENTS = ("go", "javascript", "typescript", "cpp", "c", "dart")
+_BACKTICK_STRINGS = ("go", "javascript", "typescript")
+_TRIPLE_QUOTES = ("python", "dart", "elixir")
+
+# Text tried after the innermost unclosed parentheses, in order of preference;
+# "{indent}" is the indentation of the declaration's line plus one level
+_PYTHON_STUBS = ("", ":\n{indent}pass\n", "\n{indent}pass\n", "():\n{indent}pass\n")
+_ELIXIR_STUBS = ("", " do\nend")
+_BRACE_STUBS = ("", ";", " {}", "()", "() {}")
+
+
+@dataclass
+class _Open:
+    """An unclosed bracket or block and where it was opened."""
+
+    opener: str
+    offset: int
+
+
+def completion_candidates(content: str, language: str) -> List[str]:
+    """Suffixes that might complete a buffer cut off mid-declaration.
+
+    The first candidate only closes what's open; later ones add stand-in
+    bodies or parameter lists after the innermost open parentheses.
+    """
+    stack, closer = _scan(content, language)
+    # Brackets inside the innermost block, e.g. the parameters being typed
+    inner = len(stack)
+    while inner > 0 and stack[inner - 1].opener not in _BLOCKS:
+        inner -= 1
+    close_inner = "".join(_CLOSERS[o.opener] for o in reversed(stack[inner:]))
+    close_outer = "".join(_CLOSERS[o.opener] for o in reversed(stack[:inner]))
+
+    if language == "python":
+        start = stack[inner].offset if inner < len(stack) else len(content.rstrip())
+        line = content[content.rfind("\n", 0, start) + 1:]
+        indent = line[:len(line) - len(line.lstrip())] + "    "
+        stubs = [s.format(indent=indent) for s in _PYTHON_STUBS]
+    elif language == "elixir":
+        stubs = list(_ELIXIR_STUBS)
+    else:
+        stubs = list(_BRACE_STUBS)
+    return [closer + close_inner + stub + close_outer for stub in stubs]
+
+
+async def complete_buffer(
+    content: str,
+    language: str,
+    tree: tree_sitter.Tree,
+    parse: Callable[[str, str], Awaitable[tree_sitter.Tree]]
+) -> Optional[Tuple[tree_sitter.Tree, bytes]]:
+    """Parse a buffer with syntax errors as completed by the best candidate suffix.
+
+    Args:
+        content: Source that failed to parse cleanly
+        language: Language it was parsed as
+        tree: Its tree
+        parse: Coroutine turning (content, language) into a tree-sitter tree
+
+    Returns:
+        The completed tree and source, or None if no candidate has fewer
+        syntax errors than the buffer itself. The completed source starts
+        with the buffer's bytes, so positions before its end are unchanged.
+    """
+    best = None
+    best_errors = _error_count(tree)
+    for suffix in completion_candidates(content, language):
+        if not suffix:
+            continue
+        completed = content + suffix
+        candidate = await parse(completed, language)
+        errors = _error_count(candidate)
+        if errors < best_errors:
+            best, best_errors = (candidate, bytes(completed, "utf8")), errors
+            if errors == 0:
+                break
+    return best
+
+
+def _error_count(tree: tree_sitter.Tree) -> int:
+    """Number of ERROR and MISSING nodes in a tree."""
+    return len(syntax_diagnostics(tree.root_node, limit=_MAX_ERRORS))
+
+
+def _scan(content: str, language: str) -> Tuple[List[_Open], str]:
+    """Brackets left open at the end of a buffer, and the text closing an unterminated string or comment.
+
+    This is a lexer only as far as finding brackets needs: comments and
+    strings are skipped, JavaScript template substitutions are treated as
+    part of their string, and mismatched closers are ignored.
+    """
+    stack: List[_Open] = []
+    line_comment = "#" if language in _HASH_COMMENTS else "//"
+    quotes = ["'", '"'] + (["`"] if language in _BACKTICK_STRINGS else [])
+    if language in _TRIPLE_QUOTES:
+        quotes = ['"""', "'''"] + quotes
+    i = 0
+    n = len(content)
+    while i < n:
+        char = content[i]
+        if content.startswith(line_comment, i):
+            end = content.find("\n", i)
+            if end == -1:
+                return stack, ""
+            i = end + 1
+            continue
+        if language in _BLOCK_COMMENTS and content.startswith("/*", i):
+            end = content.find("*/", i + 2)
+            if end == -1:
+                return stack, "*/"
+            i = end + 2
+            continue
+        quote = next((q for q in quotes if content.startswith(q, i)), None)
+        if quote is not None:
+            # Go raw strings take no escapes
+            escapes = not (language == "go" and quote == "`")
+            multiline = len(quote) == 3 or quote == "`"
+            j = i + len(quote)
+            while j < n and not content.startswith(quote, j):
+                if content[j] == "\\" and escapes:
+                    j += 1
+                elif content[j] == "\n" and not multiline:
+                    break
+                j += 1
+            if j >= n:
+                return stack, quote
+            i = j + (len(quote) if content.startswith(quote, j) else 1)
+            continue
+        if char in "([{":
+            stack.append(_Open(char, i))
+        elif char in ")]}":
+            if stack and _CLOSERS[stack[-1].opener] == char:
+                stack.pop()
+        elif language == "elixir" and (char.isalpha() or char == "_") and (i == 0 or not _word_char(content[i - 1], ":")):
+            j = i
+            while j < n and _word_char(content[j]):
+                j += 1
+            word = content[i:j]
+            # `do:` is a keyword argument, not a block
+            if word in ("do", "fn") and not content.startswith(":", j):
+                stack.append(_Open("do", i))
+            elif word == "end" and stack and stack[-1].opener == "do":
+                stack.pop()
+            i = j
+            continue
+        i += 1
+    return stack, ""
+
+
+def _word_char(char: str, also: str = "") -> bool:
+    """Check whether a character can be part of an Elixir identifier (or is one of also)."""
+    return char.isalnum() or char in "_?!" + also
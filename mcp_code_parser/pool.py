@@ -0,0 +1,224 @@
+"""Async worker pool for running extraction tasks concurrently."""
+
+import asyncio
+import itertools
+import os
+import time
+from dataclasses import dataclass
+from typing import Any, Awaitable, Callable, List, Optional, Sequence
+
+from mcp_code_parser.logging import get_logger
+
+logger = get_logger("pool")
+
+# Bytes of file content adaptive_read_workers lets reads hold ahead of extraction
+READ_AHEAD_BYTES = 64 * 1024 * 1024
+# Most reads adaptive_read_workers runs at once, like concurrent.futures' default
+_MAX_READ_WORKERS = 32
+
+
+@dataclass
+class TaskResult:
+    """Outcome of a single task run by the pool."""
+
+    task: Any
+    output: Any = None
+    error: Optional[BaseException] = None
+
+    @property
+    def success(self) -> bool:
+        """Check if the handler completed without raising."""
+        return self.error is None
+
+
+@dataclass
+class PoolStats:
+    """Point-in-time snapshot of pool counters."""
+
+    submitted: int
+    completed: int
+    failed: int
+    in_flight: int
+    queue_depth: int
+    # Mean handler run time in seconds over finished tasks
+    average_latency: float
+
+
+class WorkerPool:
+    """Run an async handler over submitted tasks with a fixed number of workers.
+
+    Results are delivered on the ``results`` queue in completion order. The
+    pool runs on a single event loop, so counters are plain integers updated
+    between awaits and need no locking.
+
+    Example:
+        async with WorkerPool(handle, workers=4) as pool:
+            for task in tasks:
+                await pool.submit(task)
+            await pool.join()
+    """
+
+    def __init__(
+        self,
+        handler: Callable[[Any], Awaitable[Any]],
+        workers: int = 4,
+        queue_size: int = 0
+    ):
+        """Create a pool.
+
+        Args:
+            handler: Coroutine function called with each task
+            workers: Number of concurrent workers
+            queue_size: Maximum pending tasks before submit waits (0 = unbounded)
+        """
+        if workers < 1:
+            raise ValueError("workers must be at least 1")
+        self.workers = workers
+        self._handler = handler
+        self._queue: asyncio.Queue = asyncio.Queue(maxsize=queue_size)
+        self.results: asyncio.Queue = asyncio.Queue()
+        self._tasks: List[asyncio.Task] = []
+        self._submitted = 0
+        self._completed = 0
+        self._failed = 0
+        self._in_flight = 0
+        self._total_latency = 0.0
+
+    async def __aenter__(self) -> "WorkerPool":
+        """Start workers on entering the context."""
+        self.start()
+        return self
+
+    async def __aexit__(self, exc_type, exc_val, exc_tb) -> None:
+        """Stop workers on leaving the context."""
+        await self.stop()
+
+    def start(self) -> None:
+        """Start the worker tasks."""
+        if self._tasks:
+            return
+        self._tasks = [asyncio.create_task(self._worker()) for _ in range(self.workers)]
+
+    async def submit(self, task: Any) -> None:
+        """Queue a task, waiting if the queue is full."""
+        await self._queue.put(task)
+        # Counted once queued, so a submit cancelled while waiting isn't
+        self._submitted += 1
+
+    async def join(self) -> None:
+        """Wait until every submitted task has finished."""
+        await self._queue.join()
+
+    async def stop(self) -> None:
+        """Cancel the workers. Tasks still queued are not run."""
+        for worker in self._tasks:
+            worker.cancel()
+        await asyncio.gather(*self._tasks, return_exceptions=True)
+        self._tasks = []
+
+    def stats(self) -> PoolStats:
+        """Return a snapshot of the pool counters."""
+        finished = self._completed + self._failed
+        return PoolStats(
+            submitted=self._submitted,
+            completed=self._completed,
+            failed=self._failed,
+            in_flight=self._in_flight,
+            queue_depth=self._queue.qsize(),
+            average_latency=self._total_latency / finished if finished else 0.0,
+        )
+
+    def _unwrap(self, item: Any) -> Any:
+        """Task of a queue entry."""
+        return item
+
+    async def _worker(self) -> None:
+        """Pull tasks off the queue and run the handler on them."""
+        while True:
+            task = self._unwrap(await self._queue.get())
+            self._in_flight += 1
+            start = time.perf_counter()
+            try:
+                output = await self._handler(task)
+            except asyncio.CancelledError:
+                self._in_flight -= 1
+                self._queue.task_done()
+                raise
+            except Exception as e:
+                logger.debug(f"Task {task!r} failed: {e}")
+                self._failed += 1
+                result = TaskResult(task=task, error=e)
+            else:
+                self._completed += 1
+                result = TaskResult(task=task, output=output)
+
+            self._in_flight -= 1
+            self._total_latency += time.perf_counter() - start
+            # Publish the result before marking the task done so join() callers see it
+            self.results.put_nowait(result)
+            self._queue.task_done()
+
+
+class PriorityWorkerPool(WorkerPool):
+    """A WorkerPool that runs higher-priority tasks first.
+
+    Queued tasks are kept in a heap ordered by priority, then by submission,
+    so tasks of equal priority run first in, first out. Tasks already running
+    aren't preempted.
+
+    Example:
+        async with PriorityWorkerPool(handle, workers=4) as pool:
+            for path in background:
+                await pool.submit(path)
+            await pool.submit(open_file, priority=10)
+    """
+
+    def __init__(
+        self,
+        handler: Callable[[Any], Awaitable[Any]],
+        workers: int = 4,
+        queue_size: int = 0
+    ):
+        """Create a pool (see WorkerPool)."""
+        super().__init__(handler, workers, queue_size)
+        self._queue = asyncio.PriorityQueue(maxsize=queue_size)
+        self._sequence = itertools.count()
+
+    async def submit(self, task: Any, priority: int = 0) -> None:
+        """Queue a task, waiting if the queue is full.
+
+        Args:
+            task: Task to pass to the handler
+            priority: Higher values run sooner
+        """
+        # The sequence number breaks ties, so tasks themselves are never compared
+        await self._queue.put((-priority, next(self._sequence), task))
+        self._submitted += 1
+
+    def _unwrap(self, item: Any) -> Any:
+        """Task of a (negated priority, sequence, task) heap entry."""
+        return item[2]
+
+
+def adaptive_read_workers(sizes: Sequence[int], cpus: Optional[int] = None) -> int:
+    """Number of files to read at once when walking files of these sizes.
+
+    Reads mostly wait on storage, so trees of small files get many in
+    flight: min(32, cpus + 4), as concurrent.futures sizes thread pools.
+    Large files get fewer, so the content read ahead of extraction stays
+    around READ_AHEAD_BYTES; the 90th percentile size is used, so a handful
+    of huge files doesn't throttle a tree of mostly small ones.
+
+    Args:
+        sizes: File sizes in bytes, in any order
+        cpus: Available CPUs (defaults to os.cpu_count())
+
+    Returns:
+        Concurrent reads, at least 1
+    """
+    if not sizes:
+        return 1
+    ceiling = min(_MAX_READ_WORKERS, (cpus or os.cpu_count() or 1) + 4)
+    ordered = sorted(sizes)
+    large = ordered[(len(ordered) - 1) * 9 // 10]
+    return max(1, min(ceiling, READ_AHEAD_BYTES // max(large, 1)))
@@ -0,0 +1,93 @@
+"""Column computations for reporting source positions."""
+
+from bisect import bisect_right
+from dataclasses import dataclass
+from typing import List, Tuple
+
+# Tab stop spacing assumed when no tab width is configured
+DEFAULT_TAB_WIDTH = 4
+
+
+def visual_column(line: bytes, byte_column: int, tab_width: int = DEFAULT_TAB_WIDTH) -> int:
+    """Convert a 0-based byte column to the 0-based column an editor displays.
+
+    Tabs advance to the next multiple of tab_width and every other character
+    (however many UTF-8 bytes it takes) counts as one column.
+
+    Args:
+        line: UTF-8 bytes of the line, without its newline
+        byte_column: Offset into line, as reported by tree-sitter
+        tab_width: Tab stop spacing
+
+    Returns:
+        Display column of the character at byte_column
+    """
+    column = 0
+    for char in line[:byte_column].decode("utf8", errors="replace"):
+        if char == "\t":
+            column += tab_width - column % tab_width
+        else:
+            column += 1
+    return column
+
+
+
+@dataclass
+class Utf16Range:
+    """A source range in UTF-16 code units, as JavaScript strings index text.
+
+    Offsets count from the start of the source and columns from the start
+    of the line, all 0-based, so `text.slice(start, end)` gives the range.
+    """
+
+    start: int
+    end: int
+    start_column: int
+    end_column: int
+
+
+class Utf16Offsets:
+    """Converts UTF-8 byte positions in a source to UTF-16 code units."""
+
+    def __init__(self, source: bytes):
+        """Index the starts of source's lines.
+
+        Args:
+            source: UTF-8 source the byte positions refer to
+        """
+        self.source = source
+        # Byte and UTF-16 offsets of each line's start
+        self._line_bytes: List[int] = []
+        self._line_units: List[int] = []
+        byte = unit = 0
+        for line in source.split(b"\n"):
+            self._line_bytes.append(byte)
+            self._line_units.append(unit)
+            byte += len(line) + 1
+            unit += _utf16_length(line) + 1
+
+    def position(self, byte_offset: int) -> Tuple[int, int]:
+        """Convert a byte offset to a UTF-16 offset and 0-based column.
+
+        Offsets past the end of the source are clamped to it.
+        """
+        byte_offset = max(0, min(byte_offset, len(self.source)))
+        line = bisect_right(self._line_bytes, byte_offset) - 1
+        column = _utf16_length(self.source[self._line_bytes[line]:byte_offset])
+        return self._line_units[line] + column, column
+
+    def byte_offset(self, line: int, byte_column: int) -> int:
+        """Byte offset of a 0-based line and byte column."""
+        line = max(0, min(line, len(self._line_bytes) - 1))
+        return self._line_bytes[line] + byte_column
+
+    def range(self, start_byte: int, end_byte: int) -> Utf16Range:
+        """Convert a byte range to UTF-16 code units."""
+        start, start_column = self.position(start_byte)
+        end, end_column = self.position(end_byte)
+        return Utf16Range(start=start, end=end, start_column=start_column, end_column=end_column)
+
+
+def _utf16_length(text: bytes) -> int:
+    """Number of UTF-16 code units in UTF-8 text (characters outside the BMP take two)."""
+    return len(text.decode("utf8", errors="replace").encode("utf-16-le")) // 2
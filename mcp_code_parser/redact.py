@@ -0,0 +1,160 @@
+"""Masking credentials in file content before it reaches a model."""
+
+import math
+import re
+from collections import Counter
+from dataclasses import dataclass
+from typing import Dict, List, Optional, Pattern, Tuple
+
+# Text secrets are replaced with
+REDACTED = "[REDACTED]"
+
+# Rule name counted for high-entropy strings
+HIGH_ENTROPY = "high_entropy"
+
+# Runs of base64, base64url and hex characters that may be random tokens
+_TOKEN = re.compile(r"[A-Za-z0-9+/_-]+=*")
+_HEX = re.compile(r"[0-9a-fA-F]+")
+
+
+@dataclass
+class RedactionRule:
+    """A kind of secret and the pattern matching it.
+
+    When the pattern has a group named "secret", only that group is masked,
+    so `api_key = "..."` keeps its name and quotes.
+    """
+
+    name: str
+    pattern: Pattern[str]
+
+
+DEFAULT_RULES = [
+    RedactionRule(
+        "private_key",
+        re.compile(
+            r"-----BEGIN [A-Z0-9 ]*PRIVATE KEY-----.*?(?:-----END [A-Z0-9 ]*PRIVATE KEY-----|\Z)", re.DOTALL
+        ),
+    ),
+    RedactionRule("aws_access_key", re.compile(r"\b(?:AKIA|ASIA|ABIA|ACCA)[0-9A-Z]{16}\b")),
+    RedactionRule(
+        "aws_secret_key",
+        re.compile(r"(?i)aws_?secret_?(?:access_?)?key[\"']?\s*[:=]\s*[\"']?(?P<secret>[A-Za-z0-9/+]{40})\b"),
+    ),
+    RedactionRule("github_token", re.compile(r"\b(?:gh[pousr]_[A-Za-z0-9]{36,}|github_pat_[A-Za-z0-9_]{22,})")),
+    RedactionRule("slack_token", re.compile(r"\bxox[abposr]-[A-Za-z0-9-]{10,}")),
+    RedactionRule(
+        "api_key",
+        re.compile(
+            r"(?i)\b[\w-]*(?:api[_-]?key|secret|token|passw(?:or)?d)[\"']?\s*[:=]\s*[\"'](?P<secret>[^\"'\s]{8,})[\"']"
+        ),
+    ),
+]
+
+
+class Redactor:
+    """Replaces secrets in text with a placeholder.
+
+    Secrets are matches of the rules (by default API keys, AWS access and
+    secret keys, GitHub and Slack tokens and private key blocks) and, unless
+    turned off, long strings of base64 or hex characters random enough to
+    be keys. Hex can't carry more than 4 bits per character, so it has a
+    lower threshold than base64; to spare identifiers and numbers, base64
+    strings must mix upper and lower case letters and digits, and hex ones
+    letters and digits. Hex digests such as commit hashes look like hex keys
+    and are masked too. Subclasses can override redact to plug in other
+    detectors.
+    """
+
+    def __init__(
+        self,
+        rules: Optional[List[RedactionRule]] = None,
+        entropy_threshold: Optional[float] = 4.0,
+        hex_entropy_threshold: float = 3.0,
+        min_entropy_length: int = 24,
+        replacement: str = REDACTED
+    ):
+        """Create a redactor.
+
+        Args:
+            rules: Patterns of secrets (defaults to DEFAULT_RULES)
+            entropy_threshold: Shannon entropy in bits per character above
+                which a base64 string of at least min_entropy_length
+                characters is masked; None turns the entropy check off
+            hex_entropy_threshold: The same for strings of hex digits
+            min_entropy_length: Shortest string the entropy check considers
+            replacement: Text each secret is replaced with
+        """
+        self.rules = DEFAULT_RULES if rules is None else rules
+        self.entropy_threshold = entropy_threshold
+        self.hex_entropy_threshold = hex_entropy_threshold
+        self.min_entropy_length = min_entropy_length
+        self.replacement = replacement
+
+    def redact(self, text: str) -> Tuple[str, Dict[str, int]]:
+        """Mask the secrets in text.
+
+        Where rule matches overlap the earliest wins, and the entropy check
+        only masks strings no rule matched.
+
+        Returns:
+            The masked text, and how many secrets each rule masked (rules
+            that masked none are left out)
+        """
+        matches: List[Tuple[int, int, str]] = []
+        for rule in self.rules:
+            secret = "secret" in rule.pattern.groupindex
+            for match in rule.pattern.finditer(text):
+                start, end = match.span("secret") if secret else match.span()
+                if end > start:
+                    matches.append((start, end, rule.name))
+        # Stable sort: at the same start, earlier rules win
+        spans = _disjoint(sorted(matches, key=lambda m: m[0]))
+        if self.entropy_threshold is not None:
+            for match in _TOKEN.finditer(text):
+                token = match.group()
+                if (
+                    len(token) >= self.min_entropy_length
+                    and self._random(token)
+                    and not any(start < match.end() and match.start() < end for start, end, _ in spans)
+                ):
+                    spans.append((match.start(), match.end(), HIGH_ENTROPY))
+
+        counts: Dict[str, int] = {}
+        parts = []
+        position = 0
+        for start, end, name in sorted(spans):
+            parts.append(text[position:start])
+            parts.append(self.replacement)
+            position = end
+            counts[name] = counts.get(name, 0) + 1
+        parts.append(text[position:])
+        return "".join(parts), counts
+
+    def _random(self, token: str) -> bool:
+        """Whether a string of key characters is random enough to be a key, for its alphabet."""
+        has_digit = any(c.isdigit() for c in token)
+        if _HEX.fullmatch(token):
+            return has_digit and not token.isdigit() and _entropy(token) > self.hex_entropy_threshold
+        mixed = has_digit and any(c.islower() for c in token) and any(c.isupper() for c in token)
+        return mixed and _entropy(token) > self.entropy_threshold
+
+
+def merge_counts(total: Dict[str, int], counts: Dict[str, int]) -> None:
+    """Add counts from Redactor.redact into total."""
+    for name, count in counts.items():
+        total[name] = total.get(name, 0) + count
+
+
+def _disjoint(spans: List[Tuple[int, int, str]]) -> List[Tuple[int, int, str]]:
+    """Drop spans overlapping an earlier one, of spans sorted by start."""
+    kept: List[Tuple[int, int, str]] = []
+    for span in spans:
+        if not kept or span[0] >= kept[-1][1]:
+            kept.append(span)
+    return kept
+
+
+def _entropy(text: str) -> float:
+    """Shannon entropy of text in bits per character."""
+    return -sum(n / len(text) * math.log2(n / len(text)) for n in Counter(text).values())
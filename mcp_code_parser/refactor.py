@@ -0,0 +1,191 @@
+"""Multi-file refactorings built on the symbol index."""
+
+import posixpath
+import re
+from dataclasses import dataclass, field
+from pathlib import Path
+from typing import TYPE_CHECKING, List, Optional, Set, Tuple
+
+from mcp_code_parser.analysis.references import _code_offsets
+from mcp_code_parser.edit import EditOperation, edit_operations
+from mcp_code_parser.errors import ConflictError, NotFoundError, ParseFailedError
+from mcp_code_parser.incremental import TextEdit
+from mcp_code_parser.index import Index, MemoryStorage
+from mcp_code_parser.utils import TextFormat, detect_language_from_file, read_source, write_source
+
+if TYPE_CHECKING:
+    from mcp_code_parser.api import AgentTools
+
+_IDENTIFIER = re.compile(r"^[A-Za-z_]\w*$")
+
+
+@dataclass
+class FileEdit:
+    """The edits a refactoring makes to one file."""
+
+    # Root-relative POSIX path
+    file: str
+    # Non-overlapping, in order; offsets are into the original content as
+    # normalized by read_source
+    edits: List[TextEdit] = field(default_factory=list)
+    # The file's normalized content with the edits applied
+    content: str = ""
+    # Encoding, BOM and line ending to write content back with (see write_source)
+    text_format: TextFormat = field(default_factory=TextFormat)
+    # The edits as JSON-serializable operations for an external executor
+    # (see apply_operations)
+    operations: List[EditOperation] = field(default_factory=list)
+
+
+async def rename_symbol(
+    root: str,
+    stable_id: str,
+    new_name: str,
+    dry_run: bool = False,
+    index: Optional[Index] = None,
+    tools: Optional["AgentTools"] = None
+) -> List[FileEdit]:
+    """Rename a symbol and its uses, then write the changed files.
+
+    Uses are found by name like where_is_symbol, skipping comments and
+    strings, within the symbol's scope: for Go the declaring package's
+    directory, otherwise every file under root in the same language. Uses of
+    a member such as a method or field are only the names after a `.`. The
+    rename is refused if the new name is taken in the scope, or if another
+    symbol there shares the old name, since its uses couldn't be told apart.
+    Changed files keep their encoding, byte order mark and line endings.
+
+    Args:
+        root: Directory containing the code
+        stable_id: Stable ID of the symbol, e.g. "struct:UserService"
+        new_name: Identifier to rename it to
+        dry_run: Return the edits without writing any file
+        index: Index over root to find the symbol with (a fresh in-memory
+            index is built if None); it is updated before use
+        tools: AgentTools instance to use (defaults to the global one)
+
+    Returns:
+        Edits for every changed file, in path order
+
+    Raises:
+        ValueError: If new_name isn't an identifier
+        NotFoundError: If no symbol under root has the stable ID
+        ConflictError: If the rename would collide with or can't tell
+            apart another symbol
+        ParseFailedError: If a renamed file would no longer parse
+    """
+    if tools is None:
+        from mcp_code_parser.api import _global_tools
+        tools = _global_tools
+    if not _IDENTIFIER.match(new_name):
+        raise ValueError(f"Not an identifier: {new_name!r}")
+
+    if index is None:
+        index = Index(root, MemoryStorage(), tools=tools, respect_gitignore=True)
+    await index.update()
+
+    matches = index.query()
+    declarations = [m for m in matches if m.symbol.stable_id == stable_id]
+    if not declarations:
+        raise NotFoundError(f"Symbol not found under {root}: {stable_id}")
+    target = declarations[0]
+    old_name = target.symbol.name
+    parent = _parent(stable_id)
+    language = detect_language_from_file(target.file)
+
+    scope = [m for m in matches if _in_scope(m.file, target.file, language)]
+    for match in scope:
+        if match.symbol.name == new_name and _parent(match.symbol.stable_id or "") == parent:
+            raise ConflictError(f"{match.file} already declares {match.qualified_name}")
+    for match in scope:
+        if match.symbol.name == old_name and match.symbol.stable_id != stable_id:
+            raise ConflictError(
+                f"{match.file} also declares {match.qualified_name}; its uses can't be told apart from {stable_id}"
+            )
+
+    member = bool(parent)
+    word = re.compile(rb"(?<![\w$])" + re.escape(old_name.encode("utf8")) + rb"(?![\w$])")
+    declared = {(m.file, m.symbol.start_byte, m.symbol.end_byte) for m in declarations}
+    files = sorted({m.file for m in scope})
+    file_edits = []
+    for rel in files:
+        content, text_format = read_source(str(Path(root) / rel), detector=tools.binary_detector)
+        source = content.encode("utf8")
+        offsets = await _code_offsets(
+            [m.start() for m in word.finditer(source)],
+            len(old_name.encode("utf8")),
+            content,
+            language,
+            tools,
+        )
+        if member:
+            # Keep `x.Name` uses and the declaration's own name
+            offsets = [
+                o for o in offsets
+                if source[:o].rstrip().endswith(b".") or _declares(declared, rel, source, o, word)
+            ]
+        if not offsets:
+            continue
+
+        edits = []
+        for offset in offsets:
+            start = len(source[:offset].decode("utf8", errors="replace"))
+            edits.append(TextEdit(start=start, end=start + len(old_name), text=new_name))
+        renamed = _apply(content, edits)
+        before = await tools.validate(content, language)
+        after = await tools.validate(renamed, language)
+        if len(after) > len(before):
+            raise ParseFailedError(f"Renaming {old_name} to {new_name} breaks the syntax of {rel}", after)
+        file_edits.append(FileEdit(
+            file=rel,
+            edits=edits,
+            content=renamed,
+            text_format=text_format,
+            operations=edit_operations(content, edits, rel),
+        ))
+
+    if not dry_run:
+        for file_edit in file_edits:
+            write_source(str(Path(root, file_edit.file)), file_edit.content, file_edit.text_format)
+    return file_edits
+
+
+def _in_scope(file: str, declaring_file: str, language: Optional[str]) -> bool:
+    """Whether a file can refer to the declarations of another without qualifying them."""
+    if detect_language_from_file(file) != language:
+        return False
+    if language == "go":
+        return posixpath.dirname(file) == posixpath.dirname(declaring_file)
+    return True
+
+
+def _parent(stable_id: str) -> str:
+    """Dotted name path of the symbol containing a stable ID's symbol ("" at the top level).
+
+    Taken from the ID rather than the outline so Go methods declared apart
+    from their type still belong to it.
+    """
+    qualified = stable_id.partition(":")[2].partition("(")[0].partition("#")[0]
+    return qualified.rpartition(".")[0]
+
+
+def _declares(declared: Set[Tuple[str, int, int]], rel: str, source: bytes, offset: int, word: re.Pattern) -> bool:
+    """Whether offset is the first occurrence of the name in one of the declarations."""
+    for file, start, end in declared:
+        if file != rel or not start <= offset < end:
+            continue
+        found = word.search(source, start, end)
+        return found is not None and found.start() == offset
+    return False
+
+
+def _apply(content: str, edits: List[TextEdit]) -> str:
+    """Content with ordered, non-overlapping edits applied."""
+    parts = []
+    position = 0
+    for edit in edits:
+        parts.append(content[position:edit.start])
+        parts.append(edit.text)
+        position = edit.end
+    parts.append(content[position:])
+    return "".join(parts)
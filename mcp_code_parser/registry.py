@@ -0,0 +1,142 @@
+"""Runtime registration of additional language backends."""
+
+import threading
+from dataclasses import dataclass, field
+from typing import TYPE_CHECKING, Dict, List, Optional, Tuple
+
+import tree_sitter
+
+if TYPE_CHECKING:
+    from mcp_code_parser.extractors.base import BaseExtractor
+
+
+@dataclass
+class CommentSyntax:
+    """Comment and string tokens of a language, for comment-based tools without a grammar."""
+
+    # Tokens starting a comment that runs to the end of the line, e.g. ["#", "//"]
+    line: List[str] = field(default_factory=list)
+    # Opening and closing tokens of block comments, e.g. [("/*", "*/")]
+    block: List[Tuple[str, str]] = field(default_factory=list)
+    # Delimiters of string literals, in which comment tokens don't count,
+    # e.g. ['"""', '"', "'"]
+    strings: List[str] = field(default_factory=list)
+    # Character escaping a delimiter inside a string
+    escape: Optional[str] = "\\"
+
+
+@dataclass
+class LanguageBackend:
+    """A language plugged in at runtime: its symbol extractor and, optionally, grammar.
+
+    A backend with only comments gives a language comment-based tools (TODO
+    notes, comment line counts) without symbol extraction.
+    """
+
+    # None for a language with only comment support
+    extractor: Optional["BaseExtractor"] = None
+    # Grammar used by parse_tree, parse_code, queries and the default
+    # BaseExtractor.extract_content; None for extractors that parse content
+    # themselves (by overriding extract_content)
+    grammar: Optional[tree_sitter.Language] = None
+    # File extensions dispatched to the language, e.g. [".rules"]
+    file_extensions: List[str] = field(default_factory=list)
+    # Node types whose children parse_code shows (None shows every node)
+    node_types_to_include: Optional[List[str]] = None
+    # Comment tokens, used in place of the grammar to find comments
+    comments: Optional[CommentSyntax] = None
+
+
+class LanguageRegistry:
+    """Languages registered at runtime, safe to use from several threads."""
+
+    def __init__(self) -> None:
+        """Create an empty registry."""
+        self._backends: Dict[str, LanguageBackend] = {}
+        self._extensions: Dict[str, str] = {}
+        self._lock = threading.Lock()
+
+    def register(self, language: str, backend: LanguageBackend, override: bool = False) -> None:
+        """Register a backend for a language.
+
+        Args:
+            language: Language name, e.g. "rules"
+            backend: Extractor, grammar and file extensions of the language
+            override: Replace an existing registration, a built-in language
+                or another language's claim on one of the file extensions
+
+        Raises:
+            ValueError: If the language or one of its extensions is already
+                taken and override is False, or the backend has neither an
+                extractor nor comments
+        """
+        from mcp_code_parser.parsers.languages import LANGUAGE_CONFIGS
+        from mcp_code_parser.utils import EXTENSION_LANGUAGES
+
+        if backend.extractor is None and backend.comments is None:
+            raise ValueError(f"Backend for {language} needs an extractor or comments")
+        extensions = [_normalize_extension(ext) for ext in backend.file_extensions]
+        with self._lock:
+            if not override:
+                builtin = set(LANGUAGE_CONFIGS) | set(EXTENSION_LANGUAGES.values())
+                if language in self._backends or language in builtin:
+                    raise ValueError(f"Language {language} is already registered; pass override=True to replace it")
+                for ext in extensions:
+                    owner = self._extensions.get(ext) or EXTENSION_LANGUAGES.get(ext)
+                    if owner is not None and owner != language:
+                        raise ValueError(f"Extension {ext} already belongs to {owner}; pass override=True to claim it")
+
+            self._unregister(language)
+            self._backends[language] = backend
+            for ext in extensions:
+                self._extensions[ext] = language
+
+    def unregister(self, language: str) -> None:
+        """Remove a language's registration, if any."""
+        with self._lock:
+            self._unregister(language)
+
+    def get(self, language: str) -> Optional[LanguageBackend]:
+        """Get the backend registered for a language, if any."""
+        with self._lock:
+            return self._backends.get(language)
+
+    def language_for_extension(self, extension: str) -> Optional[str]:
+        """Get the registered language a file extension dispatches to, if any."""
+        with self._lock:
+            return self._extensions.get(_normalize_extension(extension))
+
+    def languages(self) -> List[str]:
+        """Names of the registered languages, in registration order."""
+        with self._lock:
+            return list(self._backends)
+
+    def _unregister(self, language: str) -> None:
+        """Remove a registration; the caller holds the lock."""
+        self._backends.pop(language, None)
+        for ext in [e for e, lang in self._extensions.items() if lang == language]:
+            del self._extensions[ext]
+
+
+def _normalize_extension(extension: str) -> str:
+    """Lower-case an extension and give it a leading dot."""
+    ext = extension.lower()
+    return ext if ext.startswith(".") else f".{ext}"
+
+
+_default_registry = LanguageRegistry()
+
+
+def language_registry() -> LanguageRegistry:
+    """The process-wide registry consulted by language detection, parsing and extraction."""
+    return _default_registry
+
+
+def register_language(language: str, backend: LanguageBackend, override: bool = False) -> None:
+    """Register a language backend process-wide (see LanguageRegistry.register)."""
+    _default_registry.register(language, backend, override)
+
+
+def unregister_language(language: str) -> None:
+    """Remove a process-wide language registration, if any."""
+    _default_registry.unregister(language)
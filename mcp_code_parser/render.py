@@ -0,0 +1,227 @@
+"""Human-readable rendering of symbol outlines."""
+
+import os
+import re
+import sys
+from dataclasses import dataclass, field
+from typing import Callable, Dict, List, Optional, TextIO
+
+from mcp_code_parser.analysis.manifest import ManifestDiff, ManifestEntry, compare_outlines
+from mcp_code_parser.extractors.base import Outline, Symbol
+
+# Styles one element of a line: (element, text, symbol kind) -> text
+Styler = Callable[[str, str, str], str]
+
+# Elements passed to a Styler
+ELEMENT_BRANCH = "branch"
+ELEMENT_KIND = "kind"
+ELEMENT_NAME = "name"
+ELEMENT_SIGNATURE = "signature"
+ELEMENT_ERROR = "error"
+
+# Formats of render_api_diff
+FORMAT_MARKDOWN = "markdown"
+FORMAT_TEXT = "text"
+
+
+def _default_kind_colors() -> Dict[str, str]:
+    """SGR codes for common kinds: types cyan, callables yellow, members dim."""
+    colors = {}
+    for kind in ("class", "struct", "interface", "enum", "type", "mixin", "extension", "namespace"):
+        colors[kind] = "36"
+    for kind in ("function", "method", "init", "constructor", "destructor", "operator", "getter", "setter"):
+        colors[kind] = "33"
+    for kind in ("field", "enum_member", "embedded"):
+        colors[kind] = "2"
+    for kind in ("import", "constant", "variable"):
+        colors[kind] = "34"
+    return colors
+
+
+@dataclass
+class Theme:
+    """ANSI SGR parameters (e.g. "1;34" for bold blue) for each part of an outline."""
+
+    # By symbol kind; kinds not listed use default_kind
+    kinds: Dict[str, str] = field(default_factory=_default_kind_colors)
+    default_kind: str = "35"
+    name: str = "1"
+    signature: str = "2"
+    branch: str = "90"
+    error: str = "31"
+
+    def code(self, element: str, kind: str) -> str:
+        """SGR parameters for an element of a symbol of the given kind."""
+        if element == ELEMENT_KIND:
+            return self.kinds.get(kind, self.default_kind)
+        return {
+            ELEMENT_NAME: self.name,
+            ELEMENT_SIGNATURE: self.signature,
+            ELEMENT_BRANCH: self.branch,
+            ELEMENT_ERROR: self.error,
+        }.get(element, "")
+
+
+def render_tree(outline: Outline, style: Optional[Styler] = None) -> str:
+    """Render an outline as an indented tree, one symbol per line.
+
+    Each line shows the kind, name and (after two spaces) signature, with
+    box-drawing branches showing nesting:
+
+        ├── struct User  type User struct
+        │   └── field ID  ID int
+        └── function main  func main()
+
+    Args:
+        outline: Outline to render
+        style: Applied to each element of each line (plain text if None)
+
+    Returns:
+        The tree, without a trailing newline
+    """
+    style = style or _plain
+    lines: List[str] = []
+    if outline.error:
+        lines.append(style(ELEMENT_ERROR, f"error: {outline.error}", ""))
+    _render_symbols(outline.symbols, "", style, lines)
+    return "\n".join(lines)
+
+
+def render_terminal(
+    outline: Outline,
+    theme: Optional[Theme] = None,
+    color: Optional[bool] = None,
+    stream: Optional[TextIO] = None
+) -> str:
+    """Render an outline as a tree, colored with ANSI escape codes for terminals.
+
+    Args:
+        outline: Outline to render
+        theme: Colors to use (defaults to Theme())
+        color: Force colors on or off; by default they are used when stream
+            is a TTY and the NO_COLOR environment variable isn't set
+        stream: Stream the output is for (defaults to sys.stdout)
+
+    Returns:
+        The tree, without a trailing newline
+    """
+    if not use_color(stream or sys.stdout, color):
+        return render_tree(outline)
+    theme = theme or Theme()
+
+    def style(element: str, text: str, kind: str) -> str:
+        code = theme.code(element, kind)
+        return f"\x1b[{code}m{text}\x1b[0m" if code and text else text
+
+    return render_tree(outline, style)
+
+
+def render_api_diff(old: Outline, new: Outline, format: str = FORMAT_MARKDOWN) -> str:
+    """Render the changes to the exported API between two outlines, e.g. for a PR description.
+
+    See compare_outlines and render_manifest_diff.
+    """
+    return render_manifest_diff(compare_outlines(old, new), format)
+
+
+def render_manifest_diff(diff: ManifestDiff, format: str = FORMAT_MARKDOWN) -> str:
+    """Render an API diff as Added, Removed and Changed sections with signatures.
+
+    The summary line says whether the diff is breaking; sections are marked
+    non-breaking (added) or breaking (removed, changed) and left out when
+    empty:
+
+        ## API changes
+
+        Breaking: 1 removed.
+
+        ### Removed (breaking)
+
+        - function `Lookup`: `func Lookup(id int) *User`
+
+    Args:
+        diff: Result of compare_outlines or diff_manifests
+        format: FORMAT_MARKDOWN or FORMAT_TEXT
+
+    Returns:
+        The rendering, ending in a newline
+
+    Raises:
+        ValueError: If the format isn't known
+    """
+    if format not in (FORMAT_MARKDOWN, FORMAT_TEXT):
+        raise ValueError(f"Unknown API diff format {format!r}")
+    markdown = format == FORMAT_MARKDOWN
+    lines = ["## API changes" if markdown else "API changes", ""]
+    if not (diff.added or diff.removed or diff.changed):
+        lines.append("No changes to the exported API.")
+        return "\n".join(lines) + "\n"
+    counts = [f"{len(entries)} {label}" for label, entries in (("removed", diff.removed), ("changed", diff.changed)) if entries]
+    lines.append(f"Breaking: {', '.join(counts)}." if diff.breaking else "No breaking changes.")
+
+    sections = [
+        ("Added (non-breaking)", "+", [(e, None) for e in diff.added]),
+        ("Removed (breaking)", "-", [(e, None) for e in diff.removed]),
+        ("Changed (breaking)", "~", [(c.new, c.old) for c in diff.changed]),
+    ]
+    for title, marker, entries in sections:
+        if not entries:
+            continue
+        lines += ["", f"### {title}" if markdown else f"{title}:"]
+        if markdown:
+            lines.append("")
+        for entry, before in entries:
+            lines.extend(_diff_entry_lines(entry, before, marker, markdown))
+    return "\n".join(lines) + "\n"
+
+
+def _diff_entry_lines(entry: ManifestEntry, before: Optional[ManifestEntry], marker: str, markdown: bool) -> List[str]:
+    """Lines for one entry of an API diff; before is the old entry of a changed one."""
+    if not markdown:
+        head = f"  {marker} {entry.kind} {entry.name}"
+        if before is None:
+            return [head + (f"  {entry.signature}" if entry.signature else "")]
+        return [head, f"      before: {before.signature or ''}", f"      after:  {entry.signature or ''}"]
+
+    head = f"- {entry.kind} {_code(entry.name)}"
+    if before is None:
+        return [head + (f": {_code(entry.signature)}" if entry.signature else "")]
+    return [
+        head,
+        f"  - before: {_code(before.signature) if before.signature else '(none)'}",
+        f"  - after: {_code(entry.signature) if entry.signature else '(none)'}",
+    ]
+
+
+def _code(text: str) -> str:
+    """Markdown code span holding text, fenced by more backticks than it contains."""
+    fence = "`" * (1 + max((len(run) for run in re.findall(r"`+", text)), default=0))
+    padding = " " if text.startswith("`") or text.endswith("`") else ""
+    return f"{fence}{padding}{text}{padding}{fence}"
+
+
+def use_color(stream: TextIO, force: Optional[bool] = None) -> bool:
+    """Decide whether to color output for a stream (see https://no-color.org)."""
+    if force is not None:
+        return force
+    if os.environ.get("NO_COLOR"):
+        return False
+    isatty = getattr(stream, "isatty", None)
+    return bool(isatty and isatty())
+
+
+def _render_symbols(symbols: List[Symbol], prefix: str, style: Styler, lines: List[str]) -> None:
+    """Append a line per symbol (and its children) under prefix."""
+    for i, sym in enumerate(symbols):
+        last = i == len(symbols) - 1
+        line = style(ELEMENT_BRANCH, prefix + ("└── " if last else "├── "), sym.kind)
+        line += style(ELEMENT_KIND, sym.kind, sym.kind) + " " + style(ELEMENT_NAME, sym.name, sym.kind)
+        if sym.signature:
+            line += "  " + style(ELEMENT_SIGNATURE, " ".join(sym.signature.split()), sym.kind)
+        lines.append(line)
+        _render_symbols(sym.children, prefix + ("    " if last else "│   "), style, lines)
+
+
+def _plain(element: str, text: str, kind: str) -> str:
+    """Styler leaving text unchanged."""
+    return text
@@ -0,0 +1,195 @@
+"""Mapping symbols of generated code back to their original source with source maps."""
+
+import base64
+import json
+import os
+import re
+import urllib.parse
+from dataclasses import dataclass
+from pathlib import Path
+from typing import List, Optional, Tuple
+
+from mcp_code_parser.extractors.base import Symbol
+
+_BASE64 = {c: i for i, c in enumerate("ABCDEFGHIJKLMNOPQRSTUVWXYZabcdefghijklmnopqrstuvwxyz0123456789+/")}
+
+# `//# sourceMappingURL=...` (or the older `//@`), in JavaScript or CSS comments
+_SOURCE_MAPPING_URL = re.compile(r"(?://|/\*)[#@]\s*sourceMappingURL=(\S+?)\s*(?:\*/)?\s*$", re.MULTILINE)
+
+# (generated column, source index, original line, original column), all 0-based
+_Segment = Tuple[int, int, int, int]
+
+
+@dataclass
+class OriginalPosition:
+    """Where a position in generated code came from."""
+
+    file: str
+    # 1-based; the column counts UTF-16 code units, as source maps do
+    line: int
+    column: int
+
+
+class SourceMap:
+    """A decoded (version 3) source map."""
+
+    def __init__(self, sources: List[str], lines: List[List[_Segment]]):
+        """Create a source map from resolved source paths and per-line segments sorted by column."""
+        self.sources = sources
+        self._lines = lines
+
+    @classmethod
+    def parse(cls, text: str, map_path: Optional[str] = None) -> "SourceMap":
+        """Decode source map JSON.
+
+        Args:
+            text: Content of the map
+            map_path: Where the map was read from; relative source paths are
+                resolved against its directory
+
+        Raises:
+            ValueError: If the map is malformed or not a version 3 map
+        """
+        try:
+            data = json.loads(text)
+        except json.JSONDecodeError as e:
+            raise ValueError(f"Invalid source map JSON: {e}") from e
+        if not isinstance(data, dict) or data.get("version") != 3:
+            raise ValueError("Not a version 3 source map")
+        if "sections" in data:
+            raise ValueError("Indexed source maps are not supported")
+        if not isinstance(data.get("sources"), list) or not isinstance(data.get("mappings"), str):
+            raise ValueError("Source map lacks sources or mappings")
+
+        base = os.path.dirname(map_path) if map_path else ""
+        root = data.get("sourceRoot") or ""
+        sources = [_resolve_source(base, root, str(s)) for s in data["sources"]]
+        return cls(sources, _decode_mappings(data["mappings"], len(sources)))
+
+    def original_position(self, line: int, column: int) -> Optional[OriginalPosition]:
+        """Map a generated position (0-based line, UTF-16 column) to the original.
+
+        Uses the last mapping at or before the column on the line, or the
+        line's first mapping when the position precedes them all (e.g. leading
+        `export` keywords the compiler doesn't map).
+        """
+        if line >= len(self._lines) or not self._lines[line]:
+            return None
+        segments = self._lines[line]
+        segment = segments[0]
+        for candidate in segments:
+            if candidate[0] > column:
+                break
+            segment = candidate
+        _, source, original_line, original_column = segment
+        return OriginalPosition(file=self.sources[source], line=original_line + 1, column=original_column + 1)
+
+
+def load_source_map(file_path: str, content: str) -> Optional[SourceMap]:
+    """Find and decode the source map of a generated file.
+
+    The map is taken from the file's last `sourceMappingURL` comment (a
+    relative path or a base64 `data:` URL), falling back to `<file>.map`
+    next to it.
+
+    Returns:
+        The map, or None if the file has none
+
+    Raises:
+        ValueError: If the referenced map can't be read or decoded
+    """
+    urls = _SOURCE_MAPPING_URL.findall(content)
+    if urls:
+        url = urls[-1]
+        if url.startswith("data:"):
+            header, _, payload = url.partition(",")
+            if not header.endswith(";base64"):
+                return SourceMap.parse(urllib.parse.unquote(payload), file_path)
+            try:
+                return SourceMap.parse(base64.b64decode(payload).decode("utf8"), file_path)
+            except (ValueError, UnicodeDecodeError) as e:
+                raise ValueError(f"Invalid inline source map: {e}") from e
+        if "://" in url:
+            raise ValueError(f"Remote source map {url} is not fetched")
+        map_path = os.path.join(os.path.dirname(file_path), urllib.parse.unquote(url))
+    else:
+        map_path = file_path + ".map"
+        if not os.path.isfile(map_path):
+            return None
+
+    try:
+        text = Path(map_path).read_text(encoding="utf8")
+    except (OSError, UnicodeDecodeError) as e:
+        raise ValueError(f"Can't read source map {map_path}: {e}") from e
+    return SourceMap.parse(text, map_path)
+
+
+def map_symbols(symbols: List[Symbol], source_map: SourceMap, source: bytes) -> None:
+    """Set original_file, original_line and original_column on symbols (and children), in place.
+
+    Symbols whose start has no mapping are left unset.
+    """
+    for sym in symbols:
+        line_start = source.rfind(b"\n", 0, sym.start_byte) + 1
+        prefix = source[line_start:sym.start_byte].decode("utf8", errors="replace")
+        position = source_map.original_position(sym.start_line - 1, len(prefix.encode("utf-16-le")) // 2)
+        if position is not None:
+            sym.original_file = position.file
+            sym.original_line = position.line
+            sym.original_column = position.column
+        map_symbols(sym.children, source_map, source)
+
+
+def _resolve_source(base: str, root: str, source: str) -> str:
+    """Path of a map's source, relative to the map's directory unless absolute or a URL."""
+    if root and not root.endswith("/"):
+        root += "/"
+    path = root + source
+    if "://" in path or os.path.isabs(path):
+        return path
+    return os.path.normpath(os.path.join(base, path))
+
+
+def _decode_mappings(mappings: str, source_count: int) -> List[List[_Segment]]:
+    """Decode the VLQ mappings field into absolute segments per generated line."""
+    lines: List[List[_Segment]] = []
+    source = original_line = original_column = 0
+    for line_text in mappings.split(";"):
+        segments = []
+        column = 0
+        for segment_text in line_text.split(","):
+            if not segment_text:
+                continue
+            fields = _decode_vlq(segment_text)
+            column += fields[0]
+            # One-field segments map generated code to no source
+            if len(fields) < 4:
+                continue
+            source += fields[1]
+            original_line += fields[2]
+            original_column += fields[3]
+            if not 0 <= source < source_count:
+                raise ValueError(f"Source map segment refers to missing source {source}")
+            segments.append((column, source, original_line, original_column))
+        segments.sort(key=lambda s: s[0])
+        lines.append(segments)
+    return lines
+
+
+def _decode_vlq(text: str) -> List[int]:
+    """Decode a segment's base64 VLQ values."""
+    values = []
+    value = shift = 0
+    for char in text:
+        digit = _BASE64.get(char)
+        if digit is None:
+            raise ValueError(f"Invalid character {char!r} in source map mappings")
+        value += (digit & 31) << shift
+        if digit & 32:
+            shift += 5
+            continue
+        values.append(-(value >> 1) if value & 1 else value >> 1)
+        value = shift = 0
+    if shift:
+        raise ValueError("Truncated value in source map mappings")
+    return values
@@ -1,9 +1,30 @@
 """Common utilities for mcp-code-parser."""
 
+import codecs
 import hashlib
+import inspect
 import os
+from dataclasses import dataclass
 from pathlib import Path
-from typing import Optional
+from typing import Any, Dict, List, Optional, Tuple
+
+from mcp_code_parser.binary import DEFAULT_BINARY_DETECTOR, BinaryDetector, wide_text_encoding
+from mcp_code_parser.errors import BinaryFileError, PathTraversalError, TooLargeError
+from mcp_code_parser.globs import glob_match
+from mcp_code_parser.registry import language_registry
+
+# Bytes sniffed from the start of a file when checking for binary content
+_BINARY_SNIFF_SIZE = 8192
+
+# Byte order marks and the endian-specific codecs they select; UTF-32 LE
+# comes first as its BOM starts with UTF-16 LE's
+_BOMS = (
+    (codecs.BOM_UTF32_LE, "utf-32-le"),
+    (codecs.BOM_UTF32_BE, "utf-32-be"),
+    (codecs.BOM_UTF8, "utf-8"),
+    (codecs.BOM_UTF16_LE, "utf-16-le"),
+    (codecs.BOM_UTF16_BE, "utf-16-be"),
+)
 
 
 def get_cache_dir() -> Path:
@@ -20,26 +41,82 @@ def get_grammar_cache_dir() -> Path:
     return grammar_dir
 
 
+# Built-in languages by file extension
+EXTENSION_LANGUAGES = {
+    ".py": "python",
+    ".js": "javascript",
+    ".jsx": "javascript",
+    ".ts": "typescript",
+    ".tsx": "typescript",
+    ".go": "go",
+    ".c": "c",
+    ".cc": "cpp",
+    ".cpp": "cpp",
+    ".cxx": "cpp",
+    ".h": "c",
+    ".hpp": "cpp",
+    ".hxx": "cpp",
+    ".dart": "dart",
+    ".ex": "elixir",
+    ".exs": "elixir",
+    ".json": "json",
+    ".yaml": "yaml",
+    ".yml": "yaml",
+    ".vue": "vue",
+    ".svelte": "svelte",
+}
+
+
+# Per-language conventions for naming test files, as glob patterns matched
+# against the file name (or the root-relative path for patterns with a "/")
+TEST_FILE_PATTERNS: Dict[str, List[str]] = {
+    "go": ["*_test.go"],
+    "python": ["test_*.py", "*_test.py", "conftest.py"],
+    "javascript": ["*.test.js", "*.spec.js", "*.test.jsx", "*.spec.jsx", "**/__tests__/**"],
+    "typescript": ["*.test.ts", "*.spec.ts", "*.test.tsx", "*.spec.tsx", "**/__tests__/**"],
+    "java": ["*Test.java", "*Tests.java"],
+    "dart": ["*_test.dart"],
+    "elixir": ["*_test.exs"],
+    "c": ["test_*.c", "*_test.c"],
+    "cpp": ["test_*.cc", "*_test.cc", "test_*.cpp", "*_test.cpp"],
+    "vue": ["*.spec.vue", "**/__tests__/**"],
+    "svelte": ["*.spec.svelte", "**/__tests__/**"],
+}
+
+
+def is_test_file(
+    path: str,
+    language: Optional[str] = None,
+    patterns: Optional[Dict[str, List[str]]] = None
+) -> bool:
+    """Check whether a file is test code by its language's naming conventions.
+
+    Args:
+        path: File path; patterns are globs (see mcp_code_parser.globs)
+            matched against it, or against the file name if they have no `/`
+        language: Language of the file (detected from the path by default)
+        patterns: Per-language patterns replacing those of TEST_FILE_PATTERNS
+            for the languages they list
+    """
+    language = language or detect_language_from_file(path)
+    if patterns is not None and language in patterns:
+        language_patterns = patterns[language]
+    else:
+        language_patterns = TEST_FILE_PATTERNS.get(language or "", [])
+    posix = path.replace(os.sep, "/").lstrip("/")
+    return any(glob_match(posix, pattern) for pattern in language_patterns)
+
+
 def detect_language_from_file(file_path: str) -> Optional[str]:
-    """Detect programming language from file extension."""
-    ext_to_lang = {
-        ".py": "python",
-        ".js": "javascript",
-        ".jsx": "javascript",
-        ".ts": "typescript",
-        ".tsx": "typescript",
-        ".go": "go",
-        ".c": "c",
-        ".cc": "cpp",
-        ".cpp": "cpp",
-        ".cxx": "cpp",
-        ".h": "c",
-        ".hpp": "cpp",
-        ".hxx": "cpp",
-    }
+    """Detect programming language from file extension.
     
+    Extensions of languages registered with register_language take
+    precedence over the built-in ones.
+    """
     ext = Path(file_path).suffix.lower()
-    return ext_to_lang.get(ext)
+    if not ext:
+        return None
+    return language_registry().language_for_extension(ext) or EXTENSION_LANGUAGES.get(ext)
 
 
 def hash_content(content: str) -> str:
@@ -47,17 +124,268 @@ def hash_content(content: str) -> str:
     return hashlib.sha256(content.encode()).hexdigest()
 
 
-def safe_read_file(file_path: str, encoding: str = "utf-8") -> str:
-    """Safely read file content."""
+class FileTooLargeError(TooLargeError):
+    """Raised when a file exceeds the configured size limit."""
+    
+    def __init__(self, file_path: str, size: int, limit: int):
+        self.file_path = file_path
+        self.size = size
+        self.limit = limit
+        super().__init__(f"File is {size} bytes, exceeds limit of {limit} bytes")
+
+
+def safe_read_file(
+    file_path: str,
+    encoding: str = "utf-8",
+    max_size: Optional[int] = None,
+    truncate: bool = False,
+    detector: Optional[BinaryDetector] = None,
+    strip_bom: bool = True
+) -> str:
+    """Safely read file content.
+    
+    Line endings become "\n". Edits to be written back should use
+    read_source and write_source instead, which keep the original format.
+    
+    Args:
+        file_path: Path to the file
+        encoding: Preferred encoding (falls back to others on decode errors;
+            UTF-16 and UTF-32 files are detected and decoded as such)
+        max_size: Optional size limit in bytes
+        truncate: Read only the first max_size bytes of larger files instead
+            of raising FileTooLargeError
+        detector: Binary content detector (defaults to DefaultBinaryDetector)
+        strip_bom: Remove a leading byte order mark
+    
+    Raises:
+        FileTooLargeError: If the file exceeds max_size and truncate is False
+        BinaryFileError: If the detector classifies the file as binary
+    """
+    with open(file_path, "rb") as f:
+        sample = f.read(_BINARY_SNIFF_SIZE)
+    if (detector or DEFAULT_BINARY_DETECTOR).is_binary(sample):
+        raise BinaryFileError(f"{file_path} appears to be a binary file")
+    encoding = wide_text_encoding(sample) or encoding
+    
+    if max_size is not None:
+        size = os.path.getsize(file_path)
+        if size > max_size:
+            if not truncate:
+                raise FileTooLargeError(file_path, size, max_size)
+            return _without_bom(_read_prefix(file_path, max_size, encoding), strip_bom)
+    
     try:
         with open(file_path, "r", encoding=encoding) as f:
-            return f.read()
+            return _without_bom(f.read(), strip_bom)
     except UnicodeDecodeError:
         # Try with different encodings
         for enc in ["latin-1", "ascii", "utf-16"]:
             try:
                 with open(file_path, "r", encoding=enc) as f:
-                    return f.read()
+                    return _without_bom(f.read(), strip_bom)
             except UnicodeDecodeError:
                 continue
-        raise
\ No newline at end of file
+        raise
+
+
+def _without_bom(text: str, strip_bom: bool) -> str:
+    """Text without a leading byte order mark, if stripping."""
+    return text[1:] if strip_bom and text.startswith("\ufeff") else text
+
+
+@dataclass
+class TextFormat:
+    """How a text file was stored, so normalized content can be written back the same way."""
+
+    # Codec the file was decoded with, e.g. "utf-8" or "utf-16-le"
+    encoding: str = "utf-8"
+    # The file started with a byte order mark, stripped from the content
+    bom: bool = False
+    # Line ending of the file ("\r\n", "\r" or "\n"), replaced by "\n" in the content
+    newline: str = "\n"
+
+    def encode(self, content: str) -> bytes:
+        """Encode normalized content in this format."""
+        if self.newline != "\n":
+            content = content.replace("\n", self.newline)
+        if self.bom:
+            content = "\ufeff" + content
+        return content.encode(self.encoding)
+
+
+def read_source(
+    file_path: str,
+    encoding: str = "utf-8",
+    strip_bom: bool = True,
+    detector: Optional[BinaryDetector] = None
+) -> Tuple[str, TextFormat]:
+    """Read a file normalized for parsing and editing, with how it was stored.
+
+    Files with a UTF-8, UTF-16 or UTF-32 byte order mark are decoded with
+    the codec it selects, BOM-less UTF-16 is detected, and other files fall
+    back to latin-1 when they aren't valid in the preferred encoding. Line
+    endings become "\n" and the file's is recorded for write_source: "\r\n"
+    if it has any, else "\r" if it has any, so a file mixing endings is
+    written back with one throughout.
+
+    Args:
+        file_path: Path to the file
+        encoding: Preferred encoding of files without a BOM
+        strip_bom: Remove a leading byte order mark from the content (it is
+            still restored by write_source); when False it is kept as "\ufeff"
+        detector: Binary content detector (defaults to DefaultBinaryDetector)
+
+    Returns:
+        The normalized content and the file's TextFormat
+
+    Raises:
+        BinaryFileError: If the detector classifies the file as binary
+    """
+    with open(file_path, "rb") as f:
+        data = f.read()
+    sample = data[:_BINARY_SNIFF_SIZE]
+    if (detector or DEFAULT_BINARY_DETECTOR).is_binary(sample):
+        raise BinaryFileError(f"{file_path} appears to be a binary file")
+
+    known = next((codec for bom, codec in _BOMS if data.startswith(bom)), None)
+    wide = wide_text_encoding(sample)
+    # "utf-16"/"utf-32" only come back for BOMs, which known already covers
+    encoding = known or (wide if wide in ("utf-16-le", "utf-16-be") else encoding)
+    try:
+        text = data.decode(encoding)
+    except UnicodeDecodeError:
+        encoding = "latin-1"
+        text = data.decode(encoding)
+
+    text_format = TextFormat(encoding=encoding)
+    if strip_bom and text.startswith("\ufeff"):
+        text = text[1:]
+        text_format.bom = True
+    for newline in ("\r\n", "\r", "\n"):
+        if newline in text:
+            text_format.newline = newline
+            break
+    return text.replace("\r\n", "\n").replace("\r", "\n"), text_format
+
+
+def write_source(file_path: str, content: str, text_format: Optional[TextFormat] = None) -> None:
+    """Write normalized content back in a file's original encoding, BOM and line ending.
+
+    Args:
+        file_path: Path to the file
+        content: Content with "\n" line endings, e.g. edited from read_source
+        text_format: Format from read_source (UTF-8 with "\n" if None)
+    """
+    with open(file_path, "wb") as f:
+        f.write((text_format or TextFormat()).encode(content))
+
+
+async def read_stream(
+    reader: Any,
+    max_size: Optional[int] = None,
+    truncate: bool = False,
+    encoding: str = "utf-8",
+    chunk_size: int = 64 * 1024,
+    detector: Optional[BinaryDetector] = None
+) -> str:
+    """Read a text or binary stream in chunks, stopping at a size limit.
+
+    The reader's ``read(n)`` may return str or bytes and may be a coroutine
+    (as with asyncio.StreamReader). At most max_size + 1 bytes are consumed,
+    so an oversized stream isn't buffered in full.
+
+    Args:
+        reader: Object with a read(n) method
+        max_size: Optional size limit in bytes (of the encoded text for str chunks)
+        truncate: Return the first max_size bytes of larger streams instead
+            of raising TooLargeError
+        encoding: Encoding of binary streams (UTF-16 and UTF-32 are detected)
+        chunk_size: Bytes or characters requested per read
+        detector: Binary content detector (defaults to DefaultBinaryDetector)
+
+    Raises:
+        TooLargeError: If the stream exceeds max_size and truncate is False
+        BinaryFileError: If the detector classifies the content as binary
+    """
+    chunks: List[bytes] = []
+    size = 0
+    while True:
+        chunk = reader.read(chunk_size)
+        if inspect.isawaitable(chunk):
+            chunk = await chunk
+        if not chunk:
+            break
+        if isinstance(chunk, str):
+            chunk = chunk.encode(encoding)
+        chunks.append(chunk)
+        size += len(chunk)
+        if max_size is not None and size > max_size:
+            if not truncate:
+                raise TooLargeError(f"Stream exceeds limit of {max_size} bytes")
+            break
+
+    data = b"".join(chunks)
+    sample = data[:_BINARY_SNIFF_SIZE]
+    if (detector or DEFAULT_BINARY_DETECTOR).is_binary(sample):
+        raise BinaryFileError("Stream appears to contain binary data")
+    encoding = wide_text_encoding(sample) or encoding
+    if max_size is not None and len(data) > max_size:
+        return _decode_prefix(data[:max_size], encoding)
+    try:
+        text = data.decode(encoding)
+    except UnicodeDecodeError:
+        text = data.decode("latin-1")
+    return text.replace("\r\n", "\n").replace("\r", "\n")
+
+
+def repo_relative_path(file_path: str, root: Optional[str] = None) -> str:
+    """Path of a file relative to its repository, as a POSIX path.
+
+    The repository is the nearest directory containing the file with a
+    `.git` entry. Outside one, the path is taken relative to root, or
+    returned as given without a root.
+    """
+    path = Path(file_path).absolute()
+    for directory in path.parents:
+        if (directory / ".git").exists():
+            return path.relative_to(directory).as_posix()
+    if root is not None:
+        return os.path.relpath(path, Path(root).absolute()).replace(os.sep, "/")
+    return Path(file_path).as_posix()
+
+
+def resolve_within(root: str, path: str) -> Path:
+    """Resolve a path (following symlinks) and ensure it stays inside root.
+    
+    Relative paths are taken relative to root.
+    
+    Raises:
+        PathTraversalError: If the resolved path is outside root
+    """
+    root_path = Path(root).resolve()
+    resolved = (root_path / path).resolve()
+    if resolved != root_path and root_path not in resolved.parents:
+        raise PathTraversalError(f"{path} resolves outside {root}")
+    return resolved
+
+
+def _read_prefix(file_path: str, limit: int, encoding: str) -> str:
+    """Read at most limit bytes of a file, decoded like safe_read_file."""
+    with open(file_path, "rb") as f:
+        data = f.read(limit)
+    return _decode_prefix(data, encoding)
+
+
+def _decode_prefix(data: bytes, encoding: str) -> str:
+    """Decode bytes that may have been cut mid-character at the end."""
+    try:
+        text = data.decode(encoding)
+    except UnicodeDecodeError as e:
+        # The cut may have split a multi-byte character; drop the partial tail
+        if e.start >= len(data) - 3:
+            text = data[:e.start].decode(encoding, errors="replace")
+        else:
+            text = data.decode("latin-1")
+    
+    # Match the universal-newline handling of text mode reads
+    return text.replace("\r\n", "\n").replace("\r", "\n")
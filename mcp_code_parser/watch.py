@@ -0,0 +1,217 @@
+"""Watching a directory and re-extracting files as they change."""
+
+import asyncio
+import time
+from dataclasses import dataclass
+from pathlib import Path
+from typing import TYPE_CHECKING, Dict, Optional, Tuple
+
+from mcp_code_parser.errors import NotFoundError
+from mcp_code_parser.extractors.base import ExtractOptions, Outline
+from mcp_code_parser.ignore import IgnoreRules
+from mcp_code_parser.logging import get_logger
+from mcp_code_parser.utils import detect_language_from_file
+
+if TYPE_CHECKING:
+    from mcp_code_parser.api import AgentTools
+    from mcp_code_parser.index import Index
+
+logger = get_logger("watch")
+
+# (mtime in nanoseconds, size) of a file when it was last looked at
+_State = Tuple[int, int]
+
+
+@dataclass
+class WatchEvent:
+    """A file that was created, modified or deleted under a watched root."""
+
+    # "created", "modified" or "deleted"; a rename is a deletion and a creation
+    kind: str
+    # Path relative to the root, with forward slashes
+    path: str
+    # New outline of a created or modified file
+    outline: Optional[Outline] = None
+
+
+class Watcher:
+    """Reports changed files under a root along with their new outlines.
+
+    The root is polled for supported files, honoring .gitignore rules, and
+    a file is reported once it has stayed unchanged for the debounce time,
+    so a burst of writes gives one event. Events compare a file with how it
+    was last reported: a file written and then removed between polls gives
+    none, and one created and then edited is reported as created.
+
+    Example:
+        async with Watcher("repo") as watcher:
+            async for event in watcher:
+                print(event.kind, event.path)
+    """
+
+    def __init__(
+        self,
+        root: str,
+        options: Optional[ExtractOptions] = None,
+        index: Optional["Index"] = None,
+        interval: float = 0.5,
+        debounce: float = 0.2,
+        respect_gitignore: bool = True,
+        tools: Optional["AgentTools"] = None
+    ):
+        """Create a watcher over root; it starts polling on start().
+
+        Args:
+            root: Directory to watch
+            options: Optional extraction options for the reported outlines
+            index: Index to update whenever files change
+            interval: Seconds between polls
+            debounce: Seconds a file must stay unchanged before it's reported
+            respect_gitignore: Leave out files ignored by .gitignore rules
+            tools: AgentTools instance to use (defaults to the global one)
+
+        Raises:
+            ValueError: If interval isn't positive or debounce is negative
+        """
+        if interval <= 0:
+            raise ValueError(f"interval must be positive, got {interval}")
+        if debounce < 0:
+            raise ValueError(f"debounce must not be negative, got {debounce}")
+        if tools is None:
+            from mcp_code_parser.api import _global_tools
+            tools = _global_tools
+        self.root = root
+        self.options = options
+        self.index = index
+        self.interval = interval
+        self.debounce = debounce
+        self.respect_gitignore = respect_gitignore
+        self._tools = tools
+        self._ignore: Optional[IgnoreRules] = None
+        # Files as they were last reported, or first seen
+        self._reported: Dict[str, _State] = {}
+        self._queue: "asyncio.Queue[Optional[WatchEvent]]" = asyncio.Queue()
+        self._task: Optional[asyncio.Task] = None
+        self._error: Optional[BaseException] = None
+
+    async def __aenter__(self) -> "Watcher":
+        """Start watching; the watcher is stopped on exit."""
+        await self.start()
+        return self
+
+    async def __aexit__(self, exc_type, exc_val, exc_tb) -> None:
+        """Stop watching on leaving the context."""
+        await self.stop()
+
+    def __aiter__(self) -> "Watcher":
+        """Iterate over events until the watcher stops."""
+        return self
+
+    async def __anext__(self) -> WatchEvent:
+        """Wait for the next event.
+
+        Raises:
+            StopAsyncIteration: Once the watcher has stopped
+        """
+        if self._task is None and self._queue.empty():
+            raise StopAsyncIteration
+        event = await self._queue.get()
+        if event is None:
+            if self._error is not None:
+                raise self._error
+            raise StopAsyncIteration
+        return event
+
+    async def start(self) -> None:
+        """Take a first look at the root and start polling it.
+
+        Files already there aren't reported; only later changes are.
+
+        Raises:
+            NotFoundError: If root isn't a directory
+            RuntimeError: If the watcher is already running
+        """
+        if self._task is not None:
+            raise RuntimeError("Watcher is already running")
+        if not Path(self.root).is_dir():
+            raise NotFoundError(f"Directory not found: {self.root}")
+        self._ignore = IgnoreRules.load(self.root) if self.respect_gitignore else None
+        self._error = None
+        self._reported = await asyncio.to_thread(self._snapshot)
+        self._task = asyncio.create_task(self._run())
+
+    async def stop(self) -> None:
+        """Stop polling and wait for the poller to finish.
+
+        Events already reported can still be read; iteration ends after them.
+        """
+        if self._task is None:
+            return
+        task = self._task
+        self._task = None
+        task.cancel()
+        try:
+            await task
+        except asyncio.CancelledError:
+            pass
+        self._queue.put_nowait(None)
+
+    async def _run(self) -> None:
+        """Poll the root until cancelled."""
+        latest = dict(self._reported)
+        # When each changed but not yet reported file last changed
+        pending: Dict[str, float] = {}
+        try:
+            while True:
+                await asyncio.sleep(self.interval)
+                current = await asyncio.to_thread(self._snapshot)
+                now = time.monotonic()
+                for rel in latest.keys() | current.keys():
+                    if latest.get(rel) != current.get(rel):
+                        pending[rel] = now
+                latest = current
+
+                events = []
+                for rel in sorted(r for r, changed in pending.items() if now - changed >= self.debounce):
+                    del pending[rel]
+                    event = await self._event(rel, self._reported.get(rel), current.get(rel))
+                    if rel in current:
+                        self._reported[rel] = current[rel]
+                    else:
+                        self._reported.pop(rel, None)
+                    if event is not None:
+                        events.append(event)
+                if events and self.index is not None:
+                    await self.index.update()
+                for event in events:
+                    self._queue.put_nowait(event)
+        except Exception as e:
+            logger.error(f"Stopped watching {self.root}: {e}")
+            self._error = e
+            self._task = None
+            self._queue.put_nowait(None)
+
+    async def _event(self, rel: str, before: Optional[_State], after: Optional[_State]) -> Optional[WatchEvent]:
+        """The event for a file going from before to after, if it changed."""
+        if before == after:
+            return None
+        if after is None:
+            return WatchEvent(kind="deleted", path=rel)
+        outline = await self._tools.extract_file(str(Path(self.root) / rel), options=self.options)
+        return WatchEvent(kind="created" if before is None else "modified", path=rel, outline=outline)
+
+    def _snapshot(self) -> Dict[str, _State]:
+        """The state of each supported file under the root."""
+        from mcp_code_parser.api import _walk_files
+
+        states = {}
+        for path in _walk_files(Path(self.root), self._ignore):
+            if self._tools.get_extractor(detect_language_from_file(str(path)) or "") is None:
+                continue
+            try:
+                stat = path.stat()
+            except OSError:
+                # Removed since it was listed
+                continue
+            states[path.relative_to(self.root).as_posix()] = (stat.st_mtime_ns, stat.st_size)
+        return states
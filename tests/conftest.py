@@ -8,6 +8,26 @@ from typing import Generator
 import pytest
 
 
+def pytest_addoption(parser):
+    """Add --benchmark to opt in to timing benchmarks."""
+    parser.addoption("--benchmark", action="store_true", help="also run tests marked benchmark")
+
+
+def pytest_configure(config):
+    """Register the benchmark marker."""
+    config.addinivalue_line("markers", "benchmark: compares timings, so only runs with --benchmark")
+
+
+def pytest_collection_modifyitems(config, items):
+    """Skip benchmarks unless asked for: wall-clock comparisons are flaky on loaded machines."""
+    if config.getoption("--benchmark"):
+        return
+    skip = pytest.mark.skip(reason="timing benchmark; run with --benchmark")
+    for item in items:
+        if "benchmark" in item.keywords:
+            item.add_marker(skip)
+
+
 @pytest.fixture
 def temp_dir() -> Generator[Path, None, None]:
     """Create temporary directory."""
@@ -0,0 +1,45 @@
+#include <cmath>
+#include "vector.h"
+
+namespace geo {
+
+class Vector {
+public:
+    Vector();
+    Vector(double x, double y);
+    Vector(const Vector& other) = default;
+    ~Vector();
+
+    Vector operator+(const Vector& other) const;
+    Vector operator*(double scale) const;
+    Vector& operator=(const Vector& other);
+    bool operator==(const Vector& other) const;
+    explicit operator bool() const { return x_ != 0 || y_ != 0; }
+
+    double length() const;
+    void scale(double factor);
+    void scale(double fx, double fy);
+
+private:
+    double x_;
+    double y_;
+};
+
+Vector::Vector() : x_(0), y_(0) {}
+
+Vector::Vector(double x, double y) : x_(x), y_(y) {}
+
+Vector::~Vector() {}
+
+Vector Vector::operator+(const Vector& other) const {
+    return Vector(x_ + other.x_, y_ + other.y_);
+}
+
+double Vector::length() const {
+    return std::sqrt(x_ * x_ + y_ * y_);
+}
+
+double distance(const Vector& a, const Vector& b);
+double distance(const Vector& a);
+
+}  // namespace geo
@@ -0,0 +1,13 @@
+package billing
+
+import "example.com/shop/orders"
+
+// Charge bills an amount for an order.
+func Charge(orderID string, amount int) error {
+	return nil
+}
+
+// Invoice describes what an order was billed.
+func Invoice(o orders.Order) string {
+	return o.ID
+}
@@ -0,0 +1,7 @@
+package main
+
+import "example.com/shop/orders"
+
+func main() {
+	_ = orders.Checkout(orders.Order{ID: "1"})
+}
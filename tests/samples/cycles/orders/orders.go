@@ -0,0 +1,14 @@
+package orders
+
+import "example.com/shop/billing"
+
+// Order is a customer order.
+type Order struct {
+	ID    string
+	Total int
+}
+
+// Checkout bills an order.
+func Checkout(o Order) error {
+	return billing.Charge(o.ID, o.Total)
+}
@@ -0,0 +1,13 @@
+package orders
+
+import (
+	"fmt"
+
+	"example.com/shop/billing"
+)
+
+// Refund reverses the charge for an order.
+func Refund(o Order) error {
+	fmt.Println("refunding", o.ID)
+	return billing.Charge(o.ID, -o.Total)
+}
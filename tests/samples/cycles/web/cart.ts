@@ -0,0 +1,5 @@
+import { formatTotal } from "./format";
+
+export function cartLabel(total: number): string {
+  return formatTotal(total);
+}
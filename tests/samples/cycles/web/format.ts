@@ -0,0 +1,8 @@
+import { cartLabel } from "./cart";
+import { formatTotal as self } from "./format";
+
+export function formatTotal(total: number): string {
+  return `${total}`;
+}
+
+export const preview = () => cartLabel(0) + self(0);
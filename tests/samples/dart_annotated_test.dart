@@ -0,0 +1,38 @@
+class Test {
+  const Test();
+}
+
+class Skip {
+  final String reason;
+  const Skip(this.reason);
+}
+
+class CalculatorTest {
+  int value = 0;
+
+  void setUp() {
+    value = 0;
+  }
+
+  @Test()
+  void addsNumbers() {
+    value = 1 + 2;
+  }
+
+  @Test()
+  @Skip('flaky on CI')
+  void dividesByZero() {
+    value = 1 ~/ 0;
+  }
+
+  @Deprecated('Use addsNumbers')
+  @Test()
+  void oldAdd() {}
+}
+
+class ParserTest {
+  @Test()
+  void parsesEmptyInput() {}
+}
+
+void helper() {}
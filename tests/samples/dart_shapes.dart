@@ -0,0 +1,60 @@
+import 'dart:math' as math;
+
+const double tau = 2 * math.pi;
+
+/// A closed figure with an area.
+abstract class Shape {
+  final String label;
+
+  Shape(this.label);
+
+  double get area;
+
+  String describe() => '$label with area $area';
+}
+
+mixin Scalable on Shape {
+  double factor = 1;
+
+  set scale(double value) => factor = value;
+}
+
+class Circle extends Shape with Scalable {
+  static const Circle unit = Circle._unit();
+
+  final double radius;
+  int _hits = 0;
+
+  Circle(this.radius, {String label = 'circle'}) : super(label);
+
+  const Circle._unit() : radius = 1, super('unit');
+
+  factory Circle.fromDiameter(double diameter) => Circle(diameter / 2);
+
+  @override
+  double get area => math.pi * radius * radius * factor;
+
+  @Deprecated('Use area instead')
+  double size() => area;
+
+  static Circle largest(List<Circle> circles) =>
+      circles.reduce((a, b) => a.radius >= b.radius ? a : b);
+}
+
+enum Color {
+  red,
+  green,
+  blue;
+
+  bool get isWarm => this == Color.red;
+}
+
+extension CircleList on List<Circle> {
+  double totalArea() => fold(0, (sum, c) => sum + c.area);
+}
+
+double perimeter(Circle circle, [double scale = 1]) => tau * circle.radius * scale;
+
+void _log(String message) {
+  print(message);
+}
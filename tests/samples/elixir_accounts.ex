@@ -0,0 +1,54 @@
+# Elixir sample for testing
+defmodule Shop.Accounts do
+  @moduledoc """
+  Manages users of the shop.
+  """
+
+  @timeout 5_000
+  @behaviour Shop.Store
+
+  defmodule User do
+    defstruct [:id, :email, name: "guest"]
+  end
+
+  @doc "Fetches a user by ID."
+  @spec get_user(String.t(), keyword()) :: {:ok, User.t()} | {:error, :not_found}
+  def get_user(id, opts \\ [])
+
+  def get_user("", _opts), do: {:error, :not_found}
+
+  def get_user(id, opts) when is_binary(id) do
+    lookup(id, Keyword.get(opts, :timeout, @timeout))
+  end
+
+  @impl true
+  def fetch(id), do: get_user(id)
+
+  @deprecated "Use get_user/2 instead"
+  def find(id), do: get_user(id)
+
+  def count do
+    0
+  end
+
+  defp lookup(id, _timeout) do
+    {:ok, %User{id: id}}
+  end
+
+  defmacro admin?(user) do
+    quote do: unquote(user).role == :admin
+  end
+
+  defmacrop log(message) do
+    quote do: IO.puts(unquote(message))
+  end
+end
+
+defprotocol Shop.Priced do
+  @doc "Price in cents."
+  def price(item)
+end
+
+defimpl Shop.Priced, for: Shop.Accounts.User do
+  def price(_user), do: 0
+end
@@ -0,0 +1,16 @@
+const express = require("express");
+
+const { DATABASE_URL, POOL_SIZE = "10", AWS_REGION: region = "us-east-1" } = process.env;
+
+const port = process.env.PORT || 3000;
+const secret = process.env["SESSION_SECRET"];
+const level = process.env.LOG_LEVEL ?? "info";
+
+process.env.NODE_ENV = process.env.NODE_ENV || "development";
+
+function flag(name) {
+  return process.env[`FEATURE_${name}`] === "on";
+}
+
+const app = express();
+app.listen(port);
@@ -0,0 +1,29 @@
+package main
+
+import (
+	"cmp"
+	"os"
+)
+
+// Config is read from the environment.
+type Config struct {
+	Addr  string
+	Token string
+}
+
+func load(prefix string) Config {
+	token, ok := os.LookupEnv("API_TOKEN")
+	if !ok {
+		token = "anonymous"
+	}
+	_ = os.Getenv(prefix + "_REGION")
+	return Config{
+		Addr:  cmp.Or(os.Getenv("LISTEN_ADDR"), ":8080"),
+		Token: token,
+	}
+}
+
+func debug() bool {
+	// os.Getenv("IN_COMMENT") is not a read
+	return os.Getenv("DEBUG") == "1"
+}
@@ -0,0 +1,8 @@
+import os
+from os import getenv
+
+DATABASE_URL = os.environ["DATABASE_URL"]
+DEBUG = os.environ.get("DEBUG", "false") == "true"
+WORKERS = int(getenv("WORKERS") or 4)
+TIMEOUT = os.getenv("TIMEOUT", default="30")
+os.environ["TZ"] = "UTC"
@@ -0,0 +1,21 @@
+package people
+
+// Person has accessor methods in the usual Go styles.
+type Person struct {
+	name string
+	age  int
+	id   string
+}
+
+func (p *Person) GetName() string { return p.name }
+
+func (p *Person) SetName(name string) { p.name = name }
+
+func (p *Person) Age() int { return p.age }
+
+func (p *Person) SetAge(age int) { p.age = age }
+
+func (p *Person) GetID() string { return p.id }
+
+// Len has no setter, so it stays a method.
+func (p *Person) Len() int { return 0 }
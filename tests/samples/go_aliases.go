@@ -0,0 +1,22 @@
+// Go sample with type aliases and defined types
+package accounts
+
+import "time"
+
+type UserID string
+
+type Timeout = time.Duration
+
+type IDList []UserID
+
+type Account struct {
+	ID UserID
+}
+
+func Lookup(id UserID, ids IDList, wait Timeout) (*Account, error) {
+	return &Account{ID: id}, nil
+}
+
+func (a *Account) Owner() UserID {
+	return a.ID
+}
@@ -0,0 +1,35 @@
+// Go sample for anonymous struct types in var and field positions
+package config
+
+// Defaults configures the server when no file is given.
+var Defaults = struct {
+	Host string
+	Port int
+	TLS  struct {
+		Cert string
+		Key  string
+	}
+}{
+	Host: "localhost",
+	Port: 8080,
+}
+
+var routes = []struct {
+	method, path string
+}{
+	{"GET", "/health"},
+}
+
+// Server holds the loaded configuration.
+type Server struct {
+	Name    string
+	Limits  struct {
+		Requests int `json:"requests"`
+	}
+}
+
+func Load() Server {
+	local := struct{ ok bool }{ok: true}
+	_ = local
+	return Server{}
+}
@@ -0,0 +1,50 @@
+package cache
+
+import (
+	"io"
+	"sync"
+)
+
+// Cache stores values by key
+type Cache interface {
+	Get(key string) (interface{}, bool)
+	Set(key string, value interface{})
+}
+
+// InMemoryCache satisfies Cache structurally as well as by assertion
+type InMemoryCache struct {
+	mu    sync.RWMutex
+	items map[string]interface{}
+}
+
+func (c *InMemoryCache) Get(key string) (interface{}, bool) {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	v, ok := c.items[key]
+	return v, ok
+}
+
+func (c *InMemoryCache) Set(key string, value interface{}) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.items[key] = value
+}
+
+func (c *InMemoryCache) Close() error {
+	return nil
+}
+
+var _ Cache = (*InMemoryCache)(nil)
+
+// Noop discards everything
+type Noop struct{}
+
+func (Noop) Get(key string) (interface{}, bool) { return nil, false }
+func (Noop) Set(key string, value interface{})  {}
+
+var (
+	_ Cache     = Noop{}
+	_ io.Closer = &InMemoryCache{}
+	// Not an assertion: the blank name discards a call's result
+	_ = io.Discard
+)
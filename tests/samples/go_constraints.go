@@ -0,0 +1,35 @@
+package constraints
+
+import "fmt"
+
+// Number admits integer and floating-point types, including defined types.
+type Number interface {
+	~int | ~int64 | float64
+}
+
+// Bytes admits byte slices and strings.
+type Bytes interface {
+	[]byte | ~string
+}
+
+// StringableInt is an integer type with a String method.
+type StringableInt interface {
+	~int
+	fmt.Stringer
+}
+
+// Ordered is the intersection of two type sets.
+type Ordered interface {
+	Number
+	~int | ~string
+	int64 | int
+}
+
+// Sum adds up the values.
+func Sum[T Number](values []T) T {
+	var total T
+	for _, v := range values {
+		total += v
+	}
+	return total
+}
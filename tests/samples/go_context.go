@@ -0,0 +1,48 @@
+package jobs
+
+import (
+	"context"
+	"time"
+)
+
+// Runner keeps the context it was created with, which outlives the request.
+type Runner struct {
+	ctx     context.Context
+	timeout time.Duration
+}
+
+type scoped struct {
+	context.Context
+}
+
+func (r *Runner) Run(ctx context.Context, name string) error {
+	ctx, cancel := context.WithTimeout(ctx, r.timeout)
+	defer cancel()
+	return r.step(ctx, name)
+}
+
+func (r *Runner) step(ctx context.Context, name string) error {
+	return nil
+}
+
+func (r *Runner) Leak() {
+	child, _ := context.WithCancel(r.ctx)
+	_ = r.step(child, "leak")
+}
+
+func (r *Runner) Forget() {
+	child, stop := context.WithDeadline(r.ctx, time.Now())
+	_ = r.step(child, "forget")
+}
+
+func (r *Runner) Returned() (context.Context, context.CancelFunc) {
+	child, cancel := context.WithCancel(r.ctx)
+	return child, cancel
+}
+
+func (r *Runner) Nil() {
+	_ = r.step(nil, "nil")
+	_, cancel := context.WithCancel(nil)
+	defer cancel()
+	_ = r.step(context.TODO(), "todo")
+}
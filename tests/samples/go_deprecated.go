@@ -0,0 +1,33 @@
+package client
+
+// Client talks to the API.
+type Client struct {
+	// Deprecated: Set Timeout instead.
+	RetryDelay int
+	Timeout    int
+}
+
+// New creates a client with default settings.
+//
+// Deprecated: Use NewClient, which validates its options.
+// New will be removed in v2.
+func New() *Client {
+	return &Client{}
+}
+
+// NewClient creates a validated client.
+func NewClient(timeout int) (*Client, error) {
+	return &Client{Timeout: timeout}, nil
+}
+
+// Do sends a request. The word Deprecated: here is not a paragraph start.
+func (c *Client) Do() error {
+	return nil
+}
+
+// Deprecated:
+func (c *Client) Close() {}
+
+// Deprecated: Not a doc comment, separated by a blank line.
+
+func Ping() {}
@@ -0,0 +1,33 @@
+package storage
+
+import (
+	"context"
+	"fmt"
+	stdio "io"
+
+	"example.com/app/internal/codec"
+)
+
+// Blob embeds stdlib interfaces whose methods aren't declared in this file.
+type Blob interface {
+	stdio.Reader
+	stdio.Closer
+	fmt.Stringer
+	Size() int64
+}
+
+// Store only embeds a local interface, so its method set is fully visible.
+type Store interface {
+	Getter
+	Put(ctx context.Context, key string, value []byte) error
+}
+
+type Getter interface {
+	Get(ctx context.Context, key string) ([]byte, error)
+}
+
+// Codec mixes local and external embeds, including a generic one.
+type Codec interface {
+	Getter
+	codec.Encoder[[]byte]
+}
@@ -0,0 +1,37 @@
+package enums
+
+type Color int
+
+const (
+	Red Color = iota
+	Green
+	Blue
+)
+
+type Weekday uint8
+
+const (
+	_ Weekday = iota
+	Monday
+	Tuesday
+	_
+	Thursday
+)
+
+const (
+	Low = iota + 1
+	Medium
+	High = iota * 10
+	Critical
+)
+
+const (
+	KB = 1 << (10 * (iota + 1))
+	MB
+	GB
+)
+
+const (
+	Timeout = 30
+	Retries = 3
+)
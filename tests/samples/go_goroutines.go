@@ -0,0 +1,87 @@
+package monitor
+
+import (
+	"context"
+	"log"
+	"time"
+)
+
+type Monitor struct {
+	events chan string
+	stop   chan struct{}
+}
+
+// Leaks: nothing ever ends the loop
+func (m *Monitor) StartLeaky() {
+	go func() {
+		for {
+			log.Println(<-m.events)
+		}
+	}()
+}
+
+// Only stops once pinging fails
+func StartPolling(url string) {
+	go poll(url)
+}
+
+func poll(url string) {
+	for {
+		if err := ping(url); err != nil {
+			return
+		}
+		time.Sleep(time.Second)
+	}
+}
+
+// Stops with the context
+func (m *Monitor) Start(ctx context.Context) {
+	go func() {
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case e := <-m.events:
+				log.Println(e)
+			}
+		}
+	}()
+}
+
+// Stops when events is closed
+func (m *Monitor) Drain() {
+	go func() {
+		for {
+			e, ok := <-m.events
+			if !ok {
+				return
+			}
+			log.Println(e)
+		}
+	}()
+	go func() {
+		for e := range m.events {
+			log.Println(e)
+		}
+	}()
+}
+
+// Stops when told to
+func (m *Monitor) Watch() {
+	go func() {
+		ticker := time.NewTicker(time.Second)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-m.stop:
+				return
+			case <-ticker.C:
+				log.Println("tick")
+			}
+		}
+	}()
+}
+
+func ping(url string) error {
+	return nil
+}
@@ -0,0 +1,19 @@
+// Package main registers its handlers at startup.
+package main
+
+import "fmt"
+
+var handlers = map[string]func(){}
+
+func init() {
+	handlers["hello"] = func() { fmt.Println("hello") }
+}
+
+// A second initializer; Go runs them in the order they appear.
+func init() {
+	handlers["bye"] = func() { fmt.Println("bye") }
+}
+
+func main() {
+	handlers["hello"]()
+}
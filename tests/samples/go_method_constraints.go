@@ -0,0 +1,49 @@
+package generic
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Named has a name and embeds fmt.Stringer.
+type Named interface {
+	Name() string
+	fmt.Stringer
+}
+
+// Labeled adds a label to Named.
+type Labeled interface {
+	Named
+	Label() string
+}
+
+// Join calls String on every value.
+func Join[T interface{ String() string }](values []T, sep string) string {
+	parts := make([]string, len(values))
+	for i, v := range values {
+		parts[i] = v.String()
+	}
+	return strings.Join(parts, sep)
+}
+
+// Describe uses a local constraint with embedded interfaces.
+func Describe[T Labeled, K comparable](items map[K]T) []string {
+	var out []string
+	for _, item := range items {
+		out = append(out, item.Label()+": "+item.Name())
+	}
+	return out
+}
+
+// Registry holds values printable with %v.
+type Registry[V fmt.Stringer] struct {
+	items []V
+}
+
+// Max only constrains by a type set.
+func Max[N ~int | ~float64](a, b N) N {
+	if a > b {
+		return a
+	}
+	return b
+}
@@ -0,0 +1,57 @@
+package parity
+
+// Tree is a binary tree of ints.
+type Tree struct {
+	Left, Right *Tree
+	Value       int
+}
+
+// Sum adds up the values in the tree.
+func (t *Tree) Sum() int {
+	if t == nil {
+		return 0
+	}
+	return t.Value + t.Left.Sum() + t.Right.Sum()
+}
+
+func factorial(n int) int {
+	if n <= 1 {
+		return 1
+	}
+	return n * factorial(n-1)
+}
+
+func isEven(n int) bool {
+	if n == 0 {
+		return true
+	}
+	return isOdd(n - 1)
+}
+
+func isOdd(n int) bool {
+	if n == 0 {
+		return false
+	}
+	return isEven(n - 1)
+}
+
+func countdown(n int) []int {
+	var out []int
+	var step func(int)
+	step = func(i int) {
+		if i < 0 {
+			return
+		}
+		out = append(out, i)
+		step(i - 1)
+	}
+	step(n)
+	return out
+}
+
+func describe(n int) string {
+	if isEven(n) {
+		return "even"
+	}
+	return "odd"
+}
@@ -0,0 +1,12 @@
+package accounts
+
+// User is a registered account holder.
+type User struct {
+	ID    int
+	Email string
+}
+
+// DisplayName is how the user is shown in the UI.
+func (u *User) DisplayName() string {
+	return u.Email
+}
@@ -0,0 +1,18 @@
+package accounts
+
+import "errors"
+
+var errNoEmail = errors.New("user has no email")
+
+// Validate checks that the user can be saved.
+func (u *User) Validate() error {
+	if u.Email == "" {
+		return errNoEmail
+	}
+	return nil
+}
+
+// Save stores the user.
+func (u *User) Save() error {
+	return u.Validate()
+}
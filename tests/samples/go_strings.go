@@ -0,0 +1,24 @@
+package templates
+
+// Schema is code-like text that must not become symbols.
+const Schema = `
+CREATE TABLE users (id INT);
+func fake() { return }
+type Hidden struct { X int }
+`
+
+var handler = `
+func (s *Server) Serve() {
+	if ready { serve() }
+}
+`
+
+// Render returns a template with braces in it.
+func Render() string {
+	return `
+<div>{{ .Name }}</div>
+func insideRender() {}
+`
+}
+
+func Short() string { return `type Inline struct{}` }
@@ -0,0 +1,44 @@
+package middleware
+
+import (
+	"io"
+	"net/http"
+)
+
+// Logger is implemented by anything that can log.
+type Logger interface {
+	Log(msg string)
+}
+
+// LevelLogger embeds Logger, adding leveled logging.
+type LevelLogger interface {
+	Logger
+	SetLevel(level int)
+}
+
+// Base gives the types embedding it a Close method.
+type Base struct {
+	closed bool
+}
+
+func (b *Base) Close() error {
+	b.closed = true
+	return nil
+}
+
+// Handler mixes interface-typed fields with embedded interfaces and structs.
+type Handler struct {
+	// A field whose type is an interface promotes nothing
+	log Logger
+	// Embedded interfaces promote their whole method set
+	LevelLogger
+	io.Writer
+	// Embedded structs promote their methods
+	*Base
+	next  http.Handler
+	hooks interface {
+		Before()
+	}
+}
+
+func (h *Handler) ServeHTTP(w http.ResponseWriter, r *http.Request) {}
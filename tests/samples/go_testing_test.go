@@ -0,0 +1,54 @@
+// Go test file sample for test symbol extraction
+package sample
+
+import (
+	"fmt"
+	"testing"
+)
+
+func TestAdd(t *testing.T) {
+	t.Run("positive", func(t *testing.T) {
+		t.Run("small", func(t *testing.T) {})
+	})
+	t.Run(`negative`, func(t *testing.T) {})
+}
+
+func TestTableDriven(t *testing.T) {
+	tests := []struct {
+		name string
+		in   int
+	}{
+		{name: "zero", in: 0},
+		{name: "one", in: 1},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			_ = tc.in
+		})
+	}
+}
+
+func BenchmarkAdd(b *testing.B) {
+	for i := 0; i < b.N; i++ {
+		_ = 1 + 1
+	}
+}
+
+func FuzzAdd(f *testing.F) {
+	f.Fuzz(func(t *testing.T, a int) {})
+}
+
+func ExampleAdd() {
+	fmt.Println(2)
+	// Output: 2
+}
+
+// Not tests: wrong parameter type, lower-case suffix, plain helper
+func TestMain(m *testing.M) {
+	m.Run()
+}
+
+func Testhelper(t *testing.T) {}
+
+func helper() {}
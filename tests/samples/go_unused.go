@@ -0,0 +1,41 @@
+// Go sample with dead code for unused symbol detection
+package sample
+
+import "fmt"
+
+const greeting = "hello"
+
+const unusedConst = 1
+
+type formatter struct{}
+
+func (f formatter) format(name string) string {
+	return fmt.Sprintf("%s %s", greeting, name)
+}
+
+func (f formatter) unusedMethod() {}
+
+// Greet is exported, so it is never reported
+func Greet(name string) string {
+	return formatter{}.format(name) + crossFile()
+}
+
+// Only calls itself
+func countdown(n int) {
+	if n > 0 {
+		countdown(n - 1)
+	}
+}
+
+func unusedHelper() string {
+	return "nobody calls me"
+}
+
+// Looked up by name at runtime, so reported with low confidence
+func lookedUp() {}
+
+var registry = []string{"lookedUp"}
+
+func init() {
+	_ = registry
+}
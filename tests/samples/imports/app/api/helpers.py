@@ -0,0 +1,2 @@
+def slug(name):
+    return name.lower().replace(" ", "-")
@@ -0,0 +1,9 @@
+import json
+
+from .. import models
+from ..models import Product
+from app.api import helpers
+
+
+def show(name):
+    return json.dumps({"product": Product(name).name, "module": models.__name__, "helpers": helpers})
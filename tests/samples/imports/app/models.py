@@ -0,0 +1,3 @@
+class Product:
+    def __init__(self, name):
+        self.name = name
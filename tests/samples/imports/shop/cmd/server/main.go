@@ -0,0 +1,13 @@
+package main
+
+import (
+	"fmt"
+
+	"example.com/shop/internal/store"
+	"github.com/google/uuid"
+)
+
+func main() {
+	s := store.New()
+	fmt.Println(s, uuid.NewString())
+}
@@ -0,0 +1,11 @@
+package store
+
+// Store holds products in memory.
+type Store struct {
+	items map[string]int
+}
+
+// New creates an empty store.
+func New() *Store {
+	return &Store{items: map[string]int{}}
+}
@@ -0,0 +1,3 @@
+export interface Cart {
+  total: number;
+}
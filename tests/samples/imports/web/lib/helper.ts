@@ -0,0 +1,3 @@
+export function helper(value: number): number {
+  return Math.round(value);
+}
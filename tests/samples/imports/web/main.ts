@@ -0,0 +1,8 @@
+import { formatPrice } from "./lib/format";
+import { Cart } from "./components";
+import { helper } from "./lib/helper.js";
+import React from "react";
+
+export function render(cart: Cart): string {
+  return formatPrice(helper(cart.total));
+}
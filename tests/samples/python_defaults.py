@@ -0,0 +1,23 @@
+"""Functions with default and keyword-only parameters."""
+
+from typing import Optional
+
+DEFAULT_TIMEOUT = 30.0
+
+
+def connect(host, port=5432, *, timeout: float = DEFAULT_TIMEOUT, ssl: bool, retries: int = 3):
+    """Open a connection."""
+    pass
+
+
+def query(sql: str, params: Optional[dict] = None, /, *args, fetch="all", **options):
+    """Run a query."""
+    pass
+
+
+class Pool:
+    def __init__(self, size: int = 10, tags=[], name: str = "default pool"):
+        self.size = size
+
+    def acquire(self, timeout: float = 1.5 * 2):
+        pass
@@ -0,0 +1,14 @@
+"""Python sample with a decorated route handler."""
+
+from flask import Flask
+
+app = Flask(__name__)
+PREFIX = "/api"
+
+
+@app.route("/users", methods=["POST"], defaults={"page": 1, "strict": True})
+@app.route(f"{PREFIX}/users", methods=["POST"], endpoint=None)
+@limiter.limit("10/minute", exempt_when=lambda: current_user.is_admin)
+def create_user():
+    """Create a user from the posted form."""
+    return {"created": True}, 201
@@ -0,0 +1,6 @@
+package api
+
+// UserService has the same name in another package.
+type UserService interface {
+	GetUser(id string) (string, error)
+}
@@ -0,0 +1,24 @@
+package users
+
+// Handler serves users over HTTP.
+type Handler struct {
+	service *UserService
+}
+
+func NewHandler(service *UserService) *Handler {
+	return &Handler{service: service}
+}
+
+func (s *UserService) CreateUser(name string) *User {
+	user := &User{ID: name, Name: name}
+	s.users[user.ID] = user
+	return user
+}
+
+func (h *Handler) Lookup(id string) string {
+	user, err := h.service.GetUser(id)
+	if err != nil {
+		return ""
+	}
+	return user.Name
+}
@@ -0,0 +1,26 @@
+package users
+
+import "errors"
+
+// User is an account.
+type User struct {
+	ID   string
+	Name string
+}
+
+// UserService stores users in memory.
+type UserService struct {
+	users map[string]*User
+}
+
+func NewUserService() *UserService {
+	return &UserService{users: map[string]*User{}}
+}
+
+func (s *UserService) GetUser(id string) (*User, error) {
+	user, ok := s.users[id]
+	if !ok {
+		return nil, errors.New("UserService: no such user")
+	}
+	return user, nil
+}
@@ -0,0 +1,7 @@
+class Helper:
+    def run(self):
+        pass
+
+
+def helper():
+    pass
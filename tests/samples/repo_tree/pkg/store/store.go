@@ -0,0 +1,7 @@
+package store
+
+type Store struct{}
+
+func (s *Store) Get() string {
+	return ""
+}
@@ -0,0 +1,21 @@
+"""Flask app with a blueprint."""
+
+from flask import Blueprint, Flask
+
+app = Flask(__name__)
+accounts = Blueprint("accounts", __name__, url_prefix="/accounts")
+
+
+@app.route("/")
+def index():
+    return "ok"
+
+
+@accounts.route("/<int:account_id>", methods=["GET", "PUT"])
+def account(account_id):
+    return {"id": account_id}
+
+
+@accounts.post("/")
+def open_account():
+    return {}, 201
@@ -0,0 +1,13 @@
+package main
+
+import "github.com/gin-gonic/gin"
+
+func ginRoutes(e *gin.Engine) {
+	v1 := e.Group("/v1")
+	v1.GET("/orders", auth, listOrders)
+	v1.Any("/echo", func(c *gin.Context) {})
+}
+
+func auth(c *gin.Context) {}
+
+func listOrders(c *gin.Context) {}
@@ -0,0 +1,13 @@
+package main
+
+import "net/http"
+
+type Handlers struct{}
+
+func (h *Handlers) ListUsers(w http.ResponseWriter, r *http.Request) {}
+
+func (h *Handlers) CreateUser(w http.ResponseWriter, r *http.Request) {}
+
+func (h *Handlers) GetUser(w http.ResponseWriter, r *http.Request) {}
+
+func (h *Handlers) DeleteUser(w http.ResponseWriter, r *http.Request) {}
@@ -0,0 +1,34 @@
+package main
+
+import (
+	"net/http"
+
+	"github.com/go-chi/chi/v5"
+)
+
+func routes(h *Handlers) http.Handler {
+	r := chi.NewRouter()
+	r.Get("/health", health)
+	r.Route("/users", func(r chi.Router) {
+		r.Get("/", h.ListUsers)
+		r.Post("/", h.CreateUser)
+		r.Get("/{id}", h.GetUser)
+	})
+	r.Method("DELETE", "/users/{id}", http.HandlerFunc(h.DeleteUser))
+	return r
+}
+
+func admin() *http.ServeMux {
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /admin/stats", stats)
+	mux.Handle("/admin/", http.NotFoundHandler())
+	mux.HandleFunc("/admin/ping", func(w http.ResponseWriter, r *http.Request) {})
+	return mux
+}
+
+func health(w http.ResponseWriter, r *http.Request) {}
+
+func stats(w http.ResponseWriter, r *http.Request) {
+	// Lookups taking two arguments aren't routes
+	cache.Get(r.Context(), "stats")
+}
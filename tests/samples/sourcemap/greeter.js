@@ -0,0 +1,12 @@
+export class Greeter {
+    constructor(name) {
+        this.name = name;
+    }
+    greet() {
+        return `Hello, ${this.name}`;
+    }
+}
+export function shout(text) {
+    return text.toUpperCase();
+}
+//# sourceMappingURL=greeter.js.map
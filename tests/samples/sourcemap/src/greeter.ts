@@ -0,0 +1,11 @@
+export class Greeter {
+  constructor(private name: string) {}
+
+  greet(): string {
+    return `Hello, ${this.name}`;
+  }
+}
+
+export function shout(text: string): string {
+  return text.toUpperCase();
+}
@@ -0,0 +1,63 @@
+"""Tests for grouping accessors into properties."""
+
+from mcp_code_parser.extractors.accessors import collapse_accessors
+from mcp_code_parser.extractors.base import Attribute, Param, Symbol
+
+
+def _method(name, line, kind="method", **kwargs):
+    return Symbol(
+        name=name,
+        kind=kind,
+        start_line=line,
+        end_line=line,
+        start_byte=line * 10,
+        end_byte=line * 10 + 5,
+        **kwargs,
+    )
+
+
+def _class(*children):
+    return Symbol(name="Shape", kind="class", start_line=1, end_line=20, start_byte=0, end_byte=200, children=list(children))
+
+
+def test_python_properties():
+    """Test @property and @x.setter pairs, and that other methods stay."""
+    shape = _class(
+        _method("area", 2, attributes=[Attribute(name="property")]),
+        _method("area", 4, attributes=[Attribute(name="area.setter")], params=[Param(name="value", type="")]),
+        _method("draw", 6),
+    )
+    [collapsed] = collapse_accessors([shape], "python")
+    assert [(c.kind, c.name) for c in collapsed.children] == [("property", "area"), ("method", "draw")]
+    area = collapsed.children[0]
+    assert (area.readable, area.writable, area.start_line, area.end_line) == (True, True, 2, 4)
+    assert [c.start_line for c in area.children] == [2, 4]
+    # The input is left as it was
+    assert len(shape.children) == 3
+
+
+def test_convention_rules():
+    """Test the Go, C++, JavaScript and Dart conventions."""
+    go = [
+        _method("Name", 1, receiver="User", results=[Param(name="", type="string")]),
+        _method("Email", 2, receiver="User", results=[Param(name="", type="string")]),
+        _method("SetName", 3, receiver="User", params=[Param(name="n", type="string")]),
+    ]
+    collapsed = collapse_accessors(go, "go")
+    # Email() has no setter, so it stays a method
+    assert [(s.kind, s.name) for s in collapsed] == [("property", "Name"), ("method", "Email")]
+    assert collapsed[0].receiver == "User"
+
+    cpp = _class(_method("get_width", 2), _method("set_width", 3, params=[Param(name="w", type="int")]))
+    assert [(c.kind, c.name) for c in collapse_accessors([cpp], "cpp")[0].children] == [("property", "width")]
+
+    js = _class(_method("size", 2, signature="static get size()"), _method("size", 3, signature="size()"))
+    [prop, method] = collapse_accessors([js], "javascript")[0].children
+    assert (prop.kind, prop.readable, prop.writable, method.kind) == ("property", True, False, "method")
+
+    dart = _class(_method("radius", 2, kind="setter"))
+    prop = collapse_accessors([dart], "dart")[0].children[0]
+    assert (prop.kind, prop.readable, prop.writable) == ("property", False, True)
+
+    # Languages without a convention are unchanged
+    assert collapse_accessors(go, "elixir") is go
@@ -0,0 +1,52 @@
+"""Tests for multi-file code analysis."""
+
+from pathlib import Path
+
+import pytest
+
+from mcp_code_parser.analysis.unused import find_unused_symbols
+from mcp_code_parser.extractors.base import SourceFile
+from mcp_code_parser.parsers.base import LanguageNotSupportedError
+
+
+@pytest.fixture
+def samples_dir():
+    """Get samples directory."""
+    return Path(__file__).parent / "samples"
+
+
+@pytest.mark.asyncio
+async def test_find_unused_symbols(samples_dir):
+    """Test that unreferenced unexported declarations are reported."""
+    files = [
+        SourceFile.read(str(samples_dir / "go_unused.go")),
+        SourceFile("other.go", "package sample\n\nfunc crossFile() string { return greeting }\n"),
+    ]
+
+    unused = {u.symbol.name: u for u in await find_unused_symbols(files)}
+
+    assert set(unused) == {"unusedConst", "unusedMethod", "countdown", "unusedHelper", "lookedUp"}
+    assert unused["unusedHelper"].confidence > unused["unusedMethod"].confidence
+    assert unused["unusedMethod"].confidence > unused["lookedUp"].confidence
+    assert unused["lookedUp"].reason == "named in a string literal"
+    assert unused["countdown"].file.endswith("go_unused.go")
+
+
+@pytest.mark.asyncio
+async def test_find_unused_symbols_sample(samples_dir):
+    """Test the complex sample: referenced helpers are not reported."""
+    files = [SourceFile.read(str(samples_dir / "go_complex.go"))]
+
+    names = [u.symbol.name for u in await find_unused_symbols(files)]
+
+    assert "generateID" not in names
+    assert "worker" not in names
+    assert "pipeline" not in names
+    assert "safeOperation" in names
+
+
+@pytest.mark.asyncio
+async def test_find_unused_symbols_unsupported_language():
+    """Test that only Go is supported."""
+    with pytest.raises(LanguageNotSupportedError):
+        await find_unused_symbols([SourceFile("a.py", "x = 1")], language="python")
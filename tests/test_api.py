@@ -1,9 +1,16 @@
 """Unit tests for AgentTools API."""
 
+import io
+import json
+import time
+from unittest.mock import patch
+
 import pytest
 
-from mcp_code_parser.api import AgentTools
+from mcp_code_parser.api import AgentTools, SourceRoot
+from mcp_code_parser.extractors.base import ExtractOptions, OutputOptions
 from mcp_code_parser.parsers.base import BaseParser, ParseResult
+from mcp_code_parser.utils import safe_read_file
 
 
 class MockParser(BaseParser):
@@ -212,4 +219,343 @@ async def test_error_propagation(mcp_code_parser):
     
     result = await mcp_code_parser.parse_code("test", "any")
     assert not result.success
-    assert result.error == "Test error"
\ No newline at end of file
+    assert result.error == "Test error"
+
+
+@pytest.mark.asyncio
+async def test_extract_dir_skips_oversized_files(tmp_path):
+    """Test that extract_dir marks files over max_file_size as skipped."""
+    small = "package main\n\nfunc small() {}\n"
+    (tmp_path / "small.go").write_text(small)
+    (tmp_path / "pkg").mkdir()
+    (tmp_path / "pkg" / "big.go").write_text(small + "// " + "x" * 64 + "\n")
+    (tmp_path / "notes.txt").write_text("not source")
+    
+    tools = AgentTools()
+    options = ExtractOptions(max_file_size=len(small.encode()) + 1)
+    results = await tools.extract_dir(str(tmp_path), options)
+    
+    assert sorted(results) == ["pkg/big.go", "small.go"]
+    assert not results["small.go"].skipped
+    assert [s.name for s in results["small.go"].symbols] == ["small"]
+    
+    big = results["pkg/big.go"]
+    assert big.skipped
+    assert "exceeds limit" in big.skip_reason
+    assert big.symbols == []
+
+
+@pytest.mark.asyncio
+async def test_extract_roots(tmp_path):
+    """Test that outlines from several roots are keyed by root and filtered per root."""
+    for root, name in (("api", "Serve"), ("worker", "Run")):
+        (tmp_path / root / "internal").mkdir(parents=True)
+        (tmp_path / root / "util.go").write_text(f"package main\n\nfunc {name}() {{}}\n")
+        (tmp_path / root / "util_test.go").write_text("package main\n\nfunc TestX() {}\n")
+        (tmp_path / root / "internal" / "db.go").write_text("package internal\n\nfunc Open() {}\n")
+    
+    tools = AgentTools()
+    results = await tools.extract_roots(
+        [
+            str(tmp_path / "api"),
+            SourceRoot(str(tmp_path / "worker"), name="jobs", include=["internal/*"]),
+            str(tmp_path / "missing"),
+        ],
+        exclude=["*_test.go"],
+    )
+    
+    assert list(results) == ["api/internal/db.go", "api/util.go", "jobs/internal/db.go", "missing"]
+    assert [s.name for s in results["api/util.go"].symbols] == ["Serve"]
+    assert [s.name for s in results["jobs/internal/db.go"].symbols] == ["Open"]
+    assert results["missing"].error_code == "not_found"
+    
+    with pytest.raises(ValueError, match="api"):
+        await tools.extract_roots([str(tmp_path / "api"), SourceRoot(str(tmp_path / "worker"), name="api")])
+
+
+@pytest.mark.asyncio
+async def test_extract_file_truncates_oversized(tmp_path):
+    """Test that truncate_oversized extracts the leading part of large files."""
+    source = "package main\n\nfunc first() {}\n"
+    test_file = tmp_path / "big.go"
+    test_file.write_text(source + "\nfunc second() {}\n")
+    
+    tools = AgentTools()
+    options = ExtractOptions(max_file_size=len(source.encode()), truncate_oversized=True)
+    outline = await tools.extract_file(str(test_file), options=options)
+    
+    assert not outline.skipped
+    assert outline.metadata["truncated"] is True
+    assert [s.name for s in outline.symbols] == ["first"]
+
+
+@pytest.mark.asyncio
+async def test_extract_dir_jsonl(tmp_path):
+    """Test that directory outlines stream as one parseable JSON line per file."""
+    (tmp_path / "a.go").write_text("package main\n\nfunc A() {}\n")
+    (tmp_path / "b.go").write_text("package main\n\ntype B struct{}\n")
+    (tmp_path / "c.go").write_text("package main\n")
+    
+    def read_or_fail(file_path, **kwargs):
+        if file_path.endswith("c.go"):
+            raise PermissionError("Permission denied")
+        return safe_read_file(file_path, **kwargs)
+    
+    output = io.StringIO()
+    with patch("mcp_code_parser.api.safe_read_file", side_effect=read_or_fail):
+        count = await AgentTools().extract_dir_jsonl(str(tmp_path), output)
+    
+    lines = output.getvalue().splitlines()
+    assert count == len(lines) == 3
+    records = [json.loads(line) for line in lines]
+    assert [r["file"] for r in records] == ["a.go", "b.go", "c.go"]
+    assert records[0]["error"] is None
+    assert records[0]["symbols"][0]["name"] == "A"
+    assert records[1]["symbols"][0]["kind"] == "struct"
+    assert "Error reading file" in records[2]["error"]
+
+
+def _mixed_size_tree(root, small=150, large=3):
+    """Write many tiny Go files and a few large ones under root."""
+    for i in range(small):
+        (root / f"small_{i:03}.go").write_text(f"package pkg\n\nfunc Small{i}() {{}}\n")
+    for i in range(large):
+        funcs = "".join(f"func Large{i}_{j}(x int) int {{ return x + {j} }}\n" for j in range(5_000))
+        (root / f"large_{i}.go").write_text("package pkg\n\n" + funcs)
+
+
+@pytest.mark.asyncio
+async def test_extract_dir_read_workers(tmp_path):
+    """Test that results are the same whatever the number of concurrent reads."""
+    _mixed_size_tree(tmp_path, small=20, large=1)
+    (tmp_path / "locked.go").write_text("package pkg\n")
+    tools = AgentTools()
+
+    def read_or_fail(file_path, **kwargs):
+        if file_path.endswith("locked.go"):
+            raise PermissionError("Permission denied")
+        return safe_read_file(file_path, **kwargs)
+
+    results = []
+    with patch("mcp_code_parser.api.safe_read_file", side_effect=read_or_fail):
+        for workers in (None, 1, 3, 64):
+            outlines = await tools.extract_dir(str(tmp_path), ExtractOptions(read_workers=workers))
+            results.append([(rel, outline.to_dict()) for rel, outline in outlines.items()])
+    assert all(result == results[0] for result in results)
+    assert [rel for rel, _ in results[0]][:3] == ["large_0.go", "locked.go", "small_000.go"]
+    assert "Error reading file" in dict(results[0])["locked.go"]["error"]
+
+    with pytest.raises(ValueError):
+        await tools.extract_dir(str(tmp_path), ExtractOptions(read_workers=0))
+
+
+@pytest.mark.benchmark
+@pytest.mark.asyncio
+async def test_benchmark_adaptive_reads(tmp_path):
+    """Benchmark: adaptive concurrent reads beat one read at a time on slow storage."""
+    _mixed_size_tree(tmp_path)
+    tools = AgentTools()
+    await tools.extract_symbols("package pkg\n", "go")
+
+    def slow_read(file_path, **kwargs):
+        # Storage with per-file latency, like a network filesystem
+        time.sleep(0.002)
+        return safe_read_file(file_path, **kwargs)
+
+    timings = {}
+    with patch("mcp_code_parser.api.safe_read_file", side_effect=slow_read):
+        for label, workers in (("fixed", 1), ("adaptive", None)):
+            start = time.perf_counter()
+            outlines = await tools.extract_dir(str(tmp_path), ExtractOptions(read_workers=workers))
+            timings[label] = time.perf_counter() - start
+            assert len(outlines) == 153
+
+    print(f"fixed: {timings['fixed']:.3f}s, adaptive: {timings['adaptive']:.3f}s")
+    assert timings["adaptive"] < timings["fixed"]
+
+
+@pytest.mark.asyncio
+async def test_extract_dir_each(tmp_path):
+    """Test that the callback sees each file once and that raising stops the walk."""
+    for name in ("a.go", "b.go", "c.go"):
+        (tmp_path / name).write_text(f"package main\n\nfunc {name[0].upper()}() {{}}\n")
+    (tmp_path / "notes.txt").write_text("not code\n")
+    tools = AgentTools()
+
+    seen = []
+    count = await tools.extract_dir_each(str(tmp_path), lambda rel, outline: seen.append((rel, outline.symbols[0].name)))
+    assert count == 3
+    assert seen == [("a.go", "A"), ("b.go", "B"), ("c.go", "C")]
+
+    visited = []
+
+    async def stop_at_b(rel, outline):
+        visited.append(rel)
+        if rel == "b.go":
+            raise RuntimeError("enough")
+
+    with pytest.raises(RuntimeError, match="enough"):
+        await tools.extract_dir_each(str(tmp_path), stop_at_b)
+    assert visited == ["a.go", "b.go"]
+
+
+@pytest.mark.asyncio
+async def test_output_field_allowlist(tmp_path):
+    """Test that only allowlisted symbol fields are serialized."""
+    (tmp_path / "a.go").write_text("package main\n\nfunc A() {}\n\ntype B struct {\n\tX int\n}\n")
+    output = io.StringIO()
+    fields = OutputOptions(fields=["name", "kind", "start_line"])
+    await AgentTools().extract_dir_jsonl(str(tmp_path), output, output_options=fields)
+
+    record = json.loads(output.getvalue())
+    assert json.dumps(record["symbols"], separators=(",", ":")) == (
+        '[{"name":"A","kind":"function","start_line":3},'
+        '{"name":"B","kind":"struct","start_line":5}]'
+    )
+
+    # Nested symbols are only kept when children are asked for
+    outline = await AgentTools().extract_file(str(tmp_path / "a.go"))
+    nested = outline.to_dict(OutputOptions(fields=["name", "children"]))
+    assert nested["symbols"][1] == {"name": "B", "children": [{"name": "X", "children": []}]}
+
+    with pytest.raises(ValueError, match="startLine"):
+        OutputOptions(fields=["name", "startLine"])
+
+
+@pytest.mark.asyncio
+async def test_output_relative_lines():
+    """Test that a method's lines count from its type's start, with the absolute offset kept."""
+    source = "package shop\n\ntype Cart struct {\n\tItems []string\n}\n\nfunc (c *Cart) Add(item string) {\n}\n"
+    outline = await AgentTools().extract_symbols(source, "go")
+    options = OutputOptions(fields=["name", "start_line", "end_line", "line_offset", "children"], relative_lines=True)
+
+    cart = outline.to_dict(options)["symbols"][0]
+    assert (cart["start_line"], cart["end_line"], cart["line_offset"]) == (3, 5, 0)
+    items, add = cart["children"]
+    assert (items["start_line"], items["end_line"], items["line_offset"]) == (2, 2, 2)
+    # Absolute lines 7-8, counted from Cart's line 3
+    assert (add["start_line"], add["end_line"], add["line_offset"]) == (5, 6, 2)
+    assert add["start_line"] + add["line_offset"] == outline.symbols[0].children[1].start_line == 7
+
+    absolute = outline.to_dict(OutputOptions(fields=["name", "start_line", "line_offset", "children"]))
+    assert absolute["symbols"][0]["children"][1] == {"name": "Add", "start_line": 7, "line_offset": 0, "children": []}
+
+
+@pytest.mark.asyncio
+async def test_extract_reader():
+    """Test extracting from text and binary streams."""
+    source = "package main\n\nfunc first() {}\n\nfunc second() {}\n"
+    tools = AgentTools()
+    
+    outline = await tools.extract_reader(io.StringIO(source), "go")
+    assert outline.success
+    assert [s.name for s in outline.symbols] == ["first", "second"]
+    
+    outline = await tools.extract_reader(io.BytesIO(source.encode()), "go", path="main.go")
+    assert [s.name for s in outline.symbols] == ["first", "second"]
+
+
+@pytest.mark.asyncio
+async def test_extract_reader_size_limit():
+    """Test that oversized streams are skipped or truncated like files."""
+    source = "package main\n\nfunc first() {}\n"
+    stream = io.StringIO(source + "\nfunc second() {}\n")
+    tools = AgentTools()
+    
+    outline = await tools.extract_reader(stream, "go", ExtractOptions(max_file_size=len(source)))
+    assert outline.skipped
+    assert outline.error_code == "too_large"
+    
+    stream.seek(0)
+    options = ExtractOptions(max_file_size=len(source), truncate_oversized=True)
+    outline = await tools.extract_reader(stream, "go", options)
+    assert [s.name for s in outline.symbols] == ["first"]
+
+
+@pytest.mark.asyncio
+async def test_validate():
+    """Test that broken snippets yield diagnostics and valid ones none."""
+    tools = AgentTools()
+
+    assert await tools.validate("package main\n\nfunc ok() {}\n", "go") == []
+
+    diagnostics = await tools.validate("package main\n\nfunc broken( {\n", "go")
+    assert diagnostics
+    assert diagnostics[0].line == 3
+    # The same diagnostics extraction reports, without the symbol tree
+    outline = await tools.extract_symbols("package main\n\nfunc broken( {\n", "go")
+    assert diagnostics == outline.diagnostics
+
+
+@pytest.mark.benchmark
+@pytest.mark.asyncio
+async def test_benchmark_validate():
+    """Benchmark: validating is cheaper than full extraction."""
+    lines = ["package models", ""]
+    for i in range(200):
+        lines.append(f"type Model{i} struct {{")
+        lines.extend(f'\tField{j} string `json:"field{j}"`' for j in range(30))
+        lines.append("}")
+        lines.append(f"func (m *Model{i}) Validate() error {{ return nil }}")
+    source = "\n".join(lines) + "\n"
+    tools = AgentTools()
+    # Warm the grammar so only parsing and extraction are measured
+    await tools.validate("package models\n", "go")
+    runs = 5
+
+    start = time.perf_counter()
+    for _ in range(runs):
+        await tools.extract_symbols(source, "go")
+    extract_time = (time.perf_counter() - start) / runs
+
+    start = time.perf_counter()
+    for _ in range(runs):
+        diagnostics = await tools.validate(source, "go")
+    validate_time = (time.perf_counter() - start) / runs
+
+    print(f"\nvalidate: {validate_time * 1e3:.1f}ms, extract_symbols: {extract_time * 1e3:.1f}ms")
+    assert diagnostics == []
+    assert validate_time < extract_time
+
+
+@pytest.mark.asyncio
+async def test_extract_dir_classify_tests(tmp_path):
+    """Test that test files are tagged per language conventions and can be left out."""
+    files = {
+        "calc/calc.go": "package calc\n\nfunc Add(a, b int) int { return a + b }\n",
+        "calc/calc_test.go": "package calc\n\nimport \"testing\"\n\nfunc TestAdd(t *testing.T) {}\n",
+        "tools/slug.py": "def slugify(text):\n    return text.lower()\n",
+        "tools/test_slug.py": "def test_slugify():\n    pass\n",
+        "web/format.ts": "export function formatPrice(cents: number): string { return `${cents}`; }\n",
+        "web/format.test.ts": "test(\"formats\", () => {});\n",
+        "web/__tests__/render.ts": "export function renderPrice(): string { return \"\"; }\n",
+    }
+    for rel, content in files.items():
+        (tmp_path / rel).parent.mkdir(parents=True, exist_ok=True)
+        (tmp_path / rel).write_text(content)
+    root = str(tmp_path)
+    tools = AgentTools()
+
+    results = await tools.extract_dir(root, ExtractOptions(classify_tests=True))
+    assert {rel: outline.is_test for rel, outline in results.items()} == {
+        "calc/calc.go": False,
+        "calc/calc_test.go": True,
+        "tools/slug.py": False,
+        "tools/test_slug.py": True,
+        "web/__tests__/render.ts": True,
+        "web/format.test.ts": True,
+        "web/format.ts": False,
+    }
+    assert results["calc/calc_test.go"].to_dict()["is_test"] is True
+    assert (await tools.extract_dir(root))["calc/calc.go"].is_test is None
+
+    library = await tools.extract_dir(root, ExtractOptions(exclude_tests=True))
+    assert sorted(library) == ["calc/calc.go", "tools/slug.py", "web/format.ts"]
+
+    # Overrides replace a language's defaults and leave the others alone
+    overridden = await tools.extract_dir(root, ExtractOptions(
+        classify_tests=True,
+        test_patterns={"typescript": ["*.test.ts"]},
+    ))
+    assert overridden["web/__tests__/render.ts"].is_test is False
+    assert overridden["calc/calc_test.go"].is_test is True
@@ -0,0 +1,88 @@
+"""Tests for binary content detection."""
+
+import io
+
+import pytest
+
+from mcp_code_parser import AgentTools
+from mcp_code_parser.binary import BinaryDetector, DefaultBinaryDetector, wide_text_encoding
+from mcp_code_parser.errors import BinaryFileError
+from mcp_code_parser.utils import read_stream, safe_read_file
+
+PNG_HEADER = b"\x89PNG\r\n\x1a\n\x00\x00\x00\rIHDR\x00\x00\x00\x10\x00\x00\x00\x10\x08\x06\x00\x00\x00"
+
+
+class _RejectGenerated(BinaryDetector):
+    """Treats minified bundles as binary on top of the default checks."""
+
+    def is_binary(self, sample: bytes) -> bool:
+        return sample.startswith(b"/*! generated */") or DefaultBinaryDetector().is_binary(sample)
+
+
+def test_default_detector():
+    """Test signatures, wide text and control characters."""
+    detector = DefaultBinaryDetector()
+
+    assert detector.is_binary(PNG_HEADER)
+    # Signatures decide even without NUL bytes
+    assert detector.is_binary(b"GIF89a\x01\x01")
+    assert detector.is_binary(b"\x01\x02\x03\x04\x05\x06 ok")
+    assert not detector.is_binary(b"package main\n\tfunc main() {}\n")
+    assert not detector.is_binary("héllo wörld".encode("utf8"))
+    assert not detector.is_binary(b"")
+
+    text = "def main():\n    print('hi')\n"
+    for encoding in ("utf-16", "utf-16-le", "utf-16-be", "utf-32"):
+        assert not detector.is_binary(text.encode(encoding)), encoding
+
+
+def test_wide_text_encoding():
+    """Test BOM and BOM-less UTF-16 detection."""
+    assert wide_text_encoding("x = 1".encode("utf-16")) == "utf-16"
+    assert wide_text_encoding("x = 1".encode("utf-32")) == "utf-32"
+    assert wide_text_encoding("x = 1".encode("utf-16-le")) == "utf-16-le"
+    assert wide_text_encoding("x = 1".encode("utf-16-be")) == "utf-16-be"
+    assert wide_text_encoding(b"x = 1") is None
+    assert wide_text_encoding(PNG_HEADER) is None
+
+
+def test_read_utf16_and_png_files(tmp_path):
+    """Test a UTF-16 source file is decoded as text and a PNG is rejected."""
+    source = tmp_path / "script.py"
+    source.write_bytes("x = 'ü'\nprint(x)\n".encode("utf-16"))
+    assert safe_read_file(str(source)) == "x = 'ü'\nprint(x)\n"
+
+    bom_less = tmp_path / "bom_less.py"
+    bom_less.write_bytes("y = 2\n".encode("utf-16-le"))
+    assert safe_read_file(str(bom_less)) == "y = 2\n"
+
+    image = tmp_path / "logo.png"
+    image.write_bytes(PNG_HEADER)
+    with pytest.raises(BinaryFileError):
+        safe_read_file(str(image))
+
+
+@pytest.mark.asyncio
+async def test_stream_utf16():
+    """Test streams get the same detection and decoding."""
+    assert await read_stream(io.BytesIO("a = 1\n".encode("utf-16"))) == "a = 1\n"
+    with pytest.raises(BinaryFileError):
+        await read_stream(io.BytesIO(PNG_HEADER))
+
+
+@pytest.mark.asyncio
+async def test_custom_detector(tmp_path):
+    """Test a caller-supplied detector is used by file tools and extract_dir."""
+    (tmp_path / "bundle.js").write_text("/*! generated */\nfunction a() {}\n")
+    (tmp_path / "app.js").write_text("function b() {}\n")
+
+    tools = AgentTools(binary_detector=_RejectGenerated())
+    outlines = await tools.extract_dir(str(tmp_path))
+    assert outlines["bundle.js"].error_code == BinaryFileError.code
+    assert outlines["app.js"].error is None
+
+    result = await tools.parse_file(str(tmp_path / "bundle.js"))
+    assert result.error_code == BinaryFileError.code
+
+    # The default detector reads it as text
+    assert (await AgentTools().extract_dir(str(tmp_path)))["bundle.js"].error is None
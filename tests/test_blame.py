@@ -0,0 +1,104 @@
+"""Tests for git blame enrichment."""
+
+import os
+import shutil
+import subprocess
+
+import pytest
+
+from mcp_code_parser import ExtractOptions, extract_file
+from mcp_code_parser.blame import annotate_blame, blame_file
+from mcp_code_parser.extractors.base import Symbol
+
+pytestmark = pytest.mark.skipif(shutil.which("git") is None, reason="git not installed")
+
+FIRST = """package shop
+
+func Total(prices []int) int {
+	sum := 0
+	for _, p := range prices {
+		sum += p
+	}
+	return sum
+}
+
+func Name() string {
+	return "shop"
+}
+"""
+
+
+def _commit(repo, author, date, message):
+    env = dict(
+        os.environ,
+        GIT_AUTHOR_NAME=author,
+        GIT_AUTHOR_EMAIL=f"{author.lower()}@example.com",
+        GIT_COMMITTER_NAME=author,
+        GIT_COMMITTER_EMAIL=f"{author.lower()}@example.com",
+        GIT_AUTHOR_DATE=date,
+        GIT_COMMITTER_DATE=date,
+    )
+    subprocess.run(["git", "add", "-A"], cwd=repo, check=True, env=env)
+    subprocess.run(["git", "commit", "-qm", message], cwd=repo, check=True, env=env)
+    return subprocess.run(
+        ["git", "rev-parse", "HEAD"], cwd=repo, check=True, capture_output=True, text=True, env=env
+    ).stdout.strip()
+
+
+@pytest.fixture
+def repo(tmp_path):
+    """A git repo whose shop.go was written by Ann, then had Name changed by Bob."""
+    subprocess.run(["git", "init", "-q"], cwd=tmp_path, check=True)
+    (tmp_path / "shop.go").write_text(FIRST)
+    first = _commit(tmp_path, "Ann", "2024-01-02T10:00:00+0100", "Add shop")
+    (tmp_path / "shop.go").write_text(FIRST.replace('"shop"', '"store"'))
+    second = _commit(tmp_path, "Bob", "2024-03-04T12:30:00+0000", "Rename shop")
+    return tmp_path, first, second
+
+
+@pytest.mark.asyncio
+async def test_blame_symbols(repo):
+    """Test that extracted symbols carry the last commit touching their lines."""
+    root, first, second = repo
+    outline = await extract_file(str(root / "shop.go"), options=ExtractOptions(blame=True))
+
+    symbols = {s.name: s for s in outline.symbols}
+    assert (symbols["Total"].last_commit, symbols["Total"].last_author) == (first, "Ann")
+    assert symbols["Total"].last_modified == "2024-01-02T10:00:00+01:00"
+    assert (symbols["Name"].last_commit, symbols["Name"].last_author) == (second, "Bob")
+    assert symbols["Name"].last_modified == "2024-03-04T12:30:00+00:00"
+    assert "blame_error" not in outline.metadata
+
+    plain = await extract_file(str(root / "shop.go"))
+    assert plain.symbols[0].last_commit is None
+
+
+@pytest.mark.asyncio
+async def test_blame_file_lines(repo):
+    """Test blaming a file once and annotating hand-built symbols."""
+    root, first, second = repo
+    (root / "shop.go").write_text(FIRST.replace('"shop"', '"store"') + "\nvar uncommitted = 1\n")
+
+    lines = await blame_file(str(root / "shop.go"))
+    assert lines[3].commit == first
+    assert lines[12].author == "Bob"
+    # Working tree changes aren't attributed to any commit
+    assert 15 not in lines
+
+    name = Symbol(name="Name", kind="function", start_line=11, end_line=13, start_byte=0, end_byte=0)
+    pending = Symbol(name="uncommitted", kind="variable", start_line=15, end_line=15, start_byte=0, end_byte=0)
+    annotate_blame([name, pending], lines)
+    assert name.last_author == "Bob"
+    assert pending.last_commit is None
+
+
+@pytest.mark.asyncio
+async def test_blame_outside_git(tmp_path):
+    """Test that files outside a git repo are extracted without blame."""
+    (tmp_path / "shop.go").write_text(FIRST)
+    with pytest.raises(ValueError):
+        await blame_file(str(tmp_path / "shop.go"))
+
+    outline = await extract_file(str(tmp_path / "shop.go"), options=ExtractOptions(blame=True))
+    assert outline.symbols[0].last_commit is None
+    assert "git" in outline.metadata["blame_error"]
@@ -0,0 +1,141 @@
+"""Tests for the content-addressed result store."""
+
+import threading
+from concurrent.futures import ThreadPoolExecutor
+
+import pytest
+
+from mcp_code_parser import FileBlobStore, MemoryBlobStore
+from mcp_code_parser.api import AgentTools
+from mcp_code_parser.blobs import blob_digest, result_key
+from mcp_code_parser.errors import AlreadyExistsError
+from mcp_code_parser.extractors.base import BaseExtractor, ExtractOptions, Outline, make_symbol
+
+HELLO_SHA256 = "2cf24dba5fb0a30e26e83b2ac5b9e29e1b161e5c1fa7425e73043362938b9824"
+
+
+class CountingExtractor(BaseExtractor):
+    """Outlines `def <name>` lines without a grammar, counting extractions."""
+
+    def __init__(self):
+        self.calls = 0
+
+    def extract(self, tree, source, options, path=None):
+        return []
+
+    async def extract_content(self, content, language, options, path, parse):
+        self.calls += 1
+        symbols = []
+        offset = 0
+        for number, line in enumerate(content.splitlines(keepends=True), start=1):
+            if line.startswith("def "):
+                symbols.append(make_symbol(_Line(number, offset, len(line.rstrip("\n"))), line.split()[1], "function"))
+            offset += len(line.encode())
+        return Outline(language=language, symbols=symbols, metadata={})
+
+
+class _Line:
+    """Stand-in for a tree-sitter node spanning one line."""
+
+    def __init__(self, number, offset, length):
+        self.start_point = (number - 1, 0)
+        self.end_point = (number - 1, length)
+        self.start_byte = offset
+        self.end_byte = offset + length
+
+
+@pytest.mark.parametrize("make_store", [lambda tmp: MemoryBlobStore(), lambda tmp: FileBlobStore(str(tmp))])
+def test_blob_round_trip(make_store, tmp_path):
+    """Test put/get and tags on both stores."""
+    store = make_store(tmp_path)
+
+    digest = store.put(b"hello")
+    assert digest == HELLO_SHA256
+    assert store.get(digest) == b"hello"
+    assert digest in store
+    assert store.put(b"hello") == digest
+    assert store.get(blob_digest(b"missing")) is None
+    assert store.get("../not-a-digest") is None
+
+    store.tag("greeting", digest)
+    assert store.lookup("greeting") == digest
+    assert store.get_tagged("greeting") == b"hello"
+    assert store.put_tagged("greeting", b"bye") == blob_digest(b"bye")
+    assert store.get_tagged("greeting") == b"bye"
+    assert store.lookup("unknown") is None
+    assert store.get_tagged("unknown") is None
+
+
+@pytest.mark.parametrize("make_store", [lambda tmp: MemoryBlobStore(), lambda tmp: FileBlobStore(str(tmp))])
+def test_put_if_absent(make_store, tmp_path):
+    """Test that of concurrent writers creating one name exactly one wins."""
+    store = make_store(tmp_path)
+    writers = 16
+    barrier = threading.Barrier(writers)
+
+    def create(i):
+        barrier.wait()
+        try:
+            return store.put_if_absent("user:42", f"user {i}".encode())
+        except AlreadyExistsError:
+            return None
+
+    with ThreadPoolExecutor(max_workers=writers) as pool:
+        results = list(pool.map(create, range(writers)))
+    winners = [digest for digest in results if digest is not None]
+    assert len(winners) == 1
+    assert store.lookup("user:42") == winners[0]
+    assert not list(tmp_path.rglob(".tmp-*"))
+
+    with pytest.raises(AlreadyExistsError):
+        store.put_if_absent("user:42", b"late")
+    assert store.get(blob_digest(b"late")) == b"late"
+    assert store.lookup("user:42") == winners[0]
+    store.tag("user:42", blob_digest(b"late"))
+    assert store.get_tagged("user:42") == b"late"
+
+
+def test_file_store_survives_restart(tmp_path):
+    """Test that blobs and tags are found by a new store on the same directory."""
+    key = result_key("extract_symbols", {"language": "go", "content": blob_digest(b"package a")})
+    digest = FileBlobStore(str(tmp_path)).put_tagged(key, b"{}")
+
+    reopened = FileBlobStore(str(tmp_path))
+    assert reopened.get(digest) == b"{}"
+    assert reopened.lookup(key) == digest
+    assert (tmp_path / "objects" / digest[:2] / digest[2:]).read_bytes() == b"{}"
+    assert not [p for p in tmp_path.rglob(".tmp-*")]
+
+
+def test_result_key_stable():
+    """Test that result keys depend on the request, not dict order."""
+    a = result_key("extract_symbols", {"language": "go", "path": "a.go"})
+    assert a == result_key("extract_symbols", {"path": "a.go", "language": "go"})
+    assert a != result_key("extract_symbols", {"language": "go", "path": "b.go"})
+    assert a != result_key("extract_file", {"language": "go", "path": "a.go"})
+
+
+@pytest.mark.asyncio
+async def test_extract_symbols_uses_result_store(tmp_path):
+    """Test that cached outlines are reused across tools sharing a store."""
+    extractor = CountingExtractor()
+    tools = AgentTools(result_store=FileBlobStore(str(tmp_path)))
+    tools.register_extractor("toy", extractor)
+
+    first = await tools.extract_symbols("def a\ndef b\n", "toy")
+    again = await tools.extract_symbols("def a\ndef b\n", "toy")
+    assert extractor.calls == 1
+    assert again.to_dict() == first.to_dict()
+    assert [s.name for s in again.symbols] == ["a", "b"]
+
+    # Different content, options or path is a different result
+    await tools.extract_symbols("def c\n", "toy")
+    await tools.extract_symbols("def a\ndef b\n", "toy", ExtractOptions(include_subtests=True))
+    await tools.extract_symbols("def a\ndef b\n", "toy", path="x.toy")
+    assert extractor.calls == 4
+
+    # A new process with the same directory still hits the cache
+    restarted = AgentTools(result_store=FileBlobStore(str(tmp_path)))
+    restarted.register_extractor("toy", extractor)
+    await restarted.extract_symbols("def a\ndef b\n", "toy")
+    assert extractor.calls == 4
@@ -0,0 +1,99 @@
+"""Tests for call site search."""
+
+from pathlib import Path
+
+import pytest
+
+from mcp_code_parser import ExtractOptions, extract_file
+from mcp_code_parser.analysis.calls import find_calls
+
+SAMPLES_DIR = Path(__file__).parent / "samples"
+
+
+@pytest.mark.asyncio
+async def test_error_constructors_in_sample():
+    """Test errors.New and fmt.Errorf calls return their argument text."""
+    source = (SAMPLES_DIR / "go_complex.go").read_text()
+    calls = await find_calls(source, "go", ["fmt.Errorf", "errors.New"])
+
+    assert [(c.callee, c.line, c.arguments) for c in calls] == [
+        ("errors.New", 58, ['"key not found"']),
+        ("errors.New", 214, ['"invalid user data"']),
+        ("fmt.Errorf", 255, ['"panic recovered: %v"', "r"]),
+    ]
+    assert calls[2].literal_arguments == [True, False]
+    assert calls[1].text == 'errors.New("invalid user data")'
+    assert source.encode("utf8")[calls[1].start_byte:calls[1].end_byte] == calls[1].text.encode("utf8")
+
+
+@pytest.mark.asyncio
+async def test_non_constant_command_arguments():
+    """Test spotting exec.Command calls whose arguments aren't all literals."""
+    source = """package main
+
+import "os/exec"
+
+func run(name string) {
+	exec.Command("ls", "-l").Run()
+	exec.Command("sh", "-c", name).Run()
+	exec.Command(
+		"git", // the binary
+		"status",
+	).Run()
+}
+"""
+    calls = await find_calls(source, "go", ["exec.Command"])
+
+    assert [c.line for c in calls] == [6, 7, 8]
+    assert [c.arguments for c in calls if not all(c.literal_arguments)] == [['"sh"', '"-c"', "name"]]
+    # Comments between arguments aren't arguments
+    assert calls[2].arguments == ['"git"', '"status"']
+
+
+@pytest.mark.asyncio
+async def test_python_and_typescript_calls():
+    """Test other grammars' call nodes and interpolated strings."""
+    python = 'import subprocess\nsubprocess.run(f"ls {path}", shell=True)\nsubprocess.run(["ls"])\n'
+    calls = await find_calls(python, "python", ["subprocess.run"])
+    assert [(c.arguments, c.literal_arguments) for c in calls] == [
+        (['f"ls {path}"', "shell=True"], [False, False]),
+        (['["ls"]'], [False]),
+    ]
+
+    typescript = "eval(`1 + ${x}`);\neval(`2`);\nwindow.eval('3');\n"
+    calls = await find_calls(typescript, "typescript", ["eval"])
+    assert [(c.line, c.literal_arguments) for c in calls] == [(1, [False]), (2, [True])]
+
+
+@pytest.mark.asyncio
+async def test_recursion_detection():
+    """Test that direct and mutual recursion are flagged with their cycles."""
+    outline = await extract_file(
+        str(SAMPLES_DIR / "go_recursion.go"),
+        options=ExtractOptions(analyze_recursion=True),
+    )
+    assert outline.success
+    recursive = {f.symbol.stable_id: f.symbol.recursion_cycle for f in outline.flatten() if f.symbol.recursive}
+
+    assert recursive == {
+        "method:Tree.Sum": ["method:Tree.Sum"],
+        "function:factorial": ["function:factorial"],
+        "function:isEven": ["function:isEven", "function:isOdd"],
+        "function:isOdd": ["function:isEven", "function:isOdd"],
+    }
+    # Calling into a cycle, or a closure calling itself, isn't recursion of the function
+    by_name = {f.symbol.name: f.symbol for f in outline.flatten()}
+    assert not by_name["describe"].recursive
+    assert not by_name["countdown"].recursive
+
+
+@pytest.mark.asyncio
+async def test_pipeline_closure_not_recursive():
+    """Test that functions whose closures don't call back aren't flagged."""
+    outline = await extract_file(
+        str(SAMPLES_DIR / "go_complex.go"),
+        options=ExtractOptions(analyze_recursion=True),
+    )
+    pipeline = next(s for s in outline.symbols if s.name == "pipeline")
+    assert not pipeline.recursive
+    assert pipeline.recursion_cycle == []
@@ -0,0 +1,78 @@
+"""Tests for symbol-aligned chunking."""
+
+from pathlib import Path
+
+import pytest
+
+from mcp_code_parser.chunking import chunk_file, chunk_symbols
+from mcp_code_parser.extractors.base import Outline, Symbol
+
+SAMPLES_DIR = Path(__file__).parent / "samples"
+
+SOURCE = """// A does a.
+func A() {}
+
+func B() {
+	// a rather long body
+}
+
+type T struct{}
+"""
+
+
+def _symbol(name: str, kind: str, text: str) -> Symbol:
+    start = SOURCE.index(text)
+    return Symbol(
+        name=name,
+        kind=kind,
+        start_line=SOURCE.count("\n", 0, start) + 1,
+        end_line=SOURCE.count("\n", 0, start + len(text)) + 1,
+        start_byte=start,
+        end_byte=start + len(text),
+    )
+
+
+def test_chunk_symbols_budget():
+    """Test that declarations are grouped whole, with their doc comments."""
+    t = _symbol("T", "struct", "type T struct{}")
+    t.children = [_symbol("x", "field", "struct{}")]
+    outline = Outline(
+        language="go",
+        symbols=[_symbol("A", "function", "func A() {}"), _symbol("B", "function", "func B() {\n\t// a rather long body\n}"), t],
+        metadata={},
+    )
+
+    chunks = chunk_symbols(outline.flatten(), SOURCE, max_tokens=8)
+    assert [c.symbols for c in chunks] == [["A"], ["B"], ["T", "T.x"]]
+    assert chunks[0].text == "// A does a.\nfunc A() {}"
+    assert (chunks[0].start_line, chunks[0].end_line) == (1, 2)
+    assert chunks[1].oversized and not chunks[2].oversized
+
+    whole = chunk_symbols(outline.flatten(), SOURCE, max_tokens=1000, path="a.go")
+    assert len(whole) == 1
+    assert whole[0].text == SOURCE.rstrip("\n")
+    assert whole[0].path == "a.go"
+
+    with pytest.raises(ValueError):
+        chunk_symbols(outline.flatten(), SOURCE, max_tokens=0)
+
+
+@pytest.mark.asyncio
+async def test_chunk_file_small_budget():
+    """Test chunking the Go sample with a budget smaller than some functions."""
+    chunks = await chunk_file(str(SAMPLES_DIR / "go_complex.go"), max_tokens=100)
+
+    assert len(chunks) > 1
+    for chunk in chunks:
+        assert chunk.tokens <= 100 or (chunk.oversized and len(chunk.symbols) >= 1)
+    for previous, chunk in zip(chunks, chunks[1:]):
+        assert previous.end_byte <= chunk.start_byte
+
+    names = [name for chunk in chunks for name in chunk.symbols]
+    assert len(names) == len(set(names))
+    assert "UserService.GetUser" in names
+    # main is far over budget, so it's emitted alone
+    main = next(c for c in chunks if "main" in c.symbols)
+    assert main.oversized
+    assert main.symbols == ["main"]
+    assert main.text.startswith("func main() {")
@@ -0,0 +1,109 @@
+"""Tests for config schema inference and validation."""
+
+from dataclasses import asdict
+from pathlib import Path
+
+import pytest
+
+from mcp_code_parser.analysis.config_schema import (
+    RULE_ENUM,
+    RULE_MISSING_KEY,
+    RULE_TYPE,
+    RULE_UNKNOWN_KEY,
+    ConfigSchema,
+    infer_config_schema,
+    validate_config,
+)
+from mcp_code_parser.extractors.base import SourceFile
+
+CONFIG_DIR = Path(__file__).parent / "samples" / "config"
+
+
+async def _samples_schema():
+    return await infer_config_schema([
+        SourceFile.read(str(CONFIG_DIR / "staging.yaml")),
+        SourceFile.read(str(CONFIG_DIR / "prod.yaml")),
+    ])
+
+
+@pytest.mark.asyncio
+async def test_infer_config_schema():
+    """Test types, required and observed keys, enums and aliases inferred from two YAML files."""
+    schema = await _samples_schema()
+
+    assert schema.types == ["object"]
+    assert list(schema.properties) == ["service", "database", "replicas", "features"]
+    # replicas is only in staging
+    assert schema.required == ["service", "database", "features"]
+    assert schema.properties["replicas"].observed == 1
+
+    service = schema.properties["service"]
+    assert service.properties["port"].types == ["integer"]
+    # 30 and 2.5 merge into number
+    assert service.properties["timeout"].types == ["number"]
+    assert service.properties["name"].enum == ["billing"]
+    # Two different levels, neither recurring, are free text
+    assert service.properties["log_level"].enum is None
+
+    database = schema.properties["database"]
+    assert database.required == ["host", "port", "pool"]
+    assert set(database.properties) - set(database.required) == {"cache_pool", "replica_host"}
+    # The alias resolves to the anchored mapping
+    assert list(database.properties["cache_pool"].properties) == ["min", "max"]
+
+    features = schema.properties["features"]
+    assert features.types == ["array"]
+    assert features.items.observed == 3
+    assert features.items.required == ["name", "tier", "enabled"]
+    assert features.items.properties["tier"].enum == ["standard", "premium"]
+    assert features.items.properties["enabled"].types == ["boolean"]
+
+    assert service.to_json_schema() == {
+        "type": "object",
+        "properties": {
+            "name": {"type": "string", "enum": ["billing"]},
+            "port": {"type": "integer"},
+            "log_level": {"type": "string"},
+            "timeout": {"type": "number"},
+        },
+        "required": ["name", "port", "log_level", "timeout"],
+    }
+    assert ConfigSchema.from_dict(asdict(schema)) == schema
+
+
+@pytest.mark.asyncio
+async def test_validate_config():
+    """Test validating a third YAML file against the schema of two others."""
+    schema = await _samples_schema()
+    diagnostics = await validate_config((CONFIG_DIR / "dev.yaml").read_text(), schema)
+
+    assert [(d.line, d.column, d.rule) for d in diagnostics] == [
+        (4, 3, RULE_TYPE),
+        (7, 1, RULE_MISSING_KEY),
+        (8, 3, RULE_UNKNOWN_KEY),
+        (15, 11, RULE_ENUM),
+    ]
+    assert diagnostics[0].message == "`service.port` is a string; the samples have integer"
+    assert diagnostics[1].message == "Missing key `database.host`, present in every sample"
+    assert diagnostics[2].message == "Unknown key `database.hots`, in none of the samples; did you mean `host`?"
+    assert diagnostics[3].message == "`features[0].tier` is `gold`; the samples have `standard`, `premium`"
+
+    # A config like the samples is clean, and a broken one only gets syntax diagnostics
+    assert await validate_config((CONFIG_DIR / "prod.yaml").read_text(), schema) == []
+    broken = await validate_config("service: {name: billing\n", schema)
+    assert broken and all(d.rule is None for d in broken)
+
+
+@pytest.mark.asyncio
+async def test_json_config_schema():
+    """Test inferring from and validating JSON files."""
+    schema = await infer_config_schema([
+        SourceFile("a.json", '{"mode": "fast", "retries": 3, "tags": ["x"]}'),
+        SourceFile("b.json", '{"mode": "fast", "retries": null}'),
+    ])
+    assert schema.required == ["mode", "retries"]
+    assert schema.properties["retries"].types == ["integer", "null"]
+    assert schema.properties["mode"].enum == ["fast"]
+
+    diagnostics = await validate_config('{"mode": "slow", "retries": "3"}', schema, language="json")
+    assert [d.rule for d in diagnostics] == [RULE_ENUM, RULE_TYPE]
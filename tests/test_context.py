@@ -0,0 +1,109 @@
+"""Tests for the context window packer."""
+
+import pytest
+
+from mcp_code_parser.context import (
+    EXPORTED_FIRST,
+    RECENTLY_CHANGED_FIRST,
+    SIGNATURES_ONLY,
+    Packable,
+    estimate_tokens,
+    pack_context,
+)
+from mcp_code_parser.extractors.base import Symbol
+
+# Budget used by every strategy test
+BUDGET = 30
+
+
+@pytest.fixture
+def items():
+    """Items of 20, 12 and 8 estimated tokens."""
+    return [
+        Packable(name="helper", text="h" * 80, signature="func helper()", modified=3.0),
+        Packable(name="Serve", text="s" * 48, signature="func Serve(addr string) error", exported=True, modified=1.0),
+        Packable(name="Config", text="c" * 32, signature="type Config struct", exported=True, modified=2.0),
+    ]
+
+
+def _names(packed):
+    return [item.name for item in packed]
+
+
+def test_estimate_tokens():
+    """Test the character-based estimate rounds up."""
+    assert estimate_tokens("") == 0
+    assert estimate_tokens("abcd") == 1
+    assert estimate_tokens("abcde") == 2
+
+
+def test_exported_first(items):
+    """Test exported items win over an earlier unexported one."""
+    packed, used = pack_context(items, BUDGET, EXPORTED_FIRST)
+
+    assert _names(packed) == ["Serve", "Config"]
+    assert used == 20
+
+
+def test_recently_changed_first(items):
+    """Test recency order, skipping an item that no longer fits."""
+    packed, used = pack_context(items, BUDGET, RECENTLY_CHANGED_FIRST)
+
+    # helper (20) then Config (8); Serve (12) would exceed the budget
+    assert _names(packed) == ["helper", "Config"]
+    assert used == 28
+
+
+def test_signatures_only(items):
+    """Test every item fits once reduced to its signature."""
+    packed, used = pack_context(items, BUDGET, SIGNATURES_ONLY)
+
+    assert _names(packed) == ["helper", "Serve", "Config"]
+    assert [item.text for item in packed] == [item.signature for item in items]
+    assert used == sum(estimate_tokens(item.signature) for item in items)
+    assert used <= BUDGET
+    # The caller's items are left untouched
+    assert items[0].text == "h" * 80
+
+
+def test_signatures_only_respects_budget(items):
+    """Test signatures are still subject to the budget."""
+    packed, used = pack_context(items, 10, SIGNATURES_ONLY)
+
+    assert _names(packed) == ["helper", "Config"]
+    assert used == 9
+
+
+def test_nothing_fits(items):
+    """Test a budget smaller than every item."""
+    assert pack_context(items, 5, EXPORTED_FIRST) == ([], 0)
+
+
+def test_unknown_strategy(items):
+    """Test unknown strategies are rejected."""
+    with pytest.raises(ValueError, match="Unknown packing strategy"):
+        pack_context(items, BUDGET, "largest-first")
+
+
+def test_from_symbol():
+    """Test building an item from an extracted symbol."""
+    source = "// héader\nfunc Serve() {}\n"
+    start = len("// héader\n".encode("utf8"))
+    sym = Symbol(
+        name="Serve",
+        kind="function",
+        start_line=2,
+        end_line=2,
+        start_byte=start,
+        end_byte=start + len("func Serve() {}"),
+        signature="func Serve()",
+        exported=True,
+    )
+
+    item = Packable.from_symbol(sym, source, path="main.go", modified=5.0)
+
+    assert item.text == "func Serve() {}"
+    assert item.signature == "func Serve()"
+    assert item.exported
+    assert item.path == "main.go"
+    assert item.modified == 5.0
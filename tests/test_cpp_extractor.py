@@ -0,0 +1,102 @@
+"""Tests for C++ symbol extraction."""
+
+from pathlib import Path
+
+import pytest
+
+from mcp_code_parser import extract_file
+
+
+@pytest.fixture
+def samples_dir():
+    """Get samples directory."""
+    return Path(__file__).parent / "samples"
+
+
+@pytest.fixture
+async def vector(samples_dir):
+    """The Vector class from the overloads sample."""
+    outline = await extract_file(str(samples_dir / "cpp_overloads.cpp"))
+    assert outline.success
+    namespace = next(s for s in outline.symbols if s.kind == "namespace")
+    return namespace, next(s for s in namespace.children if s.name == "Vector")
+
+
+def _of_kind(symbols, kind):
+    return [s for s in symbols if s.kind == kind]
+
+
+@pytest.mark.asyncio
+async def test_includes_and_namespace(samples_dir):
+    """Test includes and namespaces at the top level."""
+    outline = await extract_file(str(samples_dir / "cpp_overloads.cpp"))
+
+    assert [s.name for s in outline.symbols] == ["cmath", "vector.h", "geo"]
+    assert [s.kind for s in outline.symbols] == ["import", "import", "namespace"]
+
+
+@pytest.mark.asyncio
+async def test_constructor_destructor_operator_kinds(vector):
+    """Test that special members get distinct kinds."""
+    _, cls = vector
+
+    assert cls.kind == "class"
+    assert len(_of_kind(cls.children, "constructor")) == 3
+    assert [s.name for s in _of_kind(cls.children, "destructor")] == ["~Vector"]
+    assert [s.name for s in _of_kind(cls.children, "operator")] == [
+        "operator+", "operator*", "operator=", "operator==", "operator bool",
+    ]
+    assert [s.name for s in _of_kind(cls.children, "field")] == ["x_", "y_"]
+    assert not any(f.exported for f in _of_kind(cls.children, "field"))
+
+
+@pytest.mark.asyncio
+async def test_overloads_are_separate_symbols(vector):
+    """Test that overloads don't collapse and keep their signatures."""
+    namespace, cls = vector
+
+    scales = [s for s in cls.children if s.name == "scale"]
+    assert [[p.type for p in s.params] for s in scales] == [["double"], ["double", "double"]]
+    assert scales[1].signature == "void scale(double fx, double fy)"
+
+    distances = [s for s in namespace.children if s.name == "distance"]
+    assert len(distances) == 2
+    assert [r.type for r in distances[0].results] == ["double"]
+
+    assign = next(s for s in cls.children if s.name == "operator=")
+    assert [(p.name, p.type) for p in assign.params] == [("other", "const Vector&")]
+    assert [r.type for r in assign.results] == ["Vector&"]
+
+
+@pytest.mark.asyncio
+async def test_out_of_class_definitions_replace_prototypes(vector):
+    """Test that `Vector::member` definitions are nested under their class."""
+    namespace, cls = vector
+
+    # Only the free functions and the class remain directly in the namespace
+    assert [s.name for s in namespace.children] == ["Vector", "distance", "distance"]
+
+    length = next(s for s in cls.children if s.name == "length")
+    assert length.signature == "double Vector::length() const"
+    assert length.receiver is None
+    assert len([s for s in cls.children if s.name == "operator+"]) == 1
+
+
+@pytest.mark.asyncio
+async def test_stable_ids_disambiguate_overloads(vector):
+    """Test that every overload gets a distinct stable ID."""
+    namespace, cls = vector
+
+    ids = [s.stable_id for s in cls.children]
+    assert len(ids) == len(set(ids))
+    assert "constructor:geo.Vector.Vector(double,double)" in ids
+    assert "method:geo.Vector.scale(double)" in ids
+    assert "method:geo.Vector.scale(double,double)" in ids
+    assert "operator:geo.Vector.operator+(const Vector&)" in ids
+    assert cls.stable_id == "class:geo.Vector"
+
+    distance_ids = {s.stable_id for s in namespace.children if s.name == "distance"}
+    assert distance_ids == {
+        "function:geo.distance(const Vector&,const Vector&)",
+        "function:geo.distance(const Vector&)",
+    }
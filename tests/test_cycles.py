@@ -0,0 +1,55 @@
+"""Tests for import graphs and cycles."""
+
+from pathlib import Path
+
+import pytest
+
+from mcp_code_parser.analysis.cycles import find_import_cycles, import_cycles, import_graph
+from mcp_code_parser.errors import NotFoundError, UnsupportedLanguageError
+
+CYCLES_DIR = Path(__file__).parent / "samples" / "cycles"
+
+
+@pytest.mark.asyncio
+async def test_go_package_cycle():
+    """Test that two Go packages importing each other are reported once."""
+    graph = await import_graph(str(CYCLES_DIR), "go")
+    assert graph == {
+        "billing": {"orders"},
+        "cmd/app": {"orders"},
+        # Both files of orders import billing: one edge
+        "orders": {"billing"},
+    }
+    assert await find_import_cycles(str(CYCLES_DIR), "go") == [["billing", "orders"]]
+
+
+@pytest.mark.asyncio
+async def test_typescript_module_cycle():
+    """Test a module cycle, ignoring a module's import of itself."""
+    cycles = await find_import_cycles(str(CYCLES_DIR), "typescript")
+    assert cycles == [["web/cart.ts", "web/format.ts"]]
+
+
+def test_import_cycles():
+    """Test cycle reporting on hand-built graphs."""
+    graph = {
+        "a": ["b", "b", "a"],
+        "b": ["a"],
+        "c": ["d"],
+        "d": ["e"],
+        "e": ["c", "f", "d"],
+        "f": ["g"],
+    }
+    # e-d is shorter than the c-d-e loop, but cycles go through the first node
+    assert import_cycles(graph) == [["a", "b"], ["c", "d", "e"]]
+    assert import_cycles({"x": ["x"]}) == []
+    assert import_cycles({}) == []
+
+
+@pytest.mark.asyncio
+async def test_import_graph_errors(tmp_path):
+    """Test unsupported languages and missing roots are refused."""
+    with pytest.raises(UnsupportedLanguageError):
+        await import_graph(str(CYCLES_DIR), "cobol")
+    with pytest.raises(NotFoundError):
+        await import_graph(str(tmp_path / "missing"), "go")
@@ -0,0 +1,120 @@
+"""Tests for Dart symbol extraction."""
+
+from pathlib import Path
+
+import pytest
+
+from mcp_code_parser import ExtractOptions, extract_file
+
+
+@pytest.fixture
+async def outline():
+    """Outline of the shapes sample."""
+    outline = await extract_file(str(Path(__file__).parent / "samples" / "dart_shapes.dart"))
+    assert outline.success
+    return outline
+
+
+def _named(symbols, name):
+    return next(s for s in symbols if s.name == name)
+
+
+@pytest.mark.asyncio
+async def test_top_level_symbols(outline):
+    """Test the kinds of top-level declarations."""
+    assert [(s.name, s.kind) for s in outline.symbols] == [
+        ("tau", "variable"),
+        ("Shape", "class"),
+        ("Scalable", "mixin"),
+        ("Circle", "class"),
+        ("Color", "enum"),
+        ("CircleList", "extension"),
+        ("perimeter", "function"),
+        ("_log", "function"),
+    ]
+    assert not _named(outline.symbols, "_log").exported
+
+    perimeter = _named(outline.symbols, "perimeter")
+    assert [(p.name, p.type, p.default, p.optional) for p in perimeter.params] == [
+        ("circle", "Circle", None, False),
+        ("scale", "double", "1", True),
+    ]
+    assert [r.type for r in perimeter.results] == ["double"]
+
+
+@pytest.mark.asyncio
+async def test_members_nested_under_types(outline):
+    """Test fields, accessors and methods nested under classes and mixins."""
+    shape = _named(outline.symbols, "Shape")
+    assert shape.abstract
+    assert [(s.name, s.kind) for s in shape.children] == [
+        ("label", "field"),
+        ("Shape", "constructor"),
+        ("area", "getter"),
+        ("describe", "method"),
+    ]
+    assert _named(shape.children, "area").abstract
+    assert not _named(shape.children, "describe").abstract
+
+    scalable = _named(outline.symbols, "Scalable")
+    assert [(s.name, s.kind) for s in scalable.children] == [("factor", "field"), ("scale", "setter")]
+
+    extension = _named(outline.symbols, "CircleList")
+    assert [(s.name, s.kind) for s in extension.children] == [("totalArea", "method")]
+
+
+@pytest.mark.asyncio
+async def test_named_constructors_and_modifiers(outline):
+    """Test that each constructor is distinct and static members are flagged."""
+    circle = _named(outline.symbols, "Circle")
+    constructors = [s for s in circle.children if s.kind == "constructor"]
+    assert [s.name for s in constructors] == ["Circle", "Circle._unit", "Circle.fromDiameter"]
+    assert len({s.stable_id for s in constructors}) == 3
+    assert not _named(circle.children, "Circle._unit").exported
+
+    assert _named(circle.children, "unit").static
+    assert _named(circle.children, "largest").static
+    assert not _named(circle.children, "radius").static
+    assert not _named(circle.children, "_hits").exported
+
+
+@pytest.mark.asyncio
+async def test_annotations(outline):
+    """Test that annotations become attributes and @Deprecated marks the member."""
+    circle = _named(outline.symbols, "Circle")
+    area = _named(circle.children, "area")
+    assert [a.name for a in area.attributes] == ["override"]
+    assert area.start_line == 34
+
+    size = _named(circle.children, "size")
+    assert size.deprecated
+    assert size.deprecation_note == "Use area instead"
+
+
+@pytest.mark.asyncio
+async def test_enum_values_and_members(outline):
+    """Test enum values alongside an enhanced enum's members."""
+    color = _named(outline.symbols, "Color")
+    assert [(s.name, s.kind) for s in color.children] == [
+        ("red", "enum_member"),
+        ("green", "enum_member"),
+        ("blue", "enum_member"),
+        ("isWarm", "getter"),
+    ]
+
+
+@pytest.mark.asyncio
+async def test_attribute_filters(samples_dir):
+    """Test keeping only @Test methods (with their classes) and excluding deprecated ones."""
+    options = ExtractOptions(include_attributes=["@Test"], exclude_attributes=["Deprecated", "Skip"])
+    outline = await extract_file(str(samples_dir / "dart_annotated_test.dart"), options=options)
+    assert outline.success
+
+    assert [(s.name, [c.name for c in s.children]) for s in outline.symbols] == [
+        ("CalculatorTest", ["addsNumbers"]),
+        ("ParserTest", ["parsesEmptyInput"]),
+    ]
+    assert outline.symbols[0].children[0].stable_id == "method:CalculatorTest.addsNumbers"
+
+    tests = await extract_file(str(samples_dir / "dart_annotated_test.dart"), options=ExtractOptions(include_attributes=["Test"]))
+    assert [c.name for c in tests.symbols[0].children] == ["addsNumbers", "dividesByZero", "oldAdd"]
@@ -0,0 +1,86 @@
+"""Tests for describing a single symbol."""
+
+from pathlib import Path
+
+import pytest
+
+from mcp_code_parser.describe import ElisionOptions, describe_symbol
+from mcp_code_parser.errors import NotFoundError
+
+SAMPLES_DIR = Path(__file__).parent / "samples"
+
+
+@pytest.mark.asyncio
+async def test_go_method_body():
+    """Test fetching GetUser's source, signature and line range."""
+    path = str(SAMPLES_DIR / "go_complex.go")
+    description = await describe_symbol(path, "method:UserService.GetUser")
+
+    assert description.name == "GetUser"
+    assert (description.start_line, description.end_line) == (206, 218)
+    assert description.source.startswith("func (s *UserService) GetUser(ctx context.Context, id string)")
+    assert description.source.endswith("\treturn user, nil\n}")
+    assert 'errors.New("invalid user data")' in description.source
+    assert description.signature.startswith("func (s *UserService) GetUser")
+    assert description.doc == ""
+    assert description.leading_context == description.trailing_context == ""
+
+
+@pytest.mark.asyncio
+async def test_context_lines_and_doc_comment():
+    """Test surrounding lines and a Go doc comment."""
+    path = str(SAMPLES_DIR / "go_complex.go")
+    description = await describe_symbol(path, "function:pipeline", context_lines=2)
+
+    assert description.doc == "Channel patterns"
+    # The doc comment is part of the leading context
+    assert description.leading_context == "\n// Channel patterns"
+    assert description.trailing_context == "\n// Defer and panic/recover"
+
+
+@pytest.mark.asyncio
+async def test_python_docstring():
+    """Test Python symbols use their docstring as doc."""
+    path = str(SAMPLES_DIR / "python_complex.py")
+    description = await describe_symbol(path, "method:AsyncTaskManager.add_task")
+
+    assert description.doc == "Add a coroutine to be executed."
+    assert description.source.startswith("async def add_task(self, coro):")
+
+
+@pytest.mark.asyncio
+async def test_missing_symbol_and_file():
+    """Test unknown stable IDs and paths raise NotFoundError."""
+    with pytest.raises(NotFoundError, match="method:UserService.Missing"):
+        await describe_symbol(str(SAMPLES_DIR / "go_complex.go"), "method:UserService.Missing")
+    with pytest.raises(NotFoundError):
+        await describe_symbol(str(SAMPLES_DIR / "missing.go"), "function:main")
+
+
+@pytest.mark.asyncio
+async def test_oversized_symbol_elided():
+    """Test that a symbol over the limit keeps its opening and tail around an elision comment."""
+    path = str(SAMPLES_DIR / "go_oversized.go")
+    elision = ElisionOptions(max_tokens=200, head_lines=4, tail_lines=2)
+    description = await describe_symbol(path, "function:Text", elision=elision)
+
+    assert description.elided_lines == 126
+    assert description.source.split("\n") == [
+        "func Text(code int) string {",
+        "\tswitch code {",
+        "\tcase 100:",
+        '\t\treturn "Continue"',
+        "\t// ... 126 lines elided ...",
+        "\t}",
+        "}",
+    ]
+    assert description.signature == "func Text(code int) string"
+    assert (description.start_line, description.end_line) == (6, 137)
+
+    # Symbols under the limit are returned whole
+    small = await describe_symbol(path, "function:Known", elision=elision)
+    assert small.elided_lines == 0
+    assert small.source.endswith('return Text(code) != ""\n}')
+
+    by_bytes = await describe_symbol(path, "function:Text", elision=ElisionOptions(max_bytes=100_000))
+    assert by_bytes.elided_lines == 0 and "I'm a teapot" in by_bytes.source
@@ -0,0 +1,124 @@
+"""Tests for calling tools by name with validated arguments."""
+
+from dataclasses import dataclass
+from typing import Dict, List, Literal, Optional
+
+import pytest
+
+from mcp_code_parser.analysis.references import where_is_symbol
+from mcp_code_parser.dispatch import ToolRegistry, tool_schema, validate_arguments
+from mcp_code_parser.errors import ArgumentProblem, InvalidArgumentsError, NotFoundError
+from mcp_code_parser.middleware import chain, logging_middleware
+
+
+@dataclass
+class Window:
+    before: int = 0
+    after: int = 0
+
+
+async def grep(
+    pattern: str,
+    paths: Optional[List[str]] = None,
+    mode: Literal["word", "regex"] = "word",
+    window: Optional[Window] = None,
+    labels: Optional[Dict[str, str]] = None
+) -> dict:
+    """Search files for a pattern.
+
+    Args:
+        pattern: Text to look for
+        paths: Files to search, all if None
+        mode: How the pattern matches
+        window: Lines shown around
+            each match
+        labels: Extra labels
+    """
+    return {"pattern": pattern, "paths": paths, "mode": mode, "window": window, "labels": labels}
+
+
+@pytest.mark.asyncio
+async def test_search_missing_required_field():
+    """Test that a search without a name is rejected before it runs."""
+    registry = ToolRegistry()
+    registry.register(where_is_symbol)
+
+    with pytest.raises(InvalidArgumentsError) as exc:
+        await registry.call("where_is_symbol", '{"root": "repo"}')
+
+    assert exc.value.problems == [ArgumentProblem(field="name", reason="required")]
+    assert exc.value.code == "invalid_arguments"
+    assert exc.value.to_dict() == {
+        "error": "Invalid arguments for where_is_symbol: name: required",
+        "error_code": "invalid_arguments",
+        "problems": [{"field": "name", "reason": "required"}],
+    }
+
+
+def test_search_schema():
+    """Test the schema generated for where_is_symbol."""
+    schema, _ = tool_schema(where_is_symbol)
+    assert schema["required"] == ["root", "name"]
+    # Objects such as the index and tools have no JSON form
+    assert list(schema["properties"]) == [
+        "root", "name", "include_comments_and_strings", "include", "exclude", "context_before", "context_after",
+    ]
+    assert schema["properties"]["include"]["type"] == ["array", "null"]
+    assert schema["properties"]["include"]["items"] == {"type": "string"}
+    assert schema["properties"]["name"]["description"] == 'Symbol name, e.g. "GetUser"'
+    assert schema["description"] == "Find the declarations of a name under root and the places it is used."
+
+
+@pytest.mark.asyncio
+async def test_call_converts_arguments():
+    """Test that valid arguments reach the tool, with dataclasses built."""
+    registry = ToolRegistry()
+    registry.register(chain(grep, logging_middleware()))
+    result = await registry.call("grep", {"pattern": "TODO", "window": {"after": 2}, "paths": ["a.go"]})
+    assert result == {"pattern": "TODO", "paths": ["a.go"], "mode": "word", "window": Window(after=2), "labels": None}
+    assert registry.schema("grep")["properties"]["window"]["description"] == "Lines shown around each match"
+
+
+@pytest.mark.asyncio
+async def test_problems():
+    """Test the field and reason of each kind of problem."""
+    registry = ToolRegistry()
+    registry.register(grep)
+
+    with pytest.raises(InvalidArgumentsError) as exc:
+        await registry.call("grep", {
+            "pattern": 3,
+            "paths": ["a.go", None],
+            "mode": "glob",
+            "window": {"before": "2", "lines": 1},
+            "labels": {"team": 1},
+            "patern": "x",
+        })
+    assert [(p.field, p.reason) for p in exc.value.problems] == [
+        ("pattern", "expected string, got integer"),
+        ("paths[1]", "expected string, got null"),
+        ("mode", 'expected one of "word", "regex", got "glob"'),
+        ("window.before", "expected integer, got string"),
+        ("window.lines", "unknown field; expected one of before, after"),
+        ("labels.team", "expected string, got integer"),
+        ("patern", "unknown field; did you mean pattern?"),
+    ]
+
+    with pytest.raises(InvalidArgumentsError) as exc:
+        await registry.call("grep", "[1]")
+    assert exc.value.problems == [ArgumentProblem("", "expected object, got array")]
+    with pytest.raises(InvalidArgumentsError) as exc:
+        await registry.call("grep", "{oops")
+    assert exc.value.problems[0].reason.startswith("invalid JSON")
+    with pytest.raises(NotFoundError):
+        await registry.call("search", {})
+
+    with pytest.raises(ValueError):
+        registry.register(grep)
+
+
+def test_validate_arguments():
+    """Test validation against a schema directly."""
+    schema = {"type": "object", "properties": {"n": {"type": "number"}}, "additionalProperties": False}
+    assert validate_arguments(schema, {"n": 1}) == []
+    assert validate_arguments(schema, {"n": True}) == [ArgumentProblem("n", "expected number, got boolean")]
@@ -0,0 +1,155 @@
+"""Tests for edit helpers."""
+
+import json
+from pathlib import Path
+
+import pytest
+
+from mcp_code_parser import extract_file, extract_symbols
+from mcp_code_parser.edit import (
+    EditOperation,
+    apply_operations,
+    edit_operations,
+    method_insertion_point,
+    replace_symbol,
+)
+from mcp_code_parser.errors import ConflictError, GeneratedFileError, NotFoundError, UnsupportedLanguageError
+from mcp_code_parser.incremental import TextEdit
+
+
+@pytest.fixture
+def go_source():
+    """Content of the complex Go sample."""
+    return (Path(__file__).parent / "samples" / "go_complex.go").read_text()
+
+
+@pytest.mark.asyncio
+async def test_go_after_last_method(go_source):
+    """Test the offset is on the line after UserService.GetUser."""
+    offset, indent = await method_insertion_point(go_source, "go", "UserService")
+
+    source = go_source.encode("utf8")
+    get_user = source.index(b"func (s *UserService) GetUser")
+    assert offset > get_user
+    assert source[:offset].endswith(b"\treturn user, nil\n}\n")
+    assert source[offset:].startswith(b"\n// Channel patterns")
+    assert indent == ""
+
+
+@pytest.mark.asyncio
+async def test_go_type_without_methods(go_source):
+    """Test a type with no methods gets the point after its declaration."""
+    offset, indent = await method_insertion_point(go_source, "go", "User")
+
+    source = go_source.encode("utf8")
+    assert source[:offset].endswith(b'`json:"updated_at"`\n}\n')
+    assert indent == ""
+
+
+@pytest.mark.asyncio
+async def test_python_class_indentation():
+    """Test class-body languages report the method indentation."""
+    source = """class Greeter:
+    greeting = "hi"
+
+    def greet(self, name):
+        return f"{self.greeting} {name}"
+
+    @property
+    def loud(self):
+        return self.greeting.upper()
+
+
+class Empty:
+    count = 0
+"""
+    offset, indent = await method_insertion_point(source, "python", "Greeter")
+    assert source[:offset].endswith("return self.greeting.upper()\n")
+    assert indent == "    "
+
+    offset, indent = await method_insertion_point(source, "python", "Empty")
+    assert source[:offset].endswith("count = 0\n")
+    assert indent == "    "
+
+
+@pytest.mark.asyncio
+async def test_errors(go_source):
+    """Test unknown types and languages raise categorized errors."""
+    with pytest.raises(NotFoundError, match="Missing"):
+        await method_insertion_point(go_source, "go", "Missing")
+    # Functions aren't types
+    with pytest.raises(NotFoundError):
+        await method_insertion_point(go_source, "go", "pipeline")
+    with pytest.raises(UnsupportedLanguageError):
+        await method_insertion_point("x", "cobol", "X")
+
+
+@pytest.mark.asyncio
+async def test_generated_file_refused():
+    """Test that generated files are flagged and edits refused unless forced."""
+    path = Path(__file__).parent / "samples" / "go_generated.go"
+    outline = await extract_file(str(path))
+    assert outline.generated
+    assert outline.to_dict()["generated"] is True
+
+    # Positions below //line directives point into the template
+    user = outline.symbols[0]
+    display_name = next(c for c in user.children if c.name == "DisplayName")
+    new_user = next(s for s in outline.symbols if s.name == "NewUser")
+    assert (display_name.original_file, display_name.original_line) == ("user.go.tmpl", 12)
+    assert (new_user.original_file, new_user.original_line) == ("user.go.tmpl", 30)
+    assert user.original_file is None
+
+    source = path.read_text()
+    with pytest.raises(GeneratedFileError):
+        await method_insertion_point(source, "go", "User")
+    offset, _ = await method_insertion_point(source, "go", "User", force=True)
+    assert source.encode("utf8")[:offset].endswith(b"\treturn u.Name\n}\n")
+
+
+@pytest.mark.asyncio
+async def test_replace_symbol_operations(go_source):
+    """Test that a symbol replacement is described as operations that reproduce the edit."""
+    new_method = 'func (s *UserService) GetUser(ctx context.Context, id string) (*User, error) {\n\treturn nil, errors.New("disabled")\n}'
+    operations = await replace_symbol(go_source, "go", "method:UserService.GetUser", new_method)
+
+    assert [o.op for o in operations] == ["replace"]
+    operation = operations[0]
+    assert operation.before.startswith("func (s *UserService) GetUser")
+    assert operation.before.endswith("return user, nil\n}")
+    assert go_source[operation.start:operation.end] == operation.before
+
+    # Serialized, then applied by an executor elsewhere
+    decoded = [EditOperation.from_dict(d) for d in json.loads(json.dumps([o.to_dict() for o in operations]))]
+    applied = apply_operations(go_source, decoded)
+    assert applied == go_source.replace(operation.before, new_method)
+    outline = await extract_symbols(applied, "go")
+    assert not outline.diagnostics
+
+    deleted = await replace_symbol(go_source, "go", "method:UserService.GetUser", "")
+    assert deleted[0].op == "delete"
+    assert await replace_symbol(go_source, "go", "method:UserService.GetUser", operation.before) == []
+    with pytest.raises(NotFoundError):
+        await replace_symbol(go_source, "go", "method:UserService.Missing", new_method)
+
+
+def test_apply_operations_checks_content():
+    """Test that operations are applied all together or not at all."""
+    content = "alpha beta gamma"
+    operations = edit_operations(content, [
+        TextEdit(start=11, end=16, text="delta"),
+        TextEdit(start=0, end=0, text="> "),
+        TextEdit(start=5, end=10, text=""),
+    ], file="words.txt")
+    assert [(o.op, o.before, o.file) for o in operations] == [
+        ("insert", "", "words.txt"),
+        ("delete", " beta", "words.txt"),
+        ("replace", "gamma", "words.txt"),
+    ]
+    assert apply_operations(content, operations) == "> alpha delta"
+
+    with pytest.raises(ConflictError, match="changed"):
+        apply_operations("alpha BETA gamma", operations)
+    overlapping = operations + [EditOperation("replace", 8, 12, "ta g", "x")]
+    with pytest.raises(ConflictError, match="overlaps"):
+        apply_operations(content, overlapping)
@@ -0,0 +1,98 @@
+"""Tests for Elixir symbol extraction."""
+
+from pathlib import Path
+
+import pytest
+
+from mcp_code_parser import extract_file
+from mcp_code_parser.utils import detect_language_from_file
+
+
+@pytest.fixture
+async def outline():
+    """Outline of the accounts sample."""
+    outline = await extract_file(str(Path(__file__).parent / "samples" / "elixir_accounts.ex"))
+    assert outline.success
+    return outline
+
+
+def _named(symbols, name):
+    return next(s for s in symbols if s.name == name)
+
+
+def test_dispatch_by_extension():
+    """Test that .ex and .exs files are Elixir."""
+    assert detect_language_from_file("lib/shop/accounts.ex") == "elixir"
+    assert detect_language_from_file("test/accounts_test.exs") == "elixir"
+
+
+@pytest.mark.asyncio
+async def test_modules(outline):
+    """Test modules, protocols and implementations with their nested definitions."""
+    assert [(s.name, s.kind) for s in outline.symbols] == [
+        ("Shop.Accounts", "module"),
+        ("Shop.Priced", "protocol"),
+        ("Shop.Priced.Shop.Accounts.User", "impl"),
+    ]
+    accounts = outline.symbols[0]
+    assert [(s.name, s.kind) for s in accounts.children] == [
+        ("timeout", "attribute"),
+        ("behaviour", "attribute"),
+        ("User", "module"),
+        ("get_user/2", "function"),
+        ("fetch/1", "function"),
+        ("find/1", "function"),
+        ("count/0", "function"),
+        ("lookup/2", "function"),
+        ("admin?/1", "macro"),
+        ("log/1", "macro"),
+    ]
+    assert [a.name for a in accounts.attributes] == ["moduledoc"]
+    assert _named(accounts.children, "timeout").signature == "@timeout 5_000"
+    assert not _named(accounts.children, "lookup/2").exported
+    assert not _named(accounts.children, "log/1").exported
+    assert _named(accounts.children, "admin?/1").exported
+
+    user = _named(accounts.children, "User")
+    struct = user.children[0]
+    assert (struct.name, struct.kind) == ("__struct__", "struct")
+    assert [f.name for f in struct.children] == ["id", "email", "name"]
+
+    protocol_price = outline.symbols[1].children[0]
+    impl_price = outline.symbols[2].children[0]
+    assert protocol_price.name == impl_price.name == "price/1"
+    assert protocol_price.abstract and not impl_price.abstract
+    assert impl_price.stable_id == "function:Shop.Priced.Shop.Accounts.User.price/1"
+
+
+@pytest.mark.asyncio
+async def test_function_clauses(outline):
+    """Test that a function's clauses are grouped, each indexed by its head."""
+    accounts = outline.symbols[0]
+    get_user = _named(accounts.children, "get_user/2")
+    assert get_user.stable_id == "function:Shop.Accounts.get_user/2"
+    assert get_user.signature == "def get_user(id, opts \\\\ [])"
+    assert [(p.name, p.default, p.optional) for p in get_user.params] == [("id", None, False), ("opts", "[]", True)]
+    assert [c.name for c in get_user.children] == [
+        "get_user(id, opts \\\\ [])",
+        'get_user("", _opts)',
+        "get_user(id, opts) when is_binary(id)",
+    ]
+    assert len({c.stable_id for c in get_user.children}) == 3
+    # The function spans its @doc and @spec and all of its clauses
+    assert (get_user.start_line, get_user.end_line) == (14, 22)
+    assert [a.name for a in get_user.attributes] == ["doc", "spec"]
+
+    assert _named(accounts.children, "count/0").children == []
+
+
+@pytest.mark.asyncio
+async def test_function_attributes(outline):
+    """Test @impl and @deprecated on the function after them."""
+    accounts = outline.symbols[0]
+    fetch = _named(accounts.children, "fetch/1")
+    assert [(a.name, a.args) for a in fetch.attributes] == [("impl", ["true"])]
+
+    find = _named(accounts.children, "find/1")
+    assert find.deprecated
+    assert find.deprecation_note == "Use get_user/2 instead"
@@ -0,0 +1,64 @@
+"""Tests for finding environment variable reads."""
+
+from pathlib import Path
+
+import pytest
+
+from mcp_code_parser.analysis.env import extract_env_usage
+
+SAMPLES = Path(__file__).parent / "samples" / "env"
+
+
+def _rows(usages):
+    return [(u.name, u.accessor, u.default, u.literal, u.line) for u in usages]
+
+
+@pytest.mark.asyncio
+async def test_go():
+    """Test Getenv and LookupEnv reads, with a cmp.Or default."""
+    usages = await extract_env_usage(str(SAMPLES), include=["*.go"])
+    assert _rows(usages) == [
+        ("API_TOKEN", "os.LookupEnv", None, True, 15),
+        ('prefix + "_REGION"', "os.Getenv", None, False, 19),
+        ("LISTEN_ADDR", "os.Getenv", '":8080"', True, 21),
+        ("DEBUG", "os.Getenv", None, True, 28),
+    ]
+    assert {u.file for u in usages} == {"service.go"}
+    assert usages[2].has_default and not usages[0].has_default
+    assert (usages[0].column, usages[2].column) == (15, 17)
+
+
+@pytest.mark.asyncio
+async def test_node():
+    """Test process.env members, subscripts and destructuring, with fallbacks."""
+    usages = await extract_env_usage(str(SAMPLES), include=["*.js"])
+    assert _rows(usages) == [
+        ("DATABASE_URL", "process.env", None, True, 3),
+        ("POOL_SIZE", "process.env", '"10"', True, 3),
+        ("AWS_REGION", "process.env", '"us-east-1"', True, 3),
+        ("PORT", "process.env", "3000", True, 5),
+        ("SESSION_SECRET", "process.env", None, True, 6),
+        ("LOG_LEVEL", "process.env", '"info"', True, 7),
+        # The assignment's target is a write
+        ("NODE_ENV", "process.env", '"development"', True, 9),
+        ("`FEATURE_${name}`", "process.env", None, False, 12),
+    ]
+
+
+@pytest.mark.asyncio
+async def test_python():
+    """Test os.environ and getenv reads, skipping writes."""
+    usages = await extract_env_usage(str(SAMPLES), include=["*.py"])
+    assert _rows(usages) == [
+        ("DATABASE_URL", "os.environ", None, True, 4),
+        ("DEBUG", "os.environ.get", '"false"', True, 5),
+        ("WORKERS", "os.getenv", "4", True, 6),
+        ("TIMEOUT", "os.getenv", '"30"', True, 7),
+    ]
+
+
+@pytest.mark.asyncio
+async def test_whole_tree():
+    """Test that files are searched in path order."""
+    usages = await extract_env_usage(str(SAMPLES))
+    assert [u.file for u in usages] == ["server.js"] * 8 + ["service.go"] * 4 + ["settings.py"] * 4
@@ -0,0 +1,214 @@
+"""Tests for the normalized error taxonomy across tools."""
+
+import io
+import json
+from unittest.mock import patch
+
+import pytest
+
+from mcp_code_parser import (
+    ExtractOptions,
+    extract_dir,
+    extract_dir_jsonl,
+    extract_file,
+    extract_symbols,
+    parse_code,
+    parse_file,
+)
+from mcp_code_parser.errors import (
+    BinaryFileError,
+    NotFoundError,
+    ParseFailedError,
+    PathTraversalError,
+    PermissionDeniedError,
+    TooLargeError,
+    ToolError,
+    UnsupportedLanguageError,
+    error_code,
+    error_for_code,
+)
+from mcp_code_parser.parsers.base import (
+    GrammarNotFoundError,
+    LanguageNotSupportedError,
+    ParseError,
+    ParseResult,
+)
+from mcp_code_parser.utils import FileTooLargeError, safe_read_file
+
+
+def test_existing_exceptions_join_taxonomy():
+    """Test that pre-existing exception types match their category."""
+    assert isinstance(LanguageNotSupportedError("x"), UnsupportedLanguageError)
+    assert isinstance(GrammarNotFoundError("x"), UnsupportedLanguageError)
+    assert isinstance(ParseError("x"), ParseFailedError)
+    assert isinstance(FileTooLargeError("a.go", 10, 5), TooLargeError)
+    for cls in (LanguageNotSupportedError, ParseError, FileTooLargeError):
+        assert issubclass(cls, ToolError)
+
+
+def test_error_code_maps_builtin_errors():
+    """Test that OS errors are categorized without wrapping."""
+    assert error_code(FileNotFoundError("gone")) == NotFoundError.code
+    assert error_code(PermissionError("no")) == PermissionDeniedError.code
+    assert error_code(BinaryFileError("bin")) == BinaryFileError.code
+    assert error_code(ValueError("other")) is None
+
+
+def test_error_for_code():
+    """Test building exceptions from result codes."""
+    assert type(error_for_code("traversal", "x")) is PathTraversalError
+    assert type(error_for_code(None, "x")) is ToolError
+    assert type(error_for_code("bogus", "x")) is ToolError
+
+
+def test_raise_for_error_success_is_noop():
+    """Test that successful results don't raise."""
+    ParseResult(language="python", ast_text="module", metadata={}).raise_for_error()
+
+
+@pytest.mark.asyncio
+async def test_parse_code_unsupported_language():
+    """Test parse_code failure path for an unknown language."""
+    result = await parse_code("x", "cobol")
+
+    assert result.error_code == UnsupportedLanguageError.code
+    with pytest.raises(UnsupportedLanguageError):
+        result.raise_for_error()
+
+
+@pytest.mark.asyncio
+async def test_parse_code_syntax_diagnostics():
+    """Test that syntax errors are reported as structured diagnostics."""
+    result = await parse_code("def broken(:\n    pass\n", "python")
+
+    assert result.success
+    assert result.diagnostics
+    assert result.diagnostics[0].line == 1
+    assert result.diagnostics[0].column >= 1
+
+
+@pytest.mark.asyncio
+async def test_parse_code_failure_carries_diagnostics():
+    """Test that unexpected parser failures are categorized as parse errors."""
+    with patch(
+        "mcp_code_parser.parsers.tree_sitter.TreeSitterParser._format_ast",
+        side_effect=RuntimeError("boom"),
+    ):
+        result = await parse_code("x = 1\n", "python")
+
+    assert result.error_code == ParseFailedError.code
+    with pytest.raises(ParseFailedError) as exc_info:
+        result.raise_for_error()
+    assert exc_info.value.diagnostics == []
+
+
+@pytest.mark.asyncio
+async def test_parse_file_failure_paths(tmp_path):
+    """Test parse_file failure paths for each category."""
+    result = await parse_file(str(tmp_path / "missing.py"))
+    assert result.error_code == NotFoundError.code
+    with pytest.raises(NotFoundError):
+        result.raise_for_error()
+
+    binary = tmp_path / "blob.py"
+    binary.write_bytes(b"\x7fELF\x00\x00\x01")
+    result = await parse_file(str(binary))
+    assert result.error_code == BinaryFileError.code
+
+    unknown = tmp_path / "notes.unknown"
+    unknown.write_text("text")
+    result = await parse_file(str(unknown))
+    assert result.error_code == UnsupportedLanguageError.code
+
+    denied = tmp_path / "denied.py"
+    denied.write_text("x = 1\n")
+    with patch(
+        "mcp_code_parser.parsers.tree_sitter.safe_read_file",
+        side_effect=PermissionError("Permission denied"),
+    ):
+        result = await parse_file(str(denied))
+    assert result.error_code == PermissionDeniedError.code
+    with pytest.raises(PermissionDeniedError):
+        result.raise_for_error()
+
+
+@pytest.mark.asyncio
+async def test_extract_symbols_unsupported_language():
+    """Test extract_symbols failure path for a language without an extractor."""
+    outline = await extract_symbols("x", "cobol")
+
+    assert outline.error_code == UnsupportedLanguageError.code
+    with pytest.raises(UnsupportedLanguageError):
+        outline.raise_for_error()
+
+
+@pytest.mark.asyncio
+async def test_extract_symbols_syntax_diagnostics():
+    """Test that extraction from broken source still returns diagnostics."""
+    outline = await extract_symbols("package main\n\nfunc Broken( {\n", "go")
+
+    assert outline.success
+    assert outline.diagnostics
+    assert outline.to_dict()["diagnostics"][0]["line"] >= 1
+
+
+@pytest.mark.asyncio
+async def test_extract_file_failure_paths(tmp_path):
+    """Test extract_file failure paths for each category."""
+    outline = await extract_file(str(tmp_path / "missing.go"))
+    assert outline.error_code == NotFoundError.code
+
+    binary = tmp_path / "blob.go"
+    binary.write_bytes(b"\x00\x01\x02")
+    outline = await extract_file(str(binary))
+    assert outline.error_code == BinaryFileError.code
+    with pytest.raises(BinaryFileError):
+        outline.raise_for_error()
+
+    big = tmp_path / "big.go"
+    big.write_text("package main\n" + "// pad\n" * 10)
+    outline = await extract_file(str(big), options=ExtractOptions(max_file_size=16))
+    # Oversized files are skipped rather than failed, but still categorized
+    assert outline.success
+    assert outline.skipped
+    assert outline.error_code == TooLargeError.code
+
+
+@pytest.mark.asyncio
+async def test_extract_dir_symlink_traversal(tmp_path):
+    """Test that symlinks escaping the root are reported, not read."""
+    outside = tmp_path / "outside"
+    outside.mkdir()
+    (outside / "secret.go").write_text("package secret\n\nfunc Leak() {}\n")
+
+    root = tmp_path / "root"
+    root.mkdir()
+    (root / "ok.go").write_text("package main\n")
+    (root / "link.go").symlink_to(outside / "secret.go")
+    (root / "linked_dir").symlink_to(outside, target_is_directory=True)
+
+    outlines = await extract_dir(str(root))
+
+    assert sorted(outlines) == ["link.go", "ok.go"]
+    assert outlines["ok.go"].success
+    assert outlines["link.go"].error_code == PathTraversalError.code
+    assert outlines["link.go"].symbols == []
+    with pytest.raises(PathTraversalError):
+        outlines["link.go"].raise_for_error()
+
+    output = io.StringIO()
+    await extract_dir_jsonl(str(root), output)
+    lines = {r["file"]: r for r in map(json.loads, output.getvalue().splitlines())}
+    assert lines["link.go"]["error_code"] == "traversal"
+
+
+def test_safe_read_file_binary(tmp_path):
+    """Test that NUL bytes mark a file as binary unless it has a UTF-16 BOM."""
+    binary = tmp_path / "data.bin"
+    binary.write_bytes(b"abc\x00def")
+    with pytest.raises(BinaryFileError):
+        safe_read_file(str(binary))
+
+    utf16 = tmp_path / "utf16.txt"
+    utf16.write_bytes("hello".encode("utf-16"))
+    assert isinstance(safe_read_file(str(utf16)), str)
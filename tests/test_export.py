@@ -0,0 +1,72 @@
+"""Tests for CSV and TSV export."""
+
+import csv
+import io
+
+from mcp_code_parser.export import format_csv, format_tsv
+from mcp_code_parser.extractors.base import Outline, OutputOptions, Param, Symbol, assign_stable_ids
+
+
+def _outline():
+    """An outline with a struct, a method and a function whose signature needs quoting."""
+    method = Symbol(
+        name="Get", kind="method", start_line=8, end_line=10, start_byte=90, end_byte=150,
+        signature='func (s *Store) Get(key string, def "none") (string, error)', exported=True,
+        receiver="Store", params=[Param(name="key", type="string")],
+    )
+    store = Symbol(name="Store", kind="struct", start_line=3, end_line=5, start_byte=20, end_byte=60,
+                   exported=True, children=[method])
+    helper = Symbol(name="helper", kind="function", start_line=12, end_line=12, start_byte=160, end_byte=180,
+                    signature="func helper(a, b int)")
+    symbols = [store, helper]
+    assign_stable_ids(symbols)
+    return Outline(language="go", symbols=symbols, metadata={})
+
+
+def test_format_csv_escaping():
+    """Test RFC 4180 quoting of commas and quotes, and the default columns."""
+    output = io.StringIO()
+    fields = OutputOptions(fields=["kind", "signature", "exported", "start_line"])
+    count = format_csv({"store.go": _outline()}, output, fields)
+
+    assert count == 3
+    lines = output.getvalue().split("\r\n")
+    assert lines[0] == "file,qualified_name,kind,signature,exported,start_line"
+    assert lines[1] == "store.go,Store,struct,,true,3"
+    assert lines[2] == 'store.go,Store.Get,method,"func (s *Store) Get(key string, def ""none"") (string, error)",true,8'
+    assert lines[3] == 'store.go,helper,function,"func helper(a, b int)",false,12'
+    assert lines[4] == ""
+
+    rows = list(csv.reader(io.StringIO(output.getvalue(), newline="")))
+    assert rows[2][3] == 'func (s *Store) Get(key string, def "none") (string, error)'
+
+    default = io.StringIO()
+    format_csv([("a.go", _outline()), ("b.go", Outline(language="go", symbols=[], metadata={}, error="boom"))], default)
+    assert default.getvalue().splitlines()[0] == "file,qualified_name,kind,exported,start_line,end_line"
+    assert len(default.getvalue().splitlines()) == 4
+
+
+def test_format_tsv_nested_values():
+    """Test tab separation and JSON-encoded list cells."""
+    output = io.StringIO()
+    format_tsv({"store.go": _outline()}, output, OutputOptions(fields=["name", "params", "children"]))
+
+    rows = list(csv.reader(io.StringIO(output.getvalue(), newline=""), delimiter="\t"))
+    assert rows[0] == ["file", "qualified_name", "name", "params"]
+    assert rows[2] == [
+        "store.go", "Store.Get", "Get",
+        '[{"name":"key","type":"string","resolved_type":null,"default":null,"optional":false,"type_ref":null}]',
+    ]
+
+
+def test_relative_lines():
+    """Test that members' rows give lines relative to their parent's start."""
+    output = io.StringIO()
+    fields = OutputOptions(fields=["start_line", "line_offset"], relative_lines=True)
+    format_csv({"store.go": _outline()}, output, fields)
+    assert output.getvalue().split("\r\n")[1:4] == [
+        "store.go,Store,3,0",
+        "store.go,Store.Get,6,2",
+        "store.go,helper,12,0",
+    ]
+
@@ -0,0 +1,50 @@
+"""Tests for generated-file markers and line directives."""
+
+import pytest
+
+from mcp_code_parser.generated import LineDirective, is_generated, line_directives
+
+
+@pytest.mark.parametrize("header", [
+    "// Code generated by protoc-gen-go. DO NOT EDIT.\n",
+    "# @generated by pants\n",
+    "// <auto-generated>\n//     This code was generated by a tool.\n// </auto-generated>\n",
+    "/*\n * This file was automatically generated.\n */\n",
+    "# Generated by Django 4.2 on 2024-01-01\n",
+    "-- This code was automatically generated; @generated\n",
+])
+def test_generated_markers(header):
+    """Test common generator notices in headers."""
+    assert is_generated(header + "\npackage x\n")
+
+
+def test_not_generated():
+    """Test ordinary comments aren't mistaken for markers."""
+    assert not is_generated("// IDs are generated by the server.\npackage x\n")
+    for comment in (
+        "# Generated by the team for reporting",
+        "/* generated from the schema below */",
+        "// autogenerated id, do not rely on it",
+        "# Please DO NOT EDIT this list by hand without review",
+        # Go's marker must end the line with "DO NOT EDIT."
+        "// Code generated by stringer; DO NOT EDIT the output by hand",
+    ):
+        assert not is_generated(comment + "\npackage x\n"), comment
+    assert not is_generated("package x\n\nvar s = \"DO NOT EDIT\"\n")
+    # Markers after the header are ignored
+    assert not is_generated("package x\n" + "\n" * 50 + "// Code generated by hand; DO NOT EDIT.\n")
+
+
+def test_line_directives():
+    """Test Go and C directive forms."""
+    go = "package x\n//line tmpl/a.go.tmpl:10\nfunc A() {}\n/*line C:\\b.tmpl:20:5*/func B() {}\n//line :40\n"
+    assert line_directives(go, "go") == [
+        LineDirective(line=3, file="tmpl/a.go.tmpl", original_line=10),
+        LineDirective(line=4, file="C:\\b.tmpl", original_line=20),
+        LineDirective(line=6, file="C:\\b.tmpl", original_line=40),
+    ]
+    assert line_directives("#line 7 \"parser.y\"\nint x;\n# 12\n", "cpp") == [
+        LineDirective(line=2, file="parser.y", original_line=7),
+        LineDirective(line=4, file="parser.y", original_line=12),
+    ]
+    assert line_directives("# line 5 \"x\"\n", "python") == []
@@ -0,0 +1,79 @@
+"""Tests for glob path matching."""
+
+import pytest
+
+from mcp_code_parser.globs import PathFilter, glob_match, translate
+
+
+@pytest.mark.parametrize("pattern, path, expected", [
+    # `*` and `?` stay within a segment
+    ("src/*.go", "src/main.go", True),
+    ("src/*.go", "src/pkg/main.go", False),
+    ("file?.txt", "file1.txt", True),
+    ("file?.txt", "file12.txt", False),
+    ("a?b", "a/b", False),
+    # Patterns without a slash match the file name anywhere
+    ("*_test.go", "pkg/store/cache_test.go", True),
+    ("*_test.go", "cache.go", False),
+    # Globstar
+    ("src/**/*.ts", "src/a.ts", True),
+    ("src/**/*.ts", "src/ui/forms/a.ts", True),
+    ("src/**/*.ts", "lib/a.ts", False),
+    ("**/__tests__/**", "__tests__/a.js", True),
+    ("**/__tests__/**", "web/ui/__tests__/deep/a.js", True),
+    ("**/__tests__/**", "web/tests/a.js", False),
+    ("docs/**", "docs/guide/intro.md", True),
+    ("docs/**", "docs", False),
+    ("a**b/*", "axxb/c", True),
+    ("a**b/*", "ax/xb/c", False),
+    # Braces
+    ("src/**/*.{ts,tsx}", "src/app/view.tsx", True),
+    ("src/**/*.{ts,tsx}", "src/app/view.ts", True),
+    ("src/**/*.{ts,tsx}", "src/app/view.js", False),
+    ("{cmd,internal}/**/*.go", "internal/db/db.go", True),
+    ("{cmd,internal}/**/*.go", "pkg/db/db.go", False),
+    ("*.{gen.go,pb.{go,gw.go}}", "api/service.pb.gw.go", True),
+    ("*.{gen.go,pb.{go,gw.go}}", "api/service.pb.json", False),
+    ("x{,.bak}", "x", True),
+    ("x{,.bak}", "x.bak", True),
+    ("{a,b", "{a,b", True),
+    # Character classes
+    ("v[0-9].go", "v2.go", True),
+    ("v[0-9].go", "vx.go", False),
+    ("[!._]*.go", "main.go", True),
+    ("[!._]*.go", "_tmp.go", False),
+    ("[^.]*", ".hidden", False),
+    ("a[/]b", "a/b", True),
+    ("[!a]", "/", False),
+    ("[]x].go", "].go", True),
+    ("[abc", "[abc", True),
+    # Escapes and regex metacharacters are literal
+    ("\\*.go", "*.go", True),
+    ("\\*.go", "main.go", False),
+    ("a+b(1).go", "a+b(1).go", True),
+    ("/main.go", "main.go", True),
+])
+def test_glob_match(pattern, path, expected):
+    """Test globstar, braces, character classes and name-only patterns."""
+    assert glob_match(path, pattern) is expected
+
+
+def test_braces_literal_when_disabled():
+    """Test that .gitignore-style translation leaves braces alone."""
+    assert translate("*.{ts,tsx}", braces=False) == r"[^/]*\.\{ts,tsx\}"
+    with pytest.raises(ValueError):
+        translate("")
+
+
+def test_path_filter():
+    """Test include and exclude together."""
+    paths = PathFilter(include=["src/**/*.{ts,tsx}"], exclude=["*.test.ts", "**/generated/**"])
+
+    assert paths.matches("src/app.ts")
+    assert paths.matches("src/ui/button.tsx")
+    assert not paths.matches("src/app.test.ts")
+    assert not paths.matches("src/generated/api.ts")
+    assert not paths.matches("scripts/build.ts")
+    assert PathFilter().matches("anything/at/all.go")
+    with pytest.raises(ValueError):
+        PathFilter(exclude=[""])
@@ -0,0 +1,1130 @@
+"""Tests for Go symbol extraction."""
+
+import time
+from pathlib import Path
+
+import pytest
+
+from mcp_code_parser import AgentTools, ExtractOptions, Outline, OutputOptions, extract_file, extract_symbols
+from mcp_code_parser.extractors.base import Symbol
+from mcp_code_parser.extractors.go_types import parse_type
+
+
+@pytest.fixture
+def samples_dir():
+    """Get samples directory."""
+    return Path(__file__).parent / "samples"
+
+
+def _by_name(symbols):
+    return {s.name: s for s in symbols}
+
+
+@pytest.mark.asyncio
+async def test_test_kinds(samples_dir):
+    """Test that go test functions are tagged with their TestKind."""
+    outline = await extract_file(str(samples_dir / "go_testing_test.go"))
+
+    assert outline.success
+    funcs = _by_name(s for s in outline.symbols if s.kind == "function")
+    assert funcs["TestAdd"].test_kind == "test"
+    assert funcs["TestTableDriven"].test_kind == "test"
+    assert funcs["BenchmarkAdd"].test_kind == "benchmark"
+    assert funcs["FuzzAdd"].test_kind == "fuzz"
+    assert funcs["ExampleAdd"].test_kind == "example"
+    assert funcs["TestMain"].test_kind is None
+    assert funcs["Testhelper"].test_kind is None
+    assert funcs["helper"].test_kind is None
+
+
+@pytest.mark.asyncio
+async def test_subtests_not_extracted_by_default(samples_dir):
+    """Test that subtests are only discovered when requested."""
+    outline = await extract_file(str(samples_dir / "go_testing_test.go"))
+
+    funcs = _by_name(outline.symbols)
+    assert funcs["TestAdd"].children == []
+
+
+@pytest.mark.asyncio
+async def test_subtests(samples_dir):
+    """Test that literal and nested t.Run subtests become children."""
+    outline = await extract_file(
+        str(samples_dir / "go_testing_test.go"),
+        options=ExtractOptions(include_subtests=True),
+    )
+
+    test_add = _by_name(outline.symbols)["TestAdd"]
+    assert [c.name for c in test_add.children] == ["positive", "negative"]
+    assert all(c.kind == "subtest" for c in test_add.children)
+    assert [c.name for c in test_add.children[0].children] == ["small"]
+
+
+@pytest.mark.asyncio
+async def test_table_driven_subtests(samples_dir):
+    """Test that subtests ranging over a struct slice are named from the table."""
+    outline = await extract_file(
+        str(samples_dir / "go_testing_test.go"),
+        options=ExtractOptions(include_subtests=True),
+    )
+
+    table = _by_name(outline.symbols)["TestTableDriven"]
+    assert [c.name for c in table.children] == ["zero", "one"]
+
+
+@pytest.mark.asyncio
+async def test_extract_sample_structure(samples_dir):
+    """Test basic structure extraction from the complex Go sample."""
+    outline = await extract_file(str(samples_dir / "go_complex.go"))
+
+    assert outline.success
+    symbols = _by_name(outline.symbols)
+    assert symbols["Storage"].kind == "interface"
+    assert symbols["User"].kind == "struct"
+    assert "ID" in [c.name for c in symbols["User"].children]
+    assert "GetUser" in [c.name for c in symbols["UserService"].children]
+    assert symbols["generateID"].exported is False
+    assert symbols["NewUserService"].exported is True
+
+
+SCOPE_SOURCE = """package cache
+
+type Store struct {
+	Size  int
+	items map[string]string
+}
+
+func (s *Store) Get(key string) string { return s.items[key] }
+
+func newStore() *Store { return &Store{} }
+"""
+
+
+@pytest.mark.asyncio
+async def test_import_scope_normal_package():
+    """Test import scopes for a package outside internal/."""
+    outline = await extract_symbols(SCOPE_SOURCE, "go", path="mod/pkg/cache/cache.go")
+
+    symbols = _by_name(outline.symbols)
+    store = symbols["Store"]
+    assert store.import_scope == "public"
+    assert symbols["newStore"].import_scope == "package_private"
+    members = _by_name(store.children)
+    assert members["Size"].import_scope == "public"
+    assert members["items"].import_scope == "package_private"
+    assert members["Get"].import_scope == "public"
+
+
+@pytest.mark.asyncio
+async def test_import_scope_internal_package():
+    """Test that exported symbols under internal/ are module-internal."""
+    outline = await extract_symbols(SCOPE_SOURCE, "go", path="mod/internal/cache/cache.go")
+
+    symbols = _by_name(outline.symbols)
+    assert symbols["Store"].import_scope == "module_internal"
+    assert _by_name(symbols["Store"].children)["Get"].import_scope == "module_internal"
+    assert symbols["newStore"].import_scope == "package_private"
+
+
+@pytest.mark.asyncio
+async def test_import_scope_without_path():
+    """Test that only identifier case is used when the path is unknown."""
+    outline = await extract_symbols(SCOPE_SOURCE, "go")
+
+    symbols = _by_name(outline.symbols)
+    assert symbols["Store"].import_scope == "public"
+    assert symbols["newStore"].import_scope == "package_private"
+
+
+@pytest.mark.asyncio
+async def test_params_and_results(samples_dir):
+    """Test that function params and results are captured structurally."""
+    outline = await extract_file(str(samples_dir / "go_aliases.go"))
+
+    lookup = _by_name(outline.symbols)["Lookup"]
+    assert [(p.name, p.type) for p in lookup.params] == [
+        ("id", "UserID"),
+        ("ids", "IDList"),
+        ("wait", "Timeout"),
+    ]
+    assert [(p.name, p.type) for p in lookup.results] == [(None, "*Account"), (None, "error")]
+    assert all(p.resolved_type is None for p in lookup.params)
+
+
+@pytest.mark.asyncio
+async def test_resolve_aliases(samples_dir):
+    """Test that local aliases and defined types resolve to underlying types."""
+    outline = await extract_file(
+        str(samples_dir / "go_aliases.go"),
+        options=ExtractOptions(resolve_aliases=True),
+    )
+
+    symbols = _by_name(outline.symbols)
+    lookup = symbols["Lookup"]
+    # Defined type
+    assert lookup.params[0].resolved_type == "string"
+    # Defined type whose underlying type uses another defined type
+    assert lookup.params[1].resolved_type == "[]string"
+    # Alias
+    assert lookup.params[2].resolved_type == "time.Duration"
+    # Struct types and builtins are left as declared
+    assert lookup.results[0].resolved_type is None
+    assert lookup.results[1].resolved_type is None
+
+    owner = _by_name(symbols["Account"].children)["Owner"]
+    assert owner.results[0].type == "UserID"
+    assert owner.results[0].resolved_type == "string"
+
+
+@pytest.mark.asyncio
+async def test_struct_tags_as_attributes():
+    """Test that struct tags map to one attribute per key."""
+    source = """package model
+
+type Account struct {
+	ID    string `json:"id,omitempty" db:"account_id"`
+	Email string "json:\\"email\\""
+	Note  string
+}
+"""
+    outline = await extract_symbols(source, "go")
+
+    fields = _by_name(outline.symbols[0].children)
+    id_attrs = fields["ID"].attributes
+    assert [(a.name, a.args) for a in id_attrs] == [
+        ("json", ["id", "omitempty"]),
+        ("db", ["account_id"]),
+    ]
+    assert id_attrs[0].raw == 'json:"id,omitempty"'
+    assert [(a.name, a.args) for a in fields["Email"].attributes] == [("json", ["email"])]
+    assert fields["Note"].attributes == []
+    assert fields["ID"].to_dict()["attributes"][1]["name"] == "db"
+
+
+@pytest.mark.asyncio
+async def test_external_interface_embeds(samples_dir):
+    """Test that embeds from other packages are recorded and flag the interface."""
+    outline = await extract_file(str(samples_dir / "go_embedding.go"))
+    symbols = _by_name(outline.symbols)
+
+    blob = symbols["Blob"]
+    assert blob.unresolved
+    assert [(e.package, e.name) for e in blob.embedded_external] == [
+        ("io", "Reader"),
+        ("io", "Closer"),
+        ("fmt", "Stringer"),
+    ]
+    # The embeds are still listed as children
+    assert [c.kind for c in blob.children] == ["embedded", "embedded", "embedded", "method"]
+
+    store = symbols["Store"]
+    assert not store.unresolved
+    assert store.embedded_external == []
+
+    codec = symbols["Codec"]
+    assert codec.unresolved
+    assert [(e.package, e.name) for e in codec.embedded_external] == [
+        ("example.com/app/internal/codec", "Encoder"),
+    ]
+
+
+@pytest.mark.asyncio
+async def test_interface_fields_and_embeds(samples_dir):
+    """Test that interface-typed fields stay fields while embeds promote methods."""
+    outline = await extract_file(str(samples_dir / "go_struct_embedding.go"))
+    symbols = _by_name(outline.symbols)
+
+    handler = _by_name(symbols["Handler"].children)
+    assert [(c.name, c.kind) for c in symbols["Handler"].children] == [
+        ("log", "field"),
+        ("LevelLogger", "embedded"),
+        ("Writer", "embedded"),
+        ("Base", "embedded"),
+        ("next", "field"),
+        ("hooks", "field"),
+        ("ServeHTTP", "method"),
+    ]
+    assert handler["log"].signature == "log Logger"
+    assert handler["next"].signature == "next http.Handler"
+    assert handler["log"].promoted == []
+    assert handler["hooks"].promoted == []
+
+    # Embedded interfaces bring their methods, including embedded ones
+    assert handler["LevelLogger"].promoted == ["Log(msg string)", "SetLevel(level int)"]
+    assert handler["Writer"].promoted == ["Write(p []byte) (n int, err error)"]
+    # Embedded structs bring the methods declared on them, without the receiver
+    assert handler["Base"].signature == "*Base"
+    assert handler["Base"].promoted == ["Close() error"]
+
+    level_logger = _by_name(symbols["LevelLogger"].children)
+    assert level_logger["Logger"].kind == "embedded"
+    assert level_logger["Logger"].promoted == ["Log(msg string)"]
+    assert level_logger["SetLevel"].kind == "method"
+    # Methods are nested under their receiver, not under embedding types
+    assert [c.name for c in symbols["Base"].children] == ["closed", "Close"]
+
+
+@pytest.mark.asyncio
+async def test_error_sites(samples_dir):
+    """Test that error returns and construction sites are detected."""
+    outline = await extract_file(
+        str(samples_dir / "go_complex.go"),
+        options=ExtractOptions(analyze_errors=True),
+    )
+    symbols = _by_name(outline.symbols)
+    service = _by_name(symbols["UserService"].children)
+
+    create = service["CreateUser"]
+    assert create.returns_error
+    assert [(e.kind, e.type) for e in create.error_sites] == [
+        ("custom", "ValidationError"),
+        ("custom", "ValidationError"),
+    ]
+
+    get_user = service["GetUser"]
+    assert get_user.returns_error
+    assert [(e.kind, e.expression, e.line) for e in get_user.error_sites] == [
+        ("errors.New", 'errors.New("invalid user data")', 214),
+    ]
+
+    # Found inside the deferred closure
+    safe = symbols["safeOperation"]
+    assert safe.returns_error
+    assert [(e.kind, e.wraps, e.line) for e in safe.error_sites] == [("fmt.Errorf", False, 255)]
+
+    assert not symbols["generateID"].returns_error
+    assert symbols["generateID"].error_sites == []
+
+
+@pytest.mark.asyncio
+async def test_type_assertions(samples_dir):
+    """Test that asserted and type-switched types are recorded per function."""
+    outline = await extract_file(
+        str(samples_dir / "go_complex.go"),
+        options=ExtractOptions(type_assertions=True),
+    )
+    service = _by_name(_by_name(outline.symbols)["UserService"].children)
+    get_user = service["GetUser"]
+    assert [(t.kind, t.text) for t in get_user.type_assertions] == [("pointer", "*User")]
+    assert get_user.type_assertions[0].elem.name == "User"
+    assert service["CreateUser"].type_assertions == []
+
+    source = """package shapes
+
+func Area(s interface{}) float64 {
+	switch v := s.(type) {
+	case nil:
+		return 0
+	case *Circle, Square:
+		return v.(interface{ Area() float64 }).Area()
+	case []Shape:
+		return 0
+	}
+	if c, ok := s.(*Circle); ok {
+		return c.r
+	}
+	return 0
+}
+"""
+    area = (await extract_symbols(source, "go", ExtractOptions(type_assertions=True))).symbols[0]
+    assert [t.text for t in area.type_assertions] == [
+        "*Circle", "Square", "interface{ Area() float64 }", "[]Shape",
+    ]
+    plain = (await extract_symbols(source, "go")).symbols[0]
+    assert plain.type_assertions == []
+
+
+@pytest.mark.asyncio
+async def test_panic_sites(samples_dir):
+    """Test that panic and recover calls are recorded per function."""
+    outline = await extract_file(
+        str(samples_dir / "go_complex.go"),
+        options=ExtractOptions(analyze_panics=True),
+    )
+    symbols = _by_name(outline.symbols)
+    safe = symbols["safeOperation"]
+    assert [(r.expression, r.line, r.deferred) for r in safe.recovers] == [("recover()", 254, True)]
+    assert safe.panics == []
+    assert symbols["generateID"].recovers == []
+
+    source = """package worker
+
+func Must(v int, err error) int {
+	if err != nil {
+		panic(err)
+	}
+	return v
+}
+
+func Run(jobs []func()) {
+	defer func() {
+		go func() { recover() }()
+	}()
+	defer recover()
+	panic("unreachable")
+}
+"""
+    must, run = (await extract_symbols(source, "go", ExtractOptions(analyze_panics=True))).symbols
+    assert [(p.expression, p.line, p.deferred) for p in must.panics] == [("panic(err)", 5, False)]
+    assert [(p.expression, p.deferred) for p in run.panics] == [('panic("unreachable")', False)]
+    # Neither stops a panic: one runs in a goroutine, the other isn't called by a deferred function
+    assert [(r.line, r.deferred) for r in run.recovers] == [(12, False), (14, False)]
+
+    plain = await extract_symbols(source, "go")
+    assert plain.symbols[0].panics == []
+
+
+@pytest.mark.asyncio
+async def test_goroutine_leaks(samples_dir):
+    """Test that goroutines with no way to stop are flagged and stoppable ones aren't."""
+    outline = await extract_file(
+        str(samples_dir / "go_goroutines.go"),
+        options=ExtractOptions(analyze_goroutines=True),
+    )
+    symbols = _by_name(outline.symbols)
+    monitor = _by_name(symbols["Monitor"].children)
+
+    leaky, = monitor["StartLeaky"].goroutine_leaks
+    assert (leaky.expression, leaky.line, leaky.confidence) == ("go func() {", 16, "high")
+    assert "ctx.Done()" in leaky.reason
+    # poll's loop only returns when a ping fails
+    polling, = symbols["StartPolling"].goroutine_leaks
+    assert (polling.expression, polling.line, polling.confidence) == ("go poll(url)", 25, "medium")
+
+    for name in ("Start", "Drain", "Watch"):
+        assert monitor[name].goroutine_leaks == []
+
+    # The sample's goroutines respect ctx, range over channels or are bounded
+    complex_outline = await extract_file(
+        str(samples_dir / "go_complex.go"),
+        options=ExtractOptions(analyze_goroutines=True),
+    )
+    for flat in complex_outline.flatten():
+        assert flat.symbol.goroutine_leaks == [], flat.qualified_name
+
+    plain = await extract_file(str(samples_dir / "go_goroutines.go"))
+    assert _by_name(_by_name(plain.symbols)["Monitor"].children)["StartLeaky"].goroutine_leaks == []
+
+
+@pytest.mark.asyncio
+async def test_include_raw_node():
+    """Test that symbols carry their defining node's S-expression only when asked."""
+    source = "package shop\n\ntype Cart struct {\n\tItems []string\n}\n\nfunc (c *Cart) Add(item string) {}\n"
+    outline = await extract_symbols(source, "go", ExtractOptions(include_raw_node=True))
+
+    cart = outline.symbols[0]
+    assert cart.raw_node.startswith("(type_spec")
+    assert "(struct_type" in cart.raw_node
+    items, add = cart.children
+    assert items.raw_node.startswith("(field_declaration")
+    assert add.raw_node.startswith("(method_declaration")
+    assert "(parameter_list" in add.raw_node
+
+    plain = await extract_symbols(source, "go")
+    assert plain.symbols[0].raw_node is None
+    assert plain.symbols[0].children[1].raw_node is None
+
+
+@pytest.mark.asyncio
+async def test_error_sites_wrapping_and_aliases():
+    """Test %w detection and import aliases; analysis is off by default."""
+    source = """package store
+
+import (
+	"errors"
+	xfmt "fmt"
+)
+
+type NotFound struct{ Key string }
+
+func (e *NotFound) Error() string { return e.Key }
+
+func Load(key string) (*Item, error) {
+	if key == "" {
+		return nil, errors.New("empty key")
+	}
+	if err := read(key); err != nil {
+		return nil, xfmt.Errorf("load %s: %w", key, err)
+	}
+	return nil, &NotFound{Key: key}
+}
+"""
+    outline = await extract_symbols(source, "go", ExtractOptions(analyze_errors=True))
+
+    load = _by_name(outline.symbols)["Load"]
+    assert [(e.kind, e.type, e.wraps) for e in load.error_sites] == [
+        ("errors.New", None, False),
+        ("fmt.Errorf", None, True),
+        ("custom", "NotFound", False),
+    ]
+
+    plain = await extract_symbols(source, "go")
+    assert _by_name(plain.symbols)["Load"].error_sites == []
+    assert not _by_name(plain.symbols)["Load"].returns_error
+
+
+@pytest.mark.asyncio
+async def test_constraint_type_sets(samples_dir):
+    """Test union elements of constraint interfaces become structured type sets."""
+    outline = await extract_file(str(samples_dir / "go_constraints.go"))
+    symbols = _by_name(outline.symbols)
+
+    number = symbols["Number"]
+    assert [(t.type, t.approximate) for t in number.type_set] == [
+        ("int", True),
+        ("int64", True),
+        ("float64", False),
+    ]
+    assert [(c.kind, c.name) for c in number.children] == [("type_set", "~int | ~int64 | float64")]
+
+    assert [(t.type, t.approximate) for t in symbols["Bytes"].type_set] == [
+        ("[]byte", False),
+        ("string", True),
+    ]
+
+    # A lone `~int` is a type set; an interface name is still an embed
+    stringable = symbols["StringableInt"]
+    assert [c.kind for c in stringable.children] == ["type_set", "embedded"]
+    assert [(t.type, t.approximate) for t in stringable.type_set] == [("int", True)]
+
+    # Intersections are only described per line
+    ordered = symbols["Ordered"]
+    assert [c.kind for c in ordered.children] == ["embedded", "type_set", "type_set"]
+    assert ordered.type_set == []
+    assert [t.type for t in ordered.children[2].type_set] == ["int64", "int"]
+
+
+@pytest.mark.asyncio
+async def test_flatten_outline(samples_dir):
+    """Test the flattened outline keeps every symbol with its parent link."""
+    outline = await extract_file(str(samples_dir / "go_complex.go"))
+
+    def count(symbols):
+        return sum(1 + count(s.children) for s in symbols)
+
+    flat = outline.flatten()
+    assert len(flat) == count(outline.symbols)
+    assert [f.symbol.start_byte for f in flat if f.depth == 0] == sorted(
+        s.start_byte for s in outline.symbols
+    )
+
+    entries = {f.qualified_name: f for f in flat}
+    get_user = entries["UserService.GetUser"]
+    assert get_user.depth == 1
+    assert get_user.parent_stable_id == "struct:UserService"
+    # Parents come before their children
+    assert flat.index(entries["UserService"]) < flat.index(get_user)
+
+    assert entries["Cache.Clear"].parent_stable_id == "interface:Cache"
+    assert entries["main"].depth == 0
+    assert entries["main"].parent_stable_id is None
+
+    row = get_user.to_dict()
+    assert "children" not in row
+    assert (row["name"], row["depth"], row["qualified_name"]) == ("GetUser", 1, "UserService.GetUser")
+
+
+@pytest.mark.asyncio
+async def test_breadcrumb(samples_dir):
+    """Test breadcrumbs from the package down, with synthesized names kept."""
+    outline = await extract_file(str(samples_dir / "go_complex.go"))
+    service = next(s for s in outline.symbols if s.name == "UserService")
+    get_user = next(c for c in service.children if c.name == "GetUser")
+    assert outline.breadcrumb(get_user) == "main > UserService > GetUser"
+    assert outline.breadcrumb(get_user, sep="/") == "main/UserService/GetUser"
+    assert get_user.breadcrumb(ancestors=[service]) == "UserService > GetUser"
+
+    # A method given on its own is placed under its receiver
+    method = Symbol(name="Get", kind="method", start_line=1, end_line=1, start_byte=0, end_byte=0, receiver="Store")
+    assert method.breadcrumb(package="store") == "store > Store > Get"
+    assert outline.breadcrumb(method) is None
+
+    outline = await extract_file(str(samples_dir / "go_anonymous.go"))
+    defaults = next(s for s in outline.symbols if s.name == "Defaults")
+    tls = next(c for c in defaults.children[0].children if c.name == "TLS")
+    assert outline.breadcrumb(tls.children[0].children[0], sep=".") == "config.Defaults.struct.TLS.struct.Cert"
+    closure = Symbol(name="", kind="function", start_line=1, end_line=1, start_byte=0, end_byte=0)
+    assert closure.breadcrumb(ancestors=[defaults]) == "Defaults > <anonymous function>"
+
+
+@pytest.mark.asyncio
+async def test_iota_enums(samples_dir):
+    """Test that iota const blocks become enums with computed member values."""
+    outline = await extract_file(str(samples_dir / "go_enums.go"))
+    enums = _by_name(s for s in outline.symbols if s.kind == "enum")
+    assert set(enums) == {"Color", "Weekday", "Low", "KB"}
+
+    def values(enum):
+        return [(m.kind, m.name, m.value) for m in enum.children]
+
+    color = enums["Color"]
+    assert (color.enum_type, color.underlying_type, color.exported) == ("Color", "int", True)
+    assert values(color) == [("enum_member", "Red", 0), ("enum_member", "Green", 1), ("enum_member", "Blue", 2)]
+    assert color.children[0].signature == "Red Color = iota"
+    # Blank entries still count
+    assert [(m.name, m.value) for m in enums["Weekday"].children] == [("Monday", 1), ("Tuesday", 2), ("Thursday", 4)]
+    assert (enums["Weekday"].enum_type, enums["Weekday"].underlying_type) == ("Weekday", "uint8")
+    # Untyped, with iota + 1 and a new expression partway through
+    assert (enums["Low"].enum_type, enums["Low"].underlying_type) == (None, None)
+    assert [(m.name, m.value) for m in enums["Low"].children] == [
+        ("Low", 1), ("Medium", 2), ("High", 20), ("Critical", 30)
+    ]
+    assert [m.value for m in enums["KB"].children] == [1 << 10, 1 << 20, 1 << 30]
+
+    # Other const blocks stay plain constants
+    assert [s.name for s in outline.symbols if s.kind == "constant"] == ["Timeout", "Retries"]
+
+    options = ExtractOptions(kind_filter=["constant"])
+    only_constants = await extract_file(str(samples_dir / "go_enums.go"), options=options)
+    assert [s.name for s in only_constants.symbols] == ["Timeout", "Retries"]
+
+
+@pytest.mark.asyncio
+async def test_constructors(samples_dir):
+    """Test that New<T> functions are linked to the types they return."""
+    outline = await extract_file(str(samples_dir / "go_complex.go"))
+    symbols = _by_name(outline.symbols)
+    for constructor, type_name in [
+        ("NewInMemoryCache", "InMemoryCache"),
+        ("NewWorkerPool", "WorkerPool"),
+        ("NewUserService", "UserService"),
+    ]:
+        assert symbols[constructor].constructs == type_name
+        assert symbols[type_name].constructors == [constructor]
+    assert symbols["User"].constructors == []
+    assert symbols["main"].constructs is None
+
+    source = """package client
+
+type Client struct{}
+
+type Pool[T any] struct{}
+
+func New() (*Client, error) { return &Client{}, nil }
+
+func MakePool[T any]() *Pool[T] { return nil }
+
+func NewServer() *Client { return nil }
+"""
+    symbols = _by_name((await extract_symbols(source, "go")).symbols)
+    assert symbols["Client"].constructors == ["New"]
+    assert symbols["Pool"].constructors == ["MakePool"]
+    # Named for another type
+    assert symbols["NewServer"].constructs is None
+
+
+@pytest.mark.asyncio
+async def test_interface_assertions(samples_dir):
+    """Test that `var _ I = (*T)(nil)` assertions record implements relations."""
+    outline = await extract_file(str(samples_dir / "go_assertions.go"))
+    symbols = _by_name(outline.symbols)
+    # The methods match too, but the assertion is recorded as declared
+    cache = symbols["InMemoryCache"]
+    assert [(i.interface, i.pointer, i.line, i.confidence) for i in cache.implements] == [
+        ("Cache", True, 37, "high"),
+        ("io.Closer", True, 47, "high"),
+    ]
+    assert [(i.interface, i.pointer) for i in symbols["Noop"].implements] == [("Cache", False)]
+    assert symbols["Cache"].implemented_by == ["*InMemoryCache", "Noop"]
+    # Assertions declare no variables
+    assert "_" not in symbols
+
+    round_trip = Outline.from_dict(outline.to_dict())
+    assert _by_name(round_trip.symbols)["InMemoryCache"].implements == cache.implements
+
+
+@pytest.mark.asyncio
+async def test_raw_strings(samples_dir):
+    """Test that code-like text in backtick strings yields no symbols."""
+    path = str(samples_dir / "go_strings.go")
+    outline = await extract_file(path)
+    assert [(s.kind, s.name) for s in outline.symbols] == [
+        ("constant", "Schema"),
+        ("variable", "handler"),
+        ("function", "Render"),
+        ("function", "Short"),
+    ]
+    assert all(not s.children for s in outline.symbols)
+
+    outline = await extract_file(path, options=ExtractOptions(string_literal_lines=3))
+    strings = [(f.qualified_name, f.symbol.start_line, f.symbol.end_line)
+               for f in outline.flatten() if f.symbol.kind == "string_literal"]
+    assert strings == [
+        ("Schema.CREATE TABLE users (id INT);", 4, 8),
+        ("handler.func (s *Server) Serve() {", 10, 14),
+        ("Render.<div>{{ .Name }}</div>", 18, 21),
+    ]
+    names = {f.symbol.name for f in outline.flatten()}
+    assert not names & {"fake", "Hidden", "Serve", "insideRender", "Inline"}
+
+    # The Render string spans only four lines
+    outline = await extract_file(path, options=ExtractOptions(string_literal_lines=5))
+    assert [f.qualified_name for f in outline.flatten() if f.symbol.kind == "string_literal"] == [
+        "Schema.CREATE TABLE users (id INT);",
+        "handler.func (s *Server) Serve() {",
+    ]
+
+
+@pytest.mark.asyncio
+async def test_collapse_accessors(samples_dir):
+    """Test that Get/Set method pairs serialize as properties with the methods kept."""
+    outline = await extract_file(str(samples_dir / "go_accessors.go"))
+    data = outline.to_dict(OutputOptions(collapse_accessors=True))
+    person = next(s for s in data["symbols"] if s["name"] == "Person")
+    members = [(c["kind"], c["name"]) for c in person["children"]]
+    assert members == [
+        ("field", "name"),
+        ("field", "age"),
+        ("field", "id"),
+        ("property", "Name"),
+        ("property", "Age"),
+        ("property", "ID"),
+        ("method", "Len"),
+    ]
+
+    name = person["children"][3]
+    assert (name["readable"], name["writable"], name["stable_id"]) == (True, True, "property:Person.Name")
+    assert [c["name"] for c in name["children"]] == ["GetName", "SetName"]
+    assert (name["start_line"], name["end_line"]) == (10, 12)
+    # A bare getter counts when it has a setter
+    assert [c["name"] for c in person["children"][4]["children"]] == ["Age", "SetAge"]
+    id_property = person["children"][5]
+    assert (id_property["readable"], id_property["writable"]) == (True, False)
+
+    # The outline itself is unchanged
+    assert "GetName" in [c.name for c in next(s for s in outline.symbols if s.name == "Person").children]
+    plain = outline.to_dict()
+    assert "property" not in [c["kind"] for s in plain["symbols"] for c in s["children"]]
+
+
+@pytest.mark.asyncio
+async def test_deprecated_doc_comments(samples_dir):
+    """Test that `// Deprecated:` paragraphs mark symbols deprecated."""
+    outline = await extract_file(str(samples_dir / "go_deprecated.go"))
+    symbols = _by_name(outline.symbols)
+
+    new = symbols["New"]
+    assert new.deprecated
+    assert new.deprecation_note == "Use NewClient, which validates its options. New will be removed in v2."
+    assert not symbols["NewClient"].deprecated
+
+    client = _by_name(symbols["Client"].children)
+    assert client["RetryDelay"].deprecated
+    assert client["RetryDelay"].deprecation_note == "Set Timeout instead."
+    assert not client["Timeout"].deprecated
+    assert not client["Do"].deprecated
+    assert client["Close"].deprecated
+    assert client["Close"].deprecation_note is None
+
+    # A comment separated by a blank line is not a doc comment
+    assert not symbols["Ping"].deprecated
+
+
+@pytest.mark.asyncio
+async def test_struct_layout_padding():
+    """Test field offsets and padding follow each architecture's alignment."""
+    source = """package layout
+
+type Padded struct {
+	A bool
+	B int64
+	C bool
+}
+
+type Holder struct {
+	Inner  Padded
+	Buf    [3]uint16
+	Remote pkg.Thing
+}
+
+type Counter struct {
+	Ready bool
+	Hits  atomic.Int64
+	Total atomic.Uint64
+}
+"""
+    outline = await extract_symbols(source, "go", ExtractOptions(layout_arch="amd64"))
+    symbols = _by_name(outline.symbols)
+
+    padded = symbols["Padded"].layout
+    assert [(f.name, f.offset, f.size, f.padding) for f in padded.fields] == [
+        ("A", 0, 1, 0),
+        ("B", 8, 8, 7),
+        ("C", 16, 1, 0),
+    ]
+    assert (padded.size, padded.align, padded.padding, padded.optimal_size) == (24, 8, 14, 16)
+
+    # Types from unknown packages leave the total size open
+    holder = symbols["Holder"].layout
+    assert [(f.offset, f.size) for f in holder.fields[:2]] == [(0, 24), (24, 6)]
+    assert holder.size is None
+    assert holder.unknown_types == ["pkg.Thing"]
+
+    # 64-bit integers are only word aligned on 32-bit targets
+    outline = await extract_symbols(source, "go", ExtractOptions(layout_arch="386"))
+    padded = _by_name(outline.symbols)["Padded"].layout
+    assert (padded.size, padded.optimal_size) == (16, 12)
+    # but sync/atomic's 64-bit types are 8-byte aligned everywhere
+    counter = _by_name(outline.symbols)["Counter"].layout
+    assert [(f.name, f.offset, f.padding) for f in counter.fields] == [
+        ("Ready", 0, 0),
+        ("Hits", 8, 7),
+        ("Total", 16, 0),
+    ]
+    assert (counter.size, counter.align) == (24, 8)
+
+    plain = await extract_symbols(source, "go")
+    assert _by_name(plain.symbols)["Padded"].layout is None
+
+
+@pytest.mark.asyncio
+async def test_struct_layout_stdlib_types(samples_dir):
+    """Test known standard library types and maps in the sample structs."""
+    outline = await extract_file(
+        str(samples_dir / "go_complex.go"),
+        options=ExtractOptions(layout_arch="arm64"),
+    )
+    symbols = _by_name(outline.symbols)
+
+    user = symbols["User"].layout
+    assert [(f.name, f.offset, f.size) for f in user.fields] == [
+        ("ID", 0, 16),
+        ("Name", 16, 16),
+        ("Email", 32, 16),
+        ("CreatedAt", 48, 24),
+        ("UpdatedAt", 72, 24),
+    ]
+    assert (user.size, user.padding) == (96, 0)
+
+    cache = symbols["InMemoryCache"].layout
+    assert [(f.type, f.offset, f.size) for f in cache.fields] == [
+        ("sync.RWMutex", 0, 24),
+        ("map[string]interface{}", 24, 8),
+    ]
+    assert cache.size == 32
+
+
+@pytest.mark.asyncio
+async def test_kind_filter(samples_dir):
+    """Test that only the requested kinds are extracted."""
+    options = ExtractOptions(kind_filter=["function", "method"])
+    outline = await extract_file(str(samples_dir / "go_complex.go"), options=options)
+    assert outline.success
+
+    kinds = {f.symbol.kind for f in outline.flatten()}
+    assert kinds == {"function", "method"}
+    # Methods of types that were filtered out are top-level
+    get_user = next(s for s in outline.symbols if s.name == "GetUser")
+    assert get_user.stable_id == "method:UserService.GetUser"
+    assert get_user.children == []
+
+    # Members of filtered-out types are still found
+    fields = await extract_file(str(samples_dir / "go_complex.go"), options=ExtractOptions(kind_filter=["field"]))
+    assert {s.kind for s in fields.symbols} == {"field"}
+    assert [s.name for s in fields.symbols][:5] == ["ID", "Name", "Email", "CreatedAt", "UpdatedAt"]
+
+
+@pytest.mark.asyncio
+async def test_global_ids(samples_dir):
+    """Test that global IDs are unique and the same across runs."""
+    options = ExtractOptions(global_ids=True)
+    first = await extract_file(str(samples_dir / "go_complex.go"), options=options)
+    second = await extract_file(str(samples_dir / "go_complex.go"), options=options)
+
+    ids = [f.symbol.global_id for f in first.flatten()]
+    assert ids == [f.symbol.global_id for f in second.flatten()]
+    assert len(set(ids)) == len(ids)
+    get_user = next(s for s in first.symbols if s.name == "UserService").children
+    assert "go:tests/samples/go_complex.go#method:UserService.GetUser" in [s.global_id for s in get_user]
+    # Off by default
+    assert (await extract_file(str(samples_dir / "go_complex.go"))).symbols[0].global_id is None
+
+
+def _struct_heavy_source(structs=200, fields=30):
+    """Go source with many wide structs and a method on each."""
+    lines = ["package models", ""]
+    for i in range(structs):
+        lines.append(f"type Model{i} struct {{")
+        lines.extend(f'\tField{j} string `json:"field{j},omitempty"`' for j in range(fields))
+        lines.append("}")
+        lines.append(f"func (m *Model{i}) Validate() error {{ return nil }}")
+    return "\n".join(lines) + "\n"
+
+
+@pytest.mark.benchmark
+@pytest.mark.asyncio
+async def test_benchmark_kind_filter():
+    """Benchmark: skipping fields makes function-only extraction of struct-heavy code cheaper."""
+    source = _struct_heavy_source()
+    functions_only = ExtractOptions(kind_filter=["function", "method"])
+    # Warm the grammar so only extraction is measured
+    await extract_symbols("package models\n", "go")
+    runs = 5
+
+    start = time.perf_counter()
+    for _ in range(runs):
+        full = await extract_symbols(source, "go")
+    full_time = (time.perf_counter() - start) / runs
+
+    start = time.perf_counter()
+    for _ in range(runs):
+        filtered = await extract_symbols(source, "go", functions_only)
+    filtered_time = (time.perf_counter() - start) / runs
+
+    print(f"\nextract_symbols: {full_time * 1e3:.1f}ms full, {filtered_time * 1e3:.1f}ms functions only")
+    assert len(full.flatten()) == 200 * 32
+    assert [s.kind for s in filtered.symbols] == ["method"] * 200
+    assert filtered_time < full_time
+
+
+@pytest.mark.asyncio
+async def test_init_functions_and_package(samples_dir):
+    """Test that the package clause is recorded and every init is emitted."""
+    outline = await extract_file(str(samples_dir / "go_init.go"))
+    assert outline.success
+    assert outline.package == "main"
+    assert outline.to_dict()["package"] == "main"
+
+    inits = [s for s in outline.symbols if s.kind == "init"]
+    assert [(s.name, s.start_line) for s in inits] == [("init", 8), ("init", 13)]
+    assert [s.stable_id for s in inits] == ["init:init", "init:init#2"]
+    assert _by_name(outline.symbols)["main"].kind == "function"
+
+    only_inits = await extract_file(str(samples_dir / "go_init.go"), options=ExtractOptions(kind_filter=["init"]))
+    assert [s.kind for s in only_inits.symbols] == ["init", "init"]
+
+
+@pytest.mark.asyncio
+async def test_exclude_deprecated(samples_dir):
+    """Test that Deprecated: doc paragraphs count as a Deprecated attribute."""
+    options = ExtractOptions(exclude_attributes=["Deprecated"])
+    outline = await extract_file(str(samples_dir / "go_deprecated.go"), options=options)
+    assert outline.success
+
+    symbols = _by_name(outline.symbols)
+    assert set(symbols) == {"Client", "NewClient", "Ping"}
+    assert [c.name for c in symbols["Client"].children] == ["Timeout", "Do"]
+
+    only = await extract_file(str(samples_dir / "go_deprecated.go"), options=ExtractOptions(include_attributes=["deprecated"]))
+    assert [(s.name, [c.name for c in s.children]) for s in only.symbols] == [
+        ("Client", ["RetryDelay", "Close"]),
+        ("New", []),
+    ]
+
+
+@pytest.mark.asyncio
+async def test_enclosing_function(samples_dir):
+    """Test mapping diff lines to the innermost enclosing function or method."""
+    outline = await extract_file(str(samples_dir / "go_complex.go"))
+    assert outline.success
+
+    def enclosing(line):
+        sym = outline.enclosing_function(line)
+        return sym.stable_id if sym is not None else None
+
+    assert enclosing(144) == "method:WorkerPool.worker"
+    assert enclosing(132) == "method:WorkerPool.worker"
+    assert enclosing(118) == "function:NewWorkerPool"
+    # Struct fields, the package clause and imports aren't in a function
+    assert enclosing(112) is None
+    assert enclosing(2) is None
+    assert enclosing(6) is None
+
+    assert outline.symbol_at(112).stable_id == "field:WorkerPool.taskQueue"
+    assert outline.symbol_at(2) is None
+
+
+@pytest.mark.asyncio
+async def test_context_misuse_diagnostics(samples_dir):
+    """Test that context antipatterns are reported with their rule IDs."""
+    outline = await extract_file(str(samples_dir / "go_context.go"), options=ExtractOptions(lint_context=True))
+    assert outline.success
+
+    findings = [(d.rule, d.line, d.column) for d in outline.diagnostics]
+    assert findings == [
+        ("go-context-in-struct", 10, 2),
+        ("go-context-in-struct", 15, 2),
+        ("go-lost-cancel", 29, 9),
+        ("go-lost-cancel", 34, 9),
+        ("go-nil-context", 44, 13),
+        ("go-nil-context", 45, 34),
+    ]
+    assert "ctx" in outline.diagnostics[0].message
+    assert "defer stop()" in outline.diagnostics[3].message
+
+    # Lint findings are opt-in
+    plain = await extract_file(str(samples_dir / "go_context.go"))
+    assert plain.diagnostics == []
+
+
+@pytest.mark.asyncio
+async def test_constraint_method_requirements(samples_dir):
+    """Test that type parameters record the methods their constraints require."""
+    outline = await extract_file(str(samples_dir / "go_method_constraints.go"))
+    assert outline.success
+    symbols = _by_name(outline.symbols)
+
+    [join] = symbols["Join"].type_params
+    assert (join.name, join.constraint, join.methods) == ("T", "interface{ String() string }", ["String() string"])
+
+    describe = {p.name: p for p in symbols["Describe"].type_params}
+    assert describe["T"].methods == ["Name() string", "String() string", "Label() string"]
+    assert describe["K"].constraint == "comparable"
+    assert describe["K"].methods == []
+
+    [registry] = symbols["Registry"].type_params
+    assert registry.methods == ["String() string"]
+
+    assert [(p.name, p.methods) for p in symbols["Max"].type_params] == [("N", [])]
+    assert symbols["Named"].type_params == []
+
+
+@pytest.mark.asyncio
+async def test_symbol_kind_config():
+    """Test re-kinding Go consts and aliases, per call and per AgentTools."""
+    source = """package config
+
+const Timeout = 30
+
+type ID = string
+
+type Name string
+
+func init() {}
+
+func Load() {}
+"""
+    options = ExtractOptions(symbol_kinds={"const_spec": "const", "function_declaration": "func"})
+    kinds = {s.name: s.kind for s in (await extract_symbols(source, "go", options)).symbols}
+    assert kinds == {"Timeout": "const", "ID": "type", "Name": "type", "init": "init", "Load": "func"}
+    # kind_filter and stable IDs see the configured kinds
+    filtered = await extract_symbols(source, "go", ExtractOptions(symbol_kinds={"const_spec": "const"}, kind_filter=["const"]))
+    assert [(s.name, s.stable_id) for s in filtered.symbols] == [("Timeout", "const:Timeout")]
+
+    tools = AgentTools(symbol_kinds={"go": {"type_alias": "alias", "const_spec": "constant"}})
+    outline = await tools.extract_symbols(source, "go", ExtractOptions(symbol_kinds={"const_spec": "const"}))
+    assert {s.name: s.kind for s in outline.symbols if s.name in ("Timeout", "ID", "Name")} == {
+        "Timeout": "const",
+        "ID": "alias",
+        "Name": "type",
+    }
+
+    with pytest.raises(ValueError, match="const_declaration"):
+        AgentTools(symbol_kinds={"go": {"const_declaration": "const"}})
+    with pytest.raises(ValueError, match="cobol"):
+        AgentTools(symbol_kinds={"cobol": {"paragraph": "function"}})
+    with pytest.raises(ValueError):
+        await extract_symbols(source, "go", ExtractOptions(symbol_kinds={"nope": "x"}))
+
+
+@pytest.mark.asyncio
+async def test_type_refs(samples_dir):
+    """Test that param, result and field types are decomposed recursively."""
+    source = """package broker
+
+type Broker struct {
+	queues map[string]chan int
+	done   <-chan struct{}
+}
+
+func (b *Broker) Subscribe(topics []string, handle func(string, int) error) (*Broker, error) {
+	return b, nil
+}
+"""
+    outline = await extract_symbols(source, "go", ExtractOptions(type_refs=True))
+    broker = outline.symbols[0]
+    fields = _by_name(broker.children)
+
+    queues = fields["queues"].type_ref
+    assert (queues.kind, queues.text) == ("map", "map[string]chan int")
+    assert (queues.key.kind, queues.key.name) == ("named", "string")
+    assert (queues.elem.kind, queues.elem.direction) == ("chan", "both")
+    assert (queues.elem.elem.kind, queues.elem.elem.name) == ("named", "int")
+    assert (fields["done"].type_ref.direction, fields["done"].type_ref.elem.kind) == ("receive", "struct")
+
+    subscribe = fields["Subscribe"]
+    topics, handle = (p.type_ref for p in subscribe.params)
+    assert (topics.kind, topics.elem.name) == ("slice", "string")
+    assert [t.name for t in handle.params] == ["string", "int"]
+    assert [t.name for t in handle.results] == ["error"]
+    assert subscribe.results[0].type_ref.kind == "pointer"
+    assert subscribe.results[0].type_ref.elem.name == "Broker"
+
+    # Round-trips through to_dict, and is off by default
+    assert Symbol.from_dict(broker.to_dict()) == broker
+    plain = await extract_symbols(source, "go")
+    assert plain.symbols[0].children[0].type_ref is None
+
+    outline = await extract_file(str(samples_dir / "go_complex.go"), options=ExtractOptions(type_refs=True))
+    cache = _by_name(outline.symbols)["InMemoryCache"]
+    items = _by_name(cache.children)["items"].type_ref
+    assert (items.key.name, items.elem.kind, items.elem.text) == ("string", "interface", "interface{}")
+
+
+@pytest.mark.asyncio
+async def test_channel_directions(samples_dir):
+    """Test that channel params and results record their direction."""
+    outline = await extract_file(str(samples_dir / "go_complex.go"), options=ExtractOptions(type_refs=True))
+
+    results = next(f.symbol for f in outline.flatten() if f.symbol.stable_id == "method:WorkerPool.Results")
+    returned = results.results[0].type_ref
+    assert (returned.kind, returned.direction, returned.elem.name) == ("chan", "receive", "Result")
+
+    pipeline = _by_name(outline.symbols)["pipeline"]
+    ctx, source = (p.type_ref for p in pipeline.params)
+    assert (ctx.kind, ctx.name) == ("named", "context.Context")
+    assert (source.kind, source.direction, source.elem.name) == ("chan", "receive", "int")
+    assert pipeline.results[0].type_ref.direction == "receive"
+
+
+def test_parse_type():
+    """Test Go type expression decomposition without a parser."""
+    arrays = parse_type("[...][4]*pkg.Item")
+    assert (arrays.kind, arrays.length) == ("array", "...")
+    assert (arrays.elem.length, arrays.elem.elem.elem.name) == ("4", "pkg.Item")
+    generic = parse_type("Set[K, map[K]V]")
+    assert (generic.name, [a.kind for a in generic.args]) == ("Set", ["named", "map"])
+    assert parse_type("chan<- Task").direction == "send"
+    nested = parse_type("chan<- <-chan int")
+    assert (nested.direction, nested.elem.direction) == ("send", "receive")
+    # `<-` after chan sends, whatever the spacing
+    spaced = parse_type("chan <-chan int")
+    assert (spaced.direction, spaced.elem.direction, spaced.elem.elem.name) == ("send", "both", "int")
+    grouped_chan = parse_type("chan (<-chan int)")
+    assert (grouped_chan.direction, grouped_chan.elem.direction) == ("both", "receive")
+    assert parse_type("...string").kind == "slice"
+    grouped = parse_type("func(a, b int, rest ...string) (n int, err error)")
+    assert [t.text for t in grouped.params] == ["int", "int", "...string"]
+    assert [t.text for t in grouped.results] == ["int", "error"]
+    assert parse_type("map[string]func() error").elem.results[0].name == "error"
+    with pytest.raises(ValueError):
+        parse_type("map[string")
+
+
+@pytest.mark.asyncio
+async def test_anonymous_structs(samples_dir):
+    """Test that inline struct types in vars and fields are extracted with their fields."""
+    outline = await extract_file(str(samples_dir / "go_anonymous.go"))
+    symbols = _by_name(outline.symbols)
+
+    defaults = symbols["Defaults"]
+    assert [(c.name, c.kind) for c in defaults.children] == [("struct", "anonymous_struct")]
+    shape = defaults.children[0]
+    assert [(c.name, c.kind) for c in shape.children] == [("Host", "field"), ("Port", "field"), ("TLS", "field")]
+    assert (shape.start_line, shape.end_line) == (5, 12)
+    # A struct nested in a field hangs off that field
+    tls = _by_name(shape.children)["TLS"]
+    assert [c.name for c in tls.children[0].children] == ["Cert", "Key"]
+
+    routes = symbols["routes"].children[0]
+    assert [c.name for c in routes.children] == ["method", "path"]
+
+    limits = _by_name(symbols["Server"].children)["Limits"]
+    assert limits.children[0].kind == "anonymous_struct"
+    requests = limits.children[0].children[0]
+    assert (requests.name, requests.attributes[0].name) == ("Requests", "json")
+
+    # Locals of function bodies aren't declarations
+    assert symbols["Load"].children == []
+    names = {f.qualified_name for f in outline.flatten() if f.symbol.kind == "anonymous_struct"}
+    assert names == {"Defaults.struct", "Defaults.struct.TLS.struct", "routes.struct", "Server.Limits.struct"}
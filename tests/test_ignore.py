@@ -0,0 +1,48 @@
+"""Tests for .gitignore matching."""
+
+from mcp_code_parser.ignore import IgnoreRules, parse_gitignore
+
+
+def _rules(text, base=""):
+    return IgnoreRules(parse_gitignore(text, base))
+
+
+def test_patterns():
+    """Test unanchored, anchored, directory-only and wildcard patterns."""
+    rules = _rules("# build output\n*.log\n/dist\nbuild/\ndocs/**/*.tmp\nfoo?.txt\n")
+
+    assert rules.is_ignored("debug.log")
+    assert rules.is_ignored("src/deep/debug.log")
+    assert rules.is_ignored("dist", is_dir=True)
+    assert not rules.is_ignored("src/dist", is_dir=True)
+    assert rules.is_ignored("src/build", is_dir=True)
+    assert not rules.is_ignored("src/build")
+    assert rules.is_ignored("docs/a/b/x.tmp")
+    assert rules.is_ignored("docs/x.tmp")
+    assert rules.is_ignored("foo1.txt")
+    assert not rules.is_ignored("foo12.txt")
+
+
+def test_negation_and_nesting():
+    """Test the last matching rule wins and nested files apply below their directory."""
+    rules = IgnoreRules(
+        parse_gitignore("*.gen.go\n!keep.gen.go\n")
+        + parse_gitignore("*.ts\n", base="web")
+    )
+
+    assert rules.is_ignored("api.gen.go")
+    assert not rules.is_ignored("keep.gen.go")
+    assert rules.is_ignored("web/app.ts")
+    assert not rules.is_ignored("app.ts")
+
+
+def test_load(tmp_path):
+    """Test rules are read from every .gitignore in the tree."""
+    (tmp_path / ".gitignore").write_text("node_modules/\n")
+    (tmp_path / "pkg").mkdir()
+    (tmp_path / "pkg" / ".gitignore").write_text("/local.go\n")
+
+    rules = IgnoreRules.load(str(tmp_path))
+    assert rules.is_ignored("node_modules", is_dir=True)
+    assert rules.is_ignored("pkg/local.go")
+    assert not rules.is_ignored("local.go")
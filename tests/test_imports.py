@@ -0,0 +1,74 @@
+"""Tests for import resolution."""
+
+from pathlib import Path
+
+import pytest
+
+from mcp_code_parser import extract_file, resolve_import
+from mcp_code_parser.errors import UnsupportedLanguageError
+from mcp_code_parser.imports import REASON_EXTERNAL, REASON_NOT_FOUND
+
+IMPORTS_DIR = (Path(__file__).parent / "samples" / "imports").resolve()
+
+
+def _imports(outline):
+    return [s for s in outline.symbols if s.kind == "import"]
+
+
+@pytest.mark.asyncio
+async def test_typescript_relative_imports():
+    """Test relative TS specifiers resolve to files, index files and .ts sources."""
+    main = IMPORTS_DIR / "web" / "main.ts"
+    outline = await extract_file(str(main))
+
+    resolutions = {s.name: resolve_import(str(main), s) for s in _imports(outline)}
+    assert resolutions["./lib/format"].path == str(IMPORTS_DIR / "web" / "lib" / "format.ts")
+    assert resolutions["./components"].path == str(IMPORTS_DIR / "web" / "components" / "index.ts")
+    # ESM-style import of the compiled name
+    assert resolutions["./lib/helper.js"].path == str(IMPORTS_DIR / "web" / "lib" / "helper.ts")
+
+    react = resolutions["react"]
+    assert (react.resolved, react.path, react.reason) == (False, None, REASON_EXTERNAL)
+
+    missing = resolve_import(str(main), "./lib/missing")
+    assert (missing.resolved, missing.reason) == (False, REASON_NOT_FOUND)
+
+
+@pytest.mark.asyncio
+async def test_go_module_local_imports():
+    """Test imports under the go.mod module path resolve to package directories."""
+    main = IMPORTS_DIR / "shop" / "cmd" / "server" / "main.go"
+    outline = await extract_file(str(main))
+
+    resolutions = {s.name: resolve_import(str(main), s) for s in _imports(outline)}
+    store = resolutions["example.com/shop/internal/store"]
+    assert store.resolved
+    assert store.path == str(IMPORTS_DIR / "shop" / "internal" / "store")
+
+    for external in ("fmt", "github.com/google/uuid"):
+        assert not resolutions[external].resolved
+        assert resolutions[external].reason == REASON_EXTERNAL
+
+    missing = resolve_import(str(main), "example.com/shop/internal/orders")
+    assert (missing.resolved, missing.reason) == (False, REASON_NOT_FOUND)
+
+
+def test_python_imports():
+    """Test relative and package-absolute Python imports."""
+    views = str(IMPORTS_DIR / "app" / "api" / "views.py")
+    app = IMPORTS_DIR / "app"
+
+    assert resolve_import(views, "..models").path == str(app / "models.py")
+    assert resolve_import(views, "..").path == str(app / "__init__.py")
+    assert resolve_import(views, ".helpers").path == str(app / "api" / "helpers.py")
+    assert resolve_import(views, "app.api.helpers").path == str(app / "api" / "helpers.py")
+
+    json_module = resolve_import(views, "json")
+    assert (json_module.resolved, json_module.reason) == (False, REASON_EXTERNAL)
+    assert resolve_import(views, ".missing").reason == REASON_NOT_FOUND
+
+
+def test_unsupported_language():
+    """Test languages without import resolution raise a categorized error."""
+    with pytest.raises(UnsupportedLanguageError):
+        resolve_import("notes.txt", "./other")
@@ -0,0 +1,119 @@
+"""Tests for incremental re-extraction and edit debouncing."""
+
+import asyncio
+import re
+
+import pytest
+
+from mcp_code_parser.extractors.base import Outline, Symbol
+from mcp_code_parser.incremental import Debouncer, IncrementalParser, TextEdit
+
+SOURCE = "package main\n\nfunc A() {}\n\nfunc B() {}\n"
+
+
+class LineTools:
+    """Stand-in for AgentTools that extracts one symbol per `func` line."""
+
+    def __init__(self):
+        self.calls = 0
+
+    async def extract_symbols(self, content, language, options=None, path=None):
+        self.calls += 1
+        symbols = []
+        offset = 0
+        for number, line in enumerate(content.splitlines(keepends=True), start=1):
+            match = re.match(r"func (\w+)", line)
+            if match:
+                symbols.append(Symbol(
+                    name=match.group(1),
+                    kind="function",
+                    start_line=number,
+                    end_line=number,
+                    start_byte=offset,
+                    end_byte=offset + len(line.rstrip("\n").encode("utf8")),
+                ))
+            offset += len(line.encode("utf8"))
+        return Outline(language=language, symbols=symbols, metadata={})
+
+
+def _names(symbols):
+    return sorted(s.name for s in symbols)
+
+
+@pytest.mark.asyncio
+async def test_apply_reports_changes():
+    """Test that added, removed and modified symbols are reported."""
+    tools = LineTools()
+    parser = IncrementalParser(SOURCE, "go", tools=tools)
+    await parser.extract()
+
+    start = SOURCE.index("func B")
+    changes = await parser.apply([
+        TextEdit(start, start + len("func B() {}"), "func C() {}"),
+        TextEdit(SOURCE.index("{}"), SOURCE.index("{}") + 2, "{ return }"),
+    ])
+
+    assert _names(changes.added) == ["C"]
+    assert _names(changes.removed) == ["B"]
+    assert _names(changes.modified) == ["A"]
+    assert "func A() { return }" in parser.content
+
+
+@pytest.mark.asyncio
+async def test_debouncer_coalesces_edits():
+    """Test that edits within the window cause a single re-extraction."""
+    tools = LineTools()
+    parser = IncrementalParser(SOURCE, "go", tools=tools)
+    await parser.extract()
+
+    end = len(SOURCE)
+    async with Debouncer(parser, window=0.05) as debouncer:
+        for name in "XYZ":
+            edit = f"\nfunc {name}() {{}}\n"
+            await debouncer.submit(TextEdit(end, end, edit))
+            end += len(edit)
+            await asyncio.sleep(0.01)
+        assert debouncer.pending == 3
+
+        changes = await asyncio.wait_for(debouncer.changes.get(), timeout=1)
+
+    assert tools.calls == 2  # initial extraction plus one coalesced re-extraction
+    assert _names(changes.added) == ["X", "Y", "Z"]
+    assert debouncer.changes.empty()
+
+
+@pytest.mark.asyncio
+async def test_debouncer_explicit_flush():
+    """Test that flush applies pending edits without waiting for the window."""
+    tools = LineTools()
+    parser = IncrementalParser(SOURCE, "go", tools=tools)
+    debouncer = Debouncer(parser, window=60)
+
+    await debouncer.submit(TextEdit(0, 0, "func Early() {}\n"))
+    changes = await debouncer.flush()
+
+    assert _names(changes.added) == ["Early"]
+    assert debouncer.pending == 0
+    assert await debouncer.flush() is None
+
+
+@pytest.mark.asyncio
+async def test_debouncer_flushes_on_cancel():
+    """Test that pending edits are applied when the owning task is cancelled."""
+    tools = LineTools()
+    parser = IncrementalParser(SOURCE, "go", tools=tools)
+    debouncer = Debouncer(parser, window=60)
+
+    async def editor():
+        async with debouncer:
+            await debouncer.submit(TextEdit(0, 0, "func Late() {}\n"))
+            await asyncio.sleep(60)
+
+    task = asyncio.create_task(editor())
+    await asyncio.sleep(0.01)
+    task.cancel()
+    with pytest.raises(asyncio.CancelledError):
+        await task
+
+    changes = debouncer.changes.get_nowait()
+    assert _names(changes.added) == ["Late"]
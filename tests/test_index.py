@@ -0,0 +1,87 @@
+"""Tests for the persistent symbol index."""
+
+import pytest
+
+from mcp_code_parser.index import Index, MemoryStorage, SQLiteStorage
+
+
+def _write_repo(root):
+    (root / "pkg").mkdir(parents=True)
+    (root / "main.go").write_text("package main\n\nfunc main() {}\n")
+    (root / "pkg" / "users.go").write_text(
+        "package pkg\n\n"
+        "type UserService struct{}\n\n"
+        "func (s *UserService) GetUser(id string) {}\n\n"
+        "func helper() {}\n"
+    )
+    (root / "README.md").write_text("not indexed\n")
+
+
+@pytest.mark.asyncio
+async def test_build_persist_reopen_query(tmp_path):
+    """Test building an index, reopening it from disk and querying it."""
+    root = tmp_path / "repo"
+    _write_repo(root)
+    db = str(tmp_path / "index.db")
+
+    with Index(str(root), SQLiteStorage(db)) as index:
+        update = await index.update()
+        assert update.added == ["main.go", "pkg/users.go"]
+        assert update.removed == []
+
+    with Index(str(root), SQLiteStorage(db)) as index:
+        assert index.files() == ["main.go", "pkg/users.go"]
+
+        matches = index.query(name="GetUser")
+        assert len(matches) == 1
+        assert matches[0].file == "pkg/users.go"
+        assert matches[0].qualified_name == "UserService.GetUser"
+        assert matches[0].symbol.kind == "method"
+
+        assert [m.symbol.name for m in index.query(kind="function", exported=False)] == ["main", "helper"]
+        assert index.outline("pkg/users.go").symbols[0].name == "UserService"
+
+        # Nothing changed on disk, so nothing is re-extracted
+        update = await index.update()
+        assert update.unchanged == 2
+        assert update.added == update.updated == update.removed == []
+
+
+@pytest.mark.asyncio
+async def test_incremental_update_and_prune(tmp_path):
+    """Test that changed files are re-extracted and deleted files pruned."""
+    root = tmp_path / "repo"
+    _write_repo(root)
+    index = Index(str(root), MemoryStorage())
+    await index.update()
+
+    (root / "main.go").write_text("package main\n\nfunc main() {}\n\nfunc Run() {}\n")
+    (root / "pkg" / "users.go").unlink()
+    (root / "pkg" / "orders.go").write_text("package pkg\n\nfunc PlaceOrder() {}\n")
+
+    update = await index.update()
+
+    assert update.added == ["pkg/orders.go"]
+    assert update.updated == ["main.go"]
+    assert update.removed == ["pkg/users.go"]
+    assert update.unchanged == 0
+    assert index.query(name="GetUser") == []
+    assert [m.file for m in index.query(prefix="R")] == ["main.go"]
+
+
+def test_sqlite_storage_round_trip(tmp_path):
+    """Test the SQLite backend on its own."""
+    db = str(tmp_path / "store.db")
+    storage = SQLiteStorage(db)
+    storage.put("b", {"n": 2})
+    storage.put("a", {"n": 1})
+    storage.put("a", {"n": 3})
+    storage.delete("missing")
+    storage.close()
+
+    storage = SQLiteStorage(db)
+    assert storage.keys() == ["a", "b"]
+    assert storage.get("a") == {"n": 3}
+    storage.delete("b")
+    assert storage.get("b") is None
+    storage.close()
@@ -0,0 +1,156 @@
+"""Tests for JavaScript and TypeScript symbol extraction."""
+
+from pathlib import Path
+
+import pytest
+
+from mcp_code_parser import extract_file, extract_symbols
+
+
+@pytest.fixture
+def samples_dir():
+    """Get samples directory."""
+    return Path(__file__).parent / "samples"
+
+
+def _by_name(symbols):
+    return {s.name: s for s in symbols}
+
+
+@pytest.mark.asyncio
+async def test_javascript_declarations():
+    """Test functions, classes, variables and imports."""
+    source = """import { readFile } from 'fs/promises';
+
+export function load(path, encoding = 'utf8', ...rest) {
+  return readFile(path, encoding);
+}
+
+const double = (x) => x * 2;
+let counter = 0;
+
+class Store {
+  items = [];
+  add(item) { this.items.push(item); }
+  clear = () => { this.items = []; };
+}
+
+export { Store };
+"""
+    outline = await extract_symbols(source, "javascript")
+
+    assert outline.success
+    symbols = _by_name(outline.symbols)
+    assert symbols["fs/promises"].kind == "import"
+
+    load = symbols["load"]
+    assert load.kind == "function"
+    assert load.exported
+    assert [p.name for p in load.params] == ["path", "encoding", "...rest"]
+
+    assert symbols["double"].kind == "function"
+    assert not symbols["double"].exported
+    assert symbols["counter"].kind == "variable"
+
+    store = symbols["Store"]
+    assert store.kind == "class"
+    assert store.exported
+    members = _by_name(store.children)
+    assert members["items"].kind == "field"
+    assert members["add"].kind == "method"
+    assert members["clear"].kind == "method"
+
+
+@pytest.mark.asyncio
+async def test_typescript_sample(samples_dir):
+    """Test TypeScript interfaces, type aliases and typed params."""
+    outline = await extract_file(str(samples_dir / "typescript_complex.ts"))
+
+    assert outline.success
+    symbols = _by_name(outline.symbols)
+    user = symbols["User"]
+    assert user.kind == "interface"
+    assert [c.name for c in user.children] == ["id", "name", "email", "roles", "metadata"]
+    assert symbols["Role"].kind == "type"
+
+    repo = symbols["InMemoryRepository"]
+    find = _by_name(repo.children)["find"]
+    assert find.kind == "method"
+    assert [(p.name, p.type) for p in find.params] == [("id", "string")]
+    assert [r.type for r in find.results] == ["Promise<T | null>"]
+
+
+@pytest.mark.asyncio
+async def test_typescript_decorator_arguments():
+    """Test that decorator call arguments are parsed when they are literals."""
+    source = """@Component({ selector: 'app-user', standalone: true, imports: [] })
+export class UserComponent {
+  @Get(':id', -1)
+  find(@Param('id') id: string) {}
+
+  @Input(config.name) name: string;
+}
+"""
+    outline = await extract_symbols(source, "typescript")
+
+    component = outline.symbols[0]
+    assert [(a.keyword, a.value, a.literal) for a in component.attributes[0].arguments] == [
+        (None, {"selector": "app-user", "standalone": True, "imports": []}, True),
+    ]
+    members = _by_name(component.children)
+    get = members["find"].attributes[0]
+    assert get.name == "Get"
+    assert [(a.keyword, a.value) for a in get.arguments] == [(None, ":id"), (None, -1)]
+    name_arg = members["name"].attributes[0].arguments[0]
+    assert (name_arg.raw, name_arg.value, name_arg.literal) == ("config.name", None, False)
+
+
+@pytest.mark.asyncio
+async def test_typescript_default_and_optional_params():
+    """Test defaults, `x?` parameters and rest parameters are optional."""
+    source = """export function paginate(items: string[], page = 1, size: number = 20, label?: string, ...rest: unknown[]) {
+  return items;
+}
+"""
+    outline = await extract_symbols(source, "typescript")
+
+    params = outline.symbols[0].params
+    assert [(p.name, p.default, p.optional) for p in params] == [
+        ("items", None, False),
+        ("page", "1", True),
+        ("size", "20", True),
+        ("label", None, True),
+        ("...rest", None, True),
+    ]
+
+
+@pytest.mark.asyncio
+async def test_jsdoc_deprecated():
+    """Test that JSDoc `@deprecated` tags mark symbols deprecated."""
+    source = """/**
+ * Formats a date.
+ * @deprecated Use Intl.DateTimeFormat
+ *   directly.
+ * @param d The date
+ */
+export function formatDate(d: Date): string {
+  return d.toString();
+}
+
+export class Store {
+  /** @deprecated */
+  clear(): void {}
+
+  /** Removes everything. */
+  reset(): void {}
+}
+"""
+    outline = await extract_symbols(source, "typescript")
+    symbols = _by_name(outline.symbols)
+
+    assert symbols["formatDate"].deprecated
+    assert symbols["formatDate"].deprecation_note == "Use Intl.DateTimeFormat directly."
+    methods = _by_name(symbols["Store"].children)
+    assert methods["clear"].deprecated
+    assert methods["clear"].deprecation_note is None
+    assert not methods["reset"].deprecated
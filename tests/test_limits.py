@@ -0,0 +1,52 @@
+"""Tests for per-call resource limits."""
+
+import pytest
+
+from mcp_code_parser import extract_symbols
+from mcp_code_parser.errors import LimitExceededError
+from mcp_code_parser.extractors.base import ExtractOptions
+from mcp_code_parser.limits import NODE_BYTES, Limits
+
+# A small function then one big enough to blow a budget of a few dozen nodes
+SOURCE = "def small():\n    pass\n\n\ndef big():\n" + "    x = 1\n" * 50
+
+
+@pytest.mark.asyncio
+async def test_node_limit():
+    """Test that a tiny node limit fails with the typed error and the symbols before it."""
+    outline = await extract_symbols(SOURCE, "python", ExtractOptions(limits=Limits(max_nodes=30)))
+    assert not outline.success
+    assert outline.error_code == LimitExceededError.code
+    assert outline.metadata["limit"] == "max_nodes"
+    assert [s.name for s in outline.symbols] == ["small"]
+
+    with pytest.raises(LimitExceededError) as exc:
+        outline.raise_for_error()
+    assert exc.value.limit == "max_nodes"
+    assert exc.value.partial is outline
+
+    unlimited = await extract_symbols(SOURCE, "python", ExtractOptions(limits=Limits(max_nodes=10_000)))
+    assert unlimited.success
+    assert [s.name for s in unlimited.symbols] == ["small", "big"]
+
+
+@pytest.mark.asyncio
+async def test_memory_limit():
+    """Test that estimated memory caps the tree, and the source on its own."""
+    limits = Limits(max_memory_bytes=len(SOURCE) + 30 * NODE_BYTES)
+    outline = await extract_symbols(SOURCE, "python", ExtractOptions(limits=limits))
+    assert outline.metadata["limit"] == "max_memory_bytes"
+    assert [s.name for s in outline.symbols] == ["small"]
+
+    outline = await extract_symbols(SOURCE, "python", ExtractOptions(limits=Limits(max_memory_bytes=10)))
+    assert outline.metadata["limit"] == "max_memory_bytes"
+    assert outline.symbols == []
+
+
+@pytest.mark.asyncio
+async def test_parse_time_limit():
+    """Test that a parse over max_parse_time fails without symbols."""
+    outline = await extract_symbols(SOURCE, "python", ExtractOptions(limits=Limits(max_parse_time=1e-9)))
+    assert outline.error_code == LimitExceededError.code
+    assert outline.metadata["limit"] == "max_parse_time"
+    assert outline.symbols == []
@@ -0,0 +1,121 @@
+"""Tests for API manifests."""
+
+import json
+
+import pytest
+
+from mcp_code_parser.analysis.manifest import ManifestDiff, api_manifest, diff_manifests
+from mcp_code_parser.extractors.base import SourceFile
+
+USER_V1 = """package users
+
+// User is an account.
+type User struct {
+	ID    int
+	Name  string
+	email string
+}
+
+type store struct{}
+
+func (s *store) Save(u User) error { return nil }
+
+func New(name string) *User { return &User{Name: name} }
+
+func helper() {}
+"""
+
+METHODS_V1 = """package users
+
+func (u *User) DisplayName()   string { return u.Name }
+func (u *User) SetName(name string) { u.Name = name }
+"""
+
+USER_V2 = """package users
+
+type User struct {
+	ID   int64
+	Name string
+}
+
+func New(name string, admin bool) *User { return &User{Name: name} }
+
+func Lookup(id int64) (*User, error) { return nil, nil }
+"""
+
+METHODS_V2 = """package users
+
+func (u *User) DisplayName() string {
+	return u.Name
+}
+"""
+
+
+@pytest.mark.asyncio
+async def test_api_manifest_is_stable():
+    """Test that manifests list exported symbols sorted, without positions."""
+    manifest = await api_manifest([SourceFile("user.go", USER_V1), SourceFile("methods.go", METHODS_V1)], "go")
+    data = json.loads(manifest)
+
+    assert data["package"] == "users"
+    assert [e["id"] for e in data["symbols"]] == sorted(e["id"] for e in data["symbols"])
+    by_id = {e["id"]: e for e in data["symbols"]}
+    assert set(by_id) == {
+        "function:New",
+        "struct:User",
+        "field:User.ID",
+        "field:User.Name",
+        "method:User.DisplayName",
+        "method:User.SetName",
+    }
+    assert by_id["method:User.DisplayName"]["signature"] == "func (u *User) DisplayName() string"
+    assert by_id["method:User.DisplayName"]["name"] == "User.DisplayName"
+    assert "start_line" not in by_id["function:New"]
+    assert manifest.endswith(b"\n")
+
+    # File order, formatting and comments don't change the manifest
+    reordered = await api_manifest(
+        [SourceFile("methods.go", METHODS_V1), SourceFile("user.go", USER_V1.replace("// User is an account.\n", ""))],
+        "go",
+    )
+    assert reordered == manifest
+
+
+@pytest.mark.asyncio
+async def test_diff_manifests_breaking_change():
+    """Test that removed and changed declarations are reported as breaking."""
+    old = await api_manifest([SourceFile("user.go", USER_V1), SourceFile("methods.go", METHODS_V1)], "go")
+    new = await api_manifest([SourceFile("user.go", USER_V2), SourceFile("methods.go", METHODS_V2)], "go")
+
+    diff = diff_manifests(old, new)
+
+    assert [e.id for e in diff.added] == ["function:Lookup"]
+    assert [e.id for e in diff.removed] == ["method:User.SetName"]
+    assert [(c.old.signature, c.new.signature) for c in diff.changed] == [
+        ("ID int", "ID int64"),
+        ("func New(name string) *User", "func New(name string, admin bool) *User"),
+    ]
+    assert diff.breaking
+    assert diff.to_dict()["breaking"] is True
+    assert not diff_manifests(old, old).breaking
+
+
+def test_diff_manifests_additions_only():
+    """Test diffing hand-written manifests, where only additions aren't breaking."""
+    old = b'{"format": 1, "language": "go", "package": "p", "symbols": []}'
+    new = json.dumps({
+        "format": 1,
+        "language": "go",
+        "package": "p",
+        "symbols": [{"id": "function:F", "kind": "function", "name": "F", "signature": "func F()"}],
+    }).encode()
+
+    diff = diff_manifests(old, new)
+    assert [e.name for e in diff.added] == ["F"]
+    assert not diff.breaking
+    assert diff_manifests(new, old) == ManifestDiff(removed=diff.added)
+
+    with pytest.raises(ValueError):
+        diff_manifests(b"not json", new)
+    with pytest.raises(ValueError):
+        diff_manifests(b'{"format": 99, "symbols": []}', new)
@@ -0,0 +1,78 @@
+"""Tests for merging outlines of split declarations."""
+
+from pathlib import Path
+
+import pytest
+
+from mcp_code_parser import extract_file, merge_outlines
+from mcp_code_parser.errors import NotFoundError
+from mcp_code_parser.extractors.base import Outline, Symbol
+
+SPLIT_DIR = Path(__file__).parent / "samples" / "go_split"
+
+
+@pytest.mark.asyncio
+async def test_merge_type_and_methods_across_files():
+    """Test that methods declared in another file end up under their type."""
+    user_file = str(SPLIT_DIR / "user.go")
+    store_file = str(SPLIT_DIR / "user_store.go")
+    user = await extract_file(user_file)
+    store = await extract_file(store_file)
+    # Without the type, the other file's methods are top-level
+    assert [s.stable_id for s in store.symbols if s.kind == "method"] == [
+        "method:User.Validate",
+        "method:User.Save",
+    ]
+
+    merged = merge_outlines(user, store)
+
+    assert merged.metadata["files"] == [user_file, store_file]
+    assert [s.stable_id for s in merged.symbols] == [
+        "struct:User",
+        "import:errors",
+        "variable:errNoEmail",
+    ]
+    struct = merged.symbols[0]
+    assert [(s.name, Path(s.file).name) for s in struct.children] == [
+        ("ID", "user.go"),
+        ("Email", "user.go"),
+        ("DisplayName", "user.go"),
+        ("Validate", "user_store.go"),
+        ("Save", "user_store.go"),
+    ]
+    # The inputs are untouched
+    assert store.symbols[-1].file is None
+    assert len(user.symbols[0].children) == 3
+
+
+def _outline(language, *stable_ids, file=None):
+    symbols = [
+        Symbol(name=sid.split(":")[1], kind=sid.split(":")[0], start_line=1, end_line=1,
+               start_byte=0, end_byte=0, stable_id=sid)
+        for sid in stable_ids
+    ]
+    return Outline(language=language, symbols=symbols, metadata={"file": file})
+
+
+def test_merge_symbols_sharing_stable_id():
+    """Test that a symbol declared in two files becomes one, keeping both sets of children."""
+    first = _outline("cpp", "namespace:geo", file="a.h")
+    first.symbols[0].children = _outline("cpp", "class:geo.Point").symbols
+    second = _outline("cpp", "namespace:geo", file="b.h")
+    second.symbols[0].children = _outline("cpp", "class:geo.Line").symbols
+
+    merged = merge_outlines(first, second)
+    assert [s.stable_id for s in merged.symbols] == ["namespace:geo"]
+    assert [(s.name, s.file) for s in merged.symbols[0].children] == [("geo.Point", "a.h"), ("geo.Line", "b.h")]
+
+
+def test_merge_errors():
+    """Test that mixed languages and failed outlines are rejected."""
+    with pytest.raises(ValueError, match="different languages"):
+        merge_outlines(_outline("go", "function:a"), _outline("python", "function:b"))
+    with pytest.raises(ValueError):
+        merge_outlines()
+
+    failed = Outline(language="go", symbols=[], metadata={}, error="missing.go not found", error_code="not_found")
+    with pytest.raises(NotFoundError):
+        merge_outlines(_outline("go", "function:a"), failed)
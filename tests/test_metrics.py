@@ -0,0 +1,58 @@
+"""Tests for file metrics."""
+
+from pathlib import Path
+
+import pytest
+
+from mcp_code_parser.analysis.metrics import dir_metrics, file_metrics
+
+SAMPLES_DIR = Path(__file__).parent / "samples"
+
+
+@pytest.mark.asyncio
+async def test_go_sample_metrics():
+    """Test line classification and symbol counts for the Go sample."""
+    source = (SAMPLES_DIR / "go_complex.go").read_text()
+    metrics = await file_metrics(source, "go")
+
+    assert metrics.total_lines == 328
+    assert metrics.blank_lines == 59
+    assert metrics.comment_lines == 20
+    assert metrics.code_lines == 249
+    assert metrics.comment_ratio == pytest.approx(20 / 269)
+    assert metrics.symbols_by_kind["import"] == 6
+    assert metrics.symbols_by_kind["struct"] == 7
+    assert metrics.symbols_by_kind["interface"] == 2
+    assert metrics.symbols_by_kind["function"] == 7
+    assert metrics.to_dict()["comment_ratio"] == metrics.comment_ratio
+
+
+@pytest.mark.asyncio
+async def test_block_and_trailing_comments():
+    """Test multi-line comments count per line and trailing comments count as code."""
+    source = """/*
+ * Package doc.
+ */
+package demo
+
+var x = 1 // trailing
+
+/* inline */ var y = 2
+"""
+    metrics = await file_metrics(source, "go")
+    assert (metrics.total_lines, metrics.comment_lines, metrics.code_lines, metrics.blank_lines) == (8, 3, 3, 2)
+
+
+@pytest.mark.asyncio
+async def test_dir_metrics_rollup(tmp_path):
+    """Test directory totals add up the files."""
+    (tmp_path / "a.go").write_text("package a\n\n// A does nothing.\nfunc A() {}\n")
+    (tmp_path / "b.go").write_text("package a\n\nfunc B() {}\n")
+    (tmp_path / "notes.txt").write_text("not source\n")
+
+    result = await dir_metrics(str(tmp_path))
+    assert list(result.files) == ["a.go", "b.go"]
+    total = result.total
+    assert (total.total_lines, total.code_lines, total.comment_lines, total.blank_lines) == (7, 4, 1, 2)
+    assert total.symbols_by_kind == {"function": 2}
+    assert result.errors == {}
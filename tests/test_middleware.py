@@ -0,0 +1,123 @@
+"""Tests for tool middleware."""
+
+import logging
+
+import pytest
+
+from mcp_code_parser.analysis.references import where_is_symbol
+from mcp_code_parser.middleware import caching_middleware, chain, logging_middleware
+
+
+class _Records(logging.Handler):
+    """Handler keeping the messages it receives."""
+
+    def __init__(self):
+        super().__init__()
+        self.messages = []
+
+    def emit(self, record):
+        self.messages.append((record.levelno, record.getMessage()))
+
+
+def _logger(name):
+    logger = logging.getLogger(f"tests.middleware.{name}")
+    logger.setLevel(logging.DEBUG)
+    handler = _Records()
+    logger.addHandler(handler)
+    return logger, handler
+
+
+def _counting():
+    """Middleware counting the calls that reach the tool."""
+    calls = []
+
+    def wrap(tool):
+        async def counted(*args, **kwargs):
+            calls.append(args)
+            return await tool(*args, **kwargs)
+        return counted
+
+    return wrap, calls
+
+
+@pytest.mark.asyncio
+async def test_logged_and_cached_search(tmp_path):
+    """Test wrapping where_is_symbol with logging and caching middleware."""
+    (tmp_path / "store.go").write_text(
+        "package shop\n\nfunc Lookup(id string) string { return id }\n\nfunc use() { Lookup(\"a\") }\n"
+    )
+    logger, records = _logger("search")
+    counter, calls = _counting()
+    cache = {}
+    search = chain(where_is_symbol, logging_middleware(logger), caching_middleware(cache), counter)
+
+    first = await search(str(tmp_path), "Lookup")
+    second = await search(str(tmp_path), "Lookup")
+
+    assert [d.file for d in first.declarations] == ["store.go"]
+    assert second is first
+    assert len(calls) == 1 and len(cache) == 1
+    # The outer logging middleware sees cache hits too
+    called = [m for _, m in records.messages if "called" in m]
+    assert len(called) == 2 and called[0].startswith("where_is_symbol called with args=")
+    assert search.__name__ == "where_is_symbol"
+
+    await search(str(tmp_path), "use")
+    assert len(calls) == 2
+
+
+@pytest.mark.asyncio
+async def test_chain_order_and_short_circuit():
+    """Test middleware order, argument rewriting and returning without calling the tool."""
+    order = []
+
+    async def tool(text):
+        order.append(f"tool {text}")
+        return text.upper()
+
+    def tag(label):
+        def wrap(inner):
+            async def tagged(text):
+                order.append(f"{label} in")
+                result = await inner(text + label)
+                order.append(f"{label} out")
+                return result + "!"
+            return tagged
+        return wrap
+
+    def refuse_empty(inner):
+        async def guarded(text):
+            if not text:
+                return ""
+            return await inner(text)
+        return guarded
+
+    wrapped = chain(tool, refuse_empty, tag("a"), tag("b"))
+    assert await wrapped("x") == "XAB!!"
+    assert order == ["a in", "b in", "tool xab", "b out", "a out"]
+    order.clear()
+    assert await wrapped("") == ""
+    assert order == []
+    assert chain(tool) is tool
+
+
+@pytest.mark.asyncio
+async def test_failures_logged_not_cached():
+    """Test that exceptions are logged as errors, propagate and aren't cached."""
+    attempts = []
+
+    async def flaky(name):
+        attempts.append(name)
+        if len(attempts) == 1:
+            raise ValueError("index busy")
+        return name
+
+    logger, records = _logger("failures")
+    tool = chain(flaky, logging_middleware(logger, level=logging.DEBUG), caching_middleware(key=lambda args, kwargs: args[0]))
+    with pytest.raises(ValueError):
+        await tool("Lookup")
+    assert await tool("Lookup") == "Lookup"
+    assert await tool("Lookup") == "Lookup"
+    assert attempts == ["Lookup", "Lookup"]
+    assert (logging.ERROR, "flaky failed after") == (records.messages[1][0], records.messages[1][1][:18])
+    assert records.messages[0][0] == logging.DEBUG
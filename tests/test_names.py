@@ -0,0 +1,78 @@
+"""Tests for qualified symbol names."""
+
+from pathlib import Path
+
+import pytest
+
+from mcp_code_parser import extract_file
+from mcp_code_parser.extractors.base import Outline, Symbol, assign_stable_ids, breadcrumb
+from mcp_code_parser.extractors.names import native_name, qualified_name, qualify
+
+SAMPLES = Path(__file__).parent / "samples"
+
+
+def _sym(name, kind, start, end, children=(), receiver=None):
+    return Symbol(
+        name=name, kind=kind, start_line=1, end_line=1, start_byte=start, end_byte=end,
+        receiver=receiver, children=list(children),
+    )
+
+
+def _qualified(outline, name):
+    return next(f.qualified_name for f in outline.flatten() if f.symbol.name == name)
+
+
+def test_rules():
+    """Test receivers, anonymous scopes and native separators."""
+    method = _sym("length", "method", 20, 30)
+    point = _sym("Point", "class", 10, 40, [method])
+    anonymous = _sym("", "namespace", 0, 50, [point])
+    get = _sym("Get", "method", 60, 70, receiver="Store")
+    outline = Outline(language="cpp", symbols=[anonymous, get], metadata={})
+
+    assert qualify(get) == "Store.Get"
+    # A receiver only qualifies top-level symbols
+    assert qualify(get, "pkg") == "pkg.Get"
+    assert qualified_name([anonymous, point, method]) == "<anonymous namespace>.Point.length"
+    assert native_name([anonymous, point, method], "cpp") == "<anonymous namespace>::Point::length"
+    assert native_name([point, method], "go") == "Point.length"
+
+    assert outline.qualified_name(method) == "<anonymous namespace>.Point.length"
+    assert outline.qualified_name(method, native=True) == "<anonymous namespace>::Point::length"
+    assert outline.qualified_name(_sym("other", "function", 0, 1)) is None
+    assert [f.qualified_name for f in outline.flatten()] == [
+        "<anonymous namespace>",
+        "<anonymous namespace>.Point",
+        "<anonymous namespace>.Point.length",
+        "Store.Get",
+    ]
+
+    # Stable IDs and breadcrumbs follow the same rules
+    assign_stable_ids(outline.symbols)
+    assert method.stable_id == "method:<anonymous namespace>.Point.length"
+    assert get.stable_id == "method:Store.Get"
+    assert breadcrumb([anonymous, point], package="geo") == "geo > <anonymous namespace> > Point"
+
+
+@pytest.mark.asyncio
+async def test_go():
+    """Test a Go method nested under its struct."""
+    outline = await extract_file(str(SAMPLES / "go_complex.go"))
+    assert _qualified(outline, "GetUser") == "UserService.GetUser"
+
+
+@pytest.mark.asyncio
+async def test_python():
+    """Test a Python method nested under its class."""
+    outline = await extract_file(str(SAMPLES / "python_complex.py"))
+    assert _qualified(outline, "__post_init__") == "Person.__post_init__"
+
+
+@pytest.mark.asyncio
+async def test_cpp():
+    """Test a C++ method nested in a class in a namespace."""
+    outline = await extract_file(str(SAMPLES / "cpp_overloads.cpp"))
+    length = next(f.symbol for f in outline.flatten() if f.symbol.name == "length")
+    assert outline.qualified_name(length) == "geo.Vector.length"
+    assert outline.qualified_name(length, native=True) == "geo::Vector::length"
+    assert length.stable_id.startswith("method:geo.Vector.length(")
@@ -0,0 +1,130 @@
+"""Tests for outlining buffers cut off mid-edit."""
+
+import pytest
+
+from mcp_code_parser import extract_symbols
+from mcp_code_parser.extractors.base import ExtractOptions
+from mcp_code_parser.partial import completion_candidates
+
+
+def _named(symbols, name):
+    return next(s for s in symbols if s.name == name)
+
+
+async def _partial_outline(content, language):
+    outline = await extract_symbols(content, language)
+    assert outline.success
+    assert outline.metadata["has_errors"] and outline.diagnostics
+    assert outline.metadata["completed"]
+    return outline
+
+
+def test_completion_candidates():
+    """Test closing brackets, strings, comments and blocks left open at the end."""
+    assert completion_candidates("class A:\n    def load(self, path", "python") == [
+        ")",
+        "):\n        pass\n",
+        ")\n        pass\n",
+        ")():\n        pass\n",
+    ]
+    # Brackets in a string or comment don't count
+    assert completion_candidates('f("(", /* { */ [1, x', "go")[0] == "])"
+    assert completion_candidates("class A {\n  load(a: string", "typescript")[2] == ") {}}"
+    assert completion_candidates("const s = `a ${b", "javascript")[0] == "`"
+    assert completion_candidates("/* note", "cpp")[0] == "*/"
+    # `do:` is a keyword argument, `do` a block closed by `end`
+    assert completion_candidates("defmodule A do\n  def a, do: 1\n  def b(x", "elixir")[0] == ")\nend"
+
+
+@pytest.mark.asyncio
+async def test_python_partial():
+    """Test Python functions and methods cut off in their parameters."""
+    outline = await _partial_outline("def ready():\n    return True\n\n\ndef load(path, mode", "python")
+    assert [s.name for s in outline.symbols] == ["ready", "load"]
+    load = _named(outline.symbols, "load")
+    assert load.partial and not _named(outline.symbols, "ready").partial
+    assert load.signature.startswith("def load(path, mode")
+    assert [p.name for p in load.params] == ["path", "mode"]
+    # Nothing points past the end of the buffer
+    assert (load.end_line, load.end_byte) == (5, len("def ready():\n    return True\n\n\ndef load(path, mode"))
+
+    outline = await _partial_outline(
+        "class Store:\n    def get(self, key):\n        return key\n\n    def put(self, key", "python"
+    )
+    store = _named(outline.symbols, "Store")
+    assert store.partial
+    assert [c.name for c in store.children] == ["get", "put"]
+    assert _named(store.children, "put").partial
+
+    # An unterminated string is closed too
+    outline = await _partial_outline('def greet(name):\n    """Say hello', "python")
+    assert _named(outline.symbols, "greet").partial
+
+
+@pytest.mark.asyncio
+async def test_go_partial():
+    """Test Go methods, structs and function bodies cut off mid-declaration."""
+    outline = await _partial_outline(
+        "package store\n\nfunc Ready() bool { return true }\n\nfunc (s *Store) Get(ctx context.Context, id", "go"
+    )
+    get = next(s for s in outline.symbols if s.stable_id == "method:Store.Get")
+    assert get.partial
+    assert get.signature.startswith("func (s *Store) Get(ctx context.Context, id")
+    assert not _named(outline.symbols, "Ready").partial
+
+    outline = await _partial_outline("package store\n\ntype Store struct {\n\tdb *sql.DB\n\tname", "go")
+    store = _named(outline.symbols, "Store")
+    assert store.partial
+    assert "db" in [c.name for c in store.children]
+
+    outline = await _partial_outline(
+        "package store\n\nfunc Load(path string) error {\n\tf, err := os.Open(", "go"
+    )
+    assert _named(outline.symbols, "Load").partial
+
+
+@pytest.mark.asyncio
+async def test_typescript_partial():
+    """Test TypeScript functions, methods and interfaces cut off mid-declaration."""
+    outline = await _partial_outline(
+        "export function ready(): boolean { return true; }\n\nexport function load(path: string, mode", "typescript"
+    )
+    load = _named(outline.symbols, "load")
+    assert load.partial and load.exported
+    assert load.signature.startswith("function load(path: string, mode")
+
+    outline = await _partial_outline(
+        "export class Store {\n  get(key: string): string { return key; }\n  put(key: string", "typescript"
+    )
+    store = _named(outline.symbols, "Store")
+    assert [c.name for c in store.children] == ["get", "put"]
+    assert _named(store.children, "put").partial
+
+    outline = await _partial_outline("export interface User {\n  id: number;\n  name", "typescript")
+    assert _named(outline.symbols, "User").partial
+
+
+@pytest.mark.asyncio
+async def test_javascript_partial():
+    """Test a JavaScript function cut off in its parameters."""
+    outline = await _partial_outline("function a() {}\n\nfunction b(x, y", "javascript")
+    assert [s.name for s in outline.symbols] == ["a", "b"]
+    assert _named(outline.symbols, "b").partial
+
+
+@pytest.mark.asyncio
+async def test_elixir_partial():
+    """Test an Elixir function head cut off inside its module."""
+    outline = await _partial_outline("defmodule Shop do\n  def get(id), do: id\n\n  def put(id, value", "elixir")
+    shop = _named(outline.symbols, "Shop")
+    assert [c.name for c in shop.children] == ["get/1", "put/2"]
+    assert _named(shop.children, "put/2").partial
+
+
+@pytest.mark.asyncio
+async def test_complete_partial_disabled():
+    """Test that complete_partial=False extracts the broken tree as is."""
+    content = "def ready():\n    return True\n\n\ndef load(path, mode"
+    outline = await extract_symbols(content, "python", ExtractOptions(complete_partial=False))
+    assert "completed" not in outline.metadata
+    assert not any(s.partial for s in outline.symbols)
@@ -0,0 +1,157 @@
+"""Unit tests for the async WorkerPool."""
+
+import asyncio
+
+import pytest
+
+from mcp_code_parser.pool import READ_AHEAD_BYTES, PriorityWorkerPool, WorkerPool, adaptive_read_workers
+
+
+async def _square_or_fail(task: int) -> int:
+    await asyncio.sleep(0.001)
+    if task % 3 == 0:
+        raise ValueError(f"bad task {task}")
+    return task * task
+
+
+@pytest.mark.asyncio
+async def test_stats_after_known_tasks():
+    """Test counters after a fixed batch of succeeding and failing tasks."""
+    async with WorkerPool(_square_or_fail, workers=3) as pool:
+        for task in range(1, 10):
+            await pool.submit(task)
+        await pool.join()
+        stats = pool.stats()
+
+    assert stats.submitted == 9
+    assert stats.completed == 6
+    assert stats.failed == 3
+    assert stats.in_flight == 0
+    assert stats.queue_depth == 0
+    assert stats.average_latency > 0
+
+
+@pytest.mark.asyncio
+async def test_results_delivered():
+    """Test that every task produces a result with output or error."""
+    async with WorkerPool(_square_or_fail, workers=2) as pool:
+        for task in (1, 2, 3):
+            await pool.submit(task)
+        await pool.join()
+
+    results = {}
+    while not pool.results.empty():
+        result = pool.results.get_nowait()
+        results[result.task] = result
+
+    assert results[1].output == 1
+    assert results[2].output == 4
+    assert not results[3].success
+    assert isinstance(results[3].error, ValueError)
+
+
+@pytest.mark.asyncio
+async def test_stats_in_flight_and_queue_depth():
+    """Test in-flight and queued counts while workers are busy."""
+    release = asyncio.Event()
+
+    async def blocked(task):
+        await release.wait()
+        return task
+
+    async with WorkerPool(blocked, workers=2) as pool:
+        for task in range(5):
+            await pool.submit(task)
+        # Let the workers pick up their first tasks
+        await asyncio.sleep(0.01)
+
+        stats = pool.stats()
+        assert stats.in_flight == 2
+        assert stats.queue_depth == 3
+        assert stats.completed == 0
+
+        release.set()
+        await pool.join()
+        assert pool.stats().completed == 5
+
+
+@pytest.mark.asyncio
+async def test_cancelled_submit_not_counted():
+    """Test that a submit cancelled while the queue is full isn't counted."""
+    for pool_class in (WorkerPool, PriorityWorkerPool):
+        # Not started, so the first task keeps the queue full
+        pool = pool_class(_square_or_fail, queue_size=1)
+        await pool.submit(1)
+        waiting = asyncio.create_task(pool.submit(2))
+        await asyncio.sleep(0.01)
+        waiting.cancel()
+        with pytest.raises(asyncio.CancelledError):
+            await waiting
+        assert (pool.stats().submitted, pool.stats().queue_depth) == (1, 1)
+
+
+def test_invalid_worker_count():
+    """Test that a pool needs at least one worker."""
+    with pytest.raises(ValueError, match="at least 1"):
+        WorkerPool(_square_or_fail, workers=0)
+
+
+@pytest.mark.asyncio
+async def test_priority_order():
+    """Test that queued high-priority tasks run before low-priority ones, FIFO within a priority."""
+    release = asyncio.Event()
+    ran = []
+
+    async def record(task):
+        if task == "busy":
+            await release.wait()
+        ran.append(task)
+        return task
+
+    async with PriorityWorkerPool(record, workers=1) as pool:
+        await pool.submit("busy")
+        await asyncio.sleep(0.01)
+        for task in ("low-1", "low-2"):
+            await pool.submit(task)
+        await pool.submit("high-1", priority=10)
+        await pool.submit("mid", priority=5)
+        await pool.submit("high-2", priority=10)
+        # Tasks needn't be comparable
+        await pool.submit({"path": "a.go"}, priority=5)
+        assert pool.stats().queue_depth == 6
+
+        release.set()
+        await pool.join()
+
+    assert ran == ["busy", "high-1", "high-2", "mid", {"path": "a.go"}, "low-1", "low-2"]
+    assert pool.stats().completed == 7
+
+
+@pytest.mark.asyncio
+async def test_priority_pool_stop_cancels_queued():
+    """Test that stopping a priority pool cancels running work and drops queued tasks."""
+    started = asyncio.Event()
+
+    async def hang(task):
+        started.set()
+        await asyncio.sleep(60)
+
+    pool = PriorityWorkerPool(hang, workers=1)
+    pool.start()
+    await pool.submit("first")
+    await pool.submit("second", priority=1)
+    await started.wait()
+    await asyncio.wait_for(pool.stop(), timeout=1)
+    assert pool.stats().completed == 0
+    assert pool.stats().in_flight == 0
+
+
+def test_adaptive_read_workers():
+    """Test that small files get many concurrent reads and large files few."""
+    assert adaptive_read_workers([], cpus=8) == 1
+    assert adaptive_read_workers([2_000] * 500, cpus=8) == 12
+    assert adaptive_read_workers([2_000] * 500, cpus=64) == 32
+    assert adaptive_read_workers([READ_AHEAD_BYTES // 2] * 10, cpus=8) == 2
+    assert adaptive_read_workers([READ_AHEAD_BYTES * 3], cpus=8) == 1
+    # A few multi-megabyte files among many small ones don't throttle reads
+    assert adaptive_read_workers([2_000] * 95 + [READ_AHEAD_BYTES] * 5, cpus=8) == 12
@@ -0,0 +1,94 @@
+"""Tests for column computations."""
+
+import pytest
+
+from mcp_code_parser import AgentTools, ExtractOptions, Outline, extract_symbols
+from mcp_code_parser.positions import Utf16Offsets, Utf16Range, visual_column
+
+# The stray `)` after two tabs is a syntax error at byte column 2 of line 4
+TABBED_GO = "package main\n\nfunc main() {\n\t\t)\n}\n"
+
+# "é" is two UTF-8 bytes but one UTF-16 unit, "🎉" four bytes but two units
+GREETING_PY = '# é\nGREETING = "🎉"\n\ndef greet():\n    return GREETING\n'
+
+
+def test_visual_column_expands_tabs():
+    """Test tabs advance to the next tab stop."""
+    assert visual_column(b"\t\tx", 2) == 8
+    assert visual_column(b"\t\tx", 2, tab_width=2) == 4
+    # A tab after text only fills up to the next stop
+    assert visual_column(b"ab\tx", 3, tab_width=4) == 4
+    assert visual_column(b"  x", 2) == 2
+
+
+def test_visual_column_counts_characters():
+    """Test multi-byte characters take one column."""
+    line = "é = 1".encode("utf8")
+    assert visual_column(line, 2) == 1
+    assert visual_column(line, len(line)) == 5
+
+
+@pytest.mark.asyncio
+async def test_diagnostic_columns_with_tabs():
+    """Test both the byte column and the visual column of a diagnostic."""
+    outline = await extract_symbols(TABBED_GO, "go")
+    diagnostic = next(d for d in outline.diagnostics if d.line == 4)
+    assert diagnostic.column == 3
+    assert diagnostic.visual_column == 9
+
+    outline = await extract_symbols(TABBED_GO, "go", ExtractOptions(tab_width=8))
+    diagnostic = next(d for d in outline.diagnostics if d.line == 4)
+    assert diagnostic.column == 3
+    assert diagnostic.visual_column == 17
+
+
+@pytest.mark.asyncio
+async def test_parse_result_columns_use_tools_tab_width():
+    """Test parse diagnostics use the tab width the tools were created with."""
+    result = await AgentTools(tab_width=2).parse_code(TABBED_GO, "go")
+
+    diagnostic = next(d for d in result.diagnostics if d.line == 4)
+    assert (diagnostic.column, diagnostic.visual_column) == (3, 5)
+
+
+def test_utf16_offsets():
+    """Test byte positions before, between and after multi-byte characters."""
+    offsets = Utf16Offsets("aé🎉b\n🎉c".encode("utf8"))
+    assert offsets.position(0) == (0, 0)
+    assert offsets.position(1) == (1, 1)
+    # After é (bytes 1-2) and 🎉 (bytes 3-6)
+    assert offsets.position(3) == (2, 2)
+    assert offsets.position(7) == (4, 4)
+    # Line 2 starts at byte 9, after b and the newline
+    assert offsets.position(9) == (6, 0)
+    assert offsets.position(13) == (8, 2)
+    assert offsets.position(100) == (9, 3)
+    assert offsets.byte_offset(1, 4) == 13
+    assert offsets.range(3, 13) == Utf16Range(start=2, end=8, start_column=2, end_column=2)
+
+
+@pytest.mark.asyncio
+async def test_symbol_utf16_ranges():
+    """Test that UTF-16 ranges of symbols after multi-byte characters differ from byte ranges."""
+    outline = await extract_symbols(GREETING_PY, "python", ExtractOptions(utf16_ranges=True))
+    greeting, greet = outline.symbols
+    assert (greeting.start_byte, greeting.end_byte) == (5, 22)
+    assert greeting.utf16 == Utf16Range(start=4, end=19, start_column=0, end_column=15)
+    assert (greet.start_byte, greet.end_byte) == (24, 56)
+    assert (greet.utf16.start, greet.utf16.end) == (21, 53)
+    assert GREETING_PY[greet.utf16.start:greet.utf16.end].startswith("def greet")
+
+    assert Outline.from_dict(outline.to_dict()).symbols[0].utf16 == greeting.utf16
+    plain = await extract_symbols(GREETING_PY, "python")
+    assert plain.symbols[0].utf16 is None
+
+
+@pytest.mark.asyncio
+async def test_diagnostic_utf16_ranges():
+    """Test diagnostic ranges in UTF-16 units after a multi-byte character."""
+    outline = await extract_symbols('package main\n\nvar s = "🎉")\n', "go", ExtractOptions(utf16_ranges=True))
+    diagnostic = next(d for d in outline.diagnostics if d.line == 3)
+    # The stray `)` is at byte column 15, after the four-byte emoji
+    assert diagnostic.column == 15
+    assert diagnostic.utf16.start_column == 12
+    assert diagnostic.utf16.start == 26
@@ -0,0 +1,196 @@
+"""Tests for Python symbol extraction."""
+
+from pathlib import Path
+
+import pytest
+
+from mcp_code_parser import ExtractOptions, extract_file, extract_symbols
+
+
+@pytest.fixture
+def samples_dir():
+    """Get samples directory."""
+    return Path(__file__).parent / "samples"
+
+
+def _by_name(symbols):
+    return {s.name: s for s in symbols}
+
+
+@pytest.mark.asyncio
+async def test_extract_sample_structure(samples_dir):
+    """Test classes, methods, functions and imports from the Python sample."""
+    outline = await extract_file(str(samples_dir / "python_complex.py"))
+
+    assert outline.success
+    symbols = _by_name(outline.symbols)
+    assert symbols["asyncio"].kind == "import"
+    assert symbols["typing"].kind == "import"
+
+    manager = symbols["AsyncTaskManager"]
+    assert manager.kind == "class"
+    methods = _by_name(manager.children)
+    assert methods["add_task"].kind == "method"
+    assert not methods["__init__"].exported
+
+    fetch = symbols["fetch_data"]
+    assert fetch.kind == "function"
+    assert [(p.name, p.type) for p in fetch.params] == [("url", "str"), ("retry_count", "int")]
+    assert [r.type for r in fetch.results] == ["dict"]
+
+    fields = _by_name(symbols["Person"].children)
+    assert fields["name"].kind == "field"
+    assert symbols["squares"].kind == "variable"
+
+
+@pytest.mark.asyncio
+async def test_decorators_as_attributes(samples_dir):
+    """Test that decorators map into attributes on the decorated symbol."""
+    outline = await extract_file(str(samples_dir / "python_complex.py"))
+    symbols = _by_name(outline.symbols)
+
+    assert [(a.name, a.args) for a in symbols["Person"].attributes] == [("dataclass", [])]
+    main = symbols["main"]
+    assert [a.raw for a in main.attributes] == ["@timing_decorator"]
+    # The symbol spans its decorators
+    assert main.start_line == 83
+    assert symbols["fibonacci"].attributes == []
+
+
+@pytest.mark.asyncio
+async def test_decorator_arguments():
+    """Test that call-style decorators record their arguments."""
+    source = """import functools
+
+class Api:
+    @staticmethod
+    def version() -> str:
+        return "1"
+
+    @app.route("/users", methods=["GET", "POST"])
+    @functools.lru_cache(maxsize=None)
+    def users(self, *args, limit: int = 10, **kwargs):
+        pass
+"""
+    outline = await extract_symbols(source, "python")
+
+    methods = _by_name(_by_name(outline.symbols)["Api"].children)
+    assert [a.name for a in methods["version"].attributes] == ["staticmethod"]
+
+    users = methods["users"]
+    assert [(a.name, a.args) for a in users.attributes] == [
+        ("app.route", ['"/users"', 'methods=["GET", "POST"]']),
+        ("functools.lru_cache", ["maxsize=None"]),
+    ]
+    assert users.attributes[0].raw == '@app.route("/users", methods=["GET", "POST"])'
+    assert [p.name for p in users.params] == ["self", "*args", "limit", "**kwargs"]
+
+
+@pytest.mark.asyncio
+async def test_decorator_arguments_parsed(samples_dir):
+    """Test positional and keyword decorator arguments, with literals evaluated."""
+    outline = await extract_file(str(samples_dir / "python_routes.py"))
+    route, prefixed, limit = _by_name(outline.symbols)["create_user"].attributes
+
+    assert route.name == "app.route"
+    assert [(a.keyword, a.value, a.literal) for a in route.arguments] == [
+        (None, "/users", True),
+        ("methods", ["POST"], True),
+        ("defaults", {"page": 1, "strict": True}, True),
+    ]
+    # Expressions that aren't literals are kept as raw text
+    assert [(a.keyword, a.raw, a.literal) for a in prefixed.arguments] == [
+        (None, 'f"{PREFIX}/users"', False),
+        ("methods", '["POST"]', True),
+        ("endpoint", "None", True),
+    ]
+    assert prefixed.arguments[0].value is None
+    assert limit.name == "limiter.limit"
+    assert [(a.keyword, a.value, a.literal) for a in limit.arguments] == [
+        (None, "10/minute", True),
+        ("exempt_when", None, False),
+    ]
+    assert limit.arguments[1].raw == "lambda: current_user.is_admin"
+
+
+@pytest.mark.asyncio
+async def test_global_ids_disambiguate_redefinitions():
+    """Test that symbols sharing a stable ID get ordinals in their global IDs."""
+    source = "def handle():\n    pass\n\n\ndef handle():\n    return 1\n"
+    outline = await extract_symbols(source, "python", ExtractOptions(global_ids=True), path="./pkg//handlers.py")
+
+    assert [s.global_id for s in outline.symbols] == [
+        "python:pkg/handlers.py#function:handle",
+        "python:pkg/handlers.py#function:handle~2",
+    ]
+
+
+@pytest.mark.asyncio
+async def test_default_values(samples_dir):
+    """Test that defaults are captured and parameters without one are required."""
+    outline = await extract_file(str(samples_dir / "python_defaults.py"))
+    symbols = _by_name(outline.symbols)
+
+    connect = symbols["connect"]
+    assert [(p.name, p.default, p.optional) for p in connect.params] == [
+        ("host", None, False),
+        ("port", "5432", True),
+        ("timeout", "DEFAULT_TIMEOUT", True),
+        # Keyword-only without a default is still required
+        ("ssl", None, False),
+        ("retries", "3", True),
+    ]
+    assert connect.params[2].type == "float"
+
+    query = symbols["query"]
+    assert [(p.name, p.default, p.optional) for p in query.params] == [
+        ("sql", None, False),
+        ("params", "None", True),
+        ("*args", None, True),
+        ("fetch", '"all"', True),
+        ("**options", None, True),
+    ]
+
+    methods = _by_name(symbols["Pool"].children)
+    assert [p.default for p in methods["__init__"].params] == [None, "10", "[]", '"default pool"']
+    assert methods["acquire"].params[1].default == "1.5 * 2"
+
+
+@pytest.mark.asyncio
+async def test_deprecations():
+    """Test `@deprecated` decorators and DeprecationWarning calls."""
+    source = """import warnings
+from typing_extensions import deprecated
+
+
+@deprecated("Use fetch_all instead")
+def fetch():
+    pass
+
+
+def legacy(x):
+    warnings.warn("legacy() is deprecated; use modern()", DeprecationWarning, stacklevel=2)
+    return x
+
+
+def noisy():
+    warnings.warn("slow path", RuntimeWarning)
+
+
+class Api:
+    @deprecation.deprecated(deprecated_in="1.0", details="Use v2")
+    def v1(self):
+        pass
+"""
+    outline = await extract_symbols(source, "python")
+    symbols = _by_name(outline.symbols)
+
+    assert symbols["fetch"].deprecated
+    assert symbols["fetch"].deprecation_note == "Use fetch_all instead"
+    assert symbols["legacy"].deprecated
+    assert symbols["legacy"].deprecation_note == "legacy() is deprecated; use modern()"
+    assert not symbols["noisy"].deprecated
+
+    v1 = _by_name(symbols["Api"].children)["v1"]
+    assert v1.deprecated
+    assert v1.deprecation_note == "Use v2"
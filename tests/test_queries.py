@@ -0,0 +1,133 @@
+"""Tests for the compiled query cache and query execution."""
+
+import threading
+import time
+from unittest.mock import patch
+
+import pytest
+
+from mcp_code_parser import AgentTools
+from mcp_code_parser.parsers.queries import QueryCache, shared_query_cache
+
+FUNCTION_NAMES = "(function_definition name: (identifier) @name)"
+
+
+@pytest.fixture
+def compile_calls():
+    """Replace query compilation with a stub that records its calls."""
+    calls = []
+
+    def fake_compile(grammar, text):
+        calls.append(text)
+        # Widen the window for concurrent misses on the same key
+        time.sleep(0.001)
+        return object()
+
+    with patch("mcp_code_parser.parsers.queries._compile", side_effect=fake_compile):
+        yield calls
+
+
+def test_hits_and_misses(compile_calls):
+    """Test that repeated queries are compiled once per language."""
+    cache = QueryCache(max_size=4)
+
+    first = cache.get("python", None, "(a)")
+    assert cache.get("python", None, "(a)") is first
+    cache.get("go", None, "(a)")
+
+    assert compile_calls == ["(a)", "(a)"]
+    assert (cache.hits, cache.misses, len(cache)) == (1, 2, 2)
+
+
+def test_evicts_least_recently_used(compile_calls):
+    """Test that the least recently used query is evicted first."""
+    cache = QueryCache(max_size=2)
+    cache.get("python", None, "(a)")
+    cache.get("python", None, "(b)")
+    cache.get("python", None, "(a)")
+    cache.get("python", None, "(c)")
+
+    # (b) was evicted, (a) survived because it was used more recently
+    cache.get("python", None, "(a)")
+    cache.get("python", None, "(b)")
+    assert compile_calls == ["(a)", "(b)", "(c)", "(b)"]
+
+    cache.max_size = 1
+    assert len(cache) == 1
+    with pytest.raises(ValueError):
+        cache.max_size = 0
+    with pytest.raises(ValueError):
+        QueryCache(max_size=0)
+
+
+def test_failures_are_not_cached():
+    """Test that a query that fails to compile is retried next time."""
+    cache = QueryCache()
+    with patch("mcp_code_parser.parsers.queries._compile", side_effect=SyntaxError("bad")):
+        with pytest.raises(SyntaxError):
+            cache.get("python", None, "(oops")
+    assert len(cache) == 0
+
+
+def test_concurrent_get_compiles_once(compile_calls):
+    """Test that concurrent misses on one key compile a single query."""
+    cache = QueryCache()
+    results = []
+    barrier = threading.Barrier(16)
+
+    def worker():
+        barrier.wait()
+        for _ in range(50):
+            results.append(cache.get("python", None, "(a)"))
+
+    threads = [threading.Thread(target=worker) for _ in range(16)]
+    for thread in threads:
+        thread.start()
+    for thread in threads:
+        thread.join()
+
+    assert compile_calls == ["(a)"]
+    assert len({id(r) for r in results}) == 1
+    assert cache.hits + cache.misses == 16 * 50
+
+
+@pytest.mark.asyncio
+async def test_run_query():
+    """Test running a query and the shared cache across tool instances."""
+    source = "def first():\n    pass\n\nclass A:\n    def second(self):\n        pass\n"
+    shared_query_cache().clear()
+
+    captures = await AgentTools().run_query(source, "python", FUNCTION_NAMES)
+    assert [(c.name, c.text, c.start_line) for c in captures] == [
+        ("name", "first", 1),
+        ("name", "second", 5),
+    ]
+
+    await AgentTools().run_query(source, "python", FUNCTION_NAMES)
+    assert shared_query_cache().misses == 1
+    assert shared_query_cache().hits == 1
+
+
+@pytest.mark.benchmark
+@pytest.mark.asyncio
+async def test_benchmark_cached_compile():
+    """Benchmark: a cached query costs far less per call than compiling it."""
+    tools = AgentTools()
+    # Warm the grammar so only query compilation is measured
+    await tools.parse_tree("x = 1\n", "python")
+    query = "\n".join(f"(function_definition name: (identifier) @f{i})" for i in range(20))
+    runs = 20
+
+    start = time.perf_counter()
+    for _ in range(runs):
+        shared_query_cache().clear()
+        await tools._tree_sitter.compile_query("python", query)
+    uncached = (time.perf_counter() - start) / runs
+
+    start = time.perf_counter()
+    for _ in range(runs):
+        await tools._tree_sitter.compile_query("python", query)
+    cached = (time.perf_counter() - start) / runs
+
+    print(f"\ncompile_query: {uncached * 1e6:.0f}us uncached, {cached * 1e6:.0f}us cached")
+    assert cached < uncached
@@ -0,0 +1,93 @@
+"""Tests for multi-file refactorings."""
+
+import shutil
+from pathlib import Path
+
+import pytest
+
+from mcp_code_parser.edit import apply_operations
+from mcp_code_parser.errors import ConflictError, NotFoundError
+from mcp_code_parser.refactor import rename_symbol
+
+SAMPLES_DIR = Path(__file__).parent / "samples" / "rename"
+
+
+@pytest.fixture
+def project(tmp_path):
+    """A writable copy of the rename sample project."""
+    root = tmp_path / "project"
+    shutil.copytree(SAMPLES_DIR, root)
+    return root
+
+
+@pytest.mark.asyncio
+async def test_rename_type_across_files(project):
+    """Test renaming UserService, its uses and its receivers within the package."""
+    edits = await rename_symbol(str(project), "struct:UserService", "AccountService", dry_run=True)
+
+    assert [e.file for e in edits] == ["users/handler.go", "users/service.go"]
+    handler, service = edits
+    assert len(handler.edits) == 3
+    assert "func NewHandler(service *AccountService) *Handler {" in handler.content
+    assert "func (s *AccountService) CreateUser(name string) *User {" in handler.content
+    assert "type AccountService struct {" in service.content
+    assert "return &AccountService{users: map[string]*User{}}" in service.content
+    # Names in comments and strings, and the constructor's name, are left alone
+    assert "// UserService stores users in memory." in service.content
+    assert 'errors.New("UserService: no such user")' in service.content
+    assert "func NewUserService() *AccountService {" in service.content
+    for edit in service.edits:
+        assert (project / "users" / "service.go").read_text()[edit.start:edit.end] == "UserService"
+
+    # A dry run writes nothing; other packages are out of scope
+    assert "AccountService" not in (project / "users" / "service.go").read_text()
+    await rename_symbol(str(project), "struct:UserService", "AccountService")
+    assert (project / "users" / "service.go").read_text() == service.content
+    assert (project / "users" / "handler.go").read_text() == handler.content
+    assert "type UserService interface" in (project / "api" / "api.go").read_text()
+
+
+@pytest.mark.asyncio
+async def test_rename_method_uses(project):
+    """Test that renaming a method only touches selector uses and its declaration."""
+    edits = await rename_symbol(str(project), "method:UserService.GetUser", "FindUser", dry_run=True)
+    contents = {e.file: e.content for e in edits}
+    assert "func (s *UserService) FindUser(id string) (*User, error) {" in contents["users/service.go"]
+    assert "h.service.FindUser(id)" in contents["users/handler.go"]
+    # The same edits as operations for an external executor
+    for edit in edits:
+        original = (project / edit.file).read_text()
+        assert {o.op for o in edit.operations} == {"replace"}
+        assert apply_operations(original, edit.operations) == edit.content
+
+
+@pytest.mark.asyncio
+async def test_rename_refused(project):
+    """Test collisions, unknown symbols and invalid names are refused."""
+    with pytest.raises(ConflictError, match="User"):
+        await rename_symbol(str(project), "struct:UserService", "User", dry_run=True)
+    with pytest.raises(ConflictError, match="CreateUser"):
+        await rename_symbol(str(project), "method:UserService.GetUser", "CreateUser", dry_run=True)
+    with pytest.raises(NotFoundError):
+        await rename_symbol(str(project), "struct:Missing", "Other", dry_run=True)
+    with pytest.raises(ValueError):
+        await rename_symbol(str(project), "struct:UserService", "not valid", dry_run=True)
+    # Nothing was written
+    assert "type UserService struct" in (project / "users" / "service.go").read_text()
+
+
+@pytest.mark.asyncio
+async def test_rename_keeps_bom_and_crlf(project):
+    """Test that a renamed CRLF file with a UTF-8 BOM is written back in its format."""
+    path = project / "users" / "service.go"
+    original = path.read_text()
+    path.write_bytes(b"\xef\xbb\xbf" + original.replace("\n", "\r\n").encode("utf-8"))
+
+    edits = await rename_symbol(str(project), "method:UserService.GetUser", "FindUser")
+
+    service = next(e for e in edits if e.file == "users/service.go")
+    assert not service.content.startswith("﻿") and "\r" not in service.content
+    expected = original.replace("GetUser", "FindUser")
+    assert path.read_bytes() == b"\xef\xbb\xbf" + expected.replace("\n", "\r\n").encode("utf-8")
+    # Untouched line-ending styles stay as they were
+    assert b"\r" not in (project / "users" / "handler.go").read_bytes()
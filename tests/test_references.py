@@ -0,0 +1,181 @@
+"""Tests for workspace-wide symbol search."""
+
+import pytest
+
+from mcp_code_parser.analysis.references import iter_references, where_is_symbol
+from mcp_code_parser.index import Index, MemoryStorage
+
+FILES = {
+    ".gitignore": "generated/\n*_gen.go\n",
+    "store.go": """package shop
+
+// Store keeps products by ID.
+type Store struct {
+	items map[string]string
+}
+
+// Lookup finds a product by ID.
+func (s *Store) Lookup(id string) (string, bool) {
+	item, ok := s.items[id]
+	return item, ok
+}
+""",
+    "main.go": """package shop
+
+import "fmt"
+
+func describe(s *Store, id string) string {
+	// Lookup may miss, so fall back to the ID
+	if item, ok := s.Lookup(id); ok {
+		return item
+	}
+	fmt.Println("Lookup failed for", id)
+	return id
+}
+""",
+    "web/client.ts": """export async function show(api: { Lookup(id: string): string }, id: string) {
+  return `${api.Lookup(id)} (Lookup)`;
+}
+""",
+    # Ignored by .gitignore
+    "cache_gen.go": "package shop\n\nfunc cached(s *Store) { s.Lookup(\"x\") }\n",
+    "generated/client.go": "package generated\n\nfunc Lookup() {}\n",
+}
+
+
+@pytest.fixture
+def workspace(tmp_path):
+    """A small multi-language tree with ignored files."""
+    for rel, content in FILES.items():
+        path = tmp_path / rel
+        path.parent.mkdir(parents=True, exist_ok=True)
+        path.write_text(content)
+    return tmp_path
+
+
+@pytest.mark.asyncio
+async def test_declaration_and_uses(workspace):
+    """Test the declaration comes from the outline and uses skip comments and strings."""
+    found = await where_is_symbol(str(workspace), "Lookup")
+
+    assert [(d.file, d.qualified_name, d.symbol.kind) for d in found.declarations] == [
+        ("store.go", "Store.Lookup", "method"),
+    ]
+    assert [(r.file, r.line) for r in found.references] == [
+        ("main.go", 7),
+        ("web/client.ts", 1),
+        ("web/client.ts", 2),
+    ]
+    call = found.references[0]
+    assert call.text == "if item, ok := s.Lookup(id); ok {"
+    assert call.kind == "call"
+    # Only Go method uses get a kind
+    assert found.references[1].kind is None
+    assert call.column == call.text.index("Lookup") + 2
+
+
+METHOD_VALUE_FILES = {
+    "users.go": """package users
+
+type UserService struct{}
+
+func (u *UserService) GetUser(id string) string {
+	return id
+}
+
+func each(ids []string, fn func(string) string) {
+	for _, id := range ids {
+		fn(id)
+	}
+}
+
+func run(u *UserService) {
+	each([]string{"a"}, u.GetUser)
+	get := (*UserService).GetUser
+	get(u, "b")
+	(u.GetUser)("c")
+	u.GetUser("d")
+}
+""",
+}
+
+
+@pytest.mark.asyncio
+async def test_go_method_values(tmp_path):
+    """Test that Go method values and expressions are told apart from calls."""
+    for rel, content in METHOD_VALUE_FILES.items():
+        (tmp_path / rel).write_text(content)
+    found = await where_is_symbol(str(tmp_path), "GetUser")
+
+    assert [(r.line, r.kind) for r in found.references] == [
+        # Passed to a higher-order function uncalled
+        (16, "method_value"),
+        (17, "method_expression"),
+        (19, "call"),
+        (20, "call"),
+    ]
+
+
+@pytest.mark.asyncio
+async def test_context_lines(tmp_path):
+    """Test context lines stop at the file's ends and aren't repeated between nearby uses."""
+    (tmp_path / "jobs.py").write_text("fetch(1)\na = 1\nb = 2\nc = 3\nfetch(2)\nfetch(3)\nd = 4\n")
+    found = await where_is_symbol(str(tmp_path), "fetch", context_before=2, context_after=2)
+
+    assert [(r.line, r.before, r.after) for r in found.references] == [
+        # Nothing before the first line
+        (1, [], ["a = 1", "b = 2"]),
+        # Line 3 was already shown after the first use
+        (5, ["c = 3"], []),
+        # The file ends after line 7
+        (6, [], ["d = 4"]),
+    ]
+    streamed = [r async for r in iter_references(str(tmp_path), "fetch", context_before=2, context_after=2)]
+    assert streamed == found.references
+
+    plain = await where_is_symbol(str(tmp_path), "fetch")
+    assert all(r.before == [] and r.after == [] for r in plain.references)
+    with pytest.raises(ValueError):
+        await where_is_symbol(str(tmp_path), "fetch", context_before=-1)
+
+
+@pytest.mark.asyncio
+async def test_include_comments_and_strings(workspace):
+    """Test comment and string matches are kept on request."""
+    found = await where_is_symbol(str(workspace), "Lookup", include_comments_and_strings=True)
+
+    lines = [(r.file, r.line) for r in found.references]
+    assert ("store.go", 8) in lines
+    assert ("main.go", 6) in lines
+    assert ("main.go", 10) in lines
+    assert lines.count(("web/client.ts", 2)) == 2
+    # Ignored files stay out either way
+    assert not any(r.file in ("cache_gen.go", "generated/client.go") for r in found.references)
+
+
+@pytest.mark.asyncio
+async def test_search_scope(workspace):
+    """Test include and exclude globs limit declarations and uses."""
+    go_only = await where_is_symbol(str(workspace), "Lookup", include=["**/*.go"])
+    assert [r.file for r in go_only.references] == ["main.go"]
+    assert [d.file for d in go_only.declarations] == ["store.go"]
+
+    no_store = await where_is_symbol(str(workspace), "Lookup", exclude=["store.go", "*.{ts,tsx}"])
+    assert no_store.declarations == []
+    assert [r.file for r in no_store.references] == ["main.go"]
+
+
+@pytest.mark.asyncio
+async def test_reuses_index(workspace):
+    """Test a caller-supplied index is updated and its ignored entries filtered."""
+    index = Index(str(workspace), MemoryStorage())
+    await index.update()
+    # Without gitignore support the index includes generated/client.go
+    assert "generated/client.go" in index.files()
+
+    found = await where_is_symbol(str(workspace), "Lookup", index=index)
+    assert [d.file for d in found.declarations] == ["store.go"]
+
+    (workspace / "store.go").write_text(FILES["store.go"].replace("Lookup", "Find"))
+    found = await where_is_symbol(str(workspace), "Lookup", index=index)
+    assert found.declarations == []
@@ -0,0 +1,111 @@
+"""Tests for runtime language registration."""
+
+import threading
+
+import pytest
+
+from mcp_code_parser import (
+    LanguageBackend,
+    register_language,
+    unregister_language,
+)
+from mcp_code_parser.api import AgentTools
+from mcp_code_parser.extractors.base import BaseExtractor, Outline, make_symbol
+from mcp_code_parser.registry import LanguageRegistry
+from mcp_code_parser.utils import detect_language_from_file
+
+
+class RuleExtractor(BaseExtractor):
+    """Outlines `rule <name>` lines of a toy DSL, without a grammar."""
+
+    def extract(self, tree, source, options, path=None):
+        return []
+
+    async def extract_content(self, content, language, options, path, parse):
+        symbols = []
+        offset = 0
+        for number, line in enumerate(content.splitlines(keepends=True), start=1):
+            if line.startswith("rule "):
+                sym = make_symbol(_Line(number, offset, len(line.rstrip("\n"))), line.split()[1], "rule")
+                symbols.append(sym)
+            offset += len(line.encode())
+        return Outline(language=language, symbols=symbols, metadata={})
+
+
+class _Line:
+    """Stand-in for a tree-sitter node spanning one line."""
+
+    def __init__(self, number, offset, length):
+        self.start_point = (number - 1, 0)
+        self.end_point = (number - 1, length)
+        self.start_byte = offset
+        self.end_byte = offset + length
+
+
+@pytest.fixture
+def rules():
+    """Register the rules language for one test."""
+    register_language("rules", LanguageBackend(RuleExtractor(), file_extensions=[".rules"]))
+    yield
+    unregister_language("rules")
+
+
+@pytest.mark.asyncio
+async def test_registered_language_dispatch(rules, tmp_path):
+    """Test that a registered backend is used for its extension and in extract_dir."""
+    (tmp_path / "auth.rules").write_text("# access\nrule allow_admin\nrule deny_all\n")
+    (tmp_path / "notes.txt").write_text("rule not_a_rule\n")
+
+    assert detect_language_from_file("policy/AUTH.RULES") == "rules"
+    tools = AgentTools()
+    outline = await tools.extract_file(str(tmp_path / "auth.rules"))
+    assert outline.success
+    assert [(s.name, s.kind, s.start_line) for s in outline.symbols] == [
+        ("allow_admin", "rule", 2),
+        ("deny_all", "rule", 3),
+    ]
+    assert outline.symbols[0].stable_id == "rule:allow_admin"
+
+    results = await tools.extract_dir(str(tmp_path))
+    assert list(results) == ["auth.rules"]
+
+
+def test_duplicate_registration_rejected(rules):
+    """Test that languages and extensions can't be taken twice without override."""
+    with pytest.raises(ValueError, match="already registered"):
+        register_language("rules", LanguageBackend(RuleExtractor()))
+    with pytest.raises(ValueError, match="already registered"):
+        register_language("go", LanguageBackend(RuleExtractor()))
+    with pytest.raises(ValueError, match="belongs to rules"):
+        register_language("policy", LanguageBackend(RuleExtractor(), file_extensions=["rules"]))
+
+    replacement = RuleExtractor()
+    register_language("rules", LanguageBackend(replacement, file_extensions=[".rule"]), override=True)
+    assert AgentTools().get_extractor("rules") is replacement
+    # The old registration's extensions go with it
+    assert detect_language_from_file("auth.rules") is None
+    assert detect_language_from_file("auth.rule") == "rules"
+
+
+def test_registry_concurrent_registration():
+    """Test that concurrent registrations are all kept."""
+    registry = LanguageRegistry()
+    errors = []
+
+    def register(i):
+        try:
+            registry.register(f"dsl{i}", LanguageBackend(RuleExtractor(), file_extensions=[f".dsl{i}"]))
+            registry.register("shared", LanguageBackend(RuleExtractor()))
+        except ValueError as e:
+            errors.append(e)
+
+    threads = [threading.Thread(target=register, args=(i,)) for i in range(16)]
+    for thread in threads:
+        thread.start()
+    for thread in threads:
+        thread.join()
+
+    assert sorted(registry.languages()) == sorted([f"dsl{i}" for i in range(16)] + ["shared"])
+    assert all(registry.language_for_extension(f".DSL{i}") == f"dsl{i}" for i in range(16))
+    # Only the first "shared" registration wins
+    assert len(errors) == 15
@@ -0,0 +1,75 @@
+"""Tests for related symbol suggestions."""
+
+from pathlib import Path
+
+import pytest
+
+from mcp_code_parser.analysis.related import find_related_symbols, related_symbols
+from mcp_code_parser.errors import NotFoundError
+from mcp_code_parser.extractors.base import Outline, Param, Symbol, assign_stable_ids
+
+SAMPLES_DIR = Path(__file__).parent / "samples"
+
+
+def _symbol(name, kind, line, children=(), **kwargs):
+    return Symbol(name=name, kind=kind, start_line=line, end_line=line, start_byte=line * 10, end_byte=line * 10 + 5,
+                  children=list(children), **kwargs)
+
+
+@pytest.fixture
+def outline():
+    """An outline shaped like a small Go service file."""
+    symbols = [
+        _symbol("User", "struct", 1),
+        _symbol("UserService", "struct", 2, [
+            _symbol("cache", "field", 3, signature="cache Cache"),
+            _symbol("CreateUser", "method", 5, params=[Param(name="user", type="*User")]),
+            _symbol("GetUser", "method", 6, params=[Param(name="id", type="string")],
+                    results=[Param(name=None, type="*User"), Param(name=None, type="error")]),
+            _symbol("Close", "method", 7),
+        ]),
+        _symbol("Cache", "interface", 8),
+        _symbol("generateID", "function", 9),
+    ]
+    assign_stable_ids(symbols)
+    return Outline(language="go", symbols=symbols, metadata={})
+
+
+def test_related_ranking(outline):
+    """Test parents, signature types and siblings are ranked by relatedness."""
+    related = related_symbols(outline, "method:UserService.GetUser")
+
+    assert [(r.qualified_name, r.relations) for r in related] == [
+        ("UserService", ["parent"]),
+        ("User", ["signature_type"]),
+        ("UserService.CreateUser", ["sibling"]),
+        ("UserService.cache", ["sibling"]),
+        ("UserService.Close", ["sibling"]),
+    ]
+    # CreateUser shares "User" with GetUser
+    assert related[2].score > related[3].score
+
+    graph = {"method:UserService.GetUser": ["function:generateID"], "method:UserService.CreateUser": ["method:UserService.GetUser"]}
+    related = related_symbols(outline, "method:UserService.GetUser", graph, limit=3)
+    assert [(r.qualified_name, r.relations) for r in related] == [
+        ("UserService.CreateUser", ["called_by", "sibling"]),
+        ("UserService", ["parent"]),
+        ("generateID", ["calls"]),
+    ]
+
+    with pytest.raises(NotFoundError):
+        related_symbols(outline, "method:UserService.Missing")
+
+
+@pytest.mark.asyncio
+async def test_get_user_related_in_sample():
+    """Test GetUser's related set in the Go sample includes User and UserService."""
+    source = (SAMPLES_DIR / "go_complex.go").read_text()
+    related = await find_related_symbols(source, "go", "method:UserService.GetUser")
+    ids = [r.symbol.stable_id for r in related]
+
+    assert ids[0] == "struct:UserService"
+    assert "struct:User" in ids
+    assert "method:UserService.CreateUser" in ids
+    # s.cache.Get(...) resolves to the Get methods by name
+    assert next(r for r in related if r.symbol.stable_id == "method:InMemoryCache.Get").relations == ["calls"]
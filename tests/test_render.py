@@ -0,0 +1,133 @@
+"""Tests for outline rendering."""
+
+import io
+from pathlib import Path
+
+import pytest
+
+from mcp_code_parser import extract_symbols
+from mcp_code_parser.extractors.base import Outline, Symbol, assign_stable_ids
+from mcp_code_parser.render import FORMAT_TEXT, Theme, render_api_diff, render_terminal, render_tree
+
+
+def _symbol(name, kind, signature=None, children=()):
+    return Symbol(
+        name=name, kind=kind, start_line=1, end_line=1, start_byte=0, end_byte=0,
+        signature=signature, children=list(children),
+    )
+
+
+@pytest.fixture
+def outline():
+    """A struct with a field, and a function."""
+    return Outline(
+        language="go",
+        symbols=[
+            _symbol("User", "struct", "type User struct", [_symbol("ID", "field", "ID int")]),
+            _symbol("main", "function", "func main()"),
+        ],
+        metadata={},
+    )
+
+
+class _TTY(io.StringIO):
+    def isatty(self):
+        return True
+
+
+def test_render_tree(outline):
+    """Test the plain tree layout."""
+    assert render_tree(outline) == (
+        "├── struct User  type User struct\n"
+        "│   └── field ID  ID int\n"
+        "└── function main  func main()"
+    )
+
+
+def test_render_terminal_colors(outline, monkeypatch):
+    """Test that colors are forced on, and off for pipes or with NO_COLOR."""
+    monkeypatch.delenv("NO_COLOR", raising=False)
+
+    colored = render_terminal(outline, color=True)
+    assert "\x1b[36mstruct\x1b[0m \x1b[1mUser\x1b[0m" in colored
+    assert "\x1b[33mfunction\x1b[0m" in colored
+    assert "\x1b[2mfunc main()\x1b[0m" in colored
+
+    assert render_terminal(outline, stream=io.StringIO()) == render_tree(outline)
+    assert "\x1b[" in render_terminal(outline, stream=_TTY())
+    monkeypatch.setenv("NO_COLOR", "1")
+    assert "\x1b[" not in render_terminal(outline, stream=_TTY())
+    assert "\x1b[" not in render_terminal(outline, color=False)
+
+
+def test_render_terminal_theme(outline):
+    """Test that theme colors replace the defaults."""
+    theme = Theme(kinds={"struct": "1;35"}, default_kind="32", name="")
+    colored = render_terminal(outline, theme=theme, color=True)
+
+    assert "\x1b[1;35mstruct\x1b[0m User" in colored
+    assert "\x1b[32mfunction\x1b[0m main" in colored
+
+
+@pytest.mark.asyncio
+async def test_render_api_diff_markdown():
+    """Test the sections for a method added to and a function changed in the sample."""
+    source = (Path(__file__).parent / "samples" / "go_complex.go").read_text()
+    changed = source.replace(
+        "func NewUserService(cache Cache) *UserService {",
+        "func NewUserService(cache Cache, ttl time.Duration) *UserService {",
+    ) + "\nfunc (s *UserService) CountUsers(ctx context.Context) (int, error) {\n\treturn 0, nil\n}\n"
+    old = await extract_symbols(source, "go")
+    new = await extract_symbols(changed, "go")
+
+    rendered = render_api_diff(old, new)
+
+    assert rendered == """## API changes
+
+Breaking: 1 changed.
+
+### Added (non-breaking)
+
+- method `UserService.CountUsers`: `func (s *UserService) CountUsers(ctx context.Context) (int, error)`
+
+### Changed (breaking)
+
+- function `NewUserService`
+  - before: `func NewUserService(cache Cache) *UserService`
+  - after: `func NewUserService(cache Cache, ttl time.Duration) *UserService`
+"""
+    assert render_api_diff(old, old) == "## API changes\n\nNo changes to the exported API.\n"
+
+
+def test_render_api_diff_text():
+    """Test the plain text rendering and code spans around backticks."""
+    def exported(name, kind, signature, children=()):
+        sym = _symbol(name, kind, signature, children)
+        sym.exported = True
+        return sym
+
+    old = Outline(language="go", metadata={}, symbols=[
+        exported("User", "struct", "type User struct", [exported("ID", "field", 'ID int `json:"id"`')]),
+        exported("Save", "function", "func Save(u User) error"),
+    ])
+    new = Outline(language="go", metadata={}, symbols=[
+        exported("User", "struct", "type User struct", [exported("ID", "field", 'ID int64 `json:"id"`')]),
+    ])
+    for outline in (old, new):
+        assign_stable_ids(outline.symbols)
+
+    assert render_api_diff(old, new, FORMAT_TEXT) == """API changes
+
+Breaking: 1 removed, 1 changed.
+
+Removed (breaking):
+  - function Save  func Save(u User) error
+
+Changed (breaking):
+  ~ field User.ID
+      before: ID int `json:"id"`
+      after:  ID int64 `json:"id"`
+"""
+    assert '- after: `` ID int64 `json:"id"` ``' in render_api_diff(old, new)
+    with pytest.raises(ValueError):
+        render_api_diff(old, new, "html")
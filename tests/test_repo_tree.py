@@ -0,0 +1,73 @@
+"""Tests for repo trees with symbol counts."""
+
+import shutil
+from pathlib import Path
+
+import pytest
+
+from mcp_code_parser.analysis.repo_tree import TreeNode, repo_tree
+from mcp_code_parser.errors import NotFoundError
+
+REPO_TREE_DIR = Path(__file__).parent / "samples" / "repo_tree"
+
+
+@pytest.fixture
+def repo(tmp_path):
+    """Copy of the fixture tree plus a file its .gitignore ignores (which git wouldn't commit)."""
+    root = tmp_path / "repo"
+    shutil.copytree(REPO_TREE_DIR, root)
+    (root / "build").mkdir()
+    (root / "build" / "out.py").write_text("def generated():\n    pass\n")
+    return root
+
+
+def _children(node):
+    return {c.name: c for c in node.children}
+
+
+@pytest.mark.asyncio
+async def test_repo_tree(repo):
+    """Test the nested structure, per-file languages and counts, and directory totals."""
+    tree = await repo_tree(str(repo))
+    assert (tree.name, tree.path, tree.kind) == ("repo", ".", "directory")
+    # Hidden and ignored paths are skipped
+    assert [c.name for c in tree.children] == ["README.md", "lib", "main.py", "pkg"]
+
+    entries = _children(tree)
+    assert (entries["main.py"].language, entries["main.py"].symbol_count) == ("python", 1)
+    # No extractor: listed without symbols
+    assert (entries["README.md"].language, entries["README.md"].symbol_count) == (None, 0)
+    util = _children(entries["lib"])["util.py"]
+    assert (util.path, util.symbol_count) == ("lib/util.py", 3)
+    store = _children(entries["pkg"])["store"]
+    assert store.path == "pkg/store"
+    assert (_children(store)["store.go"].language, _children(store)["store.go"].symbol_count) == ("go", 2)
+
+    assert (tree.file_count, tree.symbol_count) == (4, 6)
+    assert tree.languages == {"go": 1, "python": 2}
+    assert (entries["pkg"].file_count, entries["pkg"].symbol_count, entries["pkg"].languages) == (1, 2, {"go": 1})
+    assert TreeNode.from_dict(tree.to_dict()) == tree
+
+
+@pytest.mark.asyncio
+async def test_repo_tree_depth_and_filters(repo):
+    """Test that max_depth cuts children but keeps counts, and that exclude applies."""
+    tree = await repo_tree(str(repo), max_depth=1)
+    pkg = _children(tree)["pkg"]
+    assert pkg.truncated and pkg.children == []
+    assert pkg.symbol_count == 2
+    assert tree.symbol_count == 6
+    assert "pkg/  1 file, 2 symbols ..." in tree.render()
+
+    tree = await repo_tree(str(repo), exclude=["**/*.go"])
+    assert [c.name for c in tree.children] == ["README.md", "lib", "main.py"]
+    assert tree.symbol_count == 4
+
+
+@pytest.mark.asyncio
+async def test_repo_tree_errors(tmp_path):
+    """Test a missing root and a bad depth."""
+    with pytest.raises(NotFoundError):
+        await repo_tree(str(tmp_path / "missing"))
+    with pytest.raises(ValueError):
+        await repo_tree(str(tmp_path), max_depth=0)
@@ -0,0 +1,94 @@
+"""Tests for route map extraction."""
+
+from pathlib import Path
+
+import pytest
+
+from mcp_code_parser.analysis.base import ParsedFile
+from mcp_code_parser.analysis.routes import ANY_METHOD, Route, RouteRecognizer, extract_routes
+
+ROUTES_DIR = Path(__file__).parent / "samples" / "routes"
+
+
+def _summary(routes):
+    return [(r.method, r.path, r.handler, r.framework) for r in routes]
+
+
+@pytest.mark.asyncio
+async def test_go_routes():
+    """Test chi, net/http and gin registrations, with group prefixes and handlers resolved."""
+    routes = await extract_routes(str(ROUTES_DIR), include=["*.go"])
+
+    assert _summary(routes) == [
+        ("GET", "/v1/orders", "listOrders", "gin"),
+        (ANY_METHOD, "/v1/echo", None, "gin"),
+        ("GET", "/health", "health", "chi"),
+        ("GET", "/users", "h.ListUsers", "chi"),
+        ("POST", "/users", "h.CreateUser", "chi"),
+        ("GET", "/users/{id}", "h.GetUser", "chi"),
+        ("DELETE", "/users/{id}", "h.DeleteUser", "chi"),
+        # Go 1.22 ServeMux patterns carry the method
+        ("GET", "/admin/stats", "stats", "net/http"),
+        (ANY_METHOD, "/admin/", "http.NotFoundHandler()", "net/http"),
+        (ANY_METHOD, "/admin/ping", None, "net/http"),
+    ]
+    by_path = {(r.method, r.path): r for r in routes}
+    get_user = by_path[("GET", "/users/{id}")]
+    assert (get_user.file, get_user.line) == ("server.go", 15)
+    assert (get_user.handler_file, get_user.handler_id) == ("handlers.go", "method:Handlers.GetUser")
+    assert by_path[("GET", "/v1/orders")].handler_id == "function:listOrders"
+    assert by_path[(ANY_METHOD, "/admin/")].handler_id is None
+
+
+@pytest.mark.asyncio
+async def test_flask_routes():
+    """Test route decorators, their methods and blueprint prefixes."""
+    routes = await extract_routes(str(ROUTES_DIR), include=["*.py"])
+
+    assert _summary(routes) == [
+        ("GET", "/", "index", "flask"),
+        ("GET", "/accounts/<int:account_id>", "account", "flask"),
+        ("PUT", "/accounts/<int:account_id>", "account", "flask"),
+        ("POST", "/accounts", "open_account", "flask"),
+    ]
+    assert [(r.handler_file, r.handler_id) for r in routes][-1] == ("app.py", "function:open_account")
+    assert routes[0].line == 9
+
+
+@pytest.mark.asyncio
+async def test_express_routes(tmp_path):
+    """Test app.get-style registrations and app.route chains."""
+    (tmp_path / "server.js").write_text("""const express = require('express');
+const app = express();
+
+function listBooks(req, res) {}
+
+app.get('/books', auth, listBooks);
+app.route('/books/:id').put(update).delete((req, res) => res.end());
+app.get('view engine', 'pug');
+""")
+
+    routes = await extract_routes(str(tmp_path))
+
+    assert _summary(routes) == [
+        ("GET", "/books", "listBooks", "express"),
+        ("PUT", "/books/:id", "update", "express"),
+        ("DELETE", "/books/:id", None, "express"),
+    ]
+    assert routes[0].handler_id == "function:listBooks"
+
+
+@pytest.mark.asyncio
+async def test_custom_recognizer(tmp_path):
+    """Test plugging in a recognizer for another framework."""
+    (tmp_path / "main.go").write_text('package main\n\nfunc main() {\n\tserve("/jobs", runJobs)\n}\n\nfunc runJobs() {}\n')
+
+    class ServeRoutes(RouteRecognizer):
+        languages = ("go",)
+
+        def routes(self, pf: ParsedFile):
+            return [Route(method="POST", path="/jobs", handler="runJobs", framework="serve", line=4)]
+
+    routes = await extract_routes(str(tmp_path), recognizers=[ServeRoutes()])
+
+    assert [(r.file, r.handler_id) for r in routes] == [("main.go", "function:runJobs")]
@@ -0,0 +1,135 @@
+"""Tests for Vue and Svelte single-file component extraction."""
+
+from pathlib import Path
+
+import pytest
+
+from mcp_code_parser import extract_file, extract_symbols
+
+
+@pytest.fixture
+def samples_dir():
+    """Get samples directory."""
+    return Path(__file__).parent / "samples"
+
+
+def _names(symbols, kind):
+    return [s.name for s in symbols if s.kind == kind]
+
+
+@pytest.mark.asyncio
+async def test_vue_script_setup(samples_dir):
+    """Test that a <script setup> component surfaces props, emits and regions."""
+    outline = await extract_file(str(samples_dir / "vue_component.vue"))
+
+    assert outline.success
+    assert outline.language == "vue"
+    assert len(outline.symbols) == 1
+
+    component = outline.symbols[0]
+    assert component.kind == "component"
+    assert component.name == "vue_component"
+    assert _names(component.children, "prop") == ["user", "selected"]
+    assert _names(component.children, "emit") == ["select", "clear"]
+    assert _names(component.children, "registered_component") == ["UserAvatar"]
+
+    regions = [s for s in component.children if s.kind == "region"]
+    assert [r.name for r in regions] == ["template", "script", "style"]
+    # The nested <template v-if> belongs to the outer template region
+    assert regions[0].start_line == 1
+    assert regions[0].end_line == 9
+    assert regions[1].signature == '<script setup lang="ts">'
+
+
+@pytest.mark.asyncio
+async def test_vue_script_symbols_use_file_positions(samples_dir):
+    """Test that script symbols are nested under the script region at file offsets."""
+    path = samples_dir / "vue_component.vue"
+    outline = await extract_file(str(path))
+    script = next(s for s in outline.symbols[0].children if s.name == "script")
+
+    by_name = {s.name: s for s in script.children}
+    assert by_name["User"].kind == "interface"
+    assert by_name["select"].kind == "function"
+    assert by_name["select"].start_line == 33
+
+    source = path.read_bytes()
+    select = by_name["select"]
+    assert source[select.start_byte:select.end_byte].startswith(b"function select()")
+
+
+@pytest.mark.asyncio
+async def test_vue_options_api(samples_dir):
+    """Test that options API props, emits, components and name are detected."""
+    outline = await extract_file(str(samples_dir / "vue_options.vue"))
+
+    component = outline.symbols[0]
+    assert component.name == "TodoList"
+    assert _names(component.children, "prop") == ["todos", "filter"]
+    assert _names(component.children, "emit") == ["toggle", "remove"]
+    assert _names(component.children, "registered_component") == ["TodoItem"]
+
+
+@pytest.mark.asyncio
+async def test_vue_runtime_macros():
+    """Test defineProps/defineEmits with runtime object and array arguments."""
+    source = """<script setup>
+const props = defineProps({ label: String, size: { type: Number, default: 1 } })
+const emit = defineEmits(['update', 'close'])
+</script>
+"""
+    outline = await extract_symbols(source, "vue", path="Widget.vue")
+
+    component = outline.symbols[0]
+    assert component.name == "Widget"
+    assert _names(component.children, "prop") == ["label", "size"]
+    assert _names(component.children, "emit") == ["update", "close"]
+
+
+@pytest.mark.asyncio
+async def test_svelte_component(samples_dir):
+    """Test that a Svelte component surfaces props, events and regions."""
+    outline = await extract_file(str(samples_dir / "svelte_component.svelte"))
+
+    assert outline.success
+    assert outline.language == "svelte"
+
+    component = outline.symbols[0]
+    assert component.name == "svelte_component"
+    assert _names(component.children, "prop") == ["title", "count"]
+    assert _names(component.children, "emit") == ["change"]
+    assert _names(component.children, "registered_component") == ["Badge"]
+
+    regions = [s for s in component.children if s.kind == "region"]
+    assert [r.name for r in regions] == ["script", "template", "style"]
+    assert regions[1].start_line == 16
+    assert regions[1].end_line == 18
+
+    script = regions[0]
+    assert "increment" in _names(script.children, "function")
+
+
+@pytest.mark.asyncio
+async def test_svelte_runes_props():
+    """Test props destructured from $props()."""
+    source = """<script>
+  let { name, greeting = 'Hello', ...rest } = $props();
+</script>
+
+<p>{greeting}, {name}</p>
+"""
+    outline = await extract_symbols(source, "svelte", path="Greeting.svelte")
+
+    component = outline.symbols[0]
+    assert _names(component.children, "prop") == ["name", "greeting"]
+
+
+@pytest.mark.asyncio
+async def test_component_without_script():
+    """Test that markup-only components still produce a component symbol."""
+    outline = await extract_symbols("<template><p>hi</p></template>\n", "vue")
+
+    assert outline.success
+    component = outline.symbols[0]
+    assert component.name == "Component"
+    assert [s.name for s in component.children] == ["template"]
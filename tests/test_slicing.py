@@ -0,0 +1,73 @@
+"""Tests for slicing a symbol with its dependencies."""
+
+from pathlib import Path
+
+import pytest
+
+from mcp_code_parser.analysis.slicing import slice_symbol
+from mcp_code_parser.errors import NotFoundError
+from mcp_code_parser.extractors.base import SourceFile
+from mcp_code_parser.parsers.base import LanguageNotSupportedError
+
+SAMPLES_DIR = Path(__file__).parent / "samples"
+
+
+@pytest.mark.asyncio
+async def test_slice_method():
+    """Test that GetUser pulls in the types it uses and only the imports they need."""
+    files = [SourceFile.read(str(SAMPLES_DIR / "go_complex.go"))]
+
+    sliced = await slice_symbol(files, "method:UserService.GetUser")
+
+    assert sliced.startswith("package main\n")
+    assert "func (s *UserService) GetUser(ctx context.Context, id string) (*User, error) {" in sliced
+    assert "// Service with methods\ntype UserService struct" in sliced
+    for dependency in ("type User struct", "type Cache interface", "type Storage interface"):
+        assert dependency in sliced
+    for name in ('"context"', '"errors"', '"sync"', '"time"'):
+        assert name in sliced
+    assert '"encoding/json"' not in sliced
+    assert '"fmt"' not in sliced
+    for unrelated in ("CreateUser", "func pipeline", "type InMemoryCache", "func main"):
+        assert unrelated not in sliced
+    # Declarations keep their order in the file
+    assert sliced.index("type Storage") < sliced.index("type User struct") < sliced.index("type UserService")
+
+
+@pytest.mark.asyncio
+async def test_slice_across_files():
+    """Test following functions, constants and called methods into other files."""
+    files = [
+        SourceFile("order.go", (
+            "package shop\n\nimport \"strings\"\n\n"
+            "type Order struct{ lines []Line }\n\n"
+            "func (o *Order) Total() int {\n\tsum := 0\n\tfor _, l := range o.lines {\n\t\tsum += l.cost()\n\t}\n"
+            "\treturn sum + fee\n}\n\n"
+            "func (o *Order) Label() string { return strings.ToUpper(\"order\") }\n"
+        )),
+        SourceFile("line.go", (
+            "package shop\n\nimport \"fmt\"\n\n"
+            "const fee = 2\n\n"
+            "type Line struct{ price, qty int }\n\n"
+            "func (l Line) cost() int { return l.price * l.qty }\n\n"
+            "func (l Line) String() string { return fmt.Sprint(l.price) }\n"
+        )),
+    ]
+
+    sliced = await slice_symbol(files, "method:Order.Total")
+
+    for expected in ("type Order struct", "type Line struct", "const fee = 2", "func (l Line) cost() int"):
+        assert expected in sliced
+    assert "Label" not in sliced and "String()" not in sliced
+    assert "import" not in sliced
+
+
+@pytest.mark.asyncio
+async def test_slice_errors():
+    """Test unknown stable IDs and unsupported languages."""
+    files = [SourceFile.read(str(SAMPLES_DIR / "go_complex.go"))]
+
+    with pytest.raises(NotFoundError):
+        await slice_symbol(files, "method:UserService.Missing")
+    with pytest.raises(LanguageNotSupportedError):
+        await slice_symbol(files, "function:main", language="python")
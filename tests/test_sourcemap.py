@@ -0,0 +1,84 @@
+"""Tests for source map support."""
+
+import base64
+import os
+from pathlib import Path
+
+import pytest
+
+from mcp_code_parser import ExtractOptions, extract_file
+from mcp_code_parser.sourcemap import SourceMap, load_source_map
+
+
+@pytest.fixture
+def maps_dir():
+    """Get the source map samples directory."""
+    return Path(__file__).parent / "samples" / "sourcemap"
+
+
+def test_source_map_lookup(maps_dir):
+    """Test decoding mappings and looking up generated positions."""
+    path = maps_dir / "greeter.js.map"
+    source_map = SourceMap.parse(path.read_text(), str(path))
+
+    assert source_map.sources == [os.path.normpath(str(maps_dir / "src" / "greeter.ts"))]
+    # `greet() {` on generated line 5 comes from `greet(): string {` on line 4
+    position = source_map.original_position(4, 4)
+    assert (position.line, position.column) == (4, 3)
+    # Columns between mappings use the one before; leading columns the first
+    assert source_map.original_position(2, 20).column == 15
+    assert source_map.original_position(1, 0).column == 3
+    assert source_map.original_position(11, 0) is None
+    assert source_map.original_position(500, 0) is None
+
+
+def test_load_source_map_references(maps_dir, tmp_path):
+    """Test finding maps by comment, inline data URL and sibling file."""
+    assert load_source_map(str(maps_dir / "greeter.js"), (maps_dir / "greeter.js").read_text()) is not None
+    assert load_source_map(str(maps_dir / "plain.js"), (maps_dir / "plain.js").read_text()) is None
+
+    inline = base64.b64encode(b'{"version": 3, "sources": ["a.ts"], "mappings": "AAAA"}').decode()
+    source_map = load_source_map(str(tmp_path / "a.js"), f"f();\n//# sourceMappingURL=data:application/json;base64,{inline}\n")
+    assert source_map.original_position(0, 0).file == str(tmp_path / "a.ts")
+
+    with pytest.raises(ValueError):
+        load_source_map(str(maps_dir / "broken.js"), (maps_dir / "broken.js").read_text())
+    with pytest.raises(ValueError):
+        load_source_map(str(tmp_path / "b.js"), "//# sourceMappingURL=missing.js.map\n")
+    with pytest.raises(ValueError):
+        SourceMap.parse('{"version": 3, "sources": [], "mappings": "AAAA"}')
+
+
+@pytest.mark.asyncio
+async def test_extract_file_with_source_map(maps_dir):
+    """Test that extracted symbols carry their position in the original source."""
+    options = ExtractOptions(source_maps=True)
+    outline = await extract_file(str(maps_dir / "greeter.js"), options=options)
+    assert outline.success
+
+    original = os.path.normpath(str(maps_dir / "src" / "greeter.ts"))
+    greeter = next(s for s in outline.symbols if s.name == "Greeter")
+    greet = next(c for c in greeter.children if c.name == "greet")
+    shout = next(s for s in outline.symbols if s.name == "shout")
+    assert (greet.start_line, greet.original_file, greet.original_line, greet.original_column) == (5, original, 4, 3)
+    assert (shout.original_line, shout.original_column) == (9, 1)
+
+    # Without the option positions aren't mapped
+    unmapped = await extract_file(str(maps_dir / "greeter.js"))
+    assert next(s for s in unmapped.symbols if s.name == "shout").original_file is None
+
+
+@pytest.mark.asyncio
+async def test_invalid_or_missing_source_map(maps_dir):
+    """Test that files with broken or no maps are still extracted."""
+    options = ExtractOptions(source_maps=True)
+
+    broken = await extract_file(str(maps_dir / "broken.js"), options=options)
+    assert broken.success
+    assert "source_map_error" in broken.metadata
+    assert broken.symbols[0].original_file is None
+
+    plain = await extract_file(str(maps_dir / "plain.js"), options=options)
+    assert plain.success
+    assert "source_map_error" not in plain.metadata
+    assert plain.symbols[0].original_line is None
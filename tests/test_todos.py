@@ -0,0 +1,59 @@
+"""Tests for TODO notes in comments."""
+
+from pathlib import Path
+
+import pytest
+
+from mcp_code_parser import LanguageBackend, register_language, unregister_language
+from mcp_code_parser.analysis.metrics import file_metrics
+from mcp_code_parser.analysis.todos import find_todos
+from mcp_code_parser.api import AgentTools
+from mcp_code_parser.registry import CommentSyntax
+
+SAMPLES_DIR = Path(__file__).parent / "samples"
+
+
+@pytest.fixture
+def pipeline():
+    """Register a language with comment tokens but no extractor or grammar."""
+    syntax = CommentSyntax(line=["#"], block=[("/*", "*/")], strings=['"'])
+    register_language("pipeline", LanguageBackend(comments=syntax, file_extensions=[".pipeline"]))
+    yield
+    unregister_language("pipeline")
+
+
+@pytest.mark.asyncio
+async def test_config_only_language(pipeline):
+    """Test TODO extraction and comment counts from comment tokens alone."""
+    content = (SAMPLES_DIR / "todos.pipeline").read_text()
+    todos = await find_todos(content, "pipeline")
+    assert [(t.tag, t.owner, t.text, t.line, t.column) for t in todos] == [
+        ("TODO", None, "split the deploy stage", 1, 3),
+        # The tag inside a string on line 3 is skipped
+        ("FIXME", "alice", "cache the", 4, 6),
+        ("HACK", None, "pin the region", 8, 20),
+    ]
+
+    metrics = await file_metrics(content, "pipeline")
+    assert (metrics.comment_lines, metrics.code_lines, metrics.blank_lines) == (3, 6, 1)
+    assert metrics.symbols_by_kind == {}
+    # No symbol backend, so no outline
+    assert AgentTools().get_extractor("pipeline") is None
+
+
+@pytest.mark.asyncio
+async def test_grammar_comments():
+    """Test that grammar languages take notes from their comment nodes."""
+    content = 'package a\n\n// TODO(bob): retry\nvar s = "TODO: not a note"\n\n/* XXX drop this */\n'
+    todos = await find_todos(content, "go")
+    assert [(t.tag, t.owner, t.text, t.line) for t in todos] == [
+        ("TODO", "bob", "retry", 3),
+        ("XXX", None, "drop this", 6),
+    ]
+    assert await find_todos(content, "go", tags=["FIXME"]) == []
+
+
+def test_backend_needs_extractor_or_comments():
+    """Test that an empty backend is rejected."""
+    with pytest.raises(ValueError, match="extractor or comments"):
+        register_language("empty", LanguageBackend())
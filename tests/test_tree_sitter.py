@@ -66,6 +66,19 @@ async def test_parse_file_not_found(parser):
     assert "Error reading file" in result.error
 
 
+@pytest.mark.asyncio
+async def test_parse_file_over_max_size(tmp_path):
+    """Test that parse_file refuses files over the configured limit."""
+    test_file = tmp_path / "big.py"
+    test_file.write_text("x = 1\n" * 20)
+    
+    parser = TreeSitterParser(max_file_size=test_file.stat().st_size - 1)
+    result = await parser.parse_file(str(test_file))
+    
+    assert not result.success
+    assert "exceeds limit" in result.error
+
+
 @pytest.mark.asyncio
 async def test_language_detection_edge_cases(parser, tmp_path):
     """Test language detection with edge cases."""
@@ -1,17 +1,25 @@
 """Unit tests for utility functions."""
 
+import io
 import tempfile
 from pathlib import Path
 from unittest.mock import patch
 
 import pytest
 
+from mcp_code_parser.errors import BinaryFileError, PathTraversalError, TooLargeError
 from mcp_code_parser.utils import (
+    FileTooLargeError,
     detect_language_from_file,
     get_cache_dir,
     get_grammar_cache_dir,
     hash_content,
+    read_source,
+    read_stream,
+    repo_relative_path,
+    resolve_within,
     safe_read_file,
+    write_source,
 )
 
 
@@ -84,6 +92,31 @@ def test_safe_read_file_encoding_fallback():
         assert isinstance(content, str)  # Should not raise exception
 
 
+def test_read_source_round_trip(tmp_path):
+    """Test that normalized content is written back with its encoding, BOM and newlines."""
+    cases = {
+        "bom_crlf.go": b"\xef\xbb\xbfpackage a\r\n\r\nvar x = 1\r\n",
+        "utf16.go": "\ufeffpackage a\r\nvar x = 1\r\n".encode("utf-16-be"),
+        "cr.go": b"package a\rvar x = 1\r",
+        "plain.go": b"package a\nvar x = 1\n",
+    }
+    for name, data in cases.items():
+        path = tmp_path / name
+        path.write_bytes(data)
+        content, text_format = read_source(str(path))
+        assert content == "package a\n" + ("\n" if name == "bom_crlf.go" else "") + "var x = 1\n"
+        write_source(str(path), content, text_format)
+        assert path.read_bytes() == data, name
+
+    content, text_format = read_source(str(tmp_path / "utf16.go"))
+    assert (text_format.encoding, text_format.bom, text_format.newline) == ("utf-16-be", True, "\r\n")
+    kept, kept_format = read_source(str(tmp_path / "bom_crlf.go"), strip_bom=False)
+    assert kept.startswith("\ufeff") and not kept_format.bom
+    # safe_read_file strips the BOM too, so offsets agree with read_source
+    assert safe_read_file(str(tmp_path / "bom_crlf.go")) == "package a\n\nvar x = 1\n"
+    assert safe_read_file(str(tmp_path / "bom_crlf.go"), strip_bom=False).startswith("\ufeff")
+
+
 def test_safe_read_file_not_found():
     """Test reading non-existent file."""
     with pytest.raises(FileNotFoundError):
@@ -104,6 +137,33 @@ def test_safe_read_file_permission_denied():
             Path(f.name).chmod(0o644)
 
 
+def test_safe_read_file_max_size(tmp_path):
+    """Test that files over the size limit are rejected or truncated."""
+    limit = 64
+    at_limit = tmp_path / "at_limit.txt"
+    at_limit.write_bytes(b"a" * limit)
+    over_limit = tmp_path / "over_limit.txt"
+    over_limit.write_bytes(b"a" * (limit + 1))
+    
+    assert safe_read_file(str(at_limit), max_size=limit) == "a" * limit
+    
+    with pytest.raises(FileTooLargeError) as exc_info:
+        safe_read_file(str(over_limit), max_size=limit)
+    assert exc_info.value.size == limit + 1
+    assert exc_info.value.limit == limit
+    
+    assert safe_read_file(str(over_limit), max_size=limit, truncate=True) == "a" * limit
+
+
+def test_safe_read_file_truncate_multibyte(tmp_path):
+    """Test that truncation never splits a multi-byte character."""
+    test_file = tmp_path / "unicode.txt"
+    test_file.write_text("ab世界", encoding="utf-8")
+    
+    # Cut falls inside the second 3-byte character
+    assert safe_read_file(str(test_file), max_size=6, truncate=True) == "ab世"
+
+
 def test_detect_language_edge_cases():
     """Test language detection edge cases."""
     # Multiple dots in filename
@@ -181,4 +241,75 @@ def test_path_edge_cases():
     
     # Paths with special characters
     assert detect_language_from_file("/path/to/file-name_test.go") == "go"
-    assert detect_language_from_file("/path/to/file@2.0.ts") == "typescript"
\ No newline at end of file
+    assert detect_language_from_file("/path/to/file@2.0.ts") == "typescript"
+
+def test_resolve_within(tmp_path):
+    """Test that paths must stay under the root."""
+    (tmp_path / "pkg").mkdir()
+    assert resolve_within(str(tmp_path), "pkg") == (tmp_path / "pkg").resolve()
+    assert resolve_within(str(tmp_path), ".") == tmp_path.resolve()
+    
+    with pytest.raises(PathTraversalError):
+        resolve_within(str(tmp_path), "../elsewhere")
+    with pytest.raises(PathTraversalError):
+        resolve_within(str(tmp_path / "pkg"), str(tmp_path))
+
+
+class _CountingReader(io.BytesIO):
+    """BytesIO that records how many bytes were read."""
+
+    consumed = 0
+
+    def read(self, size=-1):
+        data = super().read(size)
+        self.consumed += len(data)
+        return data
+
+
+class _AsyncReader:
+    """Minimal asyncio.StreamReader stand-in."""
+
+    def __init__(self, data):
+        self._buffer = io.BytesIO(data)
+
+    async def read(self, size=-1):
+        return self._buffer.read(size)
+
+
+@pytest.mark.asyncio
+async def test_read_stream():
+    """Test reading text, binary and async streams."""
+    assert await read_stream(io.StringIO("a\r\nb"), chunk_size=1) == "a\nb"
+    assert await read_stream(io.BytesIO("héllo".encode("utf8")), chunk_size=2) == "héllo"
+    assert await read_stream(_AsyncReader(b"x = 1\n")) == "x = 1\n"
+
+    with pytest.raises(BinaryFileError):
+        await read_stream(io.BytesIO(b"\x7fELF\x00\x01"))
+
+
+@pytest.mark.asyncio
+async def test_read_stream_limit():
+    """Test that reading stops shortly after the size limit."""
+    reader = _CountingReader(b"x" * 10_000)
+    with pytest.raises(TooLargeError):
+        await read_stream(reader, max_size=100, chunk_size=16)
+    assert reader.consumed <= 116
+
+    truncated = await read_stream(io.BytesIO("ab€".encode("utf8")), max_size=3, truncate=True)
+    # The cut through the multi-byte character is dropped
+    assert truncated == "ab"
+
+
+def test_repo_relative_path(tmp_path):
+    """Test paths relative to the enclosing git work tree, or to a root outside one."""
+    (tmp_path / "repo" / ".git").mkdir(parents=True)
+    (tmp_path / "repo" / "pkg").mkdir()
+    inside = tmp_path / "repo" / "pkg" / "a.go"
+    inside.write_text("package pkg\n")
+    outside = tmp_path / "plain" / "b.go"
+    outside.parent.mkdir()
+    outside.write_text("package plain\n")
+
+    assert repo_relative_path(str(inside)) == "pkg/a.go"
+    assert repo_relative_path(str(inside), root=str(tmp_path / "repo" / "pkg")) == "pkg/a.go"
+    assert repo_relative_path(str(outside), root=str(tmp_path)) == "plain/b.go"
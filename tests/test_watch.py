@@ -0,0 +1,110 @@
+"""Tests for watching a directory for changed files."""
+
+import asyncio
+import os
+
+import pytest
+
+from mcp_code_parser.errors import NotFoundError
+from mcp_code_parser.index import Index, MemoryStorage
+from mcp_code_parser.watch import Watcher
+
+
+async def _next(watcher, timeout=5.0):
+    return await asyncio.wait_for(watcher.__anext__(), timeout)
+
+
+async def _quiet(watcher, timeout=0.3):
+    """Assert no event arrives within timeout."""
+    with pytest.raises(asyncio.TimeoutError):
+        await _next(watcher, timeout)
+
+
+@pytest.mark.asyncio
+async def test_touch_reports_update(tmp_path):
+    """Test that touching a watched file reports it with a new outline."""
+    path = tmp_path / "main.go"
+    path.write_text("package main\n\nfunc main() {}\n")
+
+    async with Watcher(str(tmp_path), interval=0.02, debounce=0.05) as watcher:
+        stat = path.stat()
+        os.utime(path, ns=(stat.st_atime_ns, stat.st_mtime_ns + 1_000_000_000))
+        event = await _next(watcher)
+
+    assert (event.kind, event.path) == ("modified", "main.go")
+    assert [s.name for s in event.outline.symbols] == ["main"]
+
+
+@pytest.mark.asyncio
+async def test_create_delete_rename(tmp_path):
+    """Test created, deleted and renamed files, and that bursts coalesce."""
+    (tmp_path / "old.go").write_text("package main\n")
+    (tmp_path / ".gitignore").write_text("gen/\n")
+    (tmp_path / "gen").mkdir()
+
+    async with Watcher(str(tmp_path), interval=0.02, debounce=0.1) as watcher:
+        # Writes in quick succession are one creation
+        new = tmp_path / "new.go"
+        for i in range(5):
+            new.write_text(f"package main\n\nvar x = {i}\n")
+            await asyncio.sleep(0.01)
+        # Ignored and unsupported files aren't reported
+        (tmp_path / "gen" / "out.go").write_text("package gen\n")
+        (tmp_path / "notes.txt").write_text("not code\n")
+        event = await _next(watcher)
+        assert (event.kind, event.path) == ("created", "new.go")
+        assert event.outline is not None
+        await _quiet(watcher)
+
+        (tmp_path / "old.go").rename(tmp_path / "renamed.go")
+        events = [await _next(watcher), await _next(watcher)]
+        assert sorted((e.kind, e.path) for e in events) == [("created", "renamed.go"), ("deleted", "old.go")]
+
+        new.unlink()
+        event = await _next(watcher)
+        assert (event.kind, event.path, event.outline) == ("deleted", "new.go", None)
+
+        # A file created and removed before it settles is never reported
+        (tmp_path / "brief.go").write_text("package main\n")
+        await asyncio.sleep(0.03)
+        (tmp_path / "brief.go").unlink()
+        await _quiet(watcher)
+
+
+@pytest.mark.asyncio
+async def test_updates_index(tmp_path):
+    """Test that changes update a given index."""
+    (tmp_path / "main.go").write_text("package main\n")
+    index = Index(str(tmp_path), MemoryStorage())
+    await index.update()
+
+    async with Watcher(str(tmp_path), index=index, interval=0.02, debounce=0.05) as watcher:
+        (tmp_path / "util.go").write_text("package main\n")
+        await _next(watcher)
+        assert index.files() == ["main.go", "util.go"]
+
+
+@pytest.mark.asyncio
+async def test_stop(tmp_path):
+    """Test that stopping ends iteration and cancels polling."""
+    watcher = Watcher(str(tmp_path), interval=0.02)
+    await watcher.start()
+    with pytest.raises(RuntimeError):
+        await watcher.start()
+    task = watcher._task
+    await watcher.stop()
+    assert task.done()
+    assert [event async for event in watcher] == []
+    # Stopping twice is fine
+    await watcher.stop()
+
+
+@pytest.mark.asyncio
+async def test_invalid(tmp_path):
+    """Test rejected arguments and missing roots."""
+    with pytest.raises(ValueError):
+        Watcher(str(tmp_path), interval=0)
+    with pytest.raises(ValueError):
+        Watcher(str(tmp_path), debounce=-1)
+    with pytest.raises(NotFoundError):
+        await Watcher(str(tmp_path / "missing")).start()